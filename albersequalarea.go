@@ -0,0 +1,623 @@
+package geographiclibgo
+
+import (
+	"errors"
+	"math"
+)
+
+const (
+	_ALBERS_NUMIT  = 5
+	_ALBERS_NUMIT0 = 20
+)
+
+// AlbersEqualArea is the Albers equal-area conic projection with one or two standard
+// parallels, following J. P. Snyder, Map Projections: A Working Manual, USGS Professional
+// Paper 1395 (1987), pp. 101-102. This implementation evaluates the projection using
+// divided differences to avoid the loss of accuracy which occurs in Snyder's formulas
+// when the standard parallels are close together or the latitude of the point is close
+// to the latitude of origin.
+//
+// The latitude of origin, lat0, is the latitude at which the scale along the parallels
+// is a minimum; this is between the two standard parallels. The northing y is measured
+// from lat0 and the easting x from the central meridian lon0, which is specified in the
+// calls to Forward and Reverse. A standard parallel of 0 gives the cylindrical equal-area
+// projection, and a standard parallel of 90 or -90 gives the Lambert azimuthal equal-area
+// projection.
+//
+// The scale returned by Forward and Reverse is the azimuthal scale, i.e. the scale along
+// the parallels; the radial scale, along the meridians, is its reciprocal. The meridian
+// convergence is the bearing of grid north (the y axis) measured clockwise from true
+// north.
+type AlbersEqualArea struct {
+	a     float64
+	f     float64
+	fm    float64
+	e2    float64
+	e     float64
+	e2m   float64
+	qZ    float64
+	qx    float64
+	sign  float64
+	lat0  float64
+	k0    float64
+	n0    float64
+	m02   float64
+	nrho0 float64
+	k2    float64
+	txi0  float64
+	scxi0 float64
+	sxi0  float64
+}
+
+// NewAlbersEqualArea creates an AlbersEqualArea projection with a single standard
+// parallel stdlat_deg (which is also the latitude of origin) for the ellipsoid with
+// equatorial radius a [meters] and flattening f, with azimuthal scale k0 on the standard
+// parallel.
+func NewAlbersEqualArea(a, f, stdlat_deg, k0 float64) AlbersEqualArea {
+	sphi, cphi := sincosd(stdlat_deg)
+	p := _new_albers_equal_area(a, f)
+	p._init(sphi, cphi, sphi, cphi, k0)
+	return p
+}
+
+// NewAlbersEqualAreaTwoParallels creates an AlbersEqualArea projection with standard
+// parallels stdlat1_deg and stdlat2_deg for the ellipsoid with equatorial radius a
+// [meters] and flattening f, with azimuthal scale k1 on the standard parallels. The
+// standard parallels cannot be opposite poles.
+func NewAlbersEqualAreaTwoParallels(
+	a, f, stdlat1_deg, stdlat2_deg, k1 float64,
+) AlbersEqualArea {
+	sphi1, cphi1 := sincosd(stdlat1_deg)
+	sphi2, cphi2 := sincosd(stdlat2_deg)
+	p := _new_albers_equal_area(a, f)
+	p._init(sphi1, cphi1, sphi2, cphi2, k1)
+	return p
+}
+
+func _new_albers_equal_area(a, f float64) AlbersEqualArea {
+	e2 := f * (2 - f)
+	p := AlbersEqualArea{
+		a:   a,
+		f:   f,
+		fm:  1 - f,
+		e2:  e2,
+		e:   math.Sqrt(math.Abs(e2)),
+		e2m: 1 - e2,
+	}
+	p.qZ = 1 + p.e2m*p._atanhee(1)
+	p.qx = p.qZ / (2 * p.e2m)
+	return p
+}
+
+func (p *AlbersEqualArea) _init(sphi1, cphi1, sphi2, cphi2, k1 float64) {
+	epsx := sq(get_epsilon())
+	tol0 := math.Pow(get_epsilon(), 0.75)
+	{
+		r := math.Hypot(sphi1, cphi1)
+		sphi1 /= r
+		cphi1 /= r
+		r = math.Hypot(sphi2, cphi2)
+		sphi2 /= r
+		cphi2 /= r
+	}
+	polar := cphi1 == 0
+	// Avoid singularities at poles
+	cphi1 = math.Max(epsx, cphi1)
+	cphi2 = math.Max(epsx, cphi2)
+	// Determine hemisphere of tangent latitude
+	p.sign = 1
+	if sphi1+sphi2 < 0 {
+		p.sign = -1
+	}
+	// Internally work with tangent latitude positive
+	sphi1 *= p.sign
+	sphi2 *= p.sign
+	if sphi1 > sphi2 {
+		// Make phi1 < phi2
+		sphi1, sphi2 = sphi2, sphi1
+		cphi1, cphi2 = cphi2, cphi1
+	}
+	tphi1, tphi2 := sphi1/cphi1, sphi2/cphi2
+
+	// q = (1-e^2)*(sphi/(1-e^2*sphi^2) - atanhee(sphi))
+	// qZ = q(pi/2) = (1 + (1-e^2)*atanhee(1))
+	// atanhee(x) = atanh(e*x)/e
+	// q = sxi * qZ
+	// dq/dphi = 2*(1-e^2)*cphi/(1-e^2*sphi^2)^2
+	//
+	// n = (m1^2-m2^2)/(q2-q1) -> sin(phi0) for phi1, phi2 -> phi0
+	// C = m1^2 + n*q1 = (m1^2*q2-m2^2*q1)/(q2-q1)
+	// let
+	//   rho(pi/2)/rho(-pi/2) = (1-s)/(1+s)
+	//   s = n*qZ/C
+	//     = qZ * (m1^2-m2^2)/(m1^2*q2-m2^2*q1)
+	//     = qZ * (scbet2^2 - scbet1^2)/(scbet2^2*q2 - scbet1^2*q1)
+	//     = (scbet2^2 - scbet1^2)/(scbet2^2*sxi2 - scbet1^2*sxi1)
+	//     = (tbet2^2 - tbet1^2)/(scbet2^2*sxi2 - scbet1^2*sxi1)
+	// 1-s = -((1-sxi2)*scbet2^2 - (1-sxi1)*scbet1^2)/
+	//         (scbet2^2*sxi2 - scbet1^2*sxi1)
+	//
+	// Define phi0 to give same value of s, i.e.,
+	//  s = sphi0 * qZ / (m0^2 + sphi0*q0)
+	//    = sphi0 * scbet0^2 / (1/qZ + sphi0 * scbet0^2 * sxi0)
+	var tphi0, C float64
+	if polar || tphi1 == tphi2 {
+		tphi0 = tphi2
+		C = 1 // ignored
+	} else {
+		tbet1 := p.fm * tphi1
+		scbet12 := 1 + sq(tbet1)
+		tbet2 := p.fm * tphi2
+		scbet22 := 1 + sq(tbet2)
+		txi1 := p._txif(tphi1)
+		cxi1 := 1 / hyp(txi1)
+		sxi1 := txi1 * cxi1
+		txi2 := p._txif(tphi2)
+		cxi2 := 1 / hyp(txi2)
+		sxi2 := txi2 * cxi2
+		dtbet2 := p.fm * (tbet1 + tbet2)
+		es1 := 1 - p.e2*sq(sphi1)
+		es2 := 1 - p.e2*sq(sphi2)
+		dsxi := ((1+p.e2*sphi1*sphi2)/(es2*es1) + p._d_atanhee(sphi2, sphi1)) *
+			d_sn(tphi2, tphi1, sphi2, sphi1) / (2 * p.qx)
+		den := (sxi2+sxi1)*dtbet2 + (scbet22+scbet12)*dsxi
+		// s = (sq(tbet2) - sq(tbet1))/(scbet22*sxi2 - scbet12*sxi1)
+		s := 2 * dtbet2 / den
+		// 1-s = -(sq(scbet2)*(1-sxi2) - sq(scbet1)*(1-sxi1)) /
+		//        (scbet22*sxi2 - scbet12*sxi1)
+		// Write
+		//   sq(scbet)*(1-sxi) = sq(scbet)*(1-sphi) * (1-sxi)/(1-sphi)
+		sm1 := -d_sn(tphi2, tphi1, sphi2, sphi1) *
+			(-(p._one_minus_ratio(sphi2, cphi2, sxi2, cxi2)+
+				p._one_minus_ratio(sphi1, cphi1, sxi1, cxi1))*
+				(1+p.e2*(sphi1+sphi2+sphi1*sphi2))/
+				(1+(sphi1+sphi2+sphi1*sphi2)) +
+				(scbet22*one_minus_sin(sphi2, cphi2)+scbet12*one_minus_sin(sphi1, cphi1))*
+					(p.e2*(1+sphi1+sphi2+p.e2*sphi1*sphi2)/(es1*es2)+
+						p.e2m*p._dd_atanhee(sphi1, sphi2))/p.qZ) / den
+		// C = (scbet22*sxi2 - scbet12*sxi1) / (scbet22 * scbet12 * (sx2 - sx1))
+		C = den / (2 * scbet12 * scbet22 * dsxi)
+		tphi0 = (tphi2 + tphi1) / 2
+		stol := tol0 * math.Max(1, math.Abs(tphi0))
+		for i := 0; i < 2*_ALBERS_NUMIT0; i++ {
+			// Solve (scbet0^2 * sphi0) / (1/qZ + scbet0^2 * sphi0 * sxi0) = s
+			// for tphi0 by Newton's method on
+			// v(tphi0) = (scbet0^2 * sphi0) - s * (1/qZ + scbet0^2 * sphi0 * sxi0)
+			//          = 0
+			// Alt:
+			// (scbet0^2 * sphi0) / (1/qZ - scbet0^2 * sphi0 * (1-sxi0)) = s / (1-s)
+			// w(tphi0) = (1-s) * (scbet0^2 * sphi0)
+			//             - s  * (1/qZ - scbet0^2 * sphi0 * (1-sxi0))
+			//          = (1-s) * (scbet0^2 * sphi0)
+			//             - S/qZ  * (1 - scbet0^2 * sphi0 * (qZ-q0))
+			// Now
+			// qZ-q0 = (1+e2*sphi0)*(1-sphi0)/(1-e2*sphi0^2) +
+			//         (1-e2)*atanhee((1-sphi0)/(1-sphi0*e2))
+			// In limit sphi0 -> 1, qZ-q0 -> 2*(1-sphi0)/(1-e2), so write
+			// qZ-q0 = 2*(1-sphi0)/(1-e2) + A + B
+			// A = (1-sphi0)*( (1+e2*sphi0)/(1-e2*sphi0^2) - (1+e2)/(1-e2) )
+			//   = -e2 *(1-sphi0)^2 * (2+(1+e2)*sphi0) / ((1-e2)*(1-e2*sphi0^2))
+			// B = (1-e2)*atanhee((1-sphi0)/(1-sphi0*e2)) - (1-sphi0)
+			//   = (1-sphi0)*(1-e2)/(1-e2*sphi0)*
+			//     ((atanhee(x)/x-1) - e2*(1-sphi0)/(1-e2))
+			// x = (1-sphi0)/(1-e2*sphi0), atanhee(x)/x = atanh(e*x)/(e*x)
+			//
+			// 1 - scbet0^2 * sphi0 * (qZ-q0)
+			//   = 1 - scbet0^2 * sphi0 * (2*(1-sphi0)/(1-e2) + A + B)
+			//   = D - scbet0^2 * sphi0 * (A + B)
+			// D = 1 - scbet0^2 * sphi0 * 2*(1-sphi0)/(1-e2)
+			//   = (1-sphi0)*(1-e2*(1+2*sphi0*(1+sphi0)))/((1-e2)*(1+sphi0))
+			// dD/dsphi0 = -2*(1-e2*sphi0^2*(2*sphi0+3))/((1-e2)*(1+sphi0)^2)
+			// d(A+B)/dsphi0 = 2*(1-sphi0^2)*e2*(2-e2*(1+sphi0^2))/
+			//                 ((1-e2)*(1-e2*sphi0^2)^2)
+			scphi02 := 1 + sq(tphi0)
+			scphi0 := math.Sqrt(scphi02)
+			// sphi0m = 1-sin(phi0) = 1/( sec(phi0) * (tan(phi0) + sec(phi0)) )
+			sphi0 := tphi0 / scphi0
+			sphi0m := 1 / (scphi0 * (tphi0 + scphi0))
+			// scbet0^2 * sphi0
+			g := (1 + sq(p.fm*tphi0)) * sphi0
+			// dg/dsphi0 = dg/dtphi0 * scphi0^3
+			dg := p.e2m*scphi02*(1+2*sq(tphi0)) + p.e2
+			D := sphi0m * (1 - p.e2*(1+2*sphi0*(1+sphi0))) / (p.e2m * (1 + sphi0))
+			// dD/dsphi0
+			dD := -2 * (1 - p.e2*sq(sphi0)*(2*sphi0+3)) / (p.e2m * sq(1+sphi0))
+			A := -p.e2 * sq(sphi0m) * (2 + (1+p.e2)*sphi0) / (p.e2m * (1 - p.e2*sq(sphi0)))
+			B := sphi0m * p.e2m / (1 - p.e2*sphi0) *
+				(atanhxm1(p.e2*sq(sphi0m/(1-p.e2*sphi0))) - p.e2*sphi0m/p.e2m)
+			// d(A+B)/dsphi0
+			dAB := 2 * p.e2 * (2 - p.e2*(1+sq(sphi0))) /
+				(p.e2m * sq(1-p.e2*sq(sphi0)) * scphi02)
+			u := sm1*g - s/p.qZ*(D-g*(A+B))
+			// du/dsphi0
+			du := sm1*dg - s/p.qZ*(dD-dg*(A+B)-g*dAB)
+			dtu := -u / du * (scphi0 * scphi02)
+			tphi0 += dtu
+			if !(math.Abs(dtu) >= stol) {
+				break
+			}
+		}
+	}
+	p.txi0 = p._txif(tphi0)
+	p.scxi0 = hyp(p.txi0)
+	p.sxi0 = p.txi0 / p.scxi0
+	p.n0 = tphi0 / hyp(tphi0)
+	p.m02 = 1 / (1 + sq(p.fm*tphi0))
+	p.nrho0 = 0
+	if !polar {
+		p.nrho0 = p.a * math.Sqrt(p.m02)
+	}
+	if polar || tphi1 == tphi2 {
+		p.k0 = k1
+	} else {
+		p.k0 = math.Sqrt(C/(p.m02+p.n0*p.qZ*p.sxi0)) * k1
+	}
+	p.k2 = sq(p.k0)
+	p.lat0 = p.sign * math.Atan(tphi0) * RAD2DEG
+}
+
+// _one_minus_ratio returns (1-sxi)/(1-sphi), avoiding cancellation for positive sphi
+func (p *AlbersEqualArea) _one_minus_ratio(sphi, cphi, sxi, cxi float64) float64 {
+	if sphi <= 0 {
+		return (1 - sxi) / (1 - sphi)
+	}
+	return sq(cxi/cphi) * (1 + sphi) / (1 + sxi)
+}
+
+// one_minus_sin returns 1-sphi, avoiding cancellation for positive sphi
+func one_minus_sin(sphi, cphi float64) float64 {
+	if sphi <= 0 {
+		return 1 - sphi
+	}
+	return sq(cphi) / (1 + sphi)
+}
+
+// EquatorialRadius returns the equatorial radius of the ellipsoid [meters]
+func (p *AlbersEqualArea) EquatorialRadius() float64 {
+	return p.a
+}
+
+// Flattening returns the flattening of the ellipsoid
+func (p *AlbersEqualArea) Flattening() float64 {
+	return p.f
+}
+
+// OriginLatitude returns the latitude of origin, at which the azimuthal scale is a
+// minimum [degrees]. For a projection with a single standard parallel, this is the
+// standard parallel.
+func (p *AlbersEqualArea) OriginLatitude() float64 {
+	return p.lat0
+}
+
+// CentralScale returns the azimuthal scale at the latitude of origin, k0
+func (p *AlbersEqualArea) CentralScale() float64 {
+	return p.k0
+}
+
+// SetScale sets the azimuthal scale of the projection so that the azimuthal scale at
+// latitude lat_deg is k. This returns an error if k is not positive, or if lat_deg is not
+// in (-90, 90).
+func (p *AlbersEqualArea) SetScale(lat_deg, k float64) error {
+	if !(!math.IsInf(k, 0) && k > 0) {
+		return errors.New("scale is not positive")
+	}
+	if !(math.Abs(lat_deg) < 90) {
+		return errors.New("latitude for SetScale not in (-90d, 90d)")
+	}
+	kold := p.Forward(0, lat_deg, 0).Scale
+	k /= kold
+	p.k0 *= k
+	p.k2 = sq(p.k0)
+	return nil
+}
+
+// Forward projects the point lat_deg, lon_deg with central meridian lon0_deg. The scale
+// in the result is the azimuthal scale; the radial scale is its reciprocal.
+func (p *AlbersEqualArea) Forward(lon0_deg, lat_deg, lon_deg float64) ProjectionForwardResult {
+	epsx := sq(get_epsilon())
+	lon, _ := ang_diff(lon0_deg, lon_deg)
+	sphi, cphi := sincosd(lat_fix(lat_deg) * p.sign)
+	cphi = math.Max(epsx, cphi)
+	lam := lon * DEG2RAD
+	tphi := sphi / cphi
+	txi := p._txif(tphi)
+	sxi := txi / hyp(txi)
+	dq := p.qZ * d_sn(txi, p.txi0, sxi, p.sxi0) * (txi - p.txi0)
+	drho := -p.a * dq / (math.Sqrt(p.m02-p.n0*dq) + p.nrho0/p.a)
+	theta := p.k2 * p.n0 * lam
+	stheta, ctheta := math.Sincos(theta)
+	t := p.nrho0 + p.n0*drho
+	var x, y float64
+	if p.n0 != 0 {
+		x = t * stheta / p.n0 / p.k0
+		if ctheta < 0 {
+			y = p.nrho0 * (1 - ctheta) / p.n0
+		} else {
+			y = p.nrho0 * sq(stheta) / (1 + ctheta) / p.n0
+		}
+	} else {
+		x = t * p.k2 * lam / p.k0
+	}
+	y = (y - drho*ctheta) / p.k0
+	k := p.k0
+	if t != 0 {
+		k *= t * hyp(p.fm*tphi) / p.a
+	}
+	return ProjectionForwardResult{
+		XM:             x,
+		YM:             y * p.sign,
+		ConvergenceDeg: p.sign * theta * RAD2DEG,
+		Scale:          k,
+	}
+}
+
+// Reverse finds the point at easting x_m and northing y_m with central meridian lon0_deg.
+// The scale in the result is the azimuthal scale; the radial scale is its reciprocal.
+func (p *AlbersEqualArea) Reverse(lon0_deg, x_m, y_m float64) ProjectionReverseResult {
+	epsx2 := sq(sq(get_epsilon()))
+	x := x_m
+	y := y_m * p.sign
+	nx := p.k0 * p.n0 * x
+	ny := p.k0 * y
+	y1 := p.nrho0 - p.n0*ny
+	den := math.Hypot(nx, y1) + p.nrho0 // 0 implies origin with polar aspect
+	drho := 0.0
+	if den != 0 {
+		drho = (p.k0*x*nx - 2*p.k0*y*p.nrho0 + p.k0*y*p.n0*ny) / den
+	}
+	// dsxia = scxi0 * dsxi
+	dsxia := -p.scxi0 * (2*p.nrho0 + p.n0*drho) * drho / (sq(p.a) * p.qZ)
+	txi := (p.txi0 + dsxia) / math.Sqrt(math.Max(1-dsxia*(2*p.txi0+dsxia), epsx2))
+	tphi := p._tphif(txi)
+	theta := math.Atan2(nx, y1)
+	lam := x / (y1 * p.k0)
+	if p.n0 != 0 {
+		lam = theta / (p.k2 * p.n0)
+	}
+	k := p.k0
+	if den != 0 {
+		k *= (p.nrho0 + p.n0*drho) * hyp(p.fm*tphi) / p.a
+	}
+	return ProjectionReverseResult{
+		LatDeg:         atand(p.sign * tphi),
+		LonDeg:         ang_normalize(lam*RAD2DEG + ang_normalize(lon0_deg)),
+		ConvergenceDeg: p.sign * theta * RAD2DEG,
+		Scale:          k,
+	}
+}
+
+// _atanhee returns atanh(e*x)/e if f > 0, atan(sqrt(-e2)*x)/sqrt(-e2) if f < 0, and x if
+// f = 0
+func (p *AlbersEqualArea) _atanhee(x float64) float64 {
+	if p.f > 0 {
+		return math.Atanh(p.e*x) / p.e
+	}
+	if p.f < 0 {
+		return math.Atan(p.e*x) / p.e
+	}
+	return x
+}
+
+// _d_atanhee is the divided difference of atanhee:
+// (atanhee(x)-atanhee(y))/(x-y) = atanhee((x-y)/(1-e^2*x*y))/(x-y)
+func (p *AlbersEqualArea) _d_atanhee(x, y float64) float64 {
+	t := x - y
+	d := 1 - p.e2*x*y
+	if t == 0 {
+		return 1 / d
+	}
+	if x*y < 0 {
+		return (p._atanhee(x) - p._atanhee(y)) / t
+	}
+	return p._atanhee(t/d) / t
+}
+
+// _dd_atanhee is the second divided difference of atanhee:
+// (Datanhee(1,y) - Datanhee(1,x))/(y-x)
+func (p *AlbersEqualArea) _dd_atanhee(x, y float64) float64 {
+	// This function is called with x = sphi1, y = sphi2, phi1 <= phi2, sphi2 >= 0,
+	// abs(sphi1) <= phi2. However for safety's sake we enforce x <= y.
+	if y < x {
+		x, y = y, x
+	}
+	q1 := math.Abs(p.e2)
+	q2 := math.Abs(2 * p.e / p.e2m * (1 - x))
+	if x <= 0 || !(math.Min(q1, q2) < 0.75) {
+		return p._dd_atanhee0(x, y)
+	}
+	if q1 < q2 {
+		return p._dd_atanhee1(x, y)
+	}
+	return p._dd_atanhee2(x, y)
+}
+
+// _dd_atanhee0 rearranges the difference so that 1 - x is in the denominator, and then
+// does a straight divided difference.
+func (p *AlbersEqualArea) _dd_atanhee0(x, y float64) float64 {
+	return (p._d_atanhee(1, y) - p._d_atanhee(x, y)) / (1 - x)
+}
+
+// _dd_atanhee1 is the expansion for e2 small
+func (p *AlbersEqualArea) _dd_atanhee1(x, y float64) float64 {
+	// The series in e2 is
+	//   sum( c[l] * e2^l, l, 1, N)
+	// where
+	//   c[l] = sum( x^i * y^j; i >= 0, j >= 0, i+j < 2*l) / (2*l + 1)
+	//        = ( (x-y) - (1-y) * x^(2*l+1) + (1-x) * y^(2*l+1) ) /
+	//          ( (2*l+1) * (x-y) * (1-y) * (1-x) )
+	// For x = y = 1, c[l] = l
+	//
+	// In the limit x,y -> 1,
+	//
+	//   DDatanhee -> e2/(1-e2)^2 = sum(l * e2^l, l, 1, inf)
+	//
+	// Use if e2 is sufficiently small.
+	s := 0.0
+	z, k, t, c, en := 1.0, 1.0, 0.0, 0.0, 1.0
+	for {
+		t = y*t + z
+		c += t
+		z *= x
+		t = y*t + z
+		c += t
+		z *= x
+		k += 2
+		en *= p.e2
+		// Here en = e2^l, k = 2*l + 1,
+		//   c = sum( x^i * y^j; i >= 0, j >= 0, i+j < 2*l) / (2*l + 1)
+		// Taylor expansion is
+		//   s = sum( c[l] * e2^l, l, 1, N)
+		ds := en * c / k
+		s += ds
+		// Iterate until the added term is sufficiently small
+		if !(math.Abs(ds) > math.Abs(s)*get_epsilon()/2) {
+			break
+		}
+	}
+	return s
+}
+
+// _dd_atanhee2 is the expansion for x (and y) close to 1
+func (p *AlbersEqualArea) _dd_atanhee2(x, y float64) float64 {
+	// If x and y are both close to 1, expand in Taylor series in dx = 1-x and
+	// dy = 1-y:
+	//
+	// DDatanhee = sum(C_m * (dx^(m+1) - dy^(m+1)) / (dx - dy), m, 0, inf)
+	//
+	// where
+	//
+	// C_m = sum( (m+2)!! / (m+2-2*k)!! *
+	//            ((m+1)/2)! / ((m+1)/2-k)! /
+	//            (k! * (2*k-1)!!) *
+	//            e2^((m+1)/2+k),
+	//           k, 0, (m+1)/2) * (-1)^m / ((m+2) * (1-e2)^(m+2))
+	// for m odd, and
+	//
+	// C_m = sum( 2 * (m+1)!! / (m+1-2*k)!! *
+	//            (m/2+1)! / (m/2-k)! /
+	//            (k! * (2*k+1)!!) *
+	//            e2^(m/2+1+k),
+	//           k, 0, m/2)) * (-1)^m / ((m+2) * (1-e2)^(m+2))
+	// for m even.
+	//
+	// Here i!! is the double factorial extended to negative i with
+	// i!! = (i+2)!!/(i+2).
+	//
+	// Use if (e2 * dx * dy) is sufficiently small.
+	dx, dy := 1-x, 1-y
+	xy, yy := 1.0, 1.0
+	ee := p.e2 / sq(p.e2m)
+	s := ee
+	for m := 1; ; m++ {
+		c := float64(m + 2)
+		t := c
+		yy *= dy // yy = dy^m
+		xy = dx*xy + yy
+		// Now xy = sum(dx^k * dy^(m-k), k, 0, m)
+		//        = (dx^(m+1) - dy^(m+1)) / (dx - dy)
+		// max value = (m+1) * max(dx,dy)^m
+		ee /= -p.e2m
+		if m%2 == 0 {
+			ee *= p.e2
+		}
+		// Now ee = (-1)^m * e2^(floor(m/2)+1) / (1-e2)^(m+2)
+		kmax := (m + 1) / 2
+		for k := kmax - 1; k >= 0; k-- {
+			// max coeff is less than 2^(m+1)
+			c *= float64((k + 1) * (2*(k+m-2*kmax) + 3))
+			c /= float64((kmax - k) * (2*(kmax-k) + 1))
+			// Horner sum for inner e2 series
+			t = p.e2*t + c
+		}
+		// Straight sum for outer m series
+		ds := t * ee * xy / float64(m+2)
+		s += ds
+		// Iterate until the added term is sufficiently small
+		if !(math.Abs(ds) > math.Abs(s)*get_epsilon()/2) {
+			break
+		}
+	}
+	return s
+}
+
+// atanhxm1 returns atanh(sqrt(x))/sqrt(x) - 1, accurate for small x
+func atanhxm1(x float64) float64 {
+	s := 0.0
+	if math.Abs(x) < 0.5 {
+		lg2eps := -math.Log2(get_epsilon() / 2)
+		_, e := math.Frexp(x)
+		e = -e
+		// x = [0.5,1) * 2^(-e)
+		// estimate n s.t. x^n/(2*n+1) < x/3 * 2^-digits
+		n := 1
+		if x != 0 {
+			n = int(math.Ceil(lg2eps/float64(e))) + 1
+		}
+		// iterating from n-1 down to 0
+		for n--; n >= 0; n-- {
+			d := 0.0
+			if n != 0 {
+				d = 1
+			}
+			s = x*s + d/float64(2*n+1)
+		}
+	} else {
+		xs := math.Sqrt(math.Abs(x))
+		if x > 0 {
+			s = math.Atanh(xs)/xs - 1
+		} else {
+			s = math.Atan(xs)/xs - 1
+		}
+	}
+	return s
+}
+
+// _txif returns tan(xi) given tan(phi), where xi is the authalic latitude
+func (p *AlbersEqualArea) _txif(tphi float64) float64 {
+	// sxi = ( sphi/(1-e2*sphi^2) + atanhee(sphi) ) /
+	//       ( 1/(1-e2) + atanhee(1) )
+	//
+	// txi = ( sphi/(1-e2*sphi^2) + atanhee(sphi) ) /
+	//       sqrt( ( (1+e2*sphi)*(1-sphi)/( (1-e2*sphi^2) * (1-e2) ) +
+	//               atanhee((1-sphi)/(1-e2*sphi)) ) *
+	//             ( (1-e2*sphi)*(1+sphi)/( (1-e2*sphi^2) * (1-e2) ) +
+	//               atanhee((1+sphi)/(1+e2*sphi)) ) )
+	//
+	// subst 1-sphi = cphi^2/(1+sphi)
+	s := 1.0 // Enforce odd parity
+	if tphi < 0 {
+		s = -1
+	}
+	tphi *= s
+	cphi2 := 1 / (1 + sq(tphi))
+	sphi := tphi * math.Sqrt(cphi2)
+	es1 := p.e2 * sphi
+	es2m1 := 1 - es1*sphi
+	sp1 := 1 + sphi
+	es1m1 := (1 - es1) * sp1
+	es2m1a := p.e2m * es2m1
+	es1p1 := sp1 / (1 + es1)
+	return s * (sphi/es2m1 + p._atanhee(sphi)) /
+		math.Sqrt((cphi2/(es1p1*es2m1a)+p._atanhee(cphi2/es1m1))*
+			(es1m1/es2m1a+p._atanhee(es1p1)))
+}
+
+// _tphif returns tan(phi) given tan(xi), where xi is the authalic latitude
+func (p *AlbersEqualArea) _tphif(txi float64) float64 {
+	tphi := txi
+	stol := math.Sqrt(get_epsilon()) * math.Max(1, math.Abs(txi))
+	for i := 0; i < _ALBERS_NUMIT; i++ {
+		// dtxi/dtphi = (scxi/scphi)^3 * 2*(1-e^2)/(qZ*(1-e^2*sphi^2)^2)
+		txia := p._txif(tphi)
+		tphi2 := sq(tphi)
+		scphi2 := 1 + tphi2
+		scterm := scphi2 / (1 + sq(txia))
+		dtphi := (txi - txia) * scterm * math.Sqrt(scterm) *
+			p.qx * sq(1-p.e2*tphi2/scphi2)
+		tphi += dtphi
+		if !(math.Abs(dtphi) >= stol) {
+			break
+		}
+	}
+	return tphi
+}
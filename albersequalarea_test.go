@@ -0,0 +1,183 @@
+package geographiclibgo
+
+import (
+	"math"
+	"testing"
+)
+
+func TestAlbersEqualAreaSnyder(t *testing.T) {
+	// Snyder (1987), p. 292: Clarke 1866, standard parallels 29.5 and 45.5, origin at 23N
+	// 96W, the point 35N 75W is at x = 1885472.7, y = 1535925.0 with k = 0.9915546
+	alb := NewAlbersEqualAreaTwoParallels(6378206.4, 1/294.9786982, 29.5, 45.5, 1)
+	fwd := alb.Forward(-96, 35, -75)
+	origin := alb.Forward(-96, 23, -96)
+	if !almost_equal(fwd.XM, 1885472.7, 0.05) {
+		t.Errorf("x = %v; want %v", fwd.XM, 1885472.7)
+	}
+	if !almost_equal(fwd.YM-origin.YM, 1535925.0, 0.05) {
+		t.Errorf("y = %v; want %v", fwd.YM-origin.YM, 1535925.0)
+	}
+	if !almost_equal(fwd.Scale, 0.9915546, 1e-7) {
+		t.Errorf("k = %v; want %v", fwd.Scale, 0.9915546)
+	}
+}
+
+func TestAlbersEqualAreaStandardParallels(t *testing.T) {
+	testCases := []struct {
+		desc             string
+		f                float64
+		stdlat1, stdlat2 float64
+	}{
+		{desc: "wgs84 conus", f: WGS84_F, stdlat1: 29.5, stdlat2: 45.5},
+		{desc: "wgs84 wide", f: WGS84_F, stdlat1: 10, stdlat2: 80},
+		{desc: "wgs84 southern", f: WGS84_F, stdlat1: -20, stdlat2: -21},
+		{desc: "wgs84 close", f: WGS84_F, stdlat1: 30, stdlat2: 30.000001},
+		{desc: "wgs84 straddling equator", f: WGS84_F, stdlat1: -10, stdlat2: 20},
+		{desc: "wgs84 near pole", f: WGS84_F, stdlat1: 89, stdlat2: 89.5},
+		// With parallels symmetric about the equator the projection is the cylindrical
+		// equal area projection
+		{desc: "wgs84 cylindrical", f: WGS84_F, stdlat1: -30, stdlat2: 30},
+		{desc: "prolate", f: -1 / 150.0, stdlat1: 60, stdlat2: 70},
+		{desc: "sphere", f: 0, stdlat1: 60, stdlat2: 70},
+		{desc: "very oblate", f: 0.2, stdlat1: 10, stdlat2: 80},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			alb := NewAlbersEqualAreaTwoParallels(WGS84_A, tC.f, tC.stdlat1, tC.stdlat2, 1)
+			ell := NewEllipsoid(WGS84_A, tC.f)
+			// meridian_scale returns h, the scale along the meridian at lat, from the
+			// change in y over 2e-4 degrees of latitude
+			meridian_scale := func(lat float64) float64 {
+				const d = 1e-4
+				dy := alb.Forward(0, lat+d, 0).YM - alb.Forward(0, lat-d, 0).YM
+				return dy / (ell.MeridionalCurvatureRadius(lat) * 2 * d * DEG2RAD)
+			}
+			// The parallels are true to scale, so the meridians are too
+			for _, lat := range []float64{tC.stdlat1, tC.stdlat2} {
+				if k := alb.Forward(0, lat, 5).Scale; !almost_equal(k, 1, 1e-14) {
+					t.Errorf("k at %v = %v; want 1", lat, k)
+				}
+				if h := meridian_scale(lat); !almost_equal(h, 1, 1e-8) {
+					t.Errorf("h at %v = %v; want 1", lat, h)
+				}
+			}
+			lat0 := alb.OriginLatitude()
+			if !(lat0 >= math.Min(tC.stdlat1, tC.stdlat2) && lat0 <= math.Max(tC.stdlat1, tC.stdlat2)) {
+				t.Errorf("lat0 = %v; want between %v and %v", lat0, tC.stdlat1, tC.stdlat2)
+			}
+			if tC.stdlat1 == -tC.stdlat2 && lat0 != 0 {
+				t.Errorf("lat0 = %v; want 0", lat0)
+			}
+			// Away from the standard parallels h and k differ, but the area scale h k is
+			// still 1
+			for _, lat := range []float64{lat0, math.Min(tC.stdlat1, tC.stdlat2) - 5} {
+				h, k := meridian_scale(lat), alb.Forward(0, lat, 0).Scale
+				if !almost_equal(h*k, 1, 1e-8) {
+					t.Errorf("h k at %v = %v * %v; want 1", lat, h, k)
+				}
+			}
+		})
+	}
+}
+
+func TestAlbersEqualAreaIsEqualArea(t *testing.T) {
+	// The product of the scales along the meridian and the parallel is 1
+	g := Wgs84()
+	alb := NewAlbersEqualAreaTwoParallels(WGS84_A, WGS84_F, 29.5, 45.5, 1)
+	const h = 1e-5
+	for _, lat := range []float64{-60, 0, 20, 40, 70} {
+		p0 := alb.Forward(-96, lat, -80)
+		pn := alb.Forward(-96, lat+h, -80)
+		pe := alb.Forward(-96, lat, -80+h)
+		kn := math.Hypot(pn.XM-p0.XM, pn.YM-p0.YM) / g.InverseCalcDistance(lat, -80, lat+h, -80)
+		ke := math.Hypot(pe.XM-p0.XM, pe.YM-p0.YM) / g.InverseCalcDistance(lat, -80, lat, -80+h)
+		if !almost_equal(kn*ke, 1, 1e-6) {
+			t.Errorf("lat = %v: area scale = %v; want 1", lat, kn*ke)
+		}
+		if !almost_equal(ke, p0.Scale, 1e-6) {
+			t.Errorf("lat = %v: k = %v; want %v", lat, p0.Scale, ke)
+		}
+	}
+}
+
+func TestAlbersEqualAreaRoundTrip(t *testing.T) {
+	alb := NewAlbersEqualAreaTwoParallels(WGS84_A, WGS84_F, 29.5, 45.5, 1)
+	for lat := -80.0; lat < 90; lat += 10 {
+		for lon := -170.0; lon < 180; lon += 20 {
+			fwd := alb.Forward(-96, lat, lon)
+			rev := alb.Reverse(-96, fwd.XM, fwd.YM)
+			if !almost_equal(rev.LatDeg, lat, 1e-10) || !almost_equal(rev.LonDeg, lon, 1e-11) {
+				t.Errorf("Reverse(Forward(%v, %v)) = (%v, %v)", lat, lon, rev.LatDeg, rev.LonDeg)
+			}
+			if !almost_equal(rev.ConvergenceDeg, fwd.ConvergenceDeg, 1e-12) {
+				t.Errorf("(%v, %v) reverse gamma = %v; want %v", lat, lon, rev.ConvergenceDeg, fwd.ConvergenceDeg)
+			}
+		}
+	}
+}
+
+func TestAlbersEqualAreaLimits(t *testing.T) {
+	// echo -30 0 | ConicProj -a -30 -30
+	alb := NewAlbersEqualArea(WGS84_A, WGS84_F, -30, 1)
+	fwd := alb.Forward(0, -30, 0)
+	if fwd.XM != 0 || fwd.YM != 0 || fwd.ConvergenceDeg != 0 || fwd.Scale != 1 {
+		t.Errorf("Forward(-30, 0) = %v; want {0 0 0 1}", fwd)
+	}
+	// echo 0 0 | ConicProj -a -30 -30 -r
+	rev := alb.Reverse(0, 0, 0)
+	if !almost_equal(rev.LatDeg, -30, 1e-14) || rev.LonDeg != 0 || rev.Scale != 1 {
+		t.Errorf("Reverse(0, 0) = %v; want {-30 0 0 1}", rev)
+	}
+
+	// A prolate ellipsoid with e^2 < -0.04 once caused an infinite loop
+	alb = NewAlbersEqualArea(1, -0.05, 0, 1)
+	rev = alb.Reverse(0, 0, 0)
+	if rev.LatDeg != 0 || rev.LonDeg != 0 {
+		t.Errorf("prolate Reverse(0, 0) = %v; want {0 0 0 1}", rev)
+	}
+
+	// The polar aspect is the Lambert azimuthal equal-area projection, for which the
+	// opposite pole is at a finite distance
+	alb = NewAlbersEqualArea(WGS84_A, WGS84_F, -90, 1)
+	fwd = alb.Forward(0, -90, 0)
+	if fwd.XM != 0 || fwd.YM != 0 || fwd.Scale != 1 {
+		t.Errorf("Forward(-90, 0) = %v; want {0 0 0 1}", fwd)
+	}
+	if y := alb.Forward(0, 90, 0).YM; math.IsInf(y, 0) || math.IsNaN(y) {
+		t.Errorf("Forward(90, 0) y = %v; want finite", y)
+	}
+	if rev := alb.Reverse(0, 0, 0); rev.LatDeg != -90 {
+		t.Errorf("Reverse(0, 0) lat = %v; want -90", rev.LatDeg)
+	}
+}
+
+func TestAlbersEqualAreaSetScale(t *testing.T) {
+	alb := NewAlbersEqualArea(WGS84_A, WGS84_F, 40, 1)
+	if err := alb.SetScale(50, 1); err != nil {
+		t.Fatalf("SetScale() error = %v", err)
+	}
+	if k := alb.Forward(0, 50, 0).Scale; !almost_equal(k, 1, 1e-14) {
+		t.Errorf("k at 50 = %v; want 1", k)
+	}
+
+	if err := alb.SetScale(45, 0); err == nil {
+		t.Errorf("SetScale(45, 0) error = nil; want non-nil")
+	}
+	if err := alb.SetScale(-90, 1); err == nil {
+		t.Errorf("SetScale(-90, 1) error = nil; want non-nil")
+	}
+}
+
+func BenchmarkAlbersEqualAreaForward(b *testing.B) {
+	alb := NewAlbersEqualAreaTwoParallels(WGS84_A, WGS84_F, 29.5, 45.5, 1)
+	for i := 0; i < b.N; i++ {
+		alb.Forward(-96, 35, -75)
+	}
+}
+
+func BenchmarkAlbersEqualAreaReverse(b *testing.B) {
+	alb := NewAlbersEqualAreaTwoParallels(WGS84_A, WGS84_F, 29.5, 45.5, 1)
+	for i := 0; i < b.N; i++ {
+		alb.Reverse(-96, 1885472.7, 1535925.0)
+	}
+}
@@ -0,0 +1,93 @@
+package geographiclibgo
+
+import "math"
+
+// GeodesicProjectionForwardResult is the result of projecting a point with one of the
+// projections built on geodesics (AzimuthalEquidistant, CassiniSoldner, Gnomonic)
+type GeodesicProjectionForwardResult struct {
+	XM              float64 // Easting of the point [meters]
+	YM              float64 // Northing of the point [meters]
+	AziDeg          float64 // Azimuth of the geodesic through the point [degrees]
+	ReciprocalScale float64 // Reciprocal of the azimuthal scale at the point
+}
+
+// GeodesicProjectionReverseResult is the result of finding the point corresponding to a
+// position on a map for one of the projections built on geodesics
+type GeodesicProjectionReverseResult struct {
+	LatDeg          float64 // Latitude of the point [degrees]
+	LonDeg          float64 // Longitude of the point [degrees]
+	AziDeg          float64 // Azimuth of the geodesic through the point [degrees]
+	ReciprocalScale float64 // Reciprocal of the azimuthal scale at the point
+}
+
+// AzimuthalEquidistant is the azimuthal equidistant projection centered at an arbitrary
+// position on the ellipsoid. For a point in projected space (x, y), the geodesic distance
+// from the center position is hypot(x, y) and the azimuth of the geodesic from the
+// center point is atan2(x, y). The Forward and Reverse methods also return the azimuth
+// of the geodesic at the point and the reciprocal scale in the azimuthal direction, which
+// multiplied by the distance gives the reduced length m12. The scale in the radial
+// direction is 1.
+//
+// This projection is useful for drawing range rings: the geodesic circle of radius r
+// about the center is the circle x^2 + y^2 = r^2.
+type AzimuthalEquidistant struct {
+	eps   float64
+	earth Geodesic
+}
+
+// NewAzimuthalEquidistant creates an AzimuthalEquidistant projection for the ellipsoid of
+// the geodesic calculator earth
+func NewAzimuthalEquidistant(earth Geodesic) AzimuthalEquidistant {
+	return AzimuthalEquidistant{
+		eps:   0.01 * math.Sqrt(get_min_val()),
+		earth: earth,
+	}
+}
+
+// EquatorialRadius returns the equatorial radius of the ellipsoid [meters]
+func (p *AzimuthalEquidistant) EquatorialRadius() float64 {
+	return p.earth.a
+}
+
+// Flattening returns the flattening of the ellipsoid
+func (p *AzimuthalEquidistant) Flattening() float64 {
+	return p.earth.f
+}
+
+// Forward projects the point lat_deg, lon_deg onto the map centered at lat0_deg,
+// lon0_deg. The point is always projected, no matter how far it is from the center.
+//   - lat0_deg, lon0_deg - Center of the projection [degrees]
+//   - lat_deg, lon_deg - The point [degrees]
+func (p *AzimuthalEquidistant) Forward(
+	lat0_deg, lon0_deg, lat_deg, lon_deg float64,
+) GeodesicProjectionForwardResult {
+	sig, s, azi0, azi, m, _, _, _ := p.earth._gen_inverse_azi(
+		lat0_deg, lon0_deg, lat_deg, lon_deg, DISTANCE|AZIMUTH|REDUCEDLENGTH,
+	)
+	x, y := sincosd(azi0)
+	rk := 1.0
+	if !(sig <= p.eps) {
+		rk = m / s
+	}
+	return GeodesicProjectionForwardResult{XM: x * s, YM: y * s, AziDeg: azi, ReciprocalScale: rk}
+}
+
+// Reverse finds the point at x_m, y_m on the map centered at lat0_deg, lon0_deg. The
+// point is found for any x_m and y_m; if hypot(x_m, y_m) exceeds the distance to the
+// antipode, the point lies beyond it on the geodesic from the center.
+//   - lat0_deg, lon0_deg - Center of the projection [degrees]
+//   - x_m, y_m - Easting and northing of the point [meters]
+func (p *AzimuthalEquidistant) Reverse(
+	lat0_deg, lon0_deg, x_m, y_m float64,
+) GeodesicProjectionReverseResult {
+	azi0 := atan2_deg(x_m, y_m)
+	s := math.Hypot(x_m, y_m)
+	sig, lat, lon, azi, _, m, _, _, _, _ := p.earth._gen_direct(
+		lat0_deg, lon0_deg, azi0, false, s, LATITUDE|LONGITUDE|AZIMUTH|REDUCEDLENGTH,
+	)
+	rk := 1.0
+	if !(sig <= p.eps) {
+		rk = m / s
+	}
+	return GeodesicProjectionReverseResult{LatDeg: lat, LonDeg: lon, AziDeg: azi, ReciprocalScale: rk}
+}
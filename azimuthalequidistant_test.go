@@ -0,0 +1,64 @@
+package geographiclibgo
+
+import (
+	"math"
+	"testing"
+)
+
+func TestAzimuthalEquidistantRoundTrip(t *testing.T) {
+	g := Wgs84()
+	p := NewAzimuthalEquidistant(g)
+	lat0, lon0 := 42.0, -71.0
+	for _, pt := range []LatLon{{42, -71}, {43, -70}, {-33, 151}, {89, 20}, {-41, 108}} {
+		fwd := p.Forward(lat0, lon0, pt.LatDeg, pt.LonDeg)
+		inv := g.InverseCalcAll(lat0, lon0, pt.LatDeg, pt.LonDeg)
+		if !almost_equal(math.Hypot(fwd.XM, fwd.YM), inv.DistanceM, 1e-8) {
+			t.Errorf("%v: hypot(x, y) = %v; want %v", pt, math.Hypot(fwd.XM, fwd.YM), inv.DistanceM)
+		}
+		if inv.DistanceM > 0 && !almost_equal(fwd.ReciprocalScale, inv.ReducedLengthM/inv.DistanceM, 1e-15) {
+			t.Errorf("%v: rk = %v; want %v", pt, fwd.ReciprocalScale, inv.ReducedLengthM/inv.DistanceM)
+		}
+
+		rev := p.Reverse(lat0, lon0, fwd.XM, fwd.YM)
+		if !almost_equal(rev.LatDeg, pt.LatDeg, 1e-12) || !almost_equal(rev.LonDeg, pt.LonDeg, 1e-12) {
+			t.Errorf("Reverse(Forward(%v)) = (%v, %v)", pt, rev.LatDeg, rev.LonDeg)
+		}
+		if !almost_equal(rev.AziDeg, fwd.AziDeg, 1e-10) {
+			t.Errorf("%v: reverse azi = %v; want %v", pt, rev.AziDeg, fwd.AziDeg)
+		}
+		if !almost_equal(rev.ReciprocalScale, fwd.ReciprocalScale, 1e-14) {
+			t.Errorf("%v: reverse rk = %v; want %v", pt, rev.ReciprocalScale, fwd.ReciprocalScale)
+		}
+	}
+
+	// The center maps to the origin with unit scale
+	got := p.Forward(lat0, lon0, lat0, lon0)
+	if got.XM != 0 || got.YM != 0 || got.ReciprocalScale != 1 {
+		t.Errorf("Forward(center) = %+v; want x = y = 0, rk = 1", got)
+	}
+}
+
+func TestAzimuthalEquidistantSphere(t *testing.T) {
+	// On a sphere, x = R * c sin(azi) and y = R * c cos(azi), and rk = sin(c)/c, where c
+	// is the angular distance from the center
+	rR := 6371000.0
+	p := NewAzimuthalEquidistant(NewGeodesic(rR, 0))
+	got := p.Forward(0, 0, 0, 60)
+	c := math.Pi / 3
+	if !almost_equal(got.XM, rR*c, 1e-6) || !almost_equal(got.YM, 0, 1e-6) {
+		t.Errorf("Forward(0, 60) = (%v, %v); want (%v, 0)", got.XM, got.YM, rR*c)
+	}
+	if !almost_equal(got.ReciprocalScale, math.Sin(c)/c, 1e-15) {
+		t.Errorf("rk = %v; want %v", got.ReciprocalScale, math.Sin(c)/c)
+	}
+	if !almost_equal(got.AziDeg, 90, 1e-13) {
+		t.Errorf("azi = %v; want 90", got.AziDeg)
+	}
+}
+
+func BenchmarkAzimuthalEquidistantForward(b *testing.B) {
+	p := NewAzimuthalEquidistant(Wgs84())
+	for i := 0; i < b.N; i++ {
+		p.Forward(42, -71, -33, 151)
+	}
+}
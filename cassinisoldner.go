@@ -0,0 +1,124 @@
+package geographiclibgo
+
+import "math"
+
+// CassiniSoldner is the Cassini-Soldner projection centered at an arbitrary position,
+// lat0, lon0, on the ellipsoid. This projection is a transverse cylindrical equidistant
+// projection. The projection from (lat, lon) to easting and northing (x, y) is defined
+// by geodesics as follows. Go north along a geodesic a distance y from the central point;
+// then turn clockwise 90 degrees and go a distance x along a geodesic. (Although the
+// initial heading is north, this changes to south if the pole is crossed.) This procedure
+// uniquely defines the reverse projection. The forward projection is constructed as
+// follows. Find the point (lat1, lon1) on the meridian closest to (lat, lon). Here we
+// consider the full meridian so that lon1 may be either lon0 or lon0 + 180. x is the
+// geodesic distance from (lat1, lon1) to (lat, lon), appropriately signed according to
+// which side of the central meridian (lat, lon) lies. y is the shortest distance along
+// the meridian from (lat0, lon0) to (lat1, lon1), again, appropriately signed according
+// to the initial heading. [Note that, in the case of prolate ellipsoids, the shortest
+// meridional path from (lat0, lon0) to (lat1, lon1) may not be the shortest path.] This
+// procedure uniquely defines the forward projection except for a small class of points
+// for which there may be two equally short routes for either leg of the path.
+//
+// The azimuth returned is that of the geodesic perpendicular to the central meridian
+// through the point, and the reciprocal scale is the reciprocal of the scale in the
+// northing direction. The scale in the easting direction is 1.
+type CassiniSoldner struct {
+	earth    Geodesic
+	meridian GeodesicLine
+	sbet0    float64
+	cbet0    float64
+}
+
+// NewCassiniSoldner creates a CassiniSoldner projection centered at lat0_deg, lon0_deg
+// for the ellipsoid of the geodesic calculator earth
+func NewCassiniSoldner(earth Geodesic, lat0_deg, lon0_deg float64) CassiniSoldner {
+	p := CassiniSoldner{earth: earth}
+	p.Reset(lat0_deg, lon0_deg)
+	return p
+}
+
+// Reset sets the central point of the projection to lat0_deg, lon0_deg
+func (p *CassiniSoldner) Reset(lat0_deg, lon0_deg float64) {
+	p.meridian = p.earth.LineWithCapabilities(
+		lat0_deg, lon0_deg, 0.0, LATITUDE|LONGITUDE|DISTANCE|DISTANCE_IN|AZIMUTH,
+	)
+	sbet0, cbet0 := sincosd(p.meridian.Latitude())
+	p.sbet0, p.cbet0 = norm(sbet0*(1-p.earth.f), cbet0)
+}
+
+// LatitudeOrigin returns the latitude of the center of the projection [degrees]
+func (p *CassiniSoldner) LatitudeOrigin() float64 {
+	return p.meridian.Latitude()
+}
+
+// LongitudeOrigin returns the longitude of the center of the projection [degrees]
+func (p *CassiniSoldner) LongitudeOrigin() float64 {
+	return p.meridian.Longitude()
+}
+
+// EquatorialRadius returns the equatorial radius of the ellipsoid [meters]
+func (p *CassiniSoldner) EquatorialRadius() float64 {
+	return p.earth.a
+}
+
+// Flattening returns the flattening of the ellipsoid
+func (p *CassiniSoldner) Flattening() float64 {
+	return p.earth.f
+}
+
+// Forward projects the point lat_deg, lon_deg. A point whose closest point on the central
+// meridian is on the far side of the pole has a northing beyond the pole.
+func (p *CassiniSoldner) Forward(lat_deg, lon_deg float64) GeodesicProjectionForwardResult {
+	dlon, _ := ang_diff(p.LongitudeOrigin(), lon_deg)
+	sig12, s12, azi1, azi2, _, _, _, _ := p.earth._gen_inverse_azi(
+		lat_deg, -math.Abs(dlon), lat_deg, math.Abs(dlon), DISTANCE|AZIMUTH,
+	)
+	sig12 *= 0.5
+	s12 *= 0.5
+	if s12 == 0 {
+		da, _ := ang_diff(azi1, azi2)
+		da /= 2
+		if math.Abs(dlon) <= 90 {
+			azi1 = 90 - da
+			azi2 = 90 + da
+		} else {
+			azi1 = -90 - da
+			azi2 = -90 + da
+		}
+	}
+	if math.Signbit(dlon) {
+		azi2 = azi1
+		s12 = -s12
+		sig12 = -sig12
+	}
+	azi := ang_normalize(azi2)
+	perp := p.earth.LineWithCapabilities(lat_deg, dlon, azi, GEODESICSCALE)
+	// Solve for the point where perp crosses the central meridian
+	_, _, _, _, _, _, _, rk, _ := perp._gen_position(true, -sig12, GEODESICSCALE)
+
+	salp0, calp0 := sincosd(perp.EquatorialAzimuth())
+	sbet1 := calp0
+	if lat_deg < 0 {
+		sbet1 = -calp0
+	}
+	cbet1 := math.Abs(salp0)
+	if math.Abs(dlon) > 90 {
+		cbet1 = -cbet1
+	}
+	sbet01 := sbet1*p.cbet0 - cbet1*p.sbet0
+	cbet01 := cbet1*p.cbet0 + sbet1*p.sbet0
+	sig01 := math.Atan2(sbet01, cbet01) * RAD2DEG
+	_, _, _, _, y, _, _, _, _ := p.meridian._gen_position(true, sig01, DISTANCE)
+	return GeodesicProjectionForwardResult{XM: s12, YM: y, AziDeg: azi, ReciprocalScale: rk}
+}
+
+// Reverse finds the point at easting x_m and northing y_m
+func (p *CassiniSoldner) Reverse(x_m, y_m float64) GeodesicProjectionReverseResult {
+	_, lat1, lon1, azi0, _, _, _, _, _ := p.meridian._gen_position(
+		false, y_m, LATITUDE|LONGITUDE|AZIMUTH,
+	)
+	_, lat, lon, azi, _, _, rk, _, _, _ := p.earth._gen_direct(
+		lat1, lon1, azi0+90, false, x_m, LATITUDE|LONGITUDE|AZIMUTH|GEODESICSCALE,
+	)
+	return GeodesicProjectionReverseResult{LatDeg: lat, LonDeg: lon, AziDeg: azi, ReciprocalScale: rk}
+}
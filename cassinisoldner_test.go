@@ -0,0 +1,64 @@
+package geographiclibgo
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCassiniSoldnerRoundTrip(t *testing.T) {
+	p := NewCassiniSoldner(Wgs84(), 45, 10)
+	for _, pt := range []LatLon{{45, 10}, {46, 12}, {40, 5}, {-10, 30}, {80, 100}, {0, 10}} {
+		fwd := p.Forward(pt.LatDeg, pt.LonDeg)
+		rev := p.Reverse(fwd.XM, fwd.YM)
+		if !almost_equal(rev.LatDeg, pt.LatDeg, 1e-11) || !almost_equal(rev.LonDeg, pt.LonDeg, 1e-11) {
+			t.Errorf("Reverse(Forward(%v)) = (%v, %v)", pt, rev.LatDeg, rev.LonDeg)
+		}
+		if !almost_equal(rev.AziDeg, fwd.AziDeg, 1e-10) {
+			t.Errorf("%v: reverse azi = %v; want %v", pt, rev.AziDeg, fwd.AziDeg)
+		}
+		if !almost_equal(rev.ReciprocalScale, fwd.ReciprocalScale, 1e-12) {
+			t.Errorf("%v: reverse rk = %v; want %v", pt, rev.ReciprocalScale, fwd.ReciprocalScale)
+		}
+	}
+}
+
+func TestCassiniSoldnerCentralMeridian(t *testing.T) {
+	// On the central meridian, x = 0 and y is the meridian distance from the center
+	g := Wgs84()
+	p := NewCassiniSoldner(g, 45, 10)
+	if p.LatitudeOrigin() != 45 || p.LongitudeOrigin() != 10 {
+		t.Errorf("origin = (%v, %v); want (45, 10)", p.LatitudeOrigin(), p.LongitudeOrigin())
+	}
+	for _, lat := range []float64{-30, 0, 44, 45, 60} {
+		got := p.Forward(lat, 10)
+		want := math.Copysign(g.InverseCalcDistance(45, 10, lat, 10), lat-45)
+		if !almost_equal(got.XM, 0, 1e-9) {
+			t.Errorf("lat = %v: x = %v; want 0", lat, got.XM)
+		}
+		if !almost_equal(got.YM, want, 1e-8) {
+			t.Errorf("lat = %v: y = %v; want %v", lat, got.YM, want)
+		}
+		if !almost_equal(got.ReciprocalScale, 1, 1e-15) {
+			t.Errorf("lat = %v: rk = %v; want 1", lat, got.ReciprocalScale)
+		}
+	}
+
+	// Moving the origin along the meridian shifts the northing
+	p.Reset(0, 10)
+	got := p.Forward(45, 11)
+	want := p.Forward(0, 10)
+	if !almost_equal(want.YM, 0, 1e-9) {
+		t.Errorf("y at the new origin = %v; want 0", want.YM)
+	}
+	q := NewCassiniSoldner(g, 45, 10)
+	if d := q.Forward(45, 11).YM + g.InverseCalcDistance(0, 10, 45, 10); !almost_equal(got.YM, d, 1e-8) {
+		t.Errorf("y after Reset = %v; want %v", got.YM, d)
+	}
+}
+
+func BenchmarkCassiniSoldnerForward(b *testing.B) {
+	p := NewCassiniSoldner(Wgs84(), 45, 10)
+	for i := 0; i < b.N; i++ {
+		p.Forward(46, 12)
+	}
+}
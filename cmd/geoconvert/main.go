@@ -0,0 +1,485 @@
+// Command geoconvert converts coordinates between geographic, UTM/UPS, MGRS, Geohash,
+// GARS, Georef and OSGB grid reference forms. It is modeled on GeographicLib's
+// GeoConvert utility and accepts the same options, together with options for the grid
+// systems GeoConvert does not support.
+//
+// Each line of input is a point, given as
+//
+//	lat lon                  e.g. 33.3 44.4 or 33d18'N 44d24'E
+//	zone easting northing    e.g. 38n 444140 3684706 (UTM/UPS)
+//	mgrs                     e.g. 38SMB4414084706
+//
+// or, with --input-format, as a Geohash, GARS, Georef or OSGB grid reference. Each point
+// is printed in the chosen output form. Errors in the input are reported on the output
+// line as "ERROR: ..." and processing continues; the exit status is then 1. Run
+// geoconvert -h for the list of options.
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+
+	geographiclibgo "github.com/natemcintosh/geographiclib-go"
+	"github.com/natemcintosh/geographiclib-go/internal/cli"
+)
+
+const usage = `Usage: geoconvert [ -g | -d | -: | -u | -m | -c | --geohash | --gars | --georef |
+    --osgb ] [ -z zone | -s | -t ] [ -n ] [ -w ] [ -p prec ] [ -l | -a ]
+    [ --input-format format ] [ --comment-delimiter commentdelim ]
+    [ --input-string instring | --input-file infile ] [ --output-file outfile ] [ -h ]
+
+Convert coordinates, reading points from standard input, one per line. A point is
+"lat lon", a UTM/UPS coordinate "zone easting northing", or an MGRS reference.
+
+Output forms:
+  -g       latitude and longitude in decimal degrees (the default)
+  -d       latitude and longitude in degrees, minutes, and seconds
+  -:       like -d, with the components separated by colons
+  -u       UTM or UPS "zone easting northing"
+  -m       MGRS
+  -c       the meridian convergence [degrees] and scale of the UTM or UPS projection
+  --geohash
+           Geohash
+  --gars   GARS
+  --georef World Geographic Reference System (Georef)
+  --osgb   Ordnance Survey National Grid reference. Latitudes and longitudes are
+           taken to be on OSGB36; no datum shift is applied.
+
+Options:
+  -z zone  use UTM zone zone, in [1, 60], or UPS for zone 0 (also "n" or "s"), for the
+           UTM/UPS, MGRS and convergence output
+  -s       use the standard UTM or UPS zone, instead of the zone of UTM/UPS or MGRS input
+  -t       like -s, but use UTM even in the polar regions
+  -n       MGRS input refers to the south-west corner of the square, instead of its
+           center
+  -w       longitude first in geographic input and output
+  -p prec  set the precision relative to 1 m, in [-5, 9], default 0. Decimal degrees
+           have prec + 5 digits after the decimal point, MGRS has prec + 5 digits in
+           each coordinate, and the grid codes are sized to about 10^-prec m.
+  -l       print the UTM/UPS hemisphere in full, e.g. "38north"
+  -a       print the UTM/UPS hemisphere as a letter, e.g. "38n" (the default)
+  --input-format format
+           read points as format, one of auto (the default), geohash, gars, georef, or
+           osgb
+  --comment-delimiter commentdelim
+           ignore everything on an input line after commentdelim, and echo it after
+           the output
+  --input-string instring
+           read points from instring, with ";" separating them
+  --input-file infile
+           read points from infile ("-" for standard input)
+  --output-file outfile
+           write results to outfile ("-" for standard output)
+  -h       print this help
+`
+
+// The output forms
+const (
+	_OUT_GEOGRAPHIC = iota
+	_OUT_DMS
+	_OUT_UTMUPS
+	_OUT_MGRS
+	_OUT_CONVERGENCE
+	_OUT_GEOHASH
+	_OUT_GARS
+	_OUT_GEOREF
+	_OUT_OSGB
+)
+
+// options holds the parsed command line
+type options struct {
+	output       int
+	dmssep       rune
+	setzone      int
+	mgrscenter   bool
+	longfirst    bool
+	prec         int
+	abbrev       bool
+	inputformat  string
+	commentdelim string
+	instring     string
+	infile       string
+	outfile      string
+}
+
+// parse_zone parses the argument of -z, a zone number or a zone designation such as
+// "38n" or "s"
+func parse_zone(s string) (int, error) {
+	if zone, err := strconv.Atoi(s); err == nil {
+		if zone < geographiclibgo.ZONE_UPS || zone > geographiclibgo.ZONE_MAX_UTM {
+			return 0, fmt.Errorf("zone %d not in [0, 60]", zone)
+		}
+		return zone, nil
+	}
+	zone, _, err := geographiclibgo.DecodeZone(s)
+	if err == nil && zone == geographiclibgo.ZONE_INVALID {
+		err = fmt.Errorf("bad zone %s", s)
+	}
+	return zone, err
+}
+
+// parse_args parses the command line arguments, not including the program name. help
+// is true if -h was given.
+func parse_args(args []string) (opts options, help bool, err error) {
+	opts = options{
+		setzone: geographiclibgo.ZONE_MATCH, mgrscenter: true, abbrev: true,
+		inputformat: "auto", infile: "-", outfile: "-",
+	}
+	// need returns the argument following option args[i]
+	need := func(i int) (string, error) {
+		if i+1 >= len(args) {
+			return "", fmt.Errorf("option %s needs an argument", args[i])
+		}
+		return args[i+1], nil
+	}
+	outputs := map[string]int{
+		"-g": _OUT_GEOGRAPHIC, "-d": _OUT_DMS, "-:": _OUT_DMS, "-u": _OUT_UTMUPS,
+		"-m": _OUT_MGRS, "-c": _OUT_CONVERGENCE, "--geohash": _OUT_GEOHASH,
+		"--gars": _OUT_GARS, "--georef": _OUT_GEOREF, "--osgb": _OUT_OSGB,
+	}
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if out, ok := outputs[arg]; ok {
+			opts.output = out
+			if arg == "-:" {
+				opts.dmssep = ':'
+			} else {
+				opts.dmssep = 0
+			}
+			continue
+		}
+		switch arg {
+		case "-s":
+			opts.setzone = geographiclibgo.ZONE_STANDARD
+		case "-t":
+			opts.setzone = geographiclibgo.ZONE_UTM
+		case "-n":
+			opts.mgrscenter = false
+		case "-w":
+			opts.longfirst = !opts.longfirst
+		case "-l":
+			opts.abbrev = false
+		case "-a":
+			opts.abbrev = true
+		case "-z", "-p", "--input-format", "--comment-delimiter", "--input-string", "--input-file", "--output-file":
+			val, err := need(i)
+			if err != nil {
+				return opts, false, err
+			}
+			switch arg {
+			case "-z":
+				if opts.setzone, err = parse_zone(val); err != nil {
+					return opts, false, err
+				}
+			case "-p":
+				if opts.prec, err = strconv.Atoi(val); err != nil {
+					return opts, false, fmt.Errorf("precision %s is not a number", val)
+				}
+			case "--input-format":
+				switch strings.ToLower(val) {
+				case "auto", "geohash", "gars", "georef", "osgb":
+					opts.inputformat = strings.ToLower(val)
+				default:
+					return opts, false, fmt.Errorf("unknown input format %s", val)
+				}
+			case "--comment-delimiter":
+				opts.commentdelim = val
+			case "--input-string":
+				opts.instring = val
+			case "--input-file":
+				opts.infile = val
+			case "--output-file":
+				opts.outfile = val
+			}
+			i++
+		case "-h", "--help":
+			return opts, true, nil
+		default:
+			return opts, false, fmt.Errorf("unknown option %s", arg)
+		}
+	}
+	if opts.instring != "" && opts.infile != "-" {
+		return opts, false, fmt.Errorf("cannot specify --input-string and --input-file together")
+	}
+	if opts.prec < -5 {
+		opts.prec = -5
+	} else if opts.prec > 9 {
+		opts.prec = 9
+	}
+	return opts, false, nil
+}
+
+// point is a point read from the input. zone is the UTM/UPS zone the point was given in,
+// or ZONE_INVALID if it was given some other way.
+type point struct {
+	lat_deg, lon_deg float64
+	zone             int
+	northp           bool
+}
+
+// converter converts points between the coordinate systems
+type converter struct {
+	opts   *options
+	utmups geographiclibgo.UTMUPS
+	mgrs   geographiclibgo.MGRS
+	osgb   geographiclibgo.OSGB
+}
+
+func new_converter(opts *options) converter {
+	return converter{
+		opts:   opts,
+		utmups: geographiclibgo.Wgs84UTMUPS(),
+		mgrs:   geographiclibgo.Wgs84MGRS(),
+		osgb:   geographiclibgo.NewOSGB(),
+	}
+}
+
+// read_utmups reads a UTM/UPS coordinate whose zone is zonestr
+func (c *converter) read_utmups(zonestr, xstr, ystr string) (point, error) {
+	zone, northp, err := geographiclibgo.DecodeZone(zonestr)
+	if err != nil {
+		return point{}, err
+	}
+	x, err := strconv.ParseFloat(xstr, 64)
+	if err != nil {
+		return point{}, fmt.Errorf("bad easting %s", xstr)
+	}
+	y, err := strconv.ParseFloat(ystr, 64)
+	if err != nil {
+		return point{}, fmt.Errorf("bad northing %s", ystr)
+	}
+	r, err := c.utmups.Reverse(zone, northp, x, y)
+	if err != nil {
+		return point{}, err
+	}
+	return point{lat_deg: r.LatDeg, lon_deg: r.LonDeg, zone: zone, northp: northp}, nil
+}
+
+// read_point reads a point from the fields of a line of input
+func (c *converter) read_point(fields []string) (point, error) {
+	invalid := point{zone: geographiclibgo.ZONE_INVALID}
+	if len(fields) == 0 {
+		return invalid, fmt.Errorf("empty coordinate")
+	}
+	from_cell := func(cell geographiclibgo.GridCell, err error) (point, error) {
+		if err != nil {
+			return invalid, err
+		}
+		return point{lat_deg: cell.Center.LatDeg, lon_deg: cell.Center.LonDeg, zone: geographiclibgo.ZONE_INVALID}, nil
+	}
+	switch c.opts.inputformat {
+	case "geohash", "gars", "georef":
+		if len(fields) > 1 {
+			return invalid, fmt.Errorf("extra input: %s", strings.Join(fields[1:], " "))
+		}
+		switch c.opts.inputformat {
+		case "geohash":
+			return from_cell(geographiclibgo.GeohashReverse(fields[0]))
+		case "gars":
+			return from_cell(geographiclibgo.GARSReverse(fields[0]))
+		default:
+			return from_cell(geographiclibgo.GeorefReverse(fields[0]))
+		}
+	case "osgb":
+		r, err := c.osgb.GridReferenceReverse(strings.Join(fields, ""), true)
+		if err != nil {
+			return invalid, err
+		}
+		p := c.osgb.Reverse(r.EastingM, r.NorthingM)
+		return point{lat_deg: p.LatDeg, lon_deg: p.LonDeg, zone: geographiclibgo.ZONE_INVALID}, nil
+	}
+	switch len(fields) {
+	case 1:
+		r, err := c.mgrs.Reverse(fields[0], c.opts.mgrscenter)
+		if err != nil {
+			return invalid, err
+		}
+		ll, err := c.utmups.Reverse(r.Zone, r.Northp, r.EastingM, r.NorthingM)
+		if err != nil {
+			return invalid, err
+		}
+		return point{lat_deg: ll.LatDeg, lon_deg: ll.LonDeg, zone: r.Zone, northp: r.Northp}, nil
+	case 2:
+		ll, err := geographiclibgo.DMSDecodeLatLon(fields[0], fields[1], c.opts.longfirst)
+		if err != nil {
+			return invalid, err
+		}
+		return point{lat_deg: ll.LatDeg, lon_deg: ll.LonDeg, zone: geographiclibgo.ZONE_INVALID}, nil
+	case 3:
+		// The zone may come first or last
+		if p, err := c.read_utmups(fields[0], fields[1], fields[2]); err == nil {
+			return p, nil
+		} else if _, _, zerr := geographiclibgo.DecodeZone(fields[2]); zerr != nil {
+			return invalid, err
+		}
+		return c.read_utmups(fields[2], fields[0], fields[1])
+	}
+	return invalid, fmt.Errorf("cannot read %q as a point", strings.Join(fields, " "))
+}
+
+// resolution returns the size in degrees which corresponds to the precision, about
+// 10^-prec m
+func (c *converter) resolution() float64 {
+	return math.Pow(10, -float64(c.opts.prec+5))
+}
+
+// to_utmups returns the UTM/UPS coordinates of p in the zone chosen by the options
+func (c *converter) to_utmups(p point) (geographiclibgo.UTMUPSResult, error) {
+	setzone := c.opts.setzone
+	if setzone == geographiclibgo.ZONE_MATCH {
+		setzone = geographiclibgo.ZONE_STANDARD
+		if p.zone != geographiclibgo.ZONE_INVALID {
+			setzone = p.zone
+		}
+	}
+	return c.utmups.ForwardWithZone(p.lat_deg, p.lon_deg, setzone, false)
+}
+
+// format_dms formats angle with the trailing component and its precision chosen from
+// prec + 5 digits, as GeoConvert does
+func (c *converter) format_dms(angle float64, ind geographiclibgo.DMSFlag) string {
+	prec := c.opts.prec + 5
+	if prec < 0 {
+		prec = 0
+	}
+	switch {
+	case prec < 2:
+		return geographiclibgo.DMSEncode(angle, geographiclibgo.DMSDegree, prec, ind, c.opts.dmssep)
+	case prec < 4:
+		return geographiclibgo.DMSEncode(angle, geographiclibgo.DMSMinute, prec-2, ind, c.opts.dmssep)
+	default:
+		return geographiclibgo.DMSEncode(angle, geographiclibgo.DMSSecond, prec-4, ind, c.opts.dmssep)
+	}
+}
+
+// format_point formats p in the output form chosen by the options
+func (c *converter) format_point(p point) (string, error) {
+	opts := c.opts
+	switch opts.output {
+	case _OUT_GEOGRAPHIC, _OUT_DMS:
+		var lat, lon string
+		if opts.output == _OUT_DMS {
+			lat = c.format_dms(p.lat_deg, geographiclibgo.DMSLatitude)
+			lon = c.format_dms(geographiclibgo.AngNormalize(p.lon_deg), geographiclibgo.DMSLongitude)
+		} else {
+			prec := opts.prec + 5
+			if prec < 0 {
+				prec = 0
+			}
+			lat = cli.FormatFixed(p.lat_deg, prec)
+			lon = cli.FormatFixed(geographiclibgo.AngNormalize(p.lon_deg), prec)
+		}
+		if opts.longfirst {
+			return lon + " " + lat, nil
+		}
+		return lat + " " + lon, nil
+	case _OUT_UTMUPS, _OUT_MGRS, _OUT_CONVERGENCE:
+		r, err := c.to_utmups(p)
+		if err != nil {
+			return "", err
+		}
+		switch opts.output {
+		case _OUT_MGRS:
+			prec := opts.prec + 5
+			if prec > 11 {
+				prec = 11
+			}
+			return c.mgrs.ForwardWithLatitude(r.Zone, r.Northp, r.EastingM, r.NorthingM, p.lat_deg, prec)
+		case _OUT_CONVERGENCE:
+			return cli.FormatFixed(r.ConvergenceDeg, opts.prec+6) + " " + cli.FormatFixed(r.Scale, opts.prec+8), nil
+		}
+		zone, err := geographiclibgo.EncodeZone(r.Zone, r.Northp, opts.abbrev)
+		if err != nil {
+			return "", err
+		}
+		x, y, prec := r.EastingM, r.NorthingM, opts.prec
+		if prec < 0 {
+			// Round to 10^-prec m
+			scale := math.Pow(10, -float64(prec))
+			x, y, prec = math.Round(x/scale)*scale, math.Round(y/scale)*scale, 0
+		}
+		return zone + " " + cli.FormatFixed(x, prec) + " " + cli.FormatFixed(y, prec), nil
+	case _OUT_GEOHASH:
+		return geographiclibgo.GeohashForward(p.lat_deg, p.lon_deg, geographiclibgo.GeohashLength(c.resolution()))
+	case _OUT_GARS:
+		return geographiclibgo.GARSForward(p.lat_deg, p.lon_deg, geographiclibgo.GARSPrecision(c.resolution()))
+	case _OUT_GEOREF:
+		return geographiclibgo.GeorefForward(p.lat_deg, p.lon_deg, geographiclibgo.GeorefPrecision(c.resolution()))
+	default:
+		r := c.osgb.Forward(p.lat_deg, p.lon_deg)
+		prec := opts.prec + 5
+		if prec < 0 {
+			prec = 0
+		} else if prec > 11 {
+			prec = 11
+		}
+		return c.osgb.GridReference(r.XM, r.YM, prec)
+	}
+}
+
+// run runs geoconvert with the command line arguments args, not including the program
+// name, and returns the exit status
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	opts, help, err := parse_args(args)
+	if help {
+		fmt.Fprint(stdout, usage)
+		return 0
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "geoconvert: %v\n\n%s", err, usage)
+		return 1
+	}
+
+	in, err := cli.OpenInput(stdin, opts.instring, opts.infile)
+	if err != nil {
+		fmt.Fprintf(stderr, "geoconvert: %v\n", err)
+		return 1
+	}
+	defer in.Close()
+	out, err := cli.OpenOutput(stdout, opts.outfile)
+	if err != nil {
+		fmt.Fprintf(stderr, "geoconvert: %v\n", err)
+		return 1
+	}
+	defer out.Close()
+	w := bufio.NewWriter(out)
+	defer w.Flush()
+
+	c := new_converter(&opts)
+	status := 0
+	scanner := bufio.NewScanner(in)
+	for scanner.Scan() {
+		text := scanner.Text()
+		comment := ""
+		if opts.commentdelim != "" {
+			if k := strings.Index(text, opts.commentdelim); k >= 0 {
+				comment = " " + text[k:]
+				text = text[:k]
+			}
+		}
+		var res string
+		p, err := c.read_point(strings.Fields(text))
+		if err == nil {
+			res, err = c.format_point(p)
+		}
+		if err != nil {
+			res = "ERROR: " + err.Error()
+			status = 1
+		}
+		fmt.Fprintln(w, res+comment)
+	}
+	if err := scanner.Err(); err != nil {
+		fmt.Fprintf(stderr, "geoconvert: %v\n", err)
+		return 1
+	}
+	return status
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
@@ -0,0 +1,199 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// run_geoconvert runs geoconvert with args on input and returns the lines of output
+func run_geoconvert(t *testing.T, args []string, input string) ([]string, int) {
+	var stdout, stderr bytes.Buffer
+	status := run(args, strings.NewReader(input), &stdout, &stderr)
+	out := strings.TrimRight(stdout.String(), "\n")
+	if out == "" {
+		return nil, status
+	}
+	return strings.Split(out, "\n"), status
+}
+
+func TestOutputForms(t *testing.T) {
+	// The point in the GeographicLib documentation for GeoConvert
+	testCases := []struct {
+		desc string
+		args []string
+		want string
+	}{
+		{"geographic", nil, "33.30000 44.40000"},
+		{"geographic precision", []string{"-g", "-p", "-3"}, "33.30 44.40"},
+		{"longitude first", []string{"-w"}, "44.40000 33.30000"},
+		{"dms", []string{"-d"}, `33°18'00.0"N 044°24'00.0"E`},
+		{"dms minutes", []string{"-d", "-p", "-2"}, `33°18.0'N 044°24.0'E`},
+		{"dms degrees", []string{"-d", "-p", "-4"}, "33.3N 044.4E"},
+		{"dms colons", []string{"-:"}, "33:18:00.0N 044:24:00.0E"},
+		{"utm", []string{"-u"}, "38n 444141 3684706"},
+		{"utm precision", []string{"-u", "-p", "2"}, "38n 444140.54 3684706.36"},
+		{"utm rounded", []string{"-u", "-p", "-2"}, "38n 444100 3684700"},
+		{"utm long hemisphere", []string{"-u", "-l"}, "38north 444141 3684706"},
+		{"mgrs", []string{"-m"}, "38SMB4414084706"},
+		{"mgrs precision", []string{"-m", "-p", "-3"}, "38SMB4484"},
+		{"convergence", []string{"-c"}, "-0.329422 0.99963847"},
+		{"geohash", []string{"--geohash", "-p", "-3"}, "svzt6q5"},
+		{"gars", []string{"--gars", "-p", "-5"}, "449LG"},
+		{"georef", []string{"--georef", "-p", "-3"}, "QJQD240179"},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			input := "33.3 44.4"
+			if tC.desc == "longitude first" {
+				input = "44.4 33.3"
+			}
+			out, status := run_geoconvert(t, tC.args, input)
+			if status != 0 || len(out) != 1 || out[0] != tC.want {
+				t.Errorf("run(%v) = %v, %q; want %q", tC.args, status, out, tC.want)
+			}
+		})
+	}
+}
+
+func TestInputForms(t *testing.T) {
+	testCases := []struct {
+		desc  string
+		args  []string
+		input string
+		want  string
+	}{
+		{"dms", nil, `33d18'N 44d24'E`, "33.30000 44.40000"},
+		{"dms longitude first", nil, `44d24'E 33d18'N`, "33.30000 44.40000"},
+		{"utm", []string{"-m"}, "38n 444140.54 3684706.36", "38SMB4414084706"},
+		{"utm zone last", []string{"-m"}, "444140.54 3684706.36 38n", "38SMB4414084706"},
+		{"mgrs", []string{"-u", "-p", "1"}, "38SMB4414084706", "38n 444140.5 3684706.5"},
+		{"mgrs corner", []string{"-u", "-p", "1", "-n"}, "38SMB4414084706", "38n 444140.0 3684706.0"},
+		{"mgrs keeps zone", []string{"-u"}, "37SHS2592590015", "37n 825926 3690016"},
+		{"mgrs standard zone", []string{"-u", "-s"}, "37SHS2592590015", "38n 267224 3687335"},
+		{"ups", []string{"-p", "-3"}, "n 2000000 1500000", "85.50 0.00"},
+		{"geohash", []string{"--input-format", "geohash", "-m", "-p", "-2"}, "svzt6q5", "38SMB441847"},
+		{"gars", []string{"--input-format", "gars", "-p", "-2"}, "449LG28", "33.292 44.375"},
+		{"georef", []string{"--input-format", "georef", "-p", "-2"}, "QJQD240179", "33.299 44.401"},
+		// The point in Annex C of A Guide to Coordinate Systems in Great Britain, Ordnance
+		// Survey (2020); the result is the center of the 1 m square
+		{"osgb", []string{"--input-format", "osgb", "-p", "2"}, "TG 51409 13177", "52.6575726 1.7179158"},
+		{"osgb round trip", []string{"--input-format", "osgb", "--osgb"}, "TG5140913177", "TG 51409 13177"},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			out, status := run_geoconvert(t, tC.args, tC.input)
+			if status != 0 || len(out) != 1 || out[0] != tC.want {
+				t.Errorf("run(%v) on %q = %v, %q; want %q", tC.args, tC.input, status, out, tC.want)
+			}
+		})
+	}
+}
+
+func TestZones(t *testing.T) {
+	testCases := []struct {
+		desc string
+		args []string
+		want []string
+	}{
+		{"standard", []string{"-u"}, []string{"37n 686212 3686331", "n 2096454 1452981"}},
+		{"utm", []string{"-u", "-t"}, []string{"37n 686212 3686331", "33n 451408 9441846"}},
+		{"forced", []string{"-u", "-z", "37"}, []string{"37n 686212 3686331", "37n 229546 9509433"}},
+		{"forced designation", []string{"-u", "-z", "37n"}, []string{"37n 686212 3686331", "37n 229546 9509433"}},
+		{"forced ups", []string{"-u", "-z", "n", "-p", "-3"}, []string{"ERROR", "n 2096000 1453000"}},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			out, _ := run_geoconvert(t, tC.args, "33.3 41\n85 10\n")
+			if len(out) != len(tC.want) {
+				t.Fatalf("run(%v) = %q; want %q", tC.args, out, tC.want)
+			}
+			for i := range out {
+				if !strings.HasPrefix(out[i], tC.want[i]) {
+					t.Errorf("run(%v) line %d = %q; want %q", tC.args, i, out[i], tC.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestInputErrors(t *testing.T) {
+	out, status := run_geoconvert(t, []string{"--comment-delimiter", "#"}, "91 0\nxx\n1 2 3 4\n38n abc 0\n\n33.3 44.4 # ok\n")
+	if status != 1 || len(out) != 6 {
+		t.Fatalf("run() = %v, %q; want 1 and 6 lines", status, out)
+	}
+	for i := 0; i < 5; i++ {
+		if !strings.HasPrefix(out[i], "ERROR: ") {
+			t.Errorf("line %d = %q; want an error", i, out[i])
+		}
+	}
+	if out[5] != "33.30000 44.40000 # ok" {
+		t.Errorf("line 5 = %q", out[5])
+	}
+	// Points outside the grid systems
+	for _, args := range [][]string{{"--osgb"}, {"-u", "-z", "10"}} {
+		if out, status := run_geoconvert(t, args, "0 0"); status != 1 || !strings.HasPrefix(out[0], "ERROR: ") {
+			t.Errorf("run(%v) = %v, %q; want an error", args, status, out)
+		}
+	}
+}
+
+func TestArgumentErrors(t *testing.T) {
+	testCases := []struct {
+		desc string
+		args []string
+	}{
+		{"unknown option", []string{"-x"}},
+		{"missing zone", []string{"-z"}},
+		{"bad zone", []string{"-z", "61"}},
+		{"bad zone designation", []string{"-z", "38q"}},
+		{"bad precision", []string{"-p", "x"}},
+		{"unknown input format", []string{"--input-format", "what3words"}},
+		{"input string and file", []string{"--input-string", "0 0", "--input-file", "x"}},
+		{"missing input file", []string{"--input-file", filepath.Join(t.TempDir(), "missing")}},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			if status := run(tC.args, strings.NewReader(""), &stdout, &stderr); status != 1 || stderr.Len() == 0 {
+				t.Errorf("run(%v) = %v, stderr %q; want 1 and a message", tC.args, status, stderr.String())
+			}
+		})
+	}
+	var stdout, stderr bytes.Buffer
+	if status := run([]string{"-h"}, strings.NewReader(""), &stdout, &stderr); status != 0 ||
+		!strings.HasPrefix(stdout.String(), "Usage: geoconvert") {
+		t.Errorf("run(-h) = %v, %q", status, stdout.String())
+	}
+}
+
+func TestFiles(t *testing.T) {
+	dir := t.TempDir()
+	in := filepath.Join(dir, "in.txt")
+	out := filepath.Join(dir, "out.txt")
+	if err := os.WriteFile(in, []byte("33.3 44.4\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if lines, status := run_geoconvert(t, []string{"-m", "--input-file", in, "--output-file", out}, ""); status != 0 || lines != nil {
+		t.Fatalf("run() = %v, %q", status, lines)
+	}
+	got, err := os.ReadFile(out)
+	if err != nil || string(got) != "38SMB4414084706\n" {
+		t.Errorf("output file = %q, %v", got, err)
+	}
+	if lines, _ := run_geoconvert(t, []string{"-u", "--input-string", "33.3 44.4;38SMB4414084706"}, ""); len(lines) != 2 ||
+		!strings.HasPrefix(lines[0], "38n 44414") || !strings.HasPrefix(lines[1], "38n 44414") {
+		t.Errorf("--input-string = %q", lines)
+	}
+}
+
+func BenchmarkGeoConvert(b *testing.B) {
+	input := strings.Repeat("33.3 44.4\n", 100)
+	var stdout, stderr bytes.Buffer
+	for i := 0; i < b.N; i++ {
+		stdout.Reset()
+		run([]string{"-m"}, strings.NewReader(input), &stdout, &stderr)
+	}
+}
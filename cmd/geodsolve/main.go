@@ -0,0 +1,517 @@
+// Command geodsolve solves geodesic problems on an ellipsoid. It is modeled on
+// GeographicLib's GeodSolve utility and accepts the same options.
+//
+// Each line of input is a problem. The direct problem (the default) reads
+//
+//	lat1 lon1 azi1 s12
+//
+// and prints lat2 lon2 azi2. The inverse problem (-i) reads
+//
+//	lat1 lon1 lat2 lon2
+//
+// and prints azi1 azi2 s12. With -L, -D, or -I, a geodesic line is fixed on the command
+// line, each line of input is just s12, and lat2 lon2 azi2 is printed. Angles may be
+// given in decimal degrees or as DMS strings, e.g. 40d26'47"N. Run geodsolve -h for the
+// list of options.
+//
+// Errors in the input are reported on the output line as "ERROR: ..." and processing
+// continues; the exit status is then 1.
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+
+	geographiclibgo "github.com/natemcintosh/geographiclib-go"
+	"github.com/natemcintosh/geographiclib-go/internal/cli"
+)
+
+const usage = `Usage: geodsolve [ -i | -L lat1 lon1 azi1 | -D lat1 lon1 azi1 s13 |
+    -I lat1 lon1 lat2 lon2 ] [ -a ] [ -e a f ] [ -u ] [ -F ] [ -d | -: ] [ -w ]
+    [ -b ] [ -f ] [ -p prec ] [ -E ] [ --comment-delimiter commentdelim ]
+    [ --input-string instring | --input-file infile ] [ --output-file outfile ] [ -h ]
+
+Solve geodesic problems, reading problems from standard input, one per line.
+
+  -i       solve the inverse problem: read lat1 lon1 lat2 lon2 and print
+           azi1 azi2 s12. The default is the direct problem: read lat1 lon1 azi1
+           s12 and print lat2 lon2 azi2.
+  -L lat1 lon1 azi1
+           line mode: fix the geodesic starting at lat1 lon1 with azimuth azi1, and
+           read just s12 on each line
+  -D lat1 lon1 azi1 s13
+           line mode with the line defined by the direct problem; point 3 is at
+           distance s13
+  -I lat1 lon1 lat2 lon2
+           line mode with the line defined by the inverse problem; point 3 is at
+           lat2 lon2
+  -a       arc mode: s12 (and s13) are replaced by the arc length a12 (and a13) on
+           the auxiliary sphere [degrees]
+  -e a f   use the ellipsoid with equatorial radius a and flattening f, which may
+           be given as a fraction, e.g. 1/297. The default is WGS84.
+  -u       unroll longitudes, so they change continuously along the geodesic,
+           instead of reducing them to [-180, 180]
+  -F       with -D or -I, read s12 (or a12) as a fraction of s13 (or a13)
+  -d       print angles as degrees, minutes, and seconds with hemisphere letters
+  -:       like -d, with the components separated by colons
+  -w       longitude first in input and output
+  -b       report the back azimuth at point 2 instead of the forward azimuth
+  -f       full output: lat1 lon1 azi1 lat2 lon2 azi2 s12 a12 m12 M12 M21 S12
+  -p prec  print distances to prec digits after the decimal point [meters], in
+           [0, 10], default 3; angles are printed with prec + 5 decimal places
+  -E       use the exact solution, GeodesicExact, instead of the series
+  --comment-delimiter commentdelim
+           ignore everything on an input line after commentdelim, and echo it after
+           the output
+  --input-string instring
+           read problems from instring, with ";" separating them
+  --input-file infile
+           read problems from infile ("-" for standard input)
+  --output-file outfile
+           write results to outfile ("-" for standard output)
+  -h       print this help
+`
+
+// options holds the parsed command line
+type options struct {
+	inverse      bool
+	linemode     byte // 0, 'L', 'D', or 'I'
+	linecoords   []string
+	arcmode      bool
+	a            float64
+	f            float64
+	unroll       bool
+	fraction     bool
+	dms          bool
+	dmssep       rune
+	longfirst    bool
+	backaz       bool
+	full         bool
+	prec         int
+	exact        bool
+	commentdelim string
+	instring     string
+	infile       string
+	outfile      string
+}
+
+// parse_args parses the command line arguments, not including the program name. help
+// is true if -h was given.
+func parse_args(args []string) (opts options, help bool, err error) {
+	opts = options{a: geographiclibgo.WGS84_A, f: geographiclibgo.WGS84_F, prec: 3, infile: "-", outfile: "-"}
+	// need returns the n arguments following option args[i]
+	need := func(i, n int) ([]string, error) {
+		if i+n >= len(args) {
+			return nil, fmt.Errorf("option %s needs %d arguments", args[i], n)
+		}
+		return args[i+1 : i+1+n], nil
+	}
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch arg {
+		case "-i":
+			opts.inverse = true
+			opts.linemode = 0
+		case "-L", "-D", "-I":
+			n := map[string]int{"-L": 3, "-D": 4, "-I": 4}[arg]
+			vals, err := need(i, n)
+			if err != nil {
+				return opts, false, err
+			}
+			opts.inverse = false
+			opts.linemode = arg[1]
+			opts.linecoords = vals
+			i += n
+		case "-a":
+			opts.arcmode = !opts.arcmode
+		case "-e":
+			vals, err := need(i, 2)
+			if err != nil {
+				return opts, false, err
+			}
+			if opts.a, err = geographiclibgo.ParseFraction(vals[0]); err != nil {
+				return opts, false, fmt.Errorf("bad equatorial radius %s", vals[0])
+			}
+			if opts.f, err = geographiclibgo.ParseFraction(vals[1]); err != nil {
+				return opts, false, fmt.Errorf("bad flattening %s", vals[1])
+			}
+			if !(opts.a > 0 && opts.f < 1) {
+				return opts, false, fmt.Errorf("bad ellipsoid %s %s", vals[0], vals[1])
+			}
+			i += 2
+		case "-u":
+			opts.unroll = true
+		case "-F":
+			opts.fraction = true
+		case "-d":
+			opts.dms = true
+			opts.dmssep = 0
+		case "-:":
+			opts.dms = true
+			opts.dmssep = ':'
+		case "-w":
+			opts.longfirst = !opts.longfirst
+		case "-b":
+			opts.backaz = true
+		case "-f":
+			opts.full = true
+		case "-p":
+			vals, err := need(i, 1)
+			if err != nil {
+				return opts, false, err
+			}
+			if opts.prec, err = strconv.Atoi(vals[0]); err != nil {
+				return opts, false, fmt.Errorf("precision %s is not a number", vals[0])
+			}
+			i++
+		case "-E":
+			opts.exact = true
+		case "--comment-delimiter", "--input-string", "--input-file", "--output-file":
+			vals, err := need(i, 1)
+			if err != nil {
+				return opts, false, err
+			}
+			switch arg {
+			case "--comment-delimiter":
+				opts.commentdelim = vals[0]
+			case "--input-string":
+				opts.instring = vals[0]
+			case "--input-file":
+				opts.infile = vals[0]
+			case "--output-file":
+				opts.outfile = vals[0]
+			}
+			i++
+		case "-h", "--help":
+			return opts, true, nil
+		default:
+			return opts, false, fmt.Errorf("unknown option %s", arg)
+		}
+	}
+	if opts.instring != "" && opts.infile != "-" {
+		return opts, false, fmt.Errorf("cannot specify --input-string and --input-file together")
+	}
+	if opts.prec < 0 {
+		opts.prec = 0
+	} else if opts.prec > 10 {
+		opts.prec = 10
+	}
+	return opts, false, nil
+}
+
+// line is a geodesic line, either a GeodesicLine or a GeodesicLineExact
+type line interface {
+	GenPosition(arcmode bool, s12_a12 float64, capabilities uint64) geographiclibgo.PositionResult
+	Distance() float64
+	Arc() float64
+}
+
+// solver solves geodesic problems with either Geodesic or GeodesicExact
+type solver struct {
+	g     geographiclibgo.Geodesic
+	ge    geographiclibgo.GeodesicExact
+	exact bool
+}
+
+// The capabilities needed for full output
+const _CAPS uint64 = geographiclibgo.ALL
+
+func (s *solver) line(lat1, lon1, azi1 float64) line {
+	if s.exact {
+		return s.ge.LineWithCapabilities(lat1, lon1, azi1, _CAPS)
+	}
+	return s.g.LineWithCapabilities(lat1, lon1, azi1, _CAPS)
+}
+
+func (s *solver) direct_line(lat1, lon1, azi1 float64, arcmode bool, s13_a13 float64) line {
+	if s.exact {
+		if arcmode {
+			return s.ge.ArcDirectLineWithCapabilities(lat1, lon1, azi1, s13_a13, _CAPS)
+		}
+		return s.ge.DirectLineWithCapabilities(lat1, lon1, azi1, s13_a13, _CAPS)
+	}
+	if arcmode {
+		return s.g.ArcDirectLineWithCapabilities(lat1, lon1, azi1, s13_a13, _CAPS)
+	}
+	return s.g.DirectLineWithCapabilities(lat1, lon1, azi1, s13_a13, _CAPS)
+}
+
+func (s *solver) inverse_line(lat1, lon1, lat2, lon2 float64) line {
+	if s.exact {
+		return s.ge.InverseLineWithCapabilities(lat1, lon1, lat2, lon2, _CAPS)
+	}
+	return s.g.InverseLineWithCapabilities(lat1, lon1, lat2, lon2, _CAPS)
+}
+
+func (s *solver) inverse(lat1, lon1, lat2, lon2 float64) geographiclibgo.AllInverseResults {
+	if s.exact {
+		return s.ge.InverseCalcAll(lat1, lon1, lat2, lon2)
+	}
+	return s.g.InverseCalcAll(lat1, lon1, lat2, lon2)
+}
+
+// formatter formats the results according to the output options
+type formatter struct {
+	opts *options
+}
+
+func (f formatter) angle(x float64, ind geographiclibgo.DMSFlag) string {
+	if f.opts.dms {
+		return geographiclibgo.DMSEncode(x, geographiclibgo.DMSSecond, f.opts.prec+1, ind, f.opts.dmssep)
+	}
+	return cli.FormatFixed(x, f.opts.prec+5)
+}
+
+func (f formatter) lat_lon(lat, lon float64) string {
+	latstr := f.angle(lat, geographiclibgo.DMSLatitude)
+	lonstr := f.angle(lon, geographiclibgo.DMSLongitude)
+	if f.opts.longfirst {
+		return lonstr + " " + latstr
+	}
+	return latstr + " " + lonstr
+}
+
+func (f formatter) azimuth(azi float64) string {
+	return f.angle(azi, geographiclibgo.DMSAzimuth)
+}
+
+func (f formatter) distances(s12, a12 float64) string {
+	var parts []string
+	if f.opts.full || !f.opts.arcmode {
+		parts = append(parts, cli.FormatFixed(s12, f.opts.prec))
+	}
+	if f.opts.full || f.opts.arcmode {
+		parts = append(parts, f.angle(a12, geographiclibgo.DMSNone))
+	}
+	return strings.Join(parts, " ")
+}
+
+// full_tail formats m12 M12 M21 S12 for full output
+func (f formatter) full_tail(m12, M12, M21, S12 float64) string {
+	S12prec := f.opts.prec - 7
+	if S12prec < 0 {
+		S12prec = 0
+	}
+	return cli.FormatFixed(m12, f.opts.prec) + " " + cli.FormatFixed(M12, f.opts.prec+7) + " " +
+		cli.FormatFixed(M21, f.opts.prec+7) + " " + cli.FormatFixed(S12, S12prec)
+}
+
+// position formats a point found on a geodesic line
+func (f formatter) position(r geographiclibgo.PositionResult) string {
+	azi2 := r.Azi2Deg
+	if f.opts.backaz {
+		if azi2 >= 0 {
+			azi2 -= 180
+		} else {
+			azi2 += 180
+		}
+	}
+	out := f.lat_lon(r.Lat2Deg, r.Lon2Deg) + " " + f.azimuth(azi2)
+	if f.opts.full {
+		out = f.lat_lon(r.Lat1Deg, r.Lon1Deg) + " " + f.azimuth(r.Azi1Deg) + " " + out + " " +
+			f.distances(r.DistanceM, r.ArcLengthDeg) + " " + f.full_tail(r.ReducedLengthM, r.M12, r.M21, r.S12M2)
+	}
+	return out
+}
+
+// read_lat_lon decodes a latitude and longitude in the order given by longfirst
+func read_lat_lon(a, b string, longfirst bool) (float64, float64, error) {
+	p, err := geographiclibgo.DMSDecodeLatLon(a, b, longfirst)
+	return p.LatDeg, p.LonDeg, err
+}
+
+// read_distance decodes a distance, or an arc length in arc mode
+func read_distance(s string, arcmode bool) (float64, error) {
+	if arcmode {
+		return geographiclibgo.DMSDecodeAngle(s)
+	}
+	v, err := geographiclibgo.ParseFraction(s)
+	if err != nil {
+		return 0, fmt.Errorf("bad distance %s", s)
+	}
+	return v, nil
+}
+
+// setup_line creates the line fixed on the command line by -L, -D, or -I
+func setup_line(s *solver, opts *options) (line, error) {
+	c := opts.linecoords
+	lat1, lon1, err := read_lat_lon(c[0], c[1], opts.longfirst)
+	if err != nil {
+		return nil, err
+	}
+	switch opts.linemode {
+	case 'L':
+		azi1, err := geographiclibgo.DMSDecodeAzimuth(c[2])
+		if err != nil {
+			return nil, err
+		}
+		return s.line(lat1, lon1, azi1), nil
+	case 'D':
+		azi1, err := geographiclibgo.DMSDecodeAzimuth(c[2])
+		if err != nil {
+			return nil, err
+		}
+		s13, err := read_distance(c[3], opts.arcmode)
+		if err != nil {
+			return nil, err
+		}
+		return s.direct_line(lat1, lon1, azi1, opts.arcmode, s13), nil
+	default:
+		lat2, lon2, err := read_lat_lon(c[2], c[3], opts.longfirst)
+		if err != nil {
+			return nil, err
+		}
+		return s.inverse_line(lat1, lon1, lat2, lon2), nil
+	}
+}
+
+// solve solves the problem on one line of input, whose fields are fields, and returns
+// the formatted result
+func solve(s *solver, l line, opts *options, fields []string) (string, error) {
+	f := formatter{opts}
+	outmask := _CAPS
+	if opts.unroll {
+		outmask |= geographiclibgo.LONG_UNROLL
+	}
+	want := 4
+	if l != nil {
+		want = 1
+	}
+	if len(fields) < want {
+		return "", fmt.Errorf("incomplete input: %s", strings.Join(fields, " "))
+	}
+	if len(fields) > want {
+		return "", fmt.Errorf("extra input: %s", strings.Join(fields[want:], " "))
+	}
+	if l != nil {
+		s12, err := read_distance(fields[0], opts.arcmode)
+		if err != nil {
+			return "", err
+		}
+		if opts.fraction && opts.linemode != 'L' {
+			if opts.arcmode {
+				s12 *= l.Arc()
+			} else {
+				s12 *= l.Distance()
+			}
+		}
+		return f.position(l.GenPosition(opts.arcmode, s12, outmask)), nil
+	}
+	lat1, lon1, err := read_lat_lon(fields[0], fields[1], opts.longfirst)
+	if err != nil {
+		return "", err
+	}
+	if !opts.inverse {
+		azi1, err := geographiclibgo.DMSDecodeAzimuth(fields[2])
+		if err != nil {
+			return "", err
+		}
+		s12, err := read_distance(fields[3], opts.arcmode)
+		if err != nil {
+			return "", err
+		}
+		return f.position(s.line(lat1, lon1, azi1).GenPosition(opts.arcmode, s12, outmask)), nil
+	}
+	lat2, lon2, err := read_lat_lon(fields[2], fields[3], opts.longfirst)
+	if err != nil {
+		return "", err
+	}
+	r := s.inverse(lat1, lon1, lat2, lon2)
+	azi2 := r.Azimuth2Deg
+	if opts.backaz {
+		if azi2 >= 0 {
+			azi2 -= 180
+		} else {
+			azi2 += 180
+		}
+	}
+	if !opts.full {
+		return f.azimuth(r.Azimuth1Deg) + " " + f.azimuth(azi2) + " " + f.distances(r.DistanceM, r.ArcLengthDeg), nil
+	}
+	if opts.unroll {
+		lon2 = lon1 + math.Remainder(lon2-lon1, 360)
+	} else {
+		lon1, lon2 = geographiclibgo.AngNormalize(lon1), geographiclibgo.AngNormalize(lon2)
+	}
+	return f.lat_lon(lat1, lon1) + " " + f.azimuth(r.Azimuth1Deg) + " " +
+		f.lat_lon(lat2, lon2) + " " + f.azimuth(azi2) + " " +
+		f.distances(r.DistanceM, r.ArcLengthDeg) + " " +
+		f.full_tail(r.ReducedLengthM, r.M12, r.M21, r.S12M2), nil
+}
+
+// run runs geodsolve with the command line arguments args, not including the program
+// name, and returns the exit status
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	opts, help, err := parse_args(args)
+	if help {
+		fmt.Fprint(stdout, usage)
+		return 0
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "geodsolve: %v\n\n%s", err, usage)
+		return 1
+	}
+
+	in, err := cli.OpenInput(stdin, opts.instring, opts.infile)
+	if err != nil {
+		fmt.Fprintf(stderr, "geodsolve: %v\n", err)
+		return 1
+	}
+	defer in.Close()
+	out, err := cli.OpenOutput(stdout, opts.outfile)
+	if err != nil {
+		fmt.Fprintf(stderr, "geodsolve: %v\n", err)
+		return 1
+	}
+	defer out.Close()
+	w := bufio.NewWriter(out)
+	defer w.Flush()
+
+	s := solver{exact: opts.exact}
+	if opts.exact {
+		s.ge = geographiclibgo.NewGeodesicExact(opts.a, opts.f)
+	} else {
+		s.g = geographiclibgo.NewGeodesic(opts.a, opts.f)
+	}
+	var l line
+	if opts.linemode != 0 {
+		l, err = setup_line(&s, &opts)
+		if err != nil {
+			fmt.Fprintf(stderr, "geodsolve: %v\n", err)
+			return 1
+		}
+	}
+
+	status := 0
+	scanner := bufio.NewScanner(in)
+	for scanner.Scan() {
+		text := scanner.Text()
+		comment := ""
+		if opts.commentdelim != "" {
+			if k := strings.Index(text, opts.commentdelim); k >= 0 {
+				comment = " " + text[k:]
+				text = text[:k]
+			}
+		}
+		res, err := solve(&s, l, &opts, strings.Fields(text))
+		if err != nil {
+			res = "ERROR: " + err.Error()
+			status = 1
+		}
+		fmt.Fprintln(w, res+comment)
+	}
+	if err := scanner.Err(); err != nil {
+		fmt.Fprintf(stderr, "geodsolve: %v\n", err)
+		return 1
+	}
+	return status
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
@@ -0,0 +1,307 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// read_fixture returns the fields of each line of GeodTest-100.dat, which are lat1 lon1
+// azi1 lat2 lon2 azi2 s12 a12 m12 S12
+func read_fixture(t *testing.T) [][]string {
+	file, err := os.Open("../../test_fixtures/GeodTest-100.dat")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	var rows [][]string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		rows = append(rows, strings.Fields(scanner.Text()))
+	}
+	return rows
+}
+
+// run_geodsolve runs geodsolve with args on input and returns the lines of output
+func run_geodsolve(t *testing.T, args []string, input string) ([]string, int) {
+	var stdout, stderr bytes.Buffer
+	status := run(args, strings.NewReader(input), &stdout, &stderr)
+	out := strings.TrimRight(stdout.String(), "\n")
+	if out == "" {
+		return nil, status
+	}
+	return strings.Split(out, "\n"), status
+}
+
+// check_fields compares the numbers in the output line got with the fixture fields want
+// at the indices cols, to within the corresponding thrs
+func check_fields(t *testing.T, row int, got string, want []string, cols []int, thrs []float64) {
+	fields := strings.Fields(got)
+	if len(fields) != len(cols) {
+		t.Fatalf("Row %d: got %q; want %d fields", row, got, len(cols))
+	}
+	for i, c := range cols {
+		g, err := strconv.ParseFloat(fields[i], 64)
+		if err != nil {
+			t.Fatalf("Row %d: field %d of %q is not a number", row, i, got)
+		}
+		w, _ := strconv.ParseFloat(want[c], 64)
+		if math.Abs(g-w) > thrs[i] {
+			t.Errorf("Row %d: field %d = %v; want %v", row, i, g, w)
+		}
+	}
+}
+
+func TestDirect(t *testing.T) {
+	rows := read_fixture(t)
+	var input strings.Builder
+	for _, r := range rows {
+		input.WriteString(strings.Join([]string{r[0], r[1], r[2], r[6]}, " ") + "\n")
+	}
+	out, status := run_geodsolve(t, []string{"-p", "9"}, input.String())
+	if status != 0 || len(out) != len(rows) {
+		t.Fatalf("run() = %v, %d lines; want 0, %d lines", status, len(out), len(rows))
+	}
+	for i, r := range rows {
+		check_fields(t, i, out[i], r, []int{3, 4, 5}, []float64{1e-12, 1e-8, 1e-8})
+	}
+}
+
+func TestInverse(t *testing.T) {
+	rows := read_fixture(t)
+	var input strings.Builder
+	for _, r := range rows {
+		input.WriteString(strings.Join([]string{r[0], r[1], r[3], r[4]}, " ") + "\n")
+	}
+	out, status := run_geodsolve(t, []string{"-i", "-p", "9"}, input.String())
+	if status != 0 || len(out) != len(rows) {
+		t.Fatalf("run() = %v, %d lines; want 0, %d lines", status, len(out), len(rows))
+	}
+	// Many of the rows are nearly antipodal, where the azimuths are ill-conditioned
+	for i, r := range rows {
+		check_fields(t, i, out[i], r, []int{2, 5, 6}, []float64{1e-4, 1e-4, 1e-8})
+	}
+	out, _ = run_geodsolve(t, []string{"-i", "-a", "-p", "9"}, input.String())
+	for i, r := range rows {
+		check_fields(t, i, out[i], r, []int{2, 5, 7}, []float64{1e-4, 1e-4, 1e-10})
+	}
+}
+
+func TestFullOutput(t *testing.T) {
+	rows := read_fixture(t)
+	var direct, inverse strings.Builder
+	for _, r := range rows {
+		direct.WriteString(strings.Join([]string{r[0], r[1], r[2], r[6]}, " ") + "\n")
+		inverse.WriteString(strings.Join([]string{r[0], r[1], r[3], r[4]}, " ") + "\n")
+	}
+	// The thresholds for lat1 lon1 azi1 lat2 lon2 azi2 s12 a12 m12; the inputs are only
+	// subject to rounding
+	direct_thrs := []float64{1e-13, 1e-13, 1e-13, 1e-12, 1e-8, 1e-8, 1e-9, 1e-10, 1e-8}
+	inverse_thrs := []float64{1e-13, 1e-13, 1e-4, 1e-13, 1e-13, 1e-4, 1e-8, 1e-10, 1e-1}
+	exact_thrs := []float64{1e-13, 1e-13, 1e-13, 1e-12, 1e-8, 1e-8, 1e-9, 1e-10, 1e-7}
+	testCases := []struct {
+		desc    string
+		args    []string
+		input   string
+		thrs    []float64
+		S12_thr float64
+	}{
+		{"direct", []string{"-f", "-p", "9"}, direct.String(), direct_thrs, 1e3},
+		// The area is ill-conditioned for the nearly antipodal rows too
+		{"inverse", []string{"-f", "-i", "-p", "9"}, inverse.String(), inverse_thrs, 2e7},
+		{"exact", []string{"-f", "-E", "-p", "9"}, direct.String(), exact_thrs, 1e3},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			out, status := run_geodsolve(t, tC.args, tC.input)
+			if status != 0 || len(out) != len(rows) {
+				t.Fatalf("run() = %v, %d lines; want 0, %d lines", status, len(out), len(rows))
+			}
+			for i, r := range rows {
+				fields := strings.Fields(out[i])
+				if len(fields) != 12 {
+					t.Fatalf("Row %d: got %q; want 12 fields", i, out[i])
+				}
+				// M12 and M21 are not in the fixture
+				check_fields(t, i, strings.Join(fields[:9], " "), r, []int{0, 1, 2, 3, 4, 5, 6, 7, 8}, tC.thrs)
+				s12, _ := strconv.ParseFloat(fields[11], 64)
+				if want, _ := strconv.ParseFloat(r[9], 64); math.Abs(s12-want) > tC.S12_thr {
+					t.Errorf("Row %d: S12 = %v; want %v", i, s12, want)
+				}
+			}
+		})
+	}
+}
+
+func TestLineModes(t *testing.T) {
+	// JFK to SIN, the example in the GeodSolve documentation
+	testCases := []struct {
+		desc  string
+		args  []string
+		input string
+		want  []string
+	}{
+		{
+			"direct",
+			[]string{"-p", "0"},
+			"40:38:23N 073:46:44W 003:18:29.9 15347628",
+			[]string{"1.35916 103.98945 177.48591"},
+		},
+		{
+			"inverse",
+			[]string{"-i", "-:", "-p", "0"},
+			"40:38:23N 073:46:44W 01:21:33N 103:59:22E",
+			[]string{"003:18:29.9 177:29:09.2 15347628"},
+		},
+		{
+			"line",
+			[]string{"-L", "40:38:23N", "073:46:44W", "003:18:29.9", "-p", "0"},
+			"0\n15347628",
+			[]string{"40.63972 -73.77889 3.30831", "1.35916 103.98945 177.48591"},
+		},
+		{
+			"inverse line fraction",
+			[]string{"-I", "40:38:23N", "073:46:44W", "01:21:33N", "103:59:22E", "-F", "-:", "-p", "0"},
+			"0\n1",
+			[]string{"40:38:23.0N 073:46:44.0W 003:18:29.9", "01:21:33.0N 103:59:22.0E 177:29:09.2"},
+		},
+		{
+			"direct line arc",
+			[]string{"-D", "0", "0", "90", "90", "-a", "-F", "-p", "0"},
+			"0.5",
+			[]string{"0.00000 44.84912 90.00000"},
+		},
+		{
+			"back azimuth",
+			[]string{"-b", "-p", "0"},
+			"0 0 90 1000",
+			[]string{"0.00000 0.00898 -90.00000"},
+		},
+		{
+			"unroll",
+			[]string{"-u", "-p", "0"},
+			"0 170 90 2226389.8",
+			[]string{"0.00000 190.00000 90.00000"},
+		},
+		{
+			"no unroll",
+			[]string{"-p", "0"},
+			"0 170 90 2226389.8",
+			[]string{"0.00000 -170.00000 90.00000"},
+		},
+		{
+			"longitude first",
+			[]string{"-i", "-w", "-p", "0"},
+			"0 0 1 0",
+			[]string{"90.00000 90.00000 111319"},
+		},
+		{
+			"sphere",
+			[]string{"-i", "-e", "6371e3", "0", "-p", "0"},
+			"0 0 0 90",
+			[]string{"90.00000 90.00000 10007543"},
+		},
+		{
+			"comment",
+			[]string{"-i", "--comment-delimiter", "#", "-p", "0"},
+			"0 0 0 1 # one degree",
+			[]string{"90.00000 90.00000 111319 # one degree"},
+		},
+		{
+			"input string",
+			[]string{"-i", "--input-string", "0 0 0 1;0 0 1 0", "-p", "0"},
+			"",
+			[]string{"90.00000 90.00000 111319", "0.00000 0.00000 110574"},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			out, status := run_geodsolve(t, tC.args, tC.input)
+			if status != 0 || strings.Join(out, "\n") != strings.Join(tC.want, "\n") {
+				t.Errorf("run(%v) = %v, %q; want %q", tC.args, status, out, tC.want)
+			}
+		})
+	}
+}
+
+func TestEllipsoidFraction(t *testing.T) {
+	a, _ := run_geodsolve(t, []string{"-e", "6378137", "1/298.257223563"}, "10 20 30 1e6")
+	b, _ := run_geodsolve(t, nil, "10 20 30 1e6")
+	if len(a) != 1 || len(b) != 1 || a[0] != b[0] {
+		t.Errorf("-e 6378137 1/298.257223563 gives %q; want %q", a, b)
+	}
+}
+
+func TestInputErrors(t *testing.T) {
+	out, status := run_geodsolve(t, []string{"-i"}, "91 0 0 0\n0 0 0\n0 0 0 0 junk\nx 0 0 0\n0 0 0 1")
+	if status != 1 || len(out) != 5 {
+		t.Fatalf("run() = %v, %q; want 1 and 5 lines", status, out)
+	}
+	for i := 0; i < 4; i++ {
+		if !strings.HasPrefix(out[i], "ERROR: ") {
+			t.Errorf("line %d = %q; want an error", i, out[i])
+		}
+	}
+	if out[4] != "90.00000000 90.00000000 111319.491" {
+		t.Errorf("line 4 = %q", out[4])
+	}
+}
+
+func TestArgumentErrors(t *testing.T) {
+	testCases := []struct {
+		desc string
+		args []string
+	}{
+		{"unknown option", []string{"-x"}},
+		{"missing line arguments", []string{"-L", "0", "0"}},
+		{"bad ellipsoid", []string{"-e", "6378137", "abc"}},
+		{"flattening too large", []string{"-e", "6378137", "1"}},
+		{"bad precision", []string{"-p", "x"}},
+		{"bad line", []string{"-L", "91", "0", "0"}},
+		{"input string and file", []string{"--input-string", "0 0 0 0", "--input-file", "x"}},
+		{"missing input file", []string{"--input-file", filepath.Join(t.TempDir(), "missing")}},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			if status := run(tC.args, strings.NewReader(""), &stdout, &stderr); status != 1 || stderr.Len() == 0 {
+				t.Errorf("run(%v) = %v, stderr %q; want 1 and a message", tC.args, status, stderr.String())
+			}
+		})
+	}
+	var stdout, stderr bytes.Buffer
+	if status := run([]string{"-h"}, strings.NewReader(""), &stdout, &stderr); status != 0 ||
+		!strings.HasPrefix(stdout.String(), "Usage: geodsolve") {
+		t.Errorf("run(-h) = %v, %q", status, stdout.String())
+	}
+}
+
+func TestFiles(t *testing.T) {
+	dir := t.TempDir()
+	in := filepath.Join(dir, "in.txt")
+	out := filepath.Join(dir, "out.txt")
+	if err := os.WriteFile(in, []byte("0 0 0 1\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if lines, status := run_geodsolve(t, []string{"-i", "--input-file", in, "--output-file", out, "-p", "0"}, ""); status != 0 || lines != nil {
+		t.Fatalf("run() = %v, %q", status, lines)
+	}
+	got, err := os.ReadFile(out)
+	if err != nil || string(got) != "90.00000 90.00000 111319\n" {
+		t.Errorf("output file = %q, %v", got, err)
+	}
+}
+
+func BenchmarkInverse(b *testing.B) {
+	input := strings.Repeat("40.6 -73.8 1.4 104\n", 100)
+	var stdout, stderr bytes.Buffer
+	for i := 0; i < b.N; i++ {
+		stdout.Reset()
+		run([]string{"-i"}, strings.NewReader(input), &stdout, &stderr)
+	}
+}
@@ -0,0 +1,290 @@
+// Command planimeter computes the perimeter and area of polygons on an ellipsoid. It is
+// modeled on GeographicLib's Planimeter utility and accepts the same options.
+//
+// Each line of input is a vertex of a polygon, given as
+//
+//	lat lon
+//
+// in decimal degrees or as DMS strings, as an MGRS reference such as 38SMB4484, or as a
+// UTM/UPS coordinate such as "38n 444140 3684706". Fields may be separated by commas, so
+// CSV exports can be read directly. A blank line, or a line which cannot be read as a
+// point (e.g. "END" or a CSV header), ends the current polygon, and
+//
+//	num perimeter area
+//
+// is printed for it. Run planimeter -h for the list of options.
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	geographiclibgo "github.com/natemcintosh/geographiclib-go"
+	"github.com/natemcintosh/geographiclib-go/internal/cli"
+)
+
+const usage = `Usage: planimeter [ -r ] [ -s ] [ -l ] [ -e a f | --ellipsoid name ] [ -R ]
+    [ -w ] [ -p prec ] [ --comment-delimiter commentdelim ]
+    [ --input-string instring | --input-file infile ] [ --output-file outfile ] [ -h ]
+
+Measure the area of polygons, reading their vertices from standard input, one per line.
+Each polygon is ended by a blank line, or by a line which is not a point, and then
+"num perimeter area" is printed for it.
+
+  -r       toggle whether clockwise traversal counts as a positive area, instead of
+           counter-clockwise
+  -s       toggle whether to report a signed area when the polygon is traversed in the
+           "wrong" direction, instead of the area of the rest of the ellipsoid. The
+           default is a signed area.
+  -l       polyline mode: the vertices are joined by lines which are not closed, and
+           only "num length" is printed
+  -e a f   use the ellipsoid with equatorial radius a and flattening f, which may be
+           given as a fraction, e.g. 1/297. The default is WGS84.
+  --ellipsoid name
+           use the named ellipsoid, e.g. "GRS80" or "Clarke 1866"
+  -R       join the vertices with rhumb lines instead of geodesics
+  -w       longitude first in input
+  -p prec  print the perimeter with prec digits after the decimal point [meters], and
+           the area with prec - 5 [meters^2]; prec is in [0, 10], default 6
+  --comment-delimiter commentdelim
+           ignore everything on an input line after commentdelim
+  --input-string instring
+           read vertices from instring, with ";" separating them
+  --input-file infile
+           read vertices from infile ("-" for standard input)
+  --output-file outfile
+           write results to outfile ("-" for standard output)
+  -h       print this help
+
+Vertices may be given as "lat lon", as an MGRS reference, or as a UTM/UPS coordinate
+"zone easting northing", and fields may be separated by commas.
+`
+
+// options holds the parsed command line
+type options struct {
+	reverse      bool
+	sign         bool
+	polyline     bool
+	a            float64
+	f            float64
+	rhumb        bool
+	longfirst    bool
+	prec         int
+	commentdelim string
+	instring     string
+	infile       string
+	outfile      string
+}
+
+// parse_args parses the command line arguments, not including the program name. help
+// is true if -h was given.
+func parse_args(args []string) (opts options, help bool, err error) {
+	opts = options{sign: true, a: geographiclibgo.WGS84_A, f: geographiclibgo.WGS84_F, prec: 6, infile: "-", outfile: "-"}
+	// need returns the n arguments following option args[i]
+	need := func(i, n int) ([]string, error) {
+		if i+n >= len(args) {
+			return nil, fmt.Errorf("option %s needs %d arguments", args[i], n)
+		}
+		return args[i+1 : i+1+n], nil
+	}
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch arg {
+		case "-r":
+			opts.reverse = !opts.reverse
+		case "-s":
+			opts.sign = !opts.sign
+		case "-l":
+			opts.polyline = true
+		case "-e":
+			vals, err := need(i, 2)
+			if err != nil {
+				return opts, false, err
+			}
+			if opts.a, err = geographiclibgo.ParseFraction(vals[0]); err != nil {
+				return opts, false, fmt.Errorf("bad equatorial radius %s", vals[0])
+			}
+			if opts.f, err = geographiclibgo.ParseFraction(vals[1]); err != nil {
+				return opts, false, fmt.Errorf("bad flattening %s", vals[1])
+			}
+			if !(opts.a > 0 && opts.f < 1) {
+				return opts, false, fmt.Errorf("bad ellipsoid %s %s", vals[0], vals[1])
+			}
+			i += 2
+		case "-R":
+			opts.rhumb = true
+		case "-w":
+			opts.longfirst = !opts.longfirst
+		case "-p":
+			vals, err := need(i, 1)
+			if err != nil {
+				return opts, false, err
+			}
+			if opts.prec, err = strconv.Atoi(vals[0]); err != nil {
+				return opts, false, fmt.Errorf("precision %s is not a number", vals[0])
+			}
+			i++
+		case "--ellipsoid", "--comment-delimiter", "--input-string", "--input-file", "--output-file":
+			vals, err := need(i, 1)
+			if err != nil {
+				return opts, false, err
+			}
+			switch arg {
+			case "--ellipsoid":
+				e, err := geographiclibgo.LookupEllipsoidByName(vals[0])
+				if err != nil {
+					return opts, false, err
+				}
+				opts.a, opts.f = e.A, e.F
+			case "--comment-delimiter":
+				opts.commentdelim = vals[0]
+			case "--input-string":
+				opts.instring = vals[0]
+			case "--input-file":
+				opts.infile = vals[0]
+			case "--output-file":
+				opts.outfile = vals[0]
+			}
+			i++
+		case "-h", "--help":
+			return opts, true, nil
+		default:
+			return opts, false, fmt.Errorf("unknown option %s", arg)
+		}
+	}
+	if opts.instring != "" && opts.infile != "-" {
+		return opts, false, fmt.Errorf("cannot specify --input-string and --input-file together")
+	}
+	if opts.prec < 0 {
+		opts.prec = 0
+	} else if opts.prec > 10 {
+		opts.prec = 10
+	}
+	return opts, false, nil
+}
+
+// read_point reads a vertex from the fields of a line of input. This is "lat lon", an
+// MGRS reference, or a UTM/UPS coordinate "zone easting northing". MGRS and UTM/UPS
+// coordinates are always on WGS84.
+func read_point(fields []string, longfirst bool) (geographiclibgo.LatLon, error) {
+	switch len(fields) {
+	case 1:
+		m := geographiclibgo.Wgs84MGRS()
+		return m.ToLatLon(fields[0])
+	case 2:
+		return geographiclibgo.DMSDecodeLatLon(fields[0], fields[1], longfirst)
+	case 3:
+		zone, northp, err := geographiclibgo.DecodeZone(fields[0])
+		if err != nil {
+			return geographiclibgo.LatLon{}, err
+		}
+		x, err := strconv.ParseFloat(fields[1], 64)
+		if err != nil {
+			return geographiclibgo.LatLon{}, fmt.Errorf("bad easting %s", fields[1])
+		}
+		y, err := strconv.ParseFloat(fields[2], 64)
+		if err != nil {
+			return geographiclibgo.LatLon{}, fmt.Errorf("bad northing %s", fields[2])
+		}
+		u := geographiclibgo.Wgs84UTMUPS()
+		r, err := u.Reverse(zone, northp, x, y)
+		if err != nil {
+			return geographiclibgo.LatLon{}, err
+		}
+		return geographiclibgo.LatLon{LatDeg: r.LatDeg, LonDeg: r.LonDeg}, nil
+	}
+	return geographiclibgo.LatLon{}, fmt.Errorf("cannot read %q as a point", strings.Join(fields, " "))
+}
+
+// format_result formats the result for a polygon as "num perimeter area", or "num
+// length" for a polyline
+func format_result(r geographiclibgo.PolygonResult, opts *options) string {
+	out := strconv.Itoa(r.Num) + " " + cli.FormatFixed(r.Perimeter, opts.prec)
+	if !opts.polyline {
+		areaprec := opts.prec - 5
+		if areaprec < 0 {
+			areaprec = 0
+		}
+		out += " " + cli.FormatFixed(r.Area, areaprec)
+	}
+	return out
+}
+
+// run runs planimeter with the command line arguments args, not including the program
+// name, and returns the exit status
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	opts, help, err := parse_args(args)
+	if help {
+		fmt.Fprint(stdout, usage)
+		return 0
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "planimeter: %v\n\n%s", err, usage)
+		return 1
+	}
+
+	in, err := cli.OpenInput(stdin, opts.instring, opts.infile)
+	if err != nil {
+		fmt.Fprintf(stderr, "planimeter: %v\n", err)
+		return 1
+	}
+	defer in.Close()
+	out, err := cli.OpenOutput(stdout, opts.outfile)
+	if err != nil {
+		fmt.Fprintf(stderr, "planimeter: %v\n", err)
+		return 1
+	}
+	defer out.Close()
+	w := bufio.NewWriter(out)
+	defer w.Flush()
+
+	var poly geographiclibgo.PolygonArea
+	if opts.rhumb {
+		poly = geographiclibgo.NewPolygonAreaRhumb(geographiclibgo.NewRhumb(opts.a, opts.f), opts.polyline)
+	} else {
+		poly = geographiclibgo.NewPolygonArea(geographiclibgo.NewGeodesic(opts.a, opts.f), opts.polyline)
+	}
+	// end_polygon prints the result for the current polygon, if it has any vertices, and
+	// starts a new one
+	end_polygon := func() {
+		if poly.Num > 0 {
+			fmt.Fprintln(w, format_result(poly.Compute(opts.reverse, opts.sign), &opts))
+		}
+		poly.Clear()
+	}
+
+	scanner := bufio.NewScanner(in)
+	for scanner.Scan() {
+		text := scanner.Text()
+		if opts.commentdelim != "" {
+			if k := strings.Index(text, opts.commentdelim); k >= 0 {
+				text = text[:k]
+			}
+		}
+		fields := strings.Fields(strings.ReplaceAll(text, ",", " "))
+		if len(fields) == 0 {
+			end_polygon()
+			continue
+		}
+		p, err := read_point(fields, opts.longfirst)
+		if err != nil {
+			end_polygon()
+			continue
+		}
+		poly.AddPoint(p.LatDeg, p.LonDeg)
+	}
+	end_polygon()
+	if err := scanner.Err(); err != nil {
+		fmt.Fprintf(stderr, "planimeter: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// run_planimeter runs planimeter with args on input and returns the lines of output
+func run_planimeter(t *testing.T, args []string, input string) ([]string, int) {
+	var stdout, stderr bytes.Buffer
+	status := run(args, strings.NewReader(input), &stdout, &stderr)
+	out := strings.TrimRight(stdout.String(), "\n")
+	if out == "" {
+		return nil, status
+	}
+	return strings.Split(out, "\n"), status
+}
+
+// The polar cap in the GeographicLib documentation for Planimeter
+const polar_cap = "89 0\n89 90\n89 180\n89 270\n"
+
+func TestPlanimeter(t *testing.T) {
+	testCases := []struct {
+		desc  string
+		args  []string
+		input string
+		want  []string
+	}{
+		{"polar cap", nil, polar_cap, []string{"4 631819.874528 24952305678.0"}},
+		{"precision", []string{"-p", "4"}, polar_cap, []string{"4 631819.8745 24952305678"}},
+		{"reverse", []string{"-r"}, polar_cap, []string{"4 631819.874528 -24952305678.0"}},
+		{"reverse unsigned", []string{"-r", "-s"}, polar_cap, []string{"4 631819.874528 510040669418410.4"}},
+		{"polyline", []string{"-l"}, polar_cap, []string{"4 473864.905896"}},
+		{"longitude first", []string{"-w", "-p", "0"}, "0 89\n90 89\n180 89\n270 89", []string{"4 631820 24952305678"}},
+		{"dms", []string{"-p", "0"}, "89d0'0\"N 0E\n89:00N 90E\n89N 180W\n89N 90W\n", []string{"4 631820 24952305678"}},
+		{
+			"several polygons",
+			[]string{"-p", "0"},
+			polar_cap + "\n" + polar_cap + "END\n" + polar_cap,
+			[]string{"4 631820 24952305678", "4 631820 24952305678", "4 631820 24952305678"},
+		},
+		{
+			"csv",
+			[]string{"-p", "0"},
+			"lat,lon\n0,0\n0,1\n1,1\n1,0\n",
+			[]string{"4 443771 12308778361"},
+		},
+		{
+			"rhumb",
+			[]string{"-R", "-p", "0"},
+			"0 0\n0 1\n1 1\n1 0\n",
+			[]string{"4 443771 12308463894"},
+		},
+		{"rhumb polyline", []string{"-R", "-l", "-p", "0"}, "0 0\n0 1\n1 1\n", []string{"3 221894"}},
+		{
+			// A 1 km square in UTM, which is scaled by about 0.9996 from the ellipsoid
+			"mgrs",
+			[]string{"-p", "0"},
+			"38SMB4484\n38SMB4485\n38SMB4585\n38SMB4584\n",
+			[]string{"4 4001 -1000726"},
+		},
+		{
+			"utm",
+			[]string{"-p", "0"},
+			"38n 444500 3684500\n38n 444500 3685500\n38n 445500 3685500\n38n 445500 3684500\n",
+			[]string{"4 4001 -1000726"},
+		},
+		{
+			"sphere",
+			[]string{"-e", "6371e3", "0", "-p", "0"},
+			"0 0\n0 90\n90 0\n",
+			[]string{"3 30022630 63758058988724"},
+		},
+		{"comment", []string{"--comment-delimiter", "#", "-p", "0"}, polar_cap + "# not a polygon\n", []string{"4 631820 24952305678"}},
+		{"input string", []string{"--input-string", "89 0;89 90;89 180;89 270", "-p", "0"}, "", []string{"4 631820 24952305678"}},
+		{"empty", nil, "\n\nEND\n", nil},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			out, status := run_planimeter(t, tC.args, tC.input)
+			if status != 0 || strings.Join(out, "\n") != strings.Join(tC.want, "\n") {
+				t.Errorf("run(%v) = %v, %q; want %q", tC.args, status, out, tC.want)
+			}
+		})
+	}
+}
+
+func TestEllipsoids(t *testing.T) {
+	// The named ellipsoid is the same as giving its parameters, and differs from WGS84
+	clarke, _ := run_planimeter(t, []string{"--ellipsoid", "Clarke 1866", "-p", "0"}, "40 -100\n40 -99\n41 -99\n41 -100\n")
+	e, _ := run_planimeter(t, []string{"-e", "6378206.4", "1/294.978698214", "-p", "0"}, "40 -100\n40 -99\n41 -99\n41 -100\n")
+	wgs84, _ := run_planimeter(t, []string{"-p", "0"}, "40 -100\n40 -99\n41 -99\n41 -100\n")
+	if len(clarke) != 1 || len(e) != 1 || clarke[0] != e[0] || clarke[0] == wgs84[0] {
+		t.Errorf("Clarke 1866 = %q, -e = %q, WGS84 = %q", clarke, e, wgs84)
+	}
+}
+
+func TestArgumentErrors(t *testing.T) {
+	testCases := []struct {
+		desc string
+		args []string
+	}{
+		{"unknown option", []string{"-x"}},
+		{"missing ellipsoid arguments", []string{"-e", "6378137"}},
+		{"bad flattening", []string{"-e", "6378137", "abc"}},
+		{"unknown ellipsoid", []string{"--ellipsoid", "Flat Earth"}},
+		{"bad precision", []string{"-p", "x"}},
+		{"input string and file", []string{"--input-string", "0 0", "--input-file", "x"}},
+		{"missing input file", []string{"--input-file", filepath.Join(t.TempDir(), "missing")}},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			if status := run(tC.args, strings.NewReader(""), &stdout, &stderr); status != 1 || stderr.Len() == 0 {
+				t.Errorf("run(%v) = %v, stderr %q; want 1 and a message", tC.args, status, stderr.String())
+			}
+		})
+	}
+	var stdout, stderr bytes.Buffer
+	if status := run([]string{"-h"}, strings.NewReader(""), &stdout, &stderr); status != 0 ||
+		!strings.HasPrefix(stdout.String(), "Usage: planimeter") {
+		t.Errorf("run(-h) = %v, %q", status, stdout.String())
+	}
+}
+
+func TestFiles(t *testing.T) {
+	dir := t.TempDir()
+	in := filepath.Join(dir, "parcels.csv")
+	out := filepath.Join(dir, "areas.txt")
+	if err := os.WriteFile(in, []byte("lat,lon\n"+strings.ReplaceAll(polar_cap, " ", ",")), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if lines, status := run_planimeter(t, []string{"--input-file", in, "--output-file", out, "-p", "0"}, ""); status != 0 || lines != nil {
+		t.Fatalf("run() = %v, %q", status, lines)
+	}
+	got, err := os.ReadFile(out)
+	if err != nil || string(got) != "4 631820 24952305678\n" {
+		t.Errorf("output file = %q, %v", got, err)
+	}
+}
+
+func BenchmarkPlanimeter(b *testing.B) {
+	input := strings.Repeat(polar_cap+"\n", 100)
+	var stdout, stderr bytes.Buffer
+	for i := 0; i < b.N; i++ {
+		stdout.Reset()
+		run(nil, strings.NewReader(input), &stdout, &stderr)
+	}
+}
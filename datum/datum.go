@@ -0,0 +1,59 @@
+// Package datum transforms geographic coordinates between geodetic datums, using Helmert
+// transformations through geocentric coordinates or NTv2 grid shift files. The results
+// are latitudes and longitudes on the target datum, ready to be used with the Geodesic
+// returned by the target Datum's Geodesic method.
+package datum
+
+import (
+	geographiclibgo "github.com/natemcintosh/geographiclib-go"
+)
+
+// Datum is a geodetic datum, identified by its name and the ellipsoid its latitudes,
+// longitudes and heights refer to
+type Datum struct {
+	Name string  // Name of the datum, e.g. "WGS84"
+	A    float64 // Equatorial radius of the ellipsoid [meters]
+	F    float64 // Flattening of the ellipsoid
+}
+
+// NewDatum creates a Datum called name for the ellipsoid with equatorial radius a
+// [meters] and flattening f
+func NewDatum(name string, a, f float64) Datum {
+	return Datum{Name: name, A: a, F: f}
+}
+
+// Wgs84 returns the WGS84 datum
+func Wgs84() Datum {
+	return NewDatum("WGS84", geographiclibgo.WGS84_A, geographiclibgo.WGS84_F)
+}
+
+// NAD27 returns the North American Datum of 1927, on the Clarke 1866 ellipsoid
+func NAD27() Datum {
+	return NewDatum("NAD27", 6378206.4, (6378206.4-6356583.8)/6378206.4)
+}
+
+// ED50 returns the European Datum 1950, on the International 1924 ellipsoid
+func ED50() Datum {
+	return NewDatum("ED50", 6378388, 1/297.0)
+}
+
+// OSGB36 returns the Ordnance Survey of Great Britain 1936 datum, on the Airy 1830
+// ellipsoid. This is the datum of the British National Grid, see OSGB.
+func OSGB36() Datum {
+	return NewDatum("OSGB36", 6377563.396, (6377563.396-6356256.909)/6377563.396)
+}
+
+// GDA94 returns the Geocentric Datum of Australia 1994, on the GRS80 ellipsoid
+func GDA94() Datum {
+	return NewDatum("GDA94", 6378137, 1/298.257222101)
+}
+
+// Geodesic returns a Geodesic for the ellipsoid of the datum
+func (d Datum) Geodesic() geographiclibgo.Geodesic {
+	return geographiclibgo.NewGeodesic(d.A, d.F)
+}
+
+// Geocentric returns a Geocentric for the ellipsoid of the datum
+func (d Datum) Geocentric() geographiclibgo.Geocentric {
+	return geographiclibgo.NewGeocentric(d.A, d.F)
+}
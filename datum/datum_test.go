@@ -0,0 +1,44 @@
+package datum
+
+import (
+	"math"
+	"testing"
+
+	geographiclibgo "github.com/natemcintosh/geographiclib-go"
+)
+
+func almost_equal(a, b, threshold float64) bool {
+	return math.Abs(a-b) < threshold
+}
+
+func TestDatumEllipsoids(t *testing.T) {
+	testCases := []struct {
+		d    Datum
+		a    float64
+		invf float64
+	}{
+		{Wgs84(), 6378137, 298.257223563},
+		{NAD27(), 6378206.4, 294.978698},
+		{ED50(), 6378388, 297},
+		{OSGB36(), 6377563.396, 299.3249613},
+		{GDA94(), 6378137, 298.257222101},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.d.Name, func(t *testing.T) {
+			if tC.d.A != tC.a || !almost_equal(1/tC.d.F, tC.invf, 1e-6) {
+				t.Errorf("%s = %+v; want a = %v, 1/f = %v", tC.d.Name, tC.d, tC.a, tC.invf)
+			}
+			g := tC.d.Geodesic()
+			c := tC.d.Geocentric()
+			if g.EqualtorialRadius() != tC.d.A || g.Flattening() != tC.d.F ||
+				c.EquatorialRadius() != tC.d.A || c.Flattening() != tC.d.F {
+				t.Errorf("%s ellipsoid mismatch", tC.d.Name)
+			}
+		})
+	}
+	// The OSGB36 datum is that of the British National Grid
+	o := geographiclibgo.NewOSGB()
+	if d := OSGB36(); o.EquatorialRadius() != d.A || !almost_equal(o.Flattening(), d.F, 1e-16) {
+		t.Errorf("OSGB36() = %+v; OSGB uses %v, %v", d, o.EquatorialRadius(), o.Flattening())
+	}
+}
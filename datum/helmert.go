@@ -0,0 +1,232 @@
+package datum
+
+import (
+	"math"
+
+	geographiclibgo "github.com/natemcintosh/geographiclib-go"
+)
+
+// RotationConvention gives the sense of the rotations of a Helmert transformation
+type RotationConvention int
+
+const (
+	// PositionVector rotates the position vector of the point, as used by IERS and by
+	// the Ordnance Survey (EPSG method 9606)
+	PositionVector RotationConvention = iota
+	// CoordinateFrame rotates the coordinate axes instead, so the rotations have the
+	// opposite sign to PositionVector (EPSG method 9607)
+	CoordinateFrame
+)
+
+// One arcsecond [radians]
+const _ARCSEC float64 = math.Pi / (180 * 3600)
+
+// Helmert is a similarity transformation of geocentric coordinates, made up of a
+// translation, a small rotation and a change of scale:
+//
+//	X' = T + (1 + s) R X
+//
+// A 3-parameter transformation has just the translations, and a 7-parameter
+// transformation adds the rotations and scale. A 14-parameter (time-dependent)
+// transformation also gives the rate of change of each of these and the reference epoch
+// EpochYear at which the parameters apply; use AtEpoch or ForwardAtEpoch for these. The
+// rotations are small enough that R is linearized.
+type Helmert struct {
+	TxM, TyM, TzM                float64 // Translations [meters]
+	RxArcsec, RyArcsec, RzArcsec float64 // Rotations about the X, Y and Z axes [arcseconds]
+	ScalePPM                     float64 // Scale difference [parts per million]
+
+	DTxMPerYear, DTyMPerYear, DTzMPerYear                float64 // Rates of the translations [meters/year]
+	DRxArcsecPerYear, DRyArcsecPerYear, DRzArcsecPerYear float64 // Rates of the rotations [arcseconds/year]
+	DScalePPMPerYear                                     float64 // Rate of the scale difference [ppm/year]
+	EpochYear                                            float64 // Reference epoch [decimal year]
+
+	Convention RotationConvention // The sense of the rotations
+}
+
+// NewHelmert3 creates a 3-parameter Helmert transformation which translates the
+// geocentric coordinates by tx_m, ty_m, tz_m
+func NewHelmert3(tx_m, ty_m, tz_m float64) Helmert {
+	return Helmert{TxM: tx_m, TyM: ty_m, TzM: tz_m}
+}
+
+// NewHelmert7 creates a 7-parameter Helmert transformation with translations tx_m,
+// ty_m, tz_m [meters], rotations rx_arcsec, ry_arcsec, rz_arcsec [arcseconds] in the
+// sense given by conv, and scale difference s_ppm [parts per million]
+func NewHelmert7(
+	tx_m, ty_m, tz_m float64,
+	rx_arcsec, ry_arcsec, rz_arcsec float64,
+	s_ppm float64,
+	conv RotationConvention,
+) Helmert {
+	return Helmert{
+		TxM: tx_m, TyM: ty_m, TzM: tz_m,
+		RxArcsec: rx_arcsec, RyArcsec: ry_arcsec, RzArcsec: rz_arcsec,
+		ScalePPM:   s_ppm,
+		Convention: conv,
+	}
+}
+
+// AtEpoch returns the 7-parameter transformation given by h at the time epoch_year
+// [decimal year], with the rates applied from the reference epoch
+func (h *Helmert) AtEpoch(epoch_year float64) Helmert {
+	dt := epoch_year - h.EpochYear
+	return Helmert{
+		TxM:        h.TxM + dt*h.DTxMPerYear,
+		TyM:        h.TyM + dt*h.DTyMPerYear,
+		TzM:        h.TzM + dt*h.DTzMPerYear,
+		RxArcsec:   h.RxArcsec + dt*h.DRxArcsecPerYear,
+		RyArcsec:   h.RyArcsec + dt*h.DRyArcsecPerYear,
+		RzArcsec:   h.RzArcsec + dt*h.DRzArcsecPerYear,
+		ScalePPM:   h.ScalePPM + dt*h.DScalePPMPerYear,
+		EpochYear:  epoch_year,
+		Convention: h.Convention,
+	}
+}
+
+// matrix returns the matrix (1 + s) R of the transformation in row-major order. The
+// rates are ignored.
+func (h *Helmert) matrix() [9]float64 {
+	rx, ry, rz := h.RxArcsec*_ARCSEC, h.RyArcsec*_ARCSEC, h.RzArcsec*_ARCSEC
+	if h.Convention == CoordinateFrame {
+		rx, ry, rz = -rx, -ry, -rz
+	}
+	m := 1 + h.ScalePPM*1e-6
+	return [9]float64{
+		m, -m * rz, m * ry,
+		m * rz, m, -m * rx,
+		-m * ry, m * rx, m,
+	}
+}
+
+// Forward applies the transformation to the geocentric point c, ignoring the rates
+func (h *Helmert) Forward(c geographiclibgo.Cartesian) geographiclibgo.Cartesian {
+	m := h.matrix()
+	return geographiclibgo.Cartesian{
+		XM: h.TxM + m[0]*c.XM + m[1]*c.YM + m[2]*c.ZM,
+		YM: h.TyM + m[3]*c.XM + m[4]*c.YM + m[5]*c.ZM,
+		ZM: h.TzM + m[6]*c.XM + m[7]*c.YM + m[8]*c.ZM,
+	}
+}
+
+// Reverse applies the inverse of the transformation to the geocentric point c, ignoring
+// the rates. This is the exact inverse of Forward, rather than the approximation
+// obtained by negating the parameters.
+func (h *Helmert) Reverse(c geographiclibgo.Cartesian) geographiclibgo.Cartesian {
+	m := h.matrix()
+	x, y, z := c.XM-h.TxM, c.YM-h.TyM, c.ZM-h.TzM
+	// Multiply (x, y, z) by the inverse of m, the adjugate of m divided by its determinant
+	c0 := m[4]*m[8] - m[5]*m[7]
+	c1 := m[5]*m[6] - m[3]*m[8]
+	c2 := m[3]*m[7] - m[4]*m[6]
+	det := m[0]*c0 + m[1]*c1 + m[2]*c2
+	return geographiclibgo.Cartesian{
+		XM: (c0*x + (m[2]*m[7]-m[1]*m[8])*y + (m[1]*m[5]-m[2]*m[4])*z) / det,
+		YM: (c1*x + (m[0]*m[8]-m[2]*m[6])*y + (m[2]*m[3]-m[0]*m[5])*z) / det,
+		ZM: (c2*x + (m[1]*m[6]-m[0]*m[7])*y + (m[0]*m[4]-m[1]*m[3])*z) / det,
+	}
+}
+
+// ForwardAtEpoch applies the transformation at the time epoch_year [decimal year] to the
+// geocentric point c
+func (h *Helmert) ForwardAtEpoch(c geographiclibgo.Cartesian, epoch_year float64) geographiclibgo.Cartesian {
+	he := h.AtEpoch(epoch_year)
+	return he.Forward(c)
+}
+
+// ReverseAtEpoch applies the inverse of the transformation at the time epoch_year
+// [decimal year] to the geocentric point c
+func (h *Helmert) ReverseAtEpoch(c geographiclibgo.Cartesian, epoch_year float64) geographiclibgo.Cartesian {
+	he := h.AtEpoch(epoch_year)
+	return he.Reverse(c)
+}
+
+// HelmertTransformation transforms latitude, longitude and height from one datum to
+// another by converting to geocentric coordinates on the ellipsoid of From, applying a
+// Helmert transformation, and converting back on the ellipsoid of To.
+type HelmertTransformation struct {
+	From    Datum
+	To      Datum
+	Helmert Helmert
+	from    geographiclibgo.Geocentric
+	to      geographiclibgo.Geocentric
+}
+
+// NewHelmertTransformation creates a HelmertTransformation from datum from to datum to,
+// where h transforms geocentric coordinates of from to those of to
+func NewHelmertTransformation(from, to Datum, h Helmert) HelmertTransformation {
+	return HelmertTransformation{
+		From:    from,
+		To:      to,
+		Helmert: h,
+		from:    from.Geocentric(),
+		to:      to.Geocentric(),
+	}
+}
+
+// Forward transforms the point lat_deg, lon_deg, h_m on From to To, ignoring the rates
+// of the Helmert transformation. Use a height of 0 if the height is unknown; the
+// resulting height is then the approximate height of the ellipsoid of From above that of
+// To, and the latitude and longitude are hardly affected.
+func (t *HelmertTransformation) Forward(lat_deg, lon_deg, h_m float64) geographiclibgo.GeodeticPosition {
+	c := t.from.Forward(lat_deg, lon_deg, h_m)
+	c = t.Helmert.Forward(c)
+	return t.to.Reverse(c.XM, c.YM, c.ZM)
+}
+
+// Reverse transforms the point lat_deg, lon_deg, h_m on To back to From, ignoring the
+// rates of the Helmert transformation
+func (t *HelmertTransformation) Reverse(lat_deg, lon_deg, h_m float64) geographiclibgo.GeodeticPosition {
+	c := t.to.Forward(lat_deg, lon_deg, h_m)
+	c = t.Helmert.Reverse(c)
+	return t.from.Reverse(c.XM, c.YM, c.ZM)
+}
+
+// ForwardAtEpoch transforms the point lat_deg, lon_deg, h_m on From to To, using the
+// Helmert transformation at the time epoch_year [decimal year]
+func (t *HelmertTransformation) ForwardAtEpoch(
+	lat_deg, lon_deg, h_m, epoch_year float64,
+) geographiclibgo.GeodeticPosition {
+	c := t.from.Forward(lat_deg, lon_deg, h_m)
+	c = t.Helmert.ForwardAtEpoch(c, epoch_year)
+	return t.to.Reverse(c.XM, c.YM, c.ZM)
+}
+
+// ReverseAtEpoch transforms the point lat_deg, lon_deg, h_m on To back to From, using
+// the Helmert transformation at the time epoch_year [decimal year]
+func (t *HelmertTransformation) ReverseAtEpoch(
+	lat_deg, lon_deg, h_m, epoch_year float64,
+) geographiclibgo.GeodeticPosition {
+	c := t.to.Forward(lat_deg, lon_deg, h_m)
+	c = t.Helmert.ReverseAtEpoch(c, epoch_year)
+	return t.from.Reverse(c.XM, c.YM, c.ZM)
+}
+
+// NAD27ToWgs84 returns the 3-parameter transformation from NAD27 to WGS84 for the
+// conterminous United States (EPSG:1173). Its accuracy is about 10 m.
+func NAD27ToWgs84() HelmertTransformation {
+	return NewHelmertTransformation(NAD27(), Wgs84(), NewHelmert3(-8, 160, 176))
+}
+
+// ED50ToWgs84 returns the 3-parameter transformation from ED50 to WGS84 for western
+// Europe (EPSG:1133). Its accuracy is about 10 m.
+func ED50ToWgs84() HelmertTransformation {
+	return NewHelmertTransformation(ED50(), Wgs84(), NewHelmert3(-87, -98, -121))
+}
+
+// Wgs84ToOSGB36 returns the 7-parameter transformation from WGS84 to OSGB36 published by
+// the Ordnance Survey for ETRS89, which agrees with WGS84 to within a meter or so. Its
+// accuracy is about 5 m; for better accuracy use the OSTN15 transformation.
+func Wgs84ToOSGB36() HelmertTransformation {
+	return NewHelmertTransformation(
+		Wgs84(), OSGB36(),
+		NewHelmert7(-446.448, 125.157, -542.060, -0.1502, -0.2470, -0.8421, 20.4894, PositionVector),
+	)
+}
+
+// GDA94ToWgs84 returns the null transformation from GDA94 to WGS84 (EPSG:1150), which
+// is accurate to about 3 m. The GRS80 ellipsoid of GDA94 and the WGS84 ellipsoid differ
+// by only 0.1 mm in their minor radii.
+func GDA94ToWgs84() HelmertTransformation {
+	return NewHelmertTransformation(GDA94(), Wgs84(), Helmert{})
+}
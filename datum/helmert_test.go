@@ -0,0 +1,147 @@
+package datum
+
+import (
+	"math"
+	"testing"
+
+	geographiclibgo "github.com/natemcintosh/geographiclib-go"
+)
+
+func TestHelmertForward(t *testing.T) {
+	// The WGS72 to WGS84 example for EPSG methods 9606 and 9607 in IOGP Guidance Note 7-2
+	p := geographiclibgo.Cartesian{XM: 3657660.66, YM: 255768.55, ZM: 5201382.11}
+	want := geographiclibgo.Cartesian{XM: 3657660.78, YM: 255778.43, ZM: 5201387.75}
+	testCases := []struct {
+		desc string
+		h    Helmert
+	}{
+		{"position vector", NewHelmert7(0, 0, 4.5, 0, 0, 0.554, 0.219, PositionVector)},
+		{"coordinate frame", NewHelmert7(0, 0, 4.5, 0, 0, -0.554, 0.219, CoordinateFrame)},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			got := tC.h.Forward(p)
+			if math.Abs(got.XM-want.XM) > 0.01 || math.Abs(got.YM-want.YM) > 0.01 ||
+				math.Abs(got.ZM-want.ZM) > 0.01 {
+				t.Errorf("Forward(%v) = %v; want %v", p, got, want)
+			}
+		})
+	}
+	// A 3-parameter transformation is a translation
+	h := NewHelmert3(-8, 160, 176)
+	if got := h.Forward(p); got != (geographiclibgo.Cartesian{XM: p.XM - 8, YM: p.YM + 160, ZM: p.ZM + 176}) {
+		t.Errorf("Forward(%v) = %v", p, got)
+	}
+}
+
+func TestHelmertReverse(t *testing.T) {
+	h := NewHelmert7(-446.448, 125.157, -542.060, -0.1502, -0.2470, -0.8421, 20.4894, PositionVector)
+	p := geographiclibgo.Cartesian{XM: 3874938.849, YM: 116218.624, ZM: 5047168.208}
+	q := h.Forward(p)
+	r := h.Reverse(q)
+	if math.Abs(r.XM-p.XM) > 1e-8 || math.Abs(r.YM-p.YM) > 1e-8 || math.Abs(r.ZM-p.ZM) > 1e-8 {
+		t.Errorf("Reverse(Forward(%v)) = %v", p, r)
+	}
+	// Negating the parameters is only approximately the inverse
+	n := NewHelmert7(446.448, -125.157, 542.060, 0.1502, 0.2470, 0.8421, -20.4894, PositionVector)
+	if a := n.Forward(q); math.Abs(a.XM-p.XM) < 1e-6 || math.Abs(a.XM-p.XM) > 1e-2 {
+		t.Errorf("approximate inverse = %v; want within 1 cm of %v", a, p)
+	}
+}
+
+func TestHelmertAtEpoch(t *testing.T) {
+	h := Helmert{
+		TxM: 0.1, TyM: -0.2, TzM: 0.3,
+		RxArcsec: 0.01, RyArcsec: 0.02, RzArcsec: -0.03,
+		ScalePPM:    0.004,
+		DTxMPerYear: 0.001, DTyMPerYear: 0.002, DTzMPerYear: -0.003,
+		DRxArcsecPerYear: 0.0001, DRyArcsecPerYear: -0.0002, DRzArcsecPerYear: 0.0003,
+		DScalePPMPerYear: 0.00005,
+		EpochYear:        2010,
+		Convention:       CoordinateFrame,
+	}
+	same := h.AtEpoch(2010)
+	if same.TxM != h.TxM || same.RzArcsec != h.RzArcsec || same.ScalePPM != h.ScalePPM ||
+		same.DTxMPerYear != 0 || same.Convention != CoordinateFrame {
+		t.Errorf("AtEpoch(2010) = %+v", same)
+	}
+	later := h.AtEpoch(2020.5)
+	if !almost_equal(later.TxM, 0.1+10.5*0.001, 1e-15) || !almost_equal(later.TzM, 0.3-10.5*0.003, 1e-15) ||
+		!almost_equal(later.RyArcsec, 0.02-10.5*0.0002, 1e-15) || !almost_equal(later.ScalePPM, 0.004+10.5*0.00005, 1e-15) ||
+		later.EpochYear != 2020.5 {
+		t.Errorf("AtEpoch(2020.5) = %+v", later)
+	}
+	p := geographiclibgo.Cartesian{XM: -4052052.0, YM: 4212836.0, ZM: -2545105.0}
+	if got, want := h.ForwardAtEpoch(p, 2020.5), later.Forward(p); got != want {
+		t.Errorf("ForwardAtEpoch() = %v; want %v", got, want)
+	}
+	q := h.ForwardAtEpoch(p, 2020.5)
+	if r := h.ReverseAtEpoch(q, 2020.5); math.Abs(r.XM-p.XM) > 1e-8 || math.Abs(r.ZM-p.ZM) > 1e-8 {
+		t.Errorf("ReverseAtEpoch(ForwardAtEpoch(%v)) = %v", p, r)
+	}
+	// The rates move the point by about 3 cm over 10 years
+	if d := math.Abs(q.XM - h.Forward(p).XM); d < 1e-3 || d > 0.1 {
+		t.Errorf("epoch changed X by %v", d)
+	}
+}
+
+func TestHelmertTransformation(t *testing.T) {
+	testCases := []struct {
+		desc string
+		tr   HelmertTransformation
+		lat  float64
+		lon  float64
+		max  float64 // The largest expected shift [meters]
+	}{
+		{"NAD27", NAD27ToWgs84(), 40, -100, 100},
+		{"ED50", ED50ToWgs84(), 48, 2, 200},
+		{"OSGB36", Wgs84ToOSGB36(), 52.657977, 1.716038, 200},
+		{"GDA94", GDA94ToWgs84(), -35, 149, 1e-3},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			p := tC.tr.Forward(tC.lat, tC.lon, 100)
+			g := tC.tr.To.Geodesic()
+			s12 := g.InverseCalcDistance(tC.lat, tC.lon, p.LatDeg, p.LonDeg)
+			if !(s12 < tC.max) {
+				t.Errorf("Forward(%v, %v) = %+v, %v m away", tC.lat, tC.lon, p, s12)
+			}
+			r := tC.tr.Reverse(p.LatDeg, p.LonDeg, p.HeightM)
+			if !almost_equal(r.LatDeg, tC.lat, 1e-12) || !almost_equal(r.LonDeg, tC.lon, 1e-12) ||
+				!almost_equal(r.HeightM, 100, 1e-6) {
+				t.Errorf("Reverse(Forward(%v, %v, 100)) = %+v", tC.lat, tC.lon, r)
+			}
+		})
+	}
+	// The ETRS89 and OSGB36 coordinates of the point in Annexes B and C of A Guide to
+	// Coordinate Systems in Great Britain, Ordnance Survey (2020). The Helmert
+	// transformation is good to about 5 m.
+	tr := Wgs84ToOSGB36()
+	p := tr.Forward(52+39.0/60+28.7230/3600, 1+42.0/60+57.8663/3600, 0)
+	g := OSGB36().Geodesic()
+	if s12 := g.InverseCalcDistance(p.LatDeg, p.LonDeg, 52+39.0/60+27.2531/3600, 1+43.0/60+4.5177/3600); s12 > 5 {
+		t.Errorf("Wgs84ToOSGB36().Forward() = %+v, %v m from the OSGB36 point", p, s12)
+	}
+}
+
+func TestHelmertTransformationAtEpoch(t *testing.T) {
+	tr := NewHelmertTransformation(GDA94(), Wgs84(), Helmert{DTxMPerYear: 0.1, EpochYear: 2000})
+	p := tr.ForwardAtEpoch(-35, 149, 0, 2010)
+	q := tr.Forward(-35, 149, 0)
+	g := Wgs84().Geodesic()
+	// A translation of 1 m along X, of which about 0.71 m is horizontal here
+	if s12 := g.InverseCalcDistance(p.LatDeg, p.LonDeg, q.LatDeg, q.LonDeg); math.Abs(s12-0.712) > 0.01 {
+		t.Errorf("ForwardAtEpoch moved the point %v m", s12)
+	}
+	r := tr.ReverseAtEpoch(p.LatDeg, p.LonDeg, p.HeightM, 2010)
+	if !almost_equal(r.LatDeg, -35, 1e-12) || !almost_equal(r.LonDeg, 149, 1e-12) {
+		t.Errorf("ReverseAtEpoch(ForwardAtEpoch()) = %+v", r)
+	}
+}
+
+func BenchmarkHelmertTransformation(b *testing.B) {
+	tr := Wgs84ToOSGB36()
+	for i := 0; i < b.N; i++ {
+		tr.Forward(52.657977, 1.716038, 0)
+	}
+}
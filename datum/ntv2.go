@@ -0,0 +1,357 @@
+package datum
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"os"
+	"strings"
+
+	geographiclibgo "github.com/natemcintosh/geographiclib-go"
+)
+
+// Sizes in the NTv2 format
+const (
+	_NTV2_RECORD    int = 16 // Each header record is an 8 byte key and an 8 byte value
+	_NTV2_NOVERVIEW int = 11 // Records in the overview header
+	_NTV2_NSUBGRID  int = 11 // Records in each subgrid header
+	_NTV2_NODE      int = 16 // Each node is 4 float32 values
+)
+
+// ntv2_subgrid is one of the grids in an NTv2 file. The limits and spacing are in
+// arcseconds with longitude positive west, as in the file. Node (i, j) is i columns west
+// of the east edge and j rows north of the south edge.
+type ntv2_subgrid struct {
+	name     string
+	parent   string
+	slat     float64
+	nlat     float64
+	elon     float64
+	wlon     float64
+	dlat     float64
+	dlon     float64
+	nrows    int
+	ncols    int
+	latshift []float32 // Latitude shifts [arcseconds]
+	lonshift []float32 // Longitude shifts, positive west [arcseconds]
+	children []int
+}
+
+// contains returns whether the point lat, lon (arcseconds, longitude positive west) is
+// within the subgrid
+func (s *ntv2_subgrid) contains(lat, lon float64) bool {
+	return lat >= s.slat && lat <= s.nlat && lon >= s.elon && lon <= s.wlon
+}
+
+// shift bilinearly interpolates the latitude and longitude shifts at the point lat, lon
+// (arcseconds, longitude positive west) within the subgrid
+func (s *ntv2_subgrid) shift(lat, lon float64) (dlat, dlon float64) {
+	x := (lon - s.elon) / s.dlon
+	y := (lat - s.slat) / s.dlat
+	i := int(math.Floor(x))
+	j := int(math.Floor(y))
+	// Points on the west and north edges use the last cell
+	if i > s.ncols-2 {
+		i = s.ncols - 2
+	}
+	if j > s.nrows-2 {
+		j = s.nrows - 2
+	}
+	x -= float64(i)
+	y -= float64(j)
+	interp := func(v []float32) float64 {
+		k := j*s.ncols + i
+		v00, v10 := float64(v[k]), float64(v[k+1])
+		v01, v11 := float64(v[k+s.ncols]), float64(v[k+s.ncols+1])
+		return (1-y)*((1-x)*v00+x*v10) + y*((1-x)*v01+x*v11)
+	}
+	return interp(s.latshift), interp(s.lonshift)
+}
+
+// NTv2 is a grid shift transformation read from a National Transformation version 2
+// file, the format used for NAD27 to NAD83 in Canada (NTV2_0.GSB), for AGD66 and AGD84 to
+// GDA94 in Australia, for ED50 to ETRS89 in Spain, and elsewhere. The files are not
+// bundled with this library.
+//
+// A file holds one or more grids of latitude and longitude shifts, some of which may be
+// denser subgrids of others. Forward interpolates the shifts bilinearly in the densest
+// grid containing the point. The shifts are applied to latitude and longitude only;
+// heights are unchanged.
+type NTv2 struct {
+	from  string
+	to    string
+	grids []ntv2_subgrid
+	roots []int
+}
+
+// NewNTv2 reads an NTv2 grid shift file from r. Both big and little endian files are
+// accepted.
+func NewNTv2(r io.Reader) (NTv2, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return NTv2{}, err
+	}
+	if len(data) < _NTV2_NOVERVIEW*_NTV2_RECORD {
+		return NTv2{}, errors.New("NTv2 file too short for its header")
+	}
+	if string(data[:8]) != "NUM_OREC" {
+		return NTv2{}, errors.New("NTv2 file does not start with NUM_OREC")
+	}
+	var order binary.ByteOrder = binary.LittleEndian
+	if order.Uint32(data[8:12]) != uint32(_NTV2_NOVERVIEW) {
+		order = binary.BigEndian
+		if order.Uint32(data[8:12]) != uint32(_NTV2_NOVERVIEW) {
+			return NTv2{}, errors.New("NTv2 file has an unexpected number of overview records")
+		}
+	}
+	pos := 0
+	// record returns the next header record, checking its key
+	record := func(key string) ([]byte, error) {
+		if pos+_NTV2_RECORD > len(data) {
+			return nil, fmt.Errorf("NTv2 file ends before %s", key)
+		}
+		rec := data[pos : pos+_NTV2_RECORD]
+		pos += _NTV2_RECORD
+		if got := strings.TrimSpace(string(rec[:8])); got != key {
+			return nil, fmt.Errorf("NTv2 file has %s where %s was expected", got, key)
+		}
+		return rec[8:], nil
+	}
+	int_record := func(key string) (int, error) {
+		v, err := record(key)
+		if err != nil {
+			return 0, err
+		}
+		return int(int32(order.Uint32(v[:4]))), nil
+	}
+	string_record := func(key string) (string, error) {
+		v, err := record(key)
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimSpace(string(bytes.TrimRight(v, "\x00"))), nil
+	}
+	float_record := func(key string) (float64, error) {
+		v, err := record(key)
+		if err != nil {
+			return 0, err
+		}
+		return math.Float64frombits(order.Uint64(v)), nil
+	}
+
+	if _, err := int_record("NUM_OREC"); err != nil {
+		return NTv2{}, err
+	}
+	nsrec, err := int_record("NUM_SREC")
+	if err != nil {
+		return NTv2{}, err
+	}
+	if nsrec != _NTV2_NSUBGRID {
+		return NTv2{}, fmt.Errorf("NTv2 file has %d subgrid header records, not %d", nsrec, _NTV2_NSUBGRID)
+	}
+	nfile, err := int_record("NUM_FILE")
+	if err != nil {
+		return NTv2{}, err
+	}
+	gstype, err := string_record("GS_TYPE")
+	if err != nil {
+		return NTv2{}, err
+	}
+	// The factor converting the units of the file to arcseconds
+	var unit float64
+	switch strings.ToUpper(gstype) {
+	case "SECONDS":
+		unit = 1
+	case "MINUTES":
+		unit = 60
+	case "DEGREES":
+		unit = 3600
+	default:
+		return NTv2{}, fmt.Errorf("unknown NTv2 GS_TYPE %s", gstype)
+	}
+	var g NTv2
+	if _, err := string_record("VERSION"); err != nil {
+		return NTv2{}, err
+	}
+	if g.from, err = string_record("SYSTEM_F"); err != nil {
+		return NTv2{}, err
+	}
+	if g.to, err = string_record("SYSTEM_T"); err != nil {
+		return NTv2{}, err
+	}
+	for _, key := range []string{"MAJOR_F", "MINOR_F", "MAJOR_T", "MINOR_T"} {
+		if _, err := float_record(key); err != nil {
+			return NTv2{}, err
+		}
+	}
+
+	index := make(map[string]int, nfile)
+	for k := 0; k < nfile; k++ {
+		var s ntv2_subgrid
+		if s.name, err = string_record("SUB_NAME"); err != nil {
+			return NTv2{}, err
+		}
+		if s.parent, err = string_record("PARENT"); err != nil {
+			return NTv2{}, err
+		}
+		if _, err := string_record("CREATED"); err != nil {
+			return NTv2{}, err
+		}
+		if _, err := string_record("UPDATED"); err != nil {
+			return NTv2{}, err
+		}
+		limits := []*float64{&s.slat, &s.nlat, &s.elon, &s.wlon, &s.dlat, &s.dlon}
+		for i, key := range []string{"S_LAT", "N_LAT", "E_LONG", "W_LONG", "LAT_INC", "LONG_INC"} {
+			v, err := float_record(key)
+			if err != nil {
+				return NTv2{}, err
+			}
+			*limits[i] = v * unit
+		}
+		count, err := int_record("GS_COUNT")
+		if err != nil {
+			return NTv2{}, err
+		}
+		if !(s.dlat > 0 && s.dlon > 0 && s.nlat > s.slat && s.wlon > s.elon) {
+			return NTv2{}, fmt.Errorf("NTv2 subgrid %s has bad limits", s.name)
+		}
+		s.nrows = int(math.Round((s.nlat-s.slat)/s.dlat)) + 1
+		s.ncols = int(math.Round((s.wlon-s.elon)/s.dlon)) + 1
+		if count != s.nrows*s.ncols {
+			return NTv2{}, fmt.Errorf(
+				"NTv2 subgrid %s has %d nodes, not %d by %d", s.name, count, s.nrows, s.ncols,
+			)
+		}
+		if pos+count*_NTV2_NODE > len(data) {
+			return NTv2{}, fmt.Errorf("NTv2 file ends within subgrid %s", s.name)
+		}
+		s.latshift = make([]float32, count)
+		s.lonshift = make([]float32, count)
+		for n := 0; n < count; n++ {
+			node := data[pos : pos+_NTV2_NODE]
+			pos += _NTV2_NODE
+			// The accuracies in the last two values are not used
+			s.latshift[n] = math.Float32frombits(order.Uint32(node[0:4])) * float32(unit)
+			s.lonshift[n] = math.Float32frombits(order.Uint32(node[4:8])) * float32(unit)
+		}
+		if _, dup := index[s.name]; dup {
+			return NTv2{}, fmt.Errorf("NTv2 subgrid %s appears twice", s.name)
+		}
+		index[s.name] = len(g.grids)
+		g.grids = append(g.grids, s)
+	}
+	for k := range g.grids {
+		parent := g.grids[k].parent
+		if strings.EqualFold(parent, "NONE") {
+			g.roots = append(g.roots, k)
+			continue
+		}
+		p, ok := index[parent]
+		if !ok {
+			return NTv2{}, fmt.Errorf("NTv2 subgrid %s has unknown parent %s", g.grids[k].name, parent)
+		}
+		g.grids[p].children = append(g.grids[p].children, k)
+	}
+	return g, nil
+}
+
+// NewNTv2FromFile reads the NTv2 grid shift file at path, e.g. "ntv2_0.gsb"
+func NewNTv2FromFile(path string) (NTv2, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return NTv2{}, err
+	}
+	defer f.Close()
+	g, err := NewNTv2(f)
+	if err != nil {
+		return NTv2{}, fmt.Errorf("%s: %v", path, err)
+	}
+	return g, nil
+}
+
+// From returns the name of the source datum given in the file, e.g. "NAD27"
+func (g *NTv2) From() string { return g.from }
+
+// To returns the name of the target datum given in the file, e.g. "NAD83"
+func (g *NTv2) To() string { return g.to }
+
+// Subgrids returns the names of the grids in the file
+func (g *NTv2) Subgrids() []string {
+	names := make([]string, len(g.grids))
+	for k := range g.grids {
+		names[k] = g.grids[k].name
+	}
+	return names
+}
+
+// find returns the densest grid containing the point lat, lon (arcseconds, longitude
+// positive west), or nil if no grid contains it
+func (g *NTv2) find(lat, lon float64) *ntv2_subgrid {
+	var s *ntv2_subgrid
+	candidates := g.roots
+	for len(candidates) > 0 {
+		var next []int
+		for _, k := range candidates {
+			if g.grids[k].contains(lat, lon) {
+				s = &g.grids[k]
+				next = s.children
+				break
+			}
+		}
+		candidates = next
+	}
+	return s
+}
+
+// shift returns the latitude and longitude shifts [degrees] at the point lat_deg,
+// lon_deg, with the longitude shift positive east
+func (g *NTv2) shift(lat_deg, lon_deg float64) (dlat_deg, dlon_deg float64, err error) {
+	lat := lat_deg * 3600
+	// Longitudes in the grid are positive west
+	lon := -lon_deg * 3600
+	s := g.find(lat, lon)
+	if s == nil {
+		return 0, 0, fmt.Errorf("point %vd, %vd is outside the NTv2 grid", lat_deg, lon_deg)
+	}
+	dlat, dlon := s.shift(lat, lon)
+	return dlat / 3600, -dlon / 3600, nil
+}
+
+// Forward transforms the point lat_deg, lon_deg from the source datum to the target
+// datum. This returns an error if the point is outside all of the grids.
+func (g *NTv2) Forward(lat_deg, lon_deg float64) (geographiclibgo.LatLon, error) {
+	dlat, dlon, err := g.shift(lat_deg, lon_deg)
+	if err != nil {
+		return geographiclibgo.LatLon{}, err
+	}
+	return geographiclibgo.LatLon{LatDeg: lat_deg + dlat, LonDeg: lon_deg + dlon}, nil
+}
+
+// Reverse transforms the point lat_deg, lon_deg from the target datum back to the source
+// datum. The shifts are evaluated at the source point, which is found by iteration, so
+// that Forward(Reverse(p)) is p. This returns an error if the point is outside all of
+// the grids, or if there is no such source point, which can happen next to the edge of
+// a subgrid whose shifts don't match those of its parent.
+func (g *NTv2) Reverse(lat_deg, lon_deg float64) (geographiclibgo.LatLon, error) {
+	// The shifts change by much less than the shifts themselves over the distance of a
+	// shift, so this converges quickly
+	lat, lon := lat_deg, lon_deg
+	for i := 0; i < 10; i++ {
+		dlat, dlon, err := g.shift(lat, lon)
+		if err != nil {
+			return geographiclibgo.LatLon{}, err
+		}
+		latn, lonn := lat_deg-dlat, lon_deg-dlon
+		if math.Abs(latn-lat) <= 1e-12 && math.Abs(lonn-lon) <= 1e-12 {
+			return geographiclibgo.LatLon{LatDeg: latn, LonDeg: lonn}, nil
+		}
+		lat, lon = latn, lonn
+	}
+	return geographiclibgo.LatLon{}, fmt.Errorf(
+		"reverse NTv2 transformation of %vd, %vd did not converge", lat_deg, lon_deg,
+	)
+}
@@ -0,0 +1,246 @@
+package datum
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// test_grid describes a subgrid of a synthetic NTv2 file. The limits are in degrees with
+// longitude positive east. The shifts are linear in latitude and longitude, so bilinear
+// interpolation reproduces them.
+type test_grid struct {
+	name, parent           string
+	slat, nlat, wlon, elon float64
+	inc                    float64
+	offset                 float64 // Added to both shifts [arcseconds]
+}
+
+// test_shift returns the latitude shift and the longitude shift (positive west) of g at
+// lat, lon [arcseconds]
+func test_shift(g test_grid, lat, lon float64) (float64, float64) {
+	return 0.1 + 1e-5*(lat-40*3600) + g.offset, -2 + 2e-5*(lon-100*3600) + g.offset
+}
+
+// write_ntv2 writes a synthetic NTv2 file with byte order order and subgrids grids
+func write_ntv2(order binary.ByteOrder, grids []test_grid) []byte {
+	var b bytes.Buffer
+	key := func(k string) {
+		b.WriteString((k + "        ")[:8])
+	}
+	int_rec := func(k string, v int) {
+		key(k)
+		binary.Write(&b, order, int32(v))
+		b.Write(make([]byte, 4))
+	}
+	str_rec := func(k, v string) {
+		key(k)
+		b.WriteString((v + "        ")[:8])
+	}
+	float_rec := func(k string, v float64) {
+		key(k)
+		binary.Write(&b, order, v)
+	}
+	int_rec("NUM_OREC", 11)
+	int_rec("NUM_SREC", 11)
+	int_rec("NUM_FILE", len(grids))
+	str_rec("GS_TYPE", "SECONDS")
+	str_rec("VERSION", "NTv2.0")
+	str_rec("SYSTEM_F", "NAD27")
+	str_rec("SYSTEM_T", "NAD83")
+	float_rec("MAJOR_F", 6378206.4)
+	float_rec("MINOR_F", 6356583.8)
+	float_rec("MAJOR_T", 6378137)
+	float_rec("MINOR_T", 6356752.314)
+	for _, g := range grids {
+		str_rec("SUB_NAME", g.name)
+		str_rec("PARENT", g.parent)
+		str_rec("CREATED", "20260101")
+		str_rec("UPDATED", "20260101")
+		// Longitudes are positive west in the file
+		float_rec("S_LAT", g.slat*3600)
+		float_rec("N_LAT", g.nlat*3600)
+		float_rec("E_LONG", -g.elon*3600)
+		float_rec("W_LONG", -g.wlon*3600)
+		float_rec("LAT_INC", g.inc*3600)
+		float_rec("LONG_INC", g.inc*3600)
+		nrows := int(math.Round((g.nlat-g.slat)/g.inc)) + 1
+		ncols := int(math.Round((g.elon-g.wlon)/g.inc)) + 1
+		int_rec("GS_COUNT", nrows*ncols)
+		for j := 0; j < nrows; j++ {
+			for i := 0; i < ncols; i++ {
+				dlat, dlon := test_shift(g, (g.slat+float64(j)*g.inc)*3600, -(g.elon-float64(i)*g.inc)*3600)
+				binary.Write(&b, order, []float32{float32(dlat), float32(dlon), 0.01, 0.01})
+			}
+		}
+	}
+	str_rec("END", "")
+	return b.Bytes()
+}
+
+var test_grids = []test_grid{
+	{"PARENT", "NONE", 40, 42, -102, -100, 0.5, 0},
+	{"CHILD", "PARENT", 41, 41.5, -101.5, -101, 0.125, 0.5},
+	{"OTHER", "NONE", 40, 41, -99, -98, 0.5, 0},
+}
+
+func TestNTv2Forward(t *testing.T) {
+	for _, order := range []binary.ByteOrder{binary.LittleEndian, binary.BigEndian} {
+		g, err := NewNTv2(bytes.NewReader(write_ntv2(order, test_grids)))
+		if err != nil {
+			t.Fatalf("NewNTv2(%v) error = %v", order, err)
+		}
+		if g.From() != "NAD27" || g.To() != "NAD83" || len(g.Subgrids()) != 3 || g.Subgrids()[1] != "CHILD" {
+			t.Errorf("NewNTv2(%v) = %v, %v, %v", order, g.From(), g.To(), g.Subgrids())
+		}
+		testCases := []struct {
+			desc string
+			lat  float64
+			lon  float64
+			grid int
+		}{
+			{"parent", 40.3, -100.7, 0},
+			{"child", 41.2, -101.3, 1},
+			{"child corner", 41, -101.5, 1},
+			{"parent north west corner", 42, -102, 0},
+			{"parent south east corner", 40, -100, 0},
+			{"other root", 40.9, -98.1, 2},
+		}
+		for _, tC := range testCases {
+			t.Run(tC.desc, func(t *testing.T) {
+				got, err := g.Forward(tC.lat, tC.lon)
+				if err != nil {
+					t.Fatalf("Forward() error = %v", err)
+				}
+				dlat, dlon := test_shift(test_grids[tC.grid], tC.lat*3600, -tC.lon*3600)
+				// float32 shifts are good to about 1e-7 arcseconds
+				if !almost_equal(got.LatDeg, tC.lat+dlat/3600, 1e-10) || !almost_equal(got.LonDeg, tC.lon-dlon/3600, 1e-10) {
+					t.Errorf("Forward(%v, %v) = %v; want %v, %v", tC.lat, tC.lon, got, tC.lat+dlat/3600, tC.lon-dlon/3600)
+				}
+			})
+		}
+		for _, p := range [][2]float64{{39.9, -101}, {41, -102.1}, {41, -99.5}, {math.NaN(), -101}} {
+			if got, err := g.Forward(p[0], p[1]); err == nil {
+				t.Errorf("Forward(%v, %v) = %v; want error", p[0], p[1], got)
+			}
+		}
+	}
+}
+
+func TestNTv2Reverse(t *testing.T) {
+	g, err := NewNTv2(bytes.NewReader(write_ntv2(binary.LittleEndian, test_grids)))
+	if err != nil {
+		t.Fatalf("NewNTv2() error = %v", err)
+	}
+	// Forward moves points about 2 arcseconds east, so keep clear of the east edge
+	for lat := 40.05; lat < 42; lat += 0.17 {
+		for lon := -101.95; lon < -100.01; lon += 0.13 {
+			p, err := g.Forward(lat, lon)
+			if err != nil {
+				t.Fatalf("Forward(%v, %v) error = %v", lat, lon, err)
+			}
+			r, err := g.Reverse(p.LatDeg, p.LonDeg)
+			if err != nil {
+				t.Fatalf("Reverse(%v) error = %v", p, err)
+			}
+			if !almost_equal(r.LatDeg, lat, 1e-11) || !almost_equal(r.LonDeg, lon, 1e-11) {
+				t.Errorf("Reverse(Forward(%v, %v)) = %v", lat, lon, r)
+			}
+		}
+	}
+}
+
+func TestNTv2ReverseSubgridEdge(t *testing.T) {
+	g, err := NewNTv2(bytes.NewReader(write_ntv2(binary.LittleEndian, test_grids)))
+	if err != nil {
+		t.Fatalf("NewNTv2() error = %v", err)
+	}
+	// CHILD spans longitudes -101.5 to -101 and its shifts are 0.5 arcseconds more than
+	// those of PARENT, so at latitude 41.2 Forward moves points about 1.41 arcseconds east
+	// in CHILD and 1.91 arcseconds east in PARENT
+	testCases := []struct {
+		desc string
+		lon  float64 // Longitude in the target datum
+		ok   bool
+	}{
+		// The first guess is in PARENT, east of CHILD, and the answer is in CHILD
+		{"from parent into child", -101 + 1.3/3600, true},
+		// The first guess is in CHILD and the answer is in PARENT, west of CHILD
+		{"from child into parent", -101.5 + 1.0/3600, true},
+		// No point maps here: the guesses alternate between the grids
+		{"between the grids", -101 + 1.6/3600, false},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			r, err := g.Reverse(41.2, tC.lon)
+			if !tC.ok {
+				if err == nil {
+					t.Errorf("Reverse(41.2, %v) = %v; want error", tC.lon, r)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Reverse(41.2, %v) error = %v", tC.lon, err)
+			}
+			p, err := g.Forward(r.LatDeg, r.LonDeg)
+			if err != nil || !almost_equal(p.LatDeg, 41.2, 1e-11) || !almost_equal(p.LonDeg, tC.lon, 1e-11) {
+				t.Errorf("Forward(Reverse(41.2, %v)) = %v, %v", tC.lon, p, err)
+			}
+		})
+	}
+}
+
+func TestNewNTv2Errors(t *testing.T) {
+	good := write_ntv2(binary.LittleEndian, test_grids)
+	bad_type := append([]byte(nil), good...)
+	copy(bad_type[3*16+8:], "RADIANS ")
+	orphan := write_ntv2(binary.LittleEndian, []test_grid{{"CHILD", "MISSING", 41, 41.5, -101.5, -101, 0.125, 0}})
+	duplicate := write_ntv2(binary.LittleEndian, []test_grid{test_grids[0], test_grids[0]})
+	bad_limits := write_ntv2(binary.LittleEndian, []test_grid{{"PARENT", "NONE", 42, 40, -102, -100, 0.5, 0}})
+	testCases := []struct {
+		desc string
+		data []byte
+	}{
+		{"empty", nil},
+		{"not ntv2", bytes.Repeat([]byte("P5 not a grid   "), 12)},
+		{"truncated", good[:len(good)/2]},
+		{"bad gs_type", bad_type},
+		{"orphan subgrid", orphan},
+		{"duplicate subgrid", duplicate},
+		{"bad limits", bad_limits},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			if _, err := NewNTv2(bytes.NewReader(tC.data)); err == nil {
+				t.Errorf("NewNTv2() succeeded; want error")
+			}
+		})
+	}
+}
+
+func TestNewNTv2FromFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.gsb")
+	if err := os.WriteFile(path, write_ntv2(binary.BigEndian, test_grids), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	g, err := NewNTv2FromFile(path)
+	if err != nil {
+		t.Fatalf("NewNTv2FromFile() error = %v", err)
+	}
+	if p, err := g.Forward(41.2, -101.3); err != nil || math.Abs(p.LonDeg+101.3) > 1e-3 {
+		t.Errorf("Forward() = %v, %v", p, err)
+	}
+	if _, err := NewNTv2FromFile(filepath.Join(t.TempDir(), "missing.gsb")); err == nil {
+		t.Errorf("NewNTv2FromFile(missing) succeeded; want error")
+	}
+}
+
+func BenchmarkNTv2Forward(b *testing.B) {
+	g, _ := NewNTv2(bytes.NewReader(write_ntv2(binary.LittleEndian, test_grids)))
+	for i := 0; i < b.N; i++ {
+		g.Forward(41.2, -101.3)
+	}
+}
@@ -0,0 +1,157 @@
+package geographiclibgo
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// The distance matrix methods solve the inverse problem between every pair of points
+// from two sets, spreading the rows of the matrix over a bounded pool of goroutines.
+// The results are written in row-major order to flat slices provided by the caller: the
+// result for from[i] and to[j] is at index i*len(to) + j.
+
+// matrix_workers returns the number of goroutines to use for a matrix with nrows rows,
+// given the number requested; workers <= 0 means runtime.GOMAXPROCS(0)
+func matrix_workers(workers, nrows int) int {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers > nrows {
+		workers = nrows
+	}
+	return workers
+}
+
+// check_matrix_buffers returns an error unless s12_m has length n and each of azi1_deg
+// and azi2_deg is nil or has length n
+func check_matrix_buffers(n int, s12_m, azi1_deg, azi2_deg []float64) error {
+	if len(s12_m) != n {
+		return fmt.Errorf("distance buffer has length %d; want %d", len(s12_m), n)
+	}
+	if azi1_deg != nil && len(azi1_deg) != n {
+		return fmt.Errorf("azimuth 1 buffer has length %d; want %d", len(azi1_deg), n)
+	}
+	if azi2_deg != nil && len(azi2_deg) != n {
+		return fmt.Errorf("azimuth 2 buffer has length %d; want %d", len(azi2_deg), n)
+	}
+	return nil
+}
+
+// run_rows calls row(i) for each i in [0, nrows) on workers goroutines, handing out the
+// rows one at a time so that rows of different lengths are balanced. It stops handing
+// out rows once ctx is done, and returns ctx.Err() in that case.
+func run_rows(ctx context.Context, nrows, workers int, row func(i int)) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	var next int64 = -1
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for ctx.Err() == nil {
+				i := int(atomic.AddInt64(&next, 1))
+				if i >= nrows {
+					return
+				}
+				row(i)
+			}
+		}()
+	}
+	wg.Wait()
+	return ctx.Err()
+}
+
+// DistanceMatrix computes the distances, and optionally the azimuths, of the geodesics
+// from each point of from to each point of to. Takes inputs
+//   - ctx - if ctx is cancelled, no more rows are started and ctx.Err() is returned; the
+//     rows already finished are left in the buffers
+//   - from - the first points, one per row of the matrix
+//   - to - the second points, one per column of the matrix
+//   - s12_m - buffer of length len(from)*len(to) for the distances [meters]
+//   - azi1_deg, azi2_deg - buffers of length len(from)*len(to) for the azimuths at the
+//     first and second points [degrees], or nil if they are not wanted
+//   - workers - the number of goroutines to use; 0 means runtime.GOMAXPROCS(0)
+func (g *Geodesic) DistanceMatrix(
+	ctx context.Context,
+	from, to []LatLon,
+	s12_m, azi1_deg, azi2_deg []float64,
+	workers int,
+) error {
+	m := len(to)
+	if err := check_matrix_buffers(len(from)*m, s12_m, azi1_deg, azi2_deg); err != nil {
+		return err
+	}
+	caps := DISTANCE
+	if azi1_deg != nil || azi2_deg != nil {
+		caps |= AZIMUTH
+	}
+	return run_rows(ctx, len(from), matrix_workers(workers, len(from)), func(i int) {
+		p := from[i]
+		for j, q := range to {
+			_, s12, azi1, azi2, _, _, _, _ := g._gen_inverse_azi(p.LatDeg, p.LonDeg, q.LatDeg, q.LonDeg, caps)
+			k := i*m + j
+			s12_m[k] = s12
+			if azi1_deg != nil {
+				azi1_deg[k] = azi1
+			}
+			if azi2_deg != nil {
+				azi2_deg[k] = azi2
+			}
+		}
+	})
+}
+
+// SymmetricDistanceMatrix computes the distances, and optionally the azimuths, of the
+// geodesics between each pair of points, giving an N×N matrix. Only the geodesics with
+// i < j are solved; the result for j, i is the same geodesic traversed in the opposite
+// direction, with the same distance and with the azimuths at its ends reversed. Takes
+// inputs
+//   - ctx - if ctx is cancelled, no more rows are started and ctx.Err() is returned
+//   - points - the points, one per row and column of the matrix
+//   - s12_m - buffer of length len(points)^2 for the distances [meters]
+//   - azi1_deg, azi2_deg - buffers of length len(points)^2 for the azimuths at the first
+//     and second points [degrees], or nil if they are not wanted
+//   - workers - the number of goroutines to use; 0 means runtime.GOMAXPROCS(0)
+func (g *Geodesic) SymmetricDistanceMatrix(
+	ctx context.Context,
+	points []LatLon,
+	s12_m, azi1_deg, azi2_deg []float64,
+	workers int,
+) error {
+	n := len(points)
+	if err := check_matrix_buffers(n*n, s12_m, azi1_deg, azi2_deg); err != nil {
+		return err
+	}
+	caps := DISTANCE
+	if azi1_deg != nil || azi2_deg != nil {
+		caps |= AZIMUTH
+	}
+	return run_rows(ctx, n, matrix_workers(workers, n), func(i int) {
+		p := points[i]
+		for j := i; j < n; j++ {
+			q := points[j]
+			_, s12, azi1, azi2, _, _, _, _ := g._gen_inverse_azi(p.LatDeg, p.LonDeg, q.LatDeg, q.LonDeg, caps)
+			k, kt := i*n+j, j*n+i
+			s12_m[k], s12_m[kt] = s12, s12
+			// The reversed geodesic starts with the back azimuth of azi2 and ends with
+			// the back azimuth of azi1
+			if azi1_deg != nil {
+				azi1_deg[k] = azi1
+				if j != i {
+					azi1_deg[kt] = ang_normalize(azi2 + 180)
+				}
+			}
+			if azi2_deg != nil {
+				azi2_deg[k] = azi2
+				if j != i {
+					azi2_deg[kt] = ang_normalize(azi1 + 180)
+				}
+			}
+		}
+	})
+}
@@ -0,0 +1,175 @@
+package geographiclibgo
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+// matrix_points returns n pseudo-random points, the same for each n
+func matrix_points(n int, seed int64) []LatLon {
+	r := rand.New(rand.NewSource(seed))
+	points := make([]LatLon, n)
+	for i := range points {
+		points[i] = LatLon{LatDeg: 180*r.Float64() - 90, LonDeg: 360*r.Float64() - 180}
+	}
+	return points
+}
+
+// angles_close tests if the angles x and y [degrees] are within thr of each other
+func angles_close(x, y, thr float64) bool {
+	return math.Abs(math.Remainder(x-y, 360)) < thr
+}
+
+func TestDistanceMatrix(t *testing.T) {
+	geod := Wgs84()
+	from := matrix_points(7, 1)
+	to := matrix_points(5, 2)
+	for _, workers := range []int{0, 1, 3, 100} {
+		s12 := make([]float64, len(from)*len(to))
+		azi1 := make([]float64, len(s12))
+		azi2 := make([]float64, len(s12))
+		if err := geod.DistanceMatrix(context.Background(), from, to, s12, azi1, azi2, workers); err != nil {
+			t.Fatalf("DistanceMatrix(workers = %d) error = %v", workers, err)
+		}
+		for i, p := range from {
+			for j, q := range to {
+				want := geod.InverseCalcDistanceAzimuths(p.LatDeg, p.LonDeg, q.LatDeg, q.LonDeg)
+				k := i*len(to) + j
+				if s12[k] != want.DistanceM || azi1[k] != want.Azimuth1Deg || azi2[k] != want.Azimuth2Deg {
+					t.Errorf("DistanceMatrix(workers = %d)[%d, %d] = %v, %v, %v; want %+v",
+						workers, i, j, s12[k], azi1[k], azi2[k], want)
+				}
+			}
+		}
+	}
+	// Distances only
+	s12 := make([]float64, len(from)*len(to))
+	if err := geod.DistanceMatrix(context.Background(), from, to, s12, nil, nil, 2); err != nil {
+		t.Fatalf("DistanceMatrix() error = %v", err)
+	}
+	if want := geod.InverseCalcDistance(from[6].LatDeg, from[6].LonDeg, to[4].LatDeg, to[4].LonDeg); s12[34] != want {
+		t.Errorf("DistanceMatrix()[6, 4] = %v; want %v", s12[34], want)
+	}
+}
+
+func TestSymmetricDistanceMatrix(t *testing.T) {
+	geod := Wgs84()
+	points := matrix_points(9, 3)
+	n := len(points)
+	s12 := make([]float64, n*n)
+	azi1 := make([]float64, n*n)
+	azi2 := make([]float64, n*n)
+	if err := geod.SymmetricDistanceMatrix(context.Background(), points, s12, azi1, azi2, 4); err != nil {
+		t.Fatalf("SymmetricDistanceMatrix() error = %v", err)
+	}
+	// Compare with solving every geodesic
+	full_s12 := make([]float64, n*n)
+	full_azi1 := make([]float64, n*n)
+	full_azi2 := make([]float64, n*n)
+	if err := geod.DistanceMatrix(context.Background(), points, points, full_s12, full_azi1, full_azi2, 4); err != nil {
+		t.Fatalf("DistanceMatrix() error = %v", err)
+	}
+	for k := range s12 {
+		if !almost_equal(s12[k], full_s12[k], 1e-8) || !angles_close(azi1[k], full_azi1[k], 1e-11) ||
+			!angles_close(azi2[k], full_azi2[k], 1e-11) {
+			t.Errorf("SymmetricDistanceMatrix()[%d, %d] = %v, %v, %v; want %v, %v, %v",
+				k/n, k%n, s12[k], azi1[k], azi2[k], full_s12[k], full_azi1[k], full_azi2[k])
+		}
+	}
+	for i := 0; i < n; i++ {
+		if s12[i*n+i] != 0 {
+			t.Errorf("SymmetricDistanceMatrix()[%d, %d] = %v; want 0", i, i, s12[i*n+i])
+		}
+	}
+	// Azimuths are optional
+	only_azi2 := make([]float64, n*n)
+	if err := geod.SymmetricDistanceMatrix(context.Background(), points, s12, nil, only_azi2, 0); err != nil {
+		t.Fatalf("SymmetricDistanceMatrix() error = %v", err)
+	}
+	if only_azi2[n+5] != azi2[n+5] || only_azi2[5*n+1] != azi2[5*n+1] {
+		t.Errorf("SymmetricDistanceMatrix() azi2 = %v; want %v", only_azi2, azi2)
+	}
+}
+
+func TestDistanceMatrixErrors(t *testing.T) {
+	geod := Wgs84()
+	points := matrix_points(3, 4)
+	testCases := []struct {
+		desc string
+		err  error
+	}{
+		{"short distances", geod.DistanceMatrix(context.Background(), points, points[:2], make([]float64, 5), nil, nil, 0)},
+		{"short azimuths", geod.DistanceMatrix(context.Background(), points, points, make([]float64, 9), make([]float64, 8), nil, 0)},
+		{"long azimuths", geod.SymmetricDistanceMatrix(context.Background(), points, make([]float64, 9), nil, make([]float64, 10), 0)},
+		{"symmetric short distances", geod.SymmetricDistanceMatrix(context.Background(), points, make([]float64, 3), nil, nil, 0)},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			if tC.err == nil {
+				t.Errorf("got no error; want error")
+			}
+		})
+	}
+	// Empty matrices are fine
+	if err := geod.DistanceMatrix(context.Background(), nil, points, nil, nil, nil, 0); err != nil {
+		t.Errorf("DistanceMatrix() of no rows error = %v", err)
+	}
+	if err := geod.SymmetricDistanceMatrix(context.Background(), nil, nil, nil, nil, 0); err != nil {
+		t.Errorf("SymmetricDistanceMatrix() of no points error = %v", err)
+	}
+}
+
+func TestDistanceMatrixCancel(t *testing.T) {
+	geod := Wgs84()
+	points := matrix_points(10, 5)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	s12 := make([]float64, 100)
+	for k := range s12 {
+		s12[k] = -1
+	}
+	if err := geod.DistanceMatrix(ctx, points, points, s12, nil, nil, 2); !errors.Is(err, context.Canceled) {
+		t.Errorf("DistanceMatrix() with a cancelled context error = %v; want %v", err, context.Canceled)
+	}
+	for k := range s12 {
+		if s12[k] != -1 {
+			t.Fatalf("DistanceMatrix() with a cancelled context wrote s12[%d] = %v", k, s12[k])
+		}
+	}
+	// A matrix which takes far longer than the timeout stops early
+	many := matrix_points(2000, 6)
+	big := make([]float64, len(many)*len(many))
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	err := geod.SymmetricDistanceMatrix(ctx, many, big, nil, nil, 2)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("SymmetricDistanceMatrix() error = %v; want %v", err, context.DeadlineExceeded)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("SymmetricDistanceMatrix() took %v after the deadline", elapsed)
+	}
+}
+
+func BenchmarkDistanceMatrix(b *testing.B) {
+	geod := Wgs84()
+	from := matrix_points(100, 7)
+	to := matrix_points(100, 8)
+	s12 := make([]float64, len(from)*len(to))
+	for i := 0; i < b.N; i++ {
+		geod.DistanceMatrix(context.Background(), from, to, s12, nil, nil, 0)
+	}
+}
+
+func BenchmarkSymmetricDistanceMatrix(b *testing.B) {
+	geod := Wgs84()
+	points := matrix_points(100, 9)
+	s12 := make([]float64, len(points)*len(points))
+	for i := 0; i < b.N; i++ {
+		geod.SymmetricDistanceMatrix(context.Background(), points, s12, nil, nil, 0)
+	}
+}
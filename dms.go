@@ -0,0 +1,648 @@
+package geographiclibgo
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// DMSFlag indicates what kind of angle a DMS string holds. When decoding, it is inferred
+// from the hemisphere letters; when encoding, it selects the format.
+type DMSFlag int
+
+const (
+	// DMSNone is a plain angle with no hemisphere letter. When encoding, negative angles
+	// get a leading '-'.
+	DMSNone DMSFlag = iota
+	// DMSLatitude is a latitude, with a trailing N or S when encoding. The degrees are
+	// padded to 2 digits.
+	DMSLatitude
+	// DMSLongitude is a longitude, with a trailing E or W when encoding. The degrees are
+	// padded to 3 digits.
+	DMSLongitude
+	// DMSAzimuth is an azimuth. When encoding, it is reduced to [0, 360) and the degrees
+	// are padded to 3 digits. When decoding, it is returned for quadrant bearings such
+	// as N 45d12'03" E.
+	DMSAzimuth
+	// DMSNumber formats the angle as a plain decimal number when encoding
+	DMSNumber
+)
+
+// DMSComponent is the trailing (least significant) component of a DMS string
+type DMSComponent int
+
+const (
+	DMSDegree DMSComponent = iota // Trailing component is degrees
+	DMSMinute                     // Trailing component is minutes
+	DMSSecond                     // Trailing component is seconds
+)
+
+const (
+	_DMS_HEMISPHERES = "SNWE"
+	_DMS_SIGNS       = "-+"
+	_DMS_DIGITS      = "0123456789"
+	_DMS_INDICATORS  = "D'\":"
+)
+
+var _DMS_COMPONENTS = [3]string{"degrees", "minutes", "seconds"}
+
+// _DMS_REPLACER maps the Unicode symbols which are commonly used in DMS strings to their
+// ASCII equivalents and removes Unicode spaces
+var _DMS_REPLACER = strings.NewReplacer(
+	"°", "d", // degree symbol
+	"º", "d", // masculine ordinal indicator
+	"⁰", "d", // superscript zero
+	"˚", "d", // ring above
+	"∘", "d", // ring operator
+	"*", "d",
+	"`", "'",
+	"′", "'", // prime
+	"‵", "'", // reversed prime
+	"´", "'", // acute accent
+	"‘", "'", // left single quote
+	"’", "'", // right single quote
+	"‛", "'", // reversed-9 single quote
+	"ʹ", "'", // modifier letter prime
+	"ˊ", "'", // modifier letter acute accent
+	"ˋ", "'", // modifier letter grave accent
+	"″", "\"", // double prime
+	"‶", "\"", // reversed double prime
+	"˝", "\"", // double acute accent
+	"“", "\"", // left double quote
+	"”", "\"", // right double quote
+	"‟", "\"", // reversed-9 double quote
+	"ʺ", "\"", // modifier letter double prime
+	"''", "\"",
+	"➕", "+", // heavy plus
+	"−", "-", // minus sign
+	"‐", "-", // hyphen
+	"‑", "-", // non-breaking hyphen
+	"–", "-", // en dash
+	"—", "-", // em dash
+	"➖", "-", // heavy minus
+	"\u00a0", "", // non-breaking space
+	"\u2007", "", // figure space
+	"\u2009", "", // thin space
+	"\u200a", "", // hair space
+	"\u200b", "", // zero width space
+	"\u202f", "", // narrow no-break space
+	"\u2063", "", // invisible separator
+)
+
+// dms_lookup returns the index of the (case-insensitive) character c in s, or -1
+func dms_lookup(s string, c byte) int {
+	if 'a' <= c && c <= 'z' {
+		c -= 'a' - 'A'
+	}
+	return strings.IndexByte(s, c)
+}
+
+// DMSDecode converts a string giving an angle in degrees, minutes, and seconds to
+// degrees. The string is made up of up to three components, each a number followed by
+// a designator: d or ° for degrees, ' or ′ for minutes, and " or ″ for seconds (”
+// also stands for seconds). The components may instead be separated by colons, as in
+// 40:45:28.6. Only the last component may have a fractional part, and its designator
+// may be omitted. Minutes and seconds must be less than 60.
+//
+// The angle may have a leading sign, or a leading or trailing hemisphere letter (N, S,
+// E, or W, in either case), which determines the returned flag: DMSLatitude for N and
+// S, DMSLongitude for E and W, and DMSNone otherwise. S and W make the angle negative.
+// A quadrant bearing, such as N 45°12'03" E or S10W, is returned as an azimuth with the
+// flag DMSAzimuth. Several terms may be joined by signs, e.g. 10d-0.5 is 9.5 degrees.
+// Finally the string may be "nan", "inf", "+inf", or "-inf".
+func DMSDecode(dms string) (float64, DMSFlag, error) {
+	dmsa := strings.TrimSpace(_DMS_REPLACER.Replace(dms))
+	if azi, ok, err := dms_decode_bearing(dmsa); ok {
+		return azi, DMSAzimuth, err
+	}
+	// Start with -0 so that "-0" returns -0
+	v := math.Copysign(0, -1)
+	ind1 := DMSNone
+	end := len(dmsa)
+	i := 0
+	for p := 0; p < end; i++ {
+		pa := p
+		// Skip over an initial hemisphere letter and then an initial sign, which is
+		// optional for the first term
+		if i == 0 && dms_lookup(_DMS_HEMISPHERES, dmsa[pa]) >= 0 {
+			pa++
+		}
+		if i > 0 || (pa < end && strings.IndexByte(_DMS_SIGNS, dmsa[pa]) >= 0) {
+			pa++
+		}
+		// The term ends at the next sign
+		pb := end
+		if k := strings.IndexAny(dmsa[pa:], _DMS_SIGNS); k >= 0 {
+			pb = pa + k
+		}
+		x, ind2, err := dms_internal_decode(dmsa[p:pb])
+		if err != nil {
+			return 0, DMSNone, err
+		}
+		v += x
+		if ind1 == DMSNone {
+			ind1 = ind2
+		} else if !(ind2 == DMSNone || ind1 == ind2) {
+			return 0, DMSNone, fmt.Errorf("incompatible hemisphere specifier in %s", dmsa[:pb])
+		}
+		p = pb
+	}
+	if i == 0 {
+		return 0, DMSNone, fmt.Errorf("empty or incomplete DMS string %q", dmsa)
+	}
+	return v, ind1, nil
+}
+
+// dms_decode_bearing decodes the quadrant bearing dmsa, e.g. N 45d12'03" E, to an
+// azimuth. ok is false if dmsa is not a bearing.
+func dms_decode_bearing(dmsa string) (azi float64, ok bool, err error) {
+	n := len(dmsa)
+	if n < 3 {
+		return 0, false, nil
+	}
+	ns, ew := dms_lookup(_DMS_HEMISPHERES, dmsa[0]), dms_lookup(_DMS_HEMISPHERES, dmsa[n-1])
+	if !(ns == 0 || ns == 1) || !(ew == 2 || ew == 3) {
+		return 0, false, nil
+	}
+	mid := strings.TrimSpace(dmsa[1 : n-1])
+	x, ind, err := DMSDecode(mid)
+	if err != nil {
+		return 0, true, err
+	}
+	if ind != DMSNone || math.Signbit(x) || !(x <= 90) {
+		return 0, true, fmt.Errorf("bearing %s not in [0d, 90d] in %s", mid, dmsa)
+	}
+	if ns == 0 {
+		x = 180 - x
+	}
+	if ew == 2 {
+		x = -x
+	}
+	return x, true, nil
+}
+
+// dms_internal_decode decodes a single term of a DMS string, which may have a sign and
+// a hemisphere letter
+func dms_internal_decode(dmsa string) (float64, DMSFlag, error) {
+	v, ind, err := dms_parse(dmsa)
+	if err != nil {
+		if v, ok := dms_nummatch(dmsa); ok {
+			return v, DMSNone, nil
+		}
+		return 0, DMSNone, err
+	}
+	return v, ind, nil
+}
+
+// dms_nummatch matches the strings for NaN and infinity
+func dms_nummatch(s string) (float64, bool) {
+	switch strings.ToUpper(strings.TrimSpace(s)) {
+	case "NAN":
+		return math.NaN(), true
+	case "INF", "+INF":
+		return math.Inf(1), true
+	case "-INF":
+		return math.Inf(-1), true
+	}
+	return 0, false
+}
+
+func dms_parse(dmsa string) (float64, DMSFlag, error) {
+	sign := 1.0
+	beg, end := 0, len(dmsa)
+	ind := DMSNone
+	if end > beg {
+		if k := dms_lookup(_DMS_HEMISPHERES, dmsa[beg]); k >= 0 {
+			ind, sign = dms_hemisphere(k)
+			beg++
+		}
+	}
+	if end > beg {
+		if k := dms_lookup(_DMS_HEMISPHERES, dmsa[end-1]); k >= 0 {
+			if ind != DMSNone {
+				if dms_lookup(_DMS_HEMISPHERES, dmsa[beg-1]) == k {
+					return 0, DMSNone, fmt.Errorf(
+						"repeated hemisphere indicators %c in %s", dmsa[beg-1], dmsa[beg-1:end],
+					)
+				}
+				return 0, DMSNone, fmt.Errorf(
+					"contradictory hemisphere indicators %c and %c in %s",
+					dmsa[beg-1], dmsa[end-1], dmsa[beg-1:end],
+				)
+			}
+			ind, sign = dms_hemisphere(k)
+			end--
+		}
+	}
+	if end > beg {
+		if k := strings.IndexByte(_DMS_SIGNS, dmsa[beg]); k >= 0 {
+			if k == 0 {
+				sign = -sign
+			}
+			beg++
+		}
+	}
+	if end == beg {
+		return 0, DMSNone, fmt.Errorf("empty or incomplete DMS string %q", dmsa)
+	}
+	s := dmsa[beg:end]
+	var ipieces, fpieces [3]float64
+	npiece := 0
+	icurrent, fcurrent := 0.0, 0.0
+	ncurrent, p := 0, beg
+	pointseen := false
+	digcount, intcount := 0, 0
+	for p < end {
+		x := dmsa[p]
+		p++
+		if k := strings.IndexByte(_DMS_DIGITS, x); k >= 0 {
+			ncurrent++
+			if digcount > 0 {
+				// Count of decimal digits
+				digcount++
+			} else {
+				icurrent = 10*icurrent + float64(k)
+				intcount++
+			}
+		} else if x == '.' {
+			if pointseen {
+				return 0, DMSNone, fmt.Errorf("multiple decimal points in %s", s)
+			}
+			pointseen = true
+			digcount = 1
+		} else if k := dms_lookup(_DMS_INDICATORS, x); k >= 0 {
+			if k >= 3 {
+				if p == end {
+					return 0, DMSNone, fmt.Errorf("illegal for : to appear at the end of %s", s)
+				}
+				k = npiece
+			}
+			if k == npiece-1 {
+				return 0, DMSNone, fmt.Errorf("repeated %s component in %s", _DMS_COMPONENTS[k], s)
+			} else if k < npiece {
+				return 0, DMSNone, fmt.Errorf(
+					"%s component follows %s component in %s",
+					_DMS_COMPONENTS[k], _DMS_COMPONENTS[npiece-1], s,
+				)
+			}
+			if ncurrent == 0 {
+				return 0, DMSNone, fmt.Errorf(
+					"missing numbers in %s component of %s", _DMS_COMPONENTS[k], s,
+				)
+			}
+			if digcount > 0 {
+				fcurrent, _ = strconv.ParseFloat(dmsa[p-intcount-digcount-1:p-1], 64)
+				icurrent = 0
+			}
+			ipieces[k] = icurrent
+			fpieces[k] = icurrent + fcurrent
+			if p < end {
+				npiece = k + 1
+				if npiece >= 3 {
+					return 0, DMSNone, fmt.Errorf("more than 3 DMS components in %s", s)
+				}
+				icurrent, fcurrent = 0, 0
+				ncurrent, digcount, intcount = 0, 0, 0
+			}
+		} else if strings.IndexByte(_DMS_SIGNS, x) >= 0 {
+			return 0, DMSNone, fmt.Errorf("internal sign in DMS string %s", s)
+		} else {
+			r, _ := utf8.DecodeRuneInString(dmsa[p-1:])
+			return 0, DMSNone, fmt.Errorf("illegal character %q in DMS string %s", r, s)
+		}
+	}
+	if dms_lookup(_DMS_INDICATORS, dmsa[p-1]) < 0 {
+		if npiece >= 3 {
+			return 0, DMSNone, fmt.Errorf("extra text following seconds in DMS string %s", s)
+		}
+		if ncurrent == 0 {
+			return 0, DMSNone, fmt.Errorf("missing numbers in trailing component of %s", s)
+		}
+		if digcount > 0 {
+			fcurrent, _ = strconv.ParseFloat(dmsa[p-intcount-digcount:p], 64)
+			icurrent = 0
+		}
+		ipieces[npiece] = icurrent
+		fpieces[npiece] = icurrent + fcurrent
+	}
+	if pointseen && digcount == 0 {
+		return 0, DMSNone, fmt.Errorf("decimal point in non-terminal component of %s", s)
+	}
+	// Note that 59.999999... is accepted even though it rounds to 60
+	if ipieces[1] >= 60 || fpieces[1] > 60 {
+		return 0, DMSNone, fmt.Errorf("minutes %v not in range [0, 60)", fpieces[1])
+	}
+	if ipieces[2] >= 60 || fpieces[2] > 60 {
+		return 0, DMSNone, fmt.Errorf("seconds %v not in range [0, 60)", fpieces[2])
+	}
+	if fpieces[2] != 0 {
+		return sign * (60*(60*fpieces[0]+fpieces[1]) + fpieces[2]) / 3600, ind, nil
+	} else if fpieces[1] != 0 {
+		return sign * (60*fpieces[0] + fpieces[1]) / 60, ind, nil
+	}
+	return sign * fpieces[0], ind, nil
+}
+
+// dms_hemisphere returns the flag and sign for the k'th letter of _DMS_HEMISPHERES
+func dms_hemisphere(k int) (DMSFlag, float64) {
+	ind := DMSLatitude
+	if k/2 == 1 {
+		ind = DMSLongitude
+	}
+	if k%2 == 1 {
+		return ind, 1
+	}
+	return ind, -1
+}
+
+// DMSDecodeLatLon converts a pair of DMS strings to a latitude and longitude. The
+// hemisphere letters determine which is which; if neither string has one, dmsa is the
+// latitude unless longfirst. An error is returned if both are latitudes or both are
+// longitudes, or if the latitude is not in [-90, 90].
+func DMSDecodeLatLon(dmsa, dmsb string, longfirst bool) (LatLon, error) {
+	a, ia, err := DMSDecode(dmsa)
+	if err != nil {
+		return LatLon{}, err
+	}
+	b, ib, err := DMSDecode(dmsb)
+	if err != nil {
+		return LatLon{}, err
+	}
+	if ia == DMSAzimuth || ib == DMSAzimuth {
+		return LatLon{}, fmt.Errorf("bearing given for latitude or longitude in %s %s", dmsa, dmsb)
+	}
+	if ia == DMSNone && ib == DMSNone {
+		ia, ib = DMSLatitude, DMSLongitude
+		if longfirst {
+			ia, ib = ib, ia
+		}
+	} else if ia == DMSNone {
+		ia = DMSLatitude + DMSLongitude - ib
+	} else if ib == DMSNone {
+		ib = DMSLatitude + DMSLongitude - ia
+	}
+	if ia == ib {
+		kind := "latitudes"
+		if ia == DMSLongitude {
+			kind = "longitudes"
+		}
+		return LatLon{}, fmt.Errorf("both %s and %s interpreted as %s", dmsa, dmsb, kind)
+	}
+	lat, lon := a, b
+	if ia == DMSLongitude {
+		lat, lon = b, a
+	}
+	if math.Abs(lat) > 90 {
+		return LatLon{}, fmt.Errorf("latitude %vd not in [-90d, 90d]", lat)
+	}
+	return LatLon{LatDeg: lat, LonDeg: lon}, nil
+}
+
+// DMSDecodeAngle converts a DMS string, which may not have a hemisphere letter, to an
+// angle in degrees
+func DMSDecodeAngle(angstr string) (float64, error) {
+	ang, ind, err := DMSDecode(angstr)
+	if err != nil {
+		return 0, err
+	}
+	if ind != DMSNone {
+		return 0, fmt.Errorf("arc angle %s includes a hemisphere, N/E/W/S", angstr)
+	}
+	return ang, nil
+}
+
+// DMSDecodeAzimuth converts a DMS string, which may have an E or W hemisphere letter or
+// be a quadrant bearing, to an azimuth in degrees in (-180, 180]
+func DMSDecodeAzimuth(azistr string) (float64, error) {
+	azi, ind, err := DMSDecode(azistr)
+	if err != nil {
+		return 0, err
+	}
+	if ind == DMSLatitude {
+		return 0, fmt.Errorf("azimuth %s has a latitude hemisphere, N/S", azistr)
+	}
+	return ang_normalize(azi), nil
+}
+
+// DMSEncode converts angle [degrees] to a DMS string. trailing is the last component
+// to include and prec is the number of digits after the decimal point in it. It is
+// capped at 15, 13, and 11 for DMSDegree, DMSMinute, and DMSSecond, which is enough to
+// give full double precision for angles in [-90, 90].
+//
+// ind selects the format (see DMSFlag). If dmssep is 0, the components are followed by
+// the designators °, ', and " (except that ° is omitted if degrees is the trailing
+// component); otherwise the components are separated by dmssep, usually ':'.
+// Non-finite angles give "nan", "inf", or "-inf".
+func DMSEncode(angle float64, trailing DMSComponent, prec int, ind DMSFlag, dmssep rune) string {
+	if math.IsNaN(angle) {
+		return "nan"
+	} else if math.IsInf(angle, 0) {
+		if angle < 0 {
+			return "-inf"
+		}
+		return "inf"
+	}
+	if prec < 0 {
+		prec = 0
+	}
+	if ind == DMSNumber {
+		return strconv.FormatFloat(angle, 'f', prec, 64)
+	}
+	if maxprec := 15 - 2*int(trailing); prec > maxprec {
+		prec = maxprec
+	}
+	scale := 1.0
+	if trailing == DMSMinute {
+		scale = 60
+	} else if trailing == DMSSecond {
+		scale = 3600
+	}
+	if ind == DMSAzimuth {
+		angle = ang_normalize(angle)
+		// Only angles strictly less than 0 can become 360; convert -0 to +0
+		if angle < 0 {
+			angle += 360
+		} else {
+			angle += 0
+		}
+	}
+	neg := math.Signbit(angle)
+	angle = math.Abs(angle)
+
+	// Break off the integer part to preserve precision and avoid overflow in the
+	// manipulation of the fractional part for DMSMinute and DMSSecond
+	idegree := 0.0
+	if trailing != DMSDegree {
+		idegree = math.Floor(angle)
+	}
+	s := strconv.FormatFloat((angle-idegree)*scale, 'f', prec, 64)
+	var degree, minute, second string
+	if trailing == DMSDegree {
+		degree = s
+	} else {
+		frac := ""
+		if p := strings.IndexByte(s, '.'); p >= 0 {
+			s, frac = s[:p], s[p:]
+		}
+		// i is in [0, 60] for DMSMinute and [0, 3600] for DMSSecond
+		i, _ := strconv.ParseInt(s, 10, 64)
+		if trailing == DMSSecond {
+			second = strconv.FormatInt(i%60, 10) + frac
+			i /= 60
+		}
+		minute = strconv.FormatInt(i%60, 10)
+		if trailing == DMSMinute {
+			minute += frac
+		}
+		i /= 60
+		degree = strconv.FormatFloat(float64(i)+idegree, 'f', 0, 64)
+	}
+
+	// Extra width for the decimal point
+	width := prec
+	if prec > 0 {
+		width++
+	}
+	degwidth := 0
+	if ind != DMSNone {
+		degwidth = 3
+		if ind == DMSLatitude {
+			degwidth = 2
+		}
+	}
+	designators := [3]string{"°", "'", "\""}
+	if dmssep != 0 {
+		designators = [3]string{string(dmssep), string(dmssep), ""}
+	}
+	var b strings.Builder
+	if ind == DMSNone && neg {
+		b.WriteByte('-')
+	}
+	switch trailing {
+	case DMSDegree:
+		if ind != DMSNone {
+			degwidth += width
+		}
+		b.WriteString(dms_zero_pad(degree, degwidth))
+	case DMSMinute:
+		b.WriteString(dms_zero_pad(degree, degwidth))
+		b.WriteString(designators[0])
+		b.WriteString(dms_zero_pad(minute, 2+width))
+		if dmssep == 0 {
+			b.WriteString(designators[1])
+		}
+	default:
+		b.WriteString(dms_zero_pad(degree, degwidth))
+		b.WriteString(designators[0])
+		b.WriteString(dms_zero_pad(minute, 2))
+		b.WriteString(designators[1])
+		b.WriteString(dms_zero_pad(second, 2+width))
+		b.WriteString(designators[2])
+	}
+	if ind == DMSLatitude || ind == DMSLongitude {
+		k := 0
+		if ind == DMSLongitude {
+			k = 2
+		}
+		if !neg {
+			k++
+		}
+		b.WriteByte(_DMS_HEMISPHERES[k])
+	}
+	return b.String()
+}
+
+// dms_zero_pad pads s on the left with zeros to width characters
+func dms_zero_pad(s string, width int) string {
+	if len(s) >= width {
+		return s
+	}
+	return strings.Repeat("0", width-len(s)) + s
+}
+
+// DMSEncodeBearing converts the azimuth azi [degrees] to a quadrant bearing, e.g.
+// N 45°12'03" E, which is the angle east or west of north or south. trailing and prec
+// are as for DMSEncode.
+func DMSEncodeBearing(azi float64, trailing DMSComponent, prec int) string {
+	if math.IsNaN(azi) || math.IsInf(azi, 0) {
+		return DMSEncode(azi, trailing, prec, DMSNone, 0)
+	}
+	azi = ang_normalize(azi)
+	ns, ew := 'N', 'E'
+	if azi < 0 {
+		ew, azi = 'W', -azi
+	}
+	if azi > 90 {
+		ns, azi = 'S', 180-azi
+	}
+	return fmt.Sprintf("%c %s %c", ns, DMSEncode(azi+0, trailing, prec, DMSNone, 0), ew)
+}
+
+// DMSFromComponents converts degrees d, minutes m, and seconds s to degrees
+func DMSFromComponents(d, m, s float64) float64 {
+	return d + (m+s/60)/60
+}
+
+// DMSToComponents splits the angle ang [degrees] into whole degrees d, whole minutes m,
+// and seconds s. All have the sign of ang.
+func DMSToComponents(ang float64) (d, m, s float64) {
+	d = math.Trunc(ang)
+	ang = 60 * (ang - d)
+	m = math.Trunc(ang)
+	s = 60 * (ang - m)
+	return d, m, s
+}
+
+// dms_fields splits s into fields separated by spaces or commas
+func dms_fields(s string) []string {
+	return strings.FieldsFunc(_DMS_REPLACER.Replace(s), func(r rune) bool {
+		return unicode.IsSpace(r) || r == ','
+	})
+}
+
+// DMSEncodeLatLon converts p to a latitude and longitude separated by a space, e.g.
+// 40°45'28.6"N 073°59'08.0"W. trailing and prec are as for DMSEncode.
+func DMSEncodeLatLon(p LatLon, trailing DMSComponent, prec int) string {
+	return DMSEncode(p.LatDeg, trailing, prec, DMSLatitude, 0) + " " +
+		DMSEncode(p.LonDeg, trailing, prec, DMSLongitude, 0)
+}
+
+// DMSParseLatLon converts a latitude and longitude, separated by spaces or a comma, to a
+// LatLon. The order is determined as in DMSDecodeLatLon. It reverses DMSEncodeLatLon.
+func DMSParseLatLon(s string, longfirst bool) (LatLon, error) {
+	fields := dms_fields(s)
+	if len(fields) != 2 {
+		return LatLon{}, fmt.Errorf("need a latitude and a longitude in %q", s)
+	}
+	return DMSDecodeLatLon(fields[0], fields[1], longfirst)
+}
+
+// DMSEncodeLatLonAzi converts p to a latitude, longitude, and azimuth separated by
+// spaces, e.g. 40°45'28.6"N 073°59'08.0"W 045°12'03.0". The azimuth is given in
+// [0, 360). trailing and prec are as for DMSEncode.
+func DMSEncodeLatLonAzi(p LatLonAzi, trailing DMSComponent, prec int) string {
+	return DMSEncodeLatLon(LatLon{LatDeg: p.LatDeg, LonDeg: p.LonDeg}, trailing, prec) + " " +
+		DMSEncode(p.AziDeg, trailing, prec, DMSAzimuth, 0)
+}
+
+// DMSParseLatLonAzi converts a latitude, longitude, and azimuth, separated by spaces or
+// commas, to a LatLonAzi. The azimuth may be a quadrant bearing, and is returned in
+// (-180, 180]. It reverses DMSEncodeLatLonAzi.
+func DMSParseLatLonAzi(s string, longfirst bool) (LatLonAzi, error) {
+	fields := dms_fields(s)
+	if len(fields) < 3 {
+		return LatLonAzi{}, fmt.Errorf("need a latitude, a longitude, and an azimuth in %q", s)
+	}
+	p, err := DMSDecodeLatLon(fields[0], fields[1], longfirst)
+	if err != nil {
+		return LatLonAzi{}, err
+	}
+	azi, err := DMSDecodeAzimuth(strings.Join(fields[2:], " "))
+	if err != nil {
+		return LatLonAzi{}, err
+	}
+	return LatLonAzi{LatDeg: p.LatDeg, LonDeg: p.LonDeg, AziDeg: azi}, nil
+}
@@ -0,0 +1,279 @@
+package geographiclibgo
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDMSDecode(t *testing.T) {
+	testCases := []struct {
+		desc string
+		dms  string
+		want float64
+		ind  DMSFlag
+	}{
+		{"degrees", "40.5", 40.5, DMSNone},
+		{"dms with hemisphere", "40d45'28.6\"N", 40 + 45.0/60 + 28.6/3600, DMSLatitude},
+		{"unicode", "73°59′08″W", -(73 + 59.0/60 + 8.0/3600), DMSLongitude},
+		{"leading hemisphere", "s33d52'", -(33 + 52.0/60), DMSLatitude},
+		{"colons", "-1:30", -1.5, DMSNone},
+		{"colons dms", "12:34:56.5", 12 + 34.0/60 + 56.5/3600, DMSNone},
+		{"two single quotes", "0d0'36''", 0.01, DMSNone},
+		{"minutes only", "30'", 0.5, DMSNone},
+		{"seconds without designator", "1d2'3", 1 + 2.0/60 + 3.0/3600, DMSNone},
+		{"unicode minus", "−5.25", -5.25, DMSNone},
+		{"spaces trimmed", "  7d30'E ", 7.5, DMSLongitude},
+		{"sum", "1d+30'", 1.5, DMSNone},
+		{"difference", "10d-0.5", 9.5, DMSNone},
+		{"sum with hemisphere", "W10d-30'", -10.5, DMSLongitude},
+		{"leading point", ".5", 0.5, DMSNone},
+		{"bearing", "N 45°12'03\" E", 45 + 12.0/60 + 3.0/3600, DMSAzimuth},
+		{"bearing NW", "N10W", -10, DMSAzimuth},
+		{"bearing SE", "S 30d E", 150, DMSAzimuth},
+		{"bearing SW", "s30.5w", -149.5, DMSAzimuth},
+		{"inf", "inf", math.Inf(1), DMSNone},
+		{"minus inf", "-inf", math.Inf(-1), DMSNone},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			got, ind, err := DMSDecode(tC.dms)
+			if err != nil {
+				t.Fatalf("DMSDecode(%q) error = %v", tC.dms, err)
+			}
+			if !(got == tC.want || almost_equal(got, tC.want, 1e-14)) || ind != tC.ind {
+				t.Errorf("DMSDecode(%q) = %v, %v; want %v, %v", tC.dms, got, ind, tC.want, tC.ind)
+			}
+		})
+	}
+	if v, _, _ := DMSDecode("-0"); !(v == 0 && math.Signbit(v)) {
+		t.Errorf("DMSDecode(-0) = %v; want -0", v)
+	}
+	if v, _, _ := DMSDecode("0"); !(v == 0 && !math.Signbit(v)) {
+		t.Errorf("DMSDecode(0) = %v; want +0", v)
+	}
+	if v, _, _ := DMSDecode("nan"); !math.IsNaN(v) {
+		t.Errorf("DMSDecode(nan) = %v; want NaN", v)
+	}
+}
+
+func TestDMSDecodeErrors(t *testing.T) {
+	testCases := []struct {
+		desc string
+		dms  string
+	}{
+		{"empty", ""},
+		{"blank", "  "},
+		{"sign only", "-"},
+		{"hemisphere only", "N"},
+		{"repeated hemisphere", "N10N"},
+		{"contradictory hemisphere", "S10N"},
+		{"incompatible terms", "10N+5E"},
+		{"repeated component", "1d2d"},
+		{"out of order", "1'2d"},
+		{"missing number", "d30'"},
+		{"too many components", "1:2:3:4"},
+		{"colon at end", "1:"},
+		{"extra text", "1d2'3\"4"},
+		{"two points", "1.2.3"},
+		{"point not last", "1.5d30'"},
+		{"minutes too big", "1d60'"},
+		{"seconds too big", "1d2'60.5\""},
+		{"internal space", "1 2"},
+		{"illegal character", "1x"},
+		{"illegal unicode", "1§"},
+		{"bearing too big", "N 100 E"},
+		{"negative bearing", "N -10 E"},
+		{"empty bearing", "N  E"},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			if v, ind, err := DMSDecode(tC.dms); err == nil {
+				t.Errorf("DMSDecode(%q) = %v, %v; want error", tC.dms, v, ind)
+			}
+		})
+	}
+}
+
+func TestDMSEncode(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		angle    float64
+		trailing DMSComponent
+		prec     int
+		ind      DMSFlag
+		dmssep   rune
+		want     string
+	}{
+		{"latitude", 40.757944, DMSSecond, 1, DMSLatitude, 0, "40°45'28.6\"N"},
+		{"longitude", -73.985556, DMSSecond, 0, DMSLongitude, 0, "073°59'08\"W"},
+		{"small latitude", -5.5, DMSDegree, 1, DMSLatitude, 0, "05.5S"},
+		{"degrees", -12.25, DMSDegree, 3, DMSNone, 0, "-12.250"},
+		{"minutes", -0.5, DMSMinute, 1, DMSNone, 0, "-0°30.0'"},
+		{"colons", 1.5, DMSMinute, 0, DMSNone, ':', "1:30"},
+		{"colons seconds", 12.582361, DMSSecond, 0, DMSNone, ':', "12:34:56"},
+		{"carry minutes", 1.999999, DMSMinute, 0, DMSNone, 0, "2°00'"},
+		{"carry seconds", 59.9999999, DMSSecond, 2, DMSLongitude, 0, "060°00'00.00\"E"},
+		{"azimuth", -10, DMSDegree, 0, DMSAzimuth, 0, "350"},
+		{"azimuth minus zero", math.Copysign(0, -1), DMSMinute, 0, DMSAzimuth, 0, "000°00'"},
+		{"azimuth seconds", 45.200833333, DMSSecond, 0, DMSAzimuth, 0, "045°12'03\""},
+		{"number", -12.3456, DMSSecond, 2, DMSNumber, 0, "-12.35"},
+		{"precision capped", 1, DMSSecond, 20, DMSNone, ':', "1:00:00.00000000000"},
+		{"negative precision", 1.25, DMSDegree, -2, DMSNone, 0, "1"},
+		{"nan", math.NaN(), DMSSecond, 0, DMSLatitude, 0, "nan"},
+		{"inf", math.Inf(-1), DMSSecond, 0, DMSNone, 0, "-inf"},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			got := DMSEncode(tC.angle, tC.trailing, tC.prec, tC.ind, tC.dmssep)
+			if got != tC.want {
+				t.Errorf("DMSEncode(%v) = %q; want %q", tC.angle, got, tC.want)
+			}
+		})
+	}
+}
+
+func TestDMSBearing(t *testing.T) {
+	testCases := []struct {
+		azi  float64
+		want string
+	}{
+		{45.200833333, "N 45°12'03\" E"},
+		{-10, "N 10°00'00\" W"},
+		{170, "S 10°00'00\" E"},
+		{-135.5, "S 44°30'00\" W"},
+		{0, "N 0°00'00\" E"},
+		{180, "S 0°00'00\" E"},
+		{90, "N 90°00'00\" E"},
+	}
+	for _, tC := range testCases {
+		got := DMSEncodeBearing(tC.azi, DMSSecond, 0)
+		if got != tC.want {
+			t.Errorf("DMSEncodeBearing(%v) = %q; want %q", tC.azi, got, tC.want)
+		}
+		azi, err := DMSDecodeAzimuth(got)
+		if err != nil {
+			t.Fatalf("DMSDecodeAzimuth(%q) error = %v", got, err)
+		}
+		if !almost_equal(azi, tC.azi, 1e-6) {
+			t.Errorf("DMSDecodeAzimuth(%q) = %v; want %v", got, azi, tC.azi)
+		}
+	}
+}
+
+func TestDMSDecodeLatLon(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		a, b      string
+		longfirst bool
+		want      LatLon
+	}{
+		{"lat lon", "40.5", "-73.25", false, LatLon{40.5, -73.25}},
+		{"lon lat", "-73.25", "40.5", true, LatLon{40.5, -73.25}},
+		{"hemispheres", "73d15'W", "40d30'N", false, LatLon{40.5, -73.25}},
+		{"one hemisphere", "73.25W", "40.5", false, LatLon{40.5, -73.25}},
+		{"hemisphere overrides longfirst", "40.5N", "-73.25", true, LatLon{40.5, -73.25}},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			got, err := DMSDecodeLatLon(tC.a, tC.b, tC.longfirst)
+			if err != nil {
+				t.Fatalf("DMSDecodeLatLon() error = %v", err)
+			}
+			if got != tC.want {
+				t.Errorf("DMSDecodeLatLon(%q, %q) = %v; want %v", tC.a, tC.b, got, tC.want)
+			}
+		})
+	}
+	for _, ab := range [][2]string{{"10N", "20S"}, {"10E", "20W"}, {"91", "10"}, {"N10E", "10"}, {"x", "1"}} {
+		if got, err := DMSDecodeLatLon(ab[0], ab[1], false); err == nil {
+			t.Errorf("DMSDecodeLatLon(%q, %q) = %v; want error", ab[0], ab[1], got)
+		}
+	}
+}
+
+func TestDMSDecodeAngleAzimuth(t *testing.T) {
+	if got, err := DMSDecodeAngle("-10d30'"); err != nil || got != -10.5 {
+		t.Errorf("DMSDecodeAngle(-10d30') = %v, %v; want -10.5", got, err)
+	}
+	if _, err := DMSDecodeAngle("10E"); err == nil {
+		t.Errorf("DMSDecodeAngle(10E) succeeded; want error")
+	}
+	if got, err := DMSDecodeAzimuth("350"); err != nil || got != -10 {
+		t.Errorf("DMSDecodeAzimuth(350) = %v, %v; want -10", got, err)
+	}
+	if got, err := DMSDecodeAzimuth("10W"); err != nil || got != -10 {
+		t.Errorf("DMSDecodeAzimuth(10W) = %v, %v; want -10", got, err)
+	}
+	if _, err := DMSDecodeAzimuth("10N"); err == nil {
+		t.Errorf("DMSDecodeAzimuth(10N) succeeded; want error")
+	}
+}
+
+func TestDMSComponents(t *testing.T) {
+	d, m, s := DMSToComponents(-40.5125)
+	if d != -40 || m != -30 || !almost_equal(s, -45, 1e-9) {
+		t.Errorf("DMSToComponents(-40.5125) = %v, %v, %v; want -40, -30, -45", d, m, s)
+	}
+	if got := DMSFromComponents(d, m, s); !almost_equal(got, -40.5125, 1e-14) {
+		t.Errorf("DMSFromComponents(%v, %v, %v) = %v; want -40.5125", d, m, s, got)
+	}
+}
+
+func TestDMSRoundTrip(t *testing.T) {
+	// Positions from the geodesic calculations round trip through their DMS strings
+	g := Wgs84()
+	for lat := -89.0; lat < 90; lat += 11.3 {
+		for azi := -179.0; azi < 180; azi += 37.7 {
+			p := g.DirectCalcLatLonAzi(lat, 17.25, azi, 5e6)
+
+			s := DMSEncodeLatLon(LatLon{p.LatDeg, p.LonDeg}, DMSSecond, 5)
+			ll, err := DMSParseLatLon(s, false)
+			if err != nil {
+				t.Fatalf("DMSParseLatLon(%q) error = %v", s, err)
+			}
+			if !almost_equal(ll.LatDeg, p.LatDeg, 3e-9) || !almost_equal(ll.LonDeg, p.LonDeg, 3e-9) {
+				t.Errorf("DMSParseLatLon(%q) = %v; want %v", s, ll, p)
+			}
+
+			s = DMSEncodeLatLonAzi(p, DMSSecond, 5)
+			lla, err := DMSParseLatLonAzi(s, false)
+			if err != nil {
+				t.Fatalf("DMSParseLatLonAzi(%q) error = %v", s, err)
+			}
+			if !almost_equal(lla.LatDeg, p.LatDeg, 3e-9) || !almost_equal(lla.LonDeg, p.LonDeg, 3e-9) ||
+				!almost_equal(lla.AziDeg, p.AziDeg, 3e-9) {
+				t.Errorf("DMSParseLatLonAzi(%q) = %v; want %v", s, lla, p)
+			}
+
+			// Comma separated with a bearing
+			s = DMSEncode(p.LatDeg, DMSMinute, 4, DMSLatitude, 0) + ", " +
+				DMSEncode(p.LonDeg, DMSMinute, 4, DMSLongitude, 0) + ", " +
+				DMSEncodeBearing(p.AziDeg, DMSMinute, 4)
+			lla, err = DMSParseLatLonAzi(s, false)
+			if err != nil {
+				t.Fatalf("DMSParseLatLonAzi(%q) error = %v", s, err)
+			}
+			if !almost_equal(lla.AziDeg, p.AziDeg, 1e-6) {
+				t.Errorf("DMSParseLatLonAzi(%q) azimuth = %v; want %v", s, lla.AziDeg, p.AziDeg)
+			}
+		}
+	}
+	if _, err := DMSParseLatLon("40N", false); err == nil {
+		t.Errorf("DMSParseLatLon(40N) succeeded; want error")
+	}
+	if _, err := DMSParseLatLonAzi("40N 20E", false); err == nil {
+		t.Errorf("DMSParseLatLonAzi(40N 20E) succeeded; want error")
+	}
+}
+
+func BenchmarkDMSDecode(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		DMSDecode("40d45'28.6\"N")
+	}
+}
+
+func BenchmarkDMSEncode(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		DMSEncode(40.757944, DMSSecond, 1, DMSLatitude, 0)
+	}
+}
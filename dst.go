@@ -0,0 +1,131 @@
+package geographiclibgo
+
+import (
+	"math"
+	"math/cmplx"
+)
+
+// dst computes discrete sine transforms of functions with odd-harmonic sine series,
+// f(x) = sum(F[i] * sin((2*i+1) * x), i, 0, N-1). It is used by GeodesicExact to find
+// the Fourier coefficients of the area integrand. N must be a power of two.
+type dst struct {
+	n int
+}
+
+func new_dst(n int) dst {
+	return dst{n: n}
+}
+
+// transform finds the N coefficients F of the sine series which matches f at the points
+// x = i * pi/(2*N) for i in (0, N]. F must have length N.
+func (d *dst) transform(f func(float64) float64, F []float64) {
+	// The odd, pi-antiperiodic extension of f, sampled at 4*N points over [0, 2*pi).
+	// Only odd harmonics are present, and the coefficient of sin((2*i+1) * x) is
+	// -Im(X[2*i+1]) / (2*N) where X is the discrete Fourier transform of the samples.
+	n := d.n
+	data := make([]complex128, 4*n)
+	h := math.Pi / float64(2*n)
+	for i := 1; i <= n; i++ {
+		data[i] = complex(f(float64(i)*h), 0)
+	}
+	for i := 1; i < n; i++ {
+		data[n+i] = data[n-i]
+	}
+	for i := 0; i < 2*n; i++ {
+		data[2*n+i] = -data[i]
+	}
+	fft(data)
+	for i := 0; i < n; i++ {
+		F[i] = -imag(data[2*i+1]) / float64(2*n)
+	}
+}
+
+// dst_eval evaluates sum(F[i] * sin((2*i+1) * x), i, 0, N-1) using Clenshaw summation,
+// given sinx = sin(x) and cosx = cos(x)
+func dst_eval(sinx, cosx float64, F []float64) float64 {
+	n := len(F)
+	ar := 2 * (cosx - sinx) * (cosx + sinx) // 2 * cos(2 * x)
+	y0 := 0.0
+	y1 := 0.0
+	if n&1 != 0 {
+		n--
+		y0 = F[n]
+	}
+	// Now n is even
+	for n > 0 {
+		// Unroll loop x 2, so accumulators return to their original role
+		n--
+		y1 = ar*y0 - y1 + F[n]
+		n--
+		y0 = ar*y1 - y0 + F[n]
+	}
+	return sinx * (y0 + y1) // sin(x) * (y0 + y1)
+}
+
+// dst_integral evaluates the integral of the sine series,
+// sum(-F[i]/(2*i+1) * cos((2*i+1) * x), i, 0, N-1), using Clenshaw summation, given
+// sinx = sin(x) and cosx = cos(x)
+func dst_integral(sinx, cosx float64, F []float64) float64 {
+	ar := 2 * (cosx - sinx) * (cosx + sinx) // 2 * cos(2 * x)
+	y0 := 0.0
+	y1 := 0.0
+	for n := len(F) - 1; n >= 0; n-- {
+		t := ar*y0 - y1 + F[n]/float64(2*n+1)
+		y1 = y0
+		y0 = t
+	}
+	return cosx * (y1 - y0) // cos(x) * (y1 - y0)
+}
+
+// sine_transform finds the coefficients F of the full sine series,
+// f(x) = sum(F[k] * sin(k * x), k, 1, N-1), of an odd, 2*pi-periodic function f. f is
+// sampled at the points x = k * pi/N for k in (0, N), and F[0] is set to zero. F must have
+// length N, which must be a power of two.
+func sine_transform(f func(float64) float64, F []float64) {
+	// The odd extension of f sampled at 2*N points over [0, 2*pi). The coefficient of
+	// sin(k * x) is -Im(X[k]) / N where X is the discrete Fourier transform of the samples.
+	n := len(F)
+	data := make([]complex128, 2*n)
+	h := math.Pi / float64(n)
+	for k := 1; k < n; k++ {
+		data[k] = complex(f(float64(k)*h), 0)
+		data[2*n-k] = -data[k]
+	}
+	fft(data)
+	F[0] = 0
+	for k := 1; k < n; k++ {
+		F[k] = -imag(data[k]) / float64(n)
+	}
+}
+
+// fft replaces a with its discrete Fourier transform,
+// X[k] = sum(a[j] * exp(-2*pi*i*j*k/N), j, 0, N-1). The length of a must be a power of
+// two.
+func fft(a []complex128) {
+	n := len(a)
+	// Bit reversal permutation
+	for i, j := 1, 0; i < n; i++ {
+		bit := n >> 1
+		for ; j&bit != 0; bit >>= 1 {
+			j ^= bit
+		}
+		j ^= bit
+		if i < j {
+			a[i], a[j] = a[j], a[i]
+		}
+	}
+	// Iterative Cooley-Tukey butterflies
+	for size := 2; size <= n; size <<= 1 {
+		for k := 0; k < size/2; k++ {
+			// Compute each twiddle factor directly, rather than by repeated
+			// multiplication, to avoid accumulating round-off
+			wk := cmplx.Rect(1, -2*math.Pi*float64(k)/float64(size))
+			for start := 0; start < n; start += size {
+				u := a[start+k]
+				v := a[start+k+size/2] * wk
+				a[start+k] = u + v
+				a[start+k+size/2] = u - v
+			}
+		}
+	}
+}
@@ -0,0 +1,357 @@
+package geographiclibgo
+
+import (
+	"fmt"
+	"math"
+)
+
+// Ellipsoid gives the properties of an ellipsoid of revolution: its derived radii,
+// flattenings, and eccentricities, conversions between the geographic latitude and the
+// auxiliary latitudes, the meridian distance, the radii of curvature, and the area of a
+// latitude band.
+//
+// The auxiliary latitudes are:
+//   - the parametric latitude beta, tan(beta) = (1-f) tan(phi)
+//   - the geocentric latitude theta, tan(theta) = (1-f)^2 tan(phi)
+//   - the rectifying latitude mu, proportional to the distance along the meridian from
+//     the equator; mu = 90 at the pole
+//   - the authalic latitude xi, the latitude on the sphere with the same area as the
+//     ellipsoid which preserves areas
+//   - the conformal latitude chi, the latitude on a sphere to which the ellipsoid is
+//     mapped conformally
+//   - the isometric latitude psi, which is the northing of the point in the Mercator
+//     projection (divided by the equatorial radius).
+//
+// All the latitudes are in degrees, including the isometric latitude, psi. The
+// conversions to the auxiliary latitudes and back are accurate to round-off for any
+// flattening; the rectifying latitude is computed with elliptic integrals and the
+// authalic latitude as in AlbersEqualArea.
+type Ellipsoid struct {
+	a   float64
+	f   float64
+	f1  float64
+	f12 float64
+	e2  float64
+	es  float64
+	e12 float64
+	n   float64
+	b   float64
+	c2  float64
+
+	// The elliptic integrals for the meridian distance, with k2 = -e12
+	ell EllipticFunction
+
+	// An Albers equal-area projection, which supplies the authalic latitude
+	au AlbersEqualArea
+}
+
+// NewEllipsoid creates an Ellipsoid with equatorial radius a [meters] and flattening f.
+// f may be negative, giving a prolate ellipsoid.
+func NewEllipsoid(a, f float64) Ellipsoid {
+	_f1 := 1.0 - f
+	_e2 := f * (2.0 - f)
+	_es := math.Sqrt(math.Abs(_e2))
+	if f < 0.0 {
+		_es = -_es
+	}
+	_e12 := _e2 / sq(_f1)
+	_b := a * _f1
+
+	// The authalic radius squared
+	to_mul := eatanhe(1.0, _es) / _e2
+	if _e2 == 0.0 {
+		to_mul = 1.0
+	}
+
+	return Ellipsoid{
+		a:   a,
+		f:   f,
+		f1:  _f1,
+		f12: sq(_f1),
+		e2:  _e2,
+		es:  _es,
+		e12: _e12,
+		n:   f / (2.0 - f),
+		b:   _b,
+		c2:  (sq(a) + sq(_b)*to_mul) / 2.0,
+		ell: NewEllipticFunction(-_e12, 0),
+		au:  NewAlbersEqualArea(a, f, 0, 1),
+	}
+}
+
+// Wgs84Ellipsoid returns the WGS84 ellipsoid
+func Wgs84Ellipsoid() Ellipsoid {
+	return NewEllipsoid(WGS84_A, WGS84_F)
+}
+
+// Ellipsoid returns the Ellipsoid on which the geodesic calculations are carried out
+func (g *Geodesic) Ellipsoid() Ellipsoid {
+	return NewEllipsoid(g.a, g.f)
+}
+
+// EquatorialRadius returns the equatorial radius of the ellipsoid, a [meters]
+func (e *Ellipsoid) EquatorialRadius() float64 {
+	return e.a
+}
+
+// MinorRadius returns the polar semi-axis of the ellipsoid, b = a (1-f) [meters]
+func (e *Ellipsoid) MinorRadius() float64 {
+	return e.b
+}
+
+// AuthalicRadius returns the radius of the sphere with the same area as the ellipsoid
+// [meters]
+func (e *Ellipsoid) AuthalicRadius() float64 {
+	return math.Sqrt(e.c2)
+}
+
+// QuarterMeridian returns the distance along the meridian from the equator to a pole
+// [meters]
+func (e *Ellipsoid) QuarterMeridian() float64 {
+	return e.b * e.ell.E()
+}
+
+// Area returns the total area of the ellipsoid [meters^2]
+func (e *Ellipsoid) Area() float64 {
+	return 4 * math.Pi * e.c2
+}
+
+// Volume returns the total volume of the ellipsoid [meters^3]
+func (e *Ellipsoid) Volume() float64 {
+	return (4 * math.Pi / 3) * sq(e.a) * e.b
+}
+
+// Flattening returns the flattening of the ellipsoid, f = (a - b)/a
+func (e *Ellipsoid) Flattening() float64 {
+	return e.f
+}
+
+// SecondFlattening returns the second flattening of the ellipsoid, f' = (a - b)/b
+func (e *Ellipsoid) SecondFlattening() float64 {
+	return e.f / e.f1
+}
+
+// ThirdFlattening returns the third flattening of the ellipsoid, n = (a - b)/(a + b)
+func (e *Ellipsoid) ThirdFlattening() float64 {
+	return e.n
+}
+
+// EccentricitySq returns the square of the eccentricity of the ellipsoid,
+// (a^2 - b^2)/a^2. This is negative for a prolate ellipsoid.
+func (e *Ellipsoid) EccentricitySq() float64 {
+	return e.e2
+}
+
+// SecondEccentricitySq returns the square of the second eccentricity of the ellipsoid,
+// (a^2 - b^2)/b^2
+func (e *Ellipsoid) SecondEccentricitySq() float64 {
+	return e.e12
+}
+
+// ThirdEccentricitySq returns the square of the third eccentricity of the ellipsoid,
+// (a^2 - b^2)/(a^2 + b^2)
+func (e *Ellipsoid) ThirdEccentricitySq() float64 {
+	return e.e2 / (2 - e.e2)
+}
+
+// ParametricLatitude returns the parametric latitude beta [degrees] of lat_deg
+func (e *Ellipsoid) ParametricLatitude(lat_deg float64) float64 {
+	return atand(e.f1 * tand(lat_fix(lat_deg)))
+}
+
+// InverseParametricLatitude returns the geographic latitude [degrees] whose parametric
+// latitude is beta_deg
+func (e *Ellipsoid) InverseParametricLatitude(beta_deg float64) float64 {
+	return atand(tand(lat_fix(beta_deg)) / e.f1)
+}
+
+// GeocentricLatitude returns the geocentric latitude theta [degrees] of lat_deg
+func (e *Ellipsoid) GeocentricLatitude(lat_deg float64) float64 {
+	return atand(e.f12 * tand(lat_fix(lat_deg)))
+}
+
+// InverseGeocentricLatitude returns the geographic latitude [degrees] whose geocentric
+// latitude is theta_deg
+func (e *Ellipsoid) InverseGeocentricLatitude(theta_deg float64) float64 {
+	return atand(tand(lat_fix(theta_deg)) / e.f12)
+}
+
+// RectifyingLatitude returns the rectifying latitude mu [degrees] of lat_deg
+func (e *Ellipsoid) RectifyingLatitude(lat_deg float64) float64 {
+	if math.Abs(lat_deg) == 90.0 {
+		return lat_deg
+	}
+	return 90.0 * e.MeridianDistance(lat_deg) / e.QuarterMeridian()
+}
+
+// InverseRectifyingLatitude returns the geographic latitude [degrees] whose rectifying
+// latitude is mu_deg
+func (e *Ellipsoid) InverseRectifyingLatitude(mu_deg float64) float64 {
+	if math.Abs(mu_deg) == 90.0 {
+		return mu_deg
+	}
+	return e.InverseParametricLatitude(e.ell.Einv(mu_deg*e.ell.E()/90.0) * RAD2DEG)
+}
+
+// AuthalicLatitude returns the authalic latitude xi [degrees] of lat_deg
+func (e *Ellipsoid) AuthalicLatitude(lat_deg float64) float64 {
+	return atand(e.au._txif(tand(lat_fix(lat_deg))))
+}
+
+// InverseAuthalicLatitude returns the geographic latitude [degrees] whose authalic
+// latitude is xi_deg
+func (e *Ellipsoid) InverseAuthalicLatitude(xi_deg float64) float64 {
+	return atand(e.au._tphif(tand(lat_fix(xi_deg))))
+}
+
+// ConformalLatitude returns the conformal latitude chi [degrees] of lat_deg
+func (e *Ellipsoid) ConformalLatitude(lat_deg float64) float64 {
+	return atand(taupf(tand(lat_fix(lat_deg)), e.es))
+}
+
+// InverseConformalLatitude returns the geographic latitude [degrees] whose conformal
+// latitude is chi_deg
+func (e *Ellipsoid) InverseConformalLatitude(chi_deg float64) float64 {
+	return atand(tauf(tand(lat_fix(chi_deg)), e.es))
+}
+
+// IsometricLatitude returns the isometric latitude psi [degrees] of lat_deg. At the
+// poles this is large, but finite.
+func (e *Ellipsoid) IsometricLatitude(lat_deg float64) float64 {
+	return math.Asinh(taupf(tand(lat_fix(lat_deg)), e.es)) * RAD2DEG
+}
+
+// InverseIsometricLatitude returns the geographic latitude [degrees] whose isometric
+// latitude is psi_deg
+func (e *Ellipsoid) InverseIsometricLatitude(psi_deg float64) float64 {
+	return atand(tauf(math.Sinh(psi_deg*DEG2RAD), e.es))
+}
+
+// CircleRadius returns the radius of the circle of latitude lat_deg [meters]. This is the
+// distance from the point to the axis of the ellipsoid.
+func (e *Ellipsoid) CircleRadius(lat_deg float64) float64 {
+	if math.Abs(lat_deg) == 90.0 {
+		return 0.0
+	}
+	return e.a / math.Hypot(1.0, e.f1*tand(lat_fix(lat_deg)))
+}
+
+// CircleHeight returns the distance of the plane of the circle of latitude lat_deg from
+// the equatorial plane [meters]
+func (e *Ellipsoid) CircleHeight(lat_deg float64) float64 {
+	tbeta := e.f1 * tand(lat_fix(lat_deg))
+	return e.b * tbeta / math.Hypot(1.0, tbeta)
+}
+
+// MeridianDistance returns the distance along the meridian from the equator to lat_deg
+// [meters]
+func (e *Ellipsoid) MeridianDistance(lat_deg float64) float64 {
+	return e.b * e.ell.EDeg(e.ParametricLatitude(lat_deg))
+}
+
+// MeridionalCurvatureRadius returns the radius of curvature of the ellipsoid in the
+// meridional direction at latitude lat_deg [meters]
+func (e *Ellipsoid) MeridionalCurvatureRadius(lat_deg float64) float64 {
+	v := 1.0 - e.e2*sq(math.Sin(lat_fix(lat_deg)*DEG2RAD))
+	return e.a * (1.0 - e.e2) / (v * math.Sqrt(v))
+}
+
+// TransverseCurvatureRadius returns the radius of curvature of the ellipsoid in the
+// prime vertical (east-west) direction at latitude lat_deg [meters]
+func (e *Ellipsoid) TransverseCurvatureRadius(lat_deg float64) float64 {
+	v := 1.0 - e.e2*sq(math.Sin(lat_fix(lat_deg)*DEG2RAD))
+	return e.a / math.Sqrt(v)
+}
+
+// NormalCurvatureRadius returns the radius of curvature of the ellipsoid in the normal
+// section at latitude lat_deg with azimuth azi_deg [meters]
+func (e *Ellipsoid) NormalCurvatureRadius(lat_deg, azi_deg float64) float64 {
+	salp, calp := sincosd(azi_deg)
+	v := 1.0 - e.e2*sq(math.Sin(lat_fix(lat_deg)*DEG2RAD))
+	return e.a / (math.Sqrt(v) * (sq(calp)*v/(1.0-e.e2) + sq(salp)))
+}
+
+// LatitudeBandArea returns the area of the band of the ellipsoid between the parallels
+// lat1_deg and lat2_deg, taken all the way round the ellipsoid [meters^2]. The result is
+// negative if lat2_deg < lat1_deg. The area of a band spanning dlon degrees of longitude
+// is this multiplied by dlon/360.
+func (e *Ellipsoid) LatitudeBandArea(lat1_deg, lat2_deg float64) float64 {
+	// The area between the equator and latitude phi is 2*pi*c2*sin(xi), where xi is the
+	// authalic latitude
+	return 2 * math.Pi * e.c2 * e._d_sin_authalic(lat1_deg, lat2_deg)
+}
+
+// RectangleArea returns the area of the region bounded by the parallels lat1_deg and
+// lat2_deg and the meridians lon1_deg and lon2_deg [meters^2]. The region extends east
+// from lon1_deg to lon2_deg, so that, for example, lon1_deg = 170 and lon2_deg = -170
+// give a region 20 degrees wide. Distinct meridians which are a multiple of 360 degrees
+// apart, such as lon1_deg = -180 and lon2_deg = 180, give the whole band between the
+// parallels. The result is non-negative, whichever way round the latitudes are given.
+// Unlike a polygon with these four corners, the north and south
+// edges of the region are parallels, not geodesics.
+func (e *Ellipsoid) RectangleArea(lat1_deg, lon1_deg, lat2_deg, lon2_deg float64) float64 {
+	lon12, _ := ang_diff(lon1_deg, lon2_deg)
+	if lon12 < 0 {
+		lon12 += 360.0
+	} else if lon12 == 0 && lon1_deg != lon2_deg {
+		lon12 = 360.0
+	}
+	return math.Abs(e.LatitudeBandArea(lat1_deg, lat2_deg)) * lon12 / 360.0
+}
+
+// GridCellAreas fills areas with the areas of the cells of a regular latitude/longitude
+// grid with nlat rows and nlon columns [meters^2]. Row i spans the latitudes
+// lat0_deg + i*dlat_deg to lat0_deg + (i+1)*dlat_deg, and every cell spans dlon_deg of
+// longitude; dlat_deg may be negative for grids which are stored from north to south.
+// The area of cell (i, j) is stored in areas[i*nlon + j]. The areas do not depend on the
+// longitude of the grid. This returns an error if areas has fewer than nlat*nlon
+// elements.
+func (e *Ellipsoid) GridCellAreas(
+	lat0_deg, dlat_deg, dlon_deg float64,
+	nlat, nlon int,
+	areas []float64,
+) error {
+	if nlat < 0 || nlon < 0 {
+		return fmt.Errorf("grid shape %d x %d is negative", nlat, nlon)
+	}
+	if len(areas) < nlat*nlon {
+		return fmt.Errorf("areas has length %d; want at least %d", len(areas), nlat*nlon)
+	}
+	scale := 2 * math.Pi * e.c2 * math.Abs(dlon_deg) / 360.0
+	for i := 0; i < nlat; i++ {
+		// Find the edges of each row directly from lat0, so that errors don't accumulate
+		lat1 := lat0_deg + float64(i)*dlat_deg
+		lat2 := lat0_deg + float64(i+1)*dlat_deg
+		area := math.Abs(e._d_sin_authalic(lat1, lat2)) * scale
+		row := areas[i*nlon : (i+1)*nlon]
+		for j := range row {
+			row[j] = area
+		}
+	}
+	return nil
+}
+
+// _d_sin_authalic returns sin(xi2) - sin(xi1), where xi1 and xi2 are the authalic
+// latitudes of lat1_deg and lat2_deg. This is evaluated as a divided difference so that
+// it stays accurate for narrow bands near the poles.
+func (e *Ellipsoid) _d_sin_authalic(lat1_deg, lat2_deg float64) float64 {
+	// sin(xi) = q(sin(phi))/q(1) with
+	// q(x) = (1-e2) * (x/(1-e2*x^2) + atanhee(x))
+	// so that
+	// Dq(x2, x1) = (1-e2) * ( (1+e2*x1*x2)/((1-e2*x1^2)*(1-e2*x2^2)) + Datanhee(x2, x1) )
+	// and sin(phi2) - sin(phi1) = 2 * cos((phi2+phi1)/2) * sin((phi2-phi1)/2)
+	lat1, lat2 := lat_fix(lat1_deg), lat_fix(lat2_deg)
+	x1, x2 := math.Sin(lat1*DEG2RAD), math.Sin(lat2*DEG2RAD)
+	if math.Abs(lat1) == 90.0 {
+		x1 = lat1 / 90.0
+	}
+	if math.Abs(lat2) == 90.0 {
+		x2 = lat2 / 90.0
+	}
+	shalf, _ := sincosd((lat2 - lat1) / 2)
+	_, cmean := sincosd((lat2 + lat1) / 2)
+	dx := 2 * cmean * shalf
+	dq := e.au.e2m * ((1+e.e2*x1*x2)/((1-e.e2*sq(x1))*(1-e.e2*sq(x2))) +
+		e.au._d_atanhee(x2, x1))
+	return dx * dq / e.au.qZ
+}
@@ -0,0 +1,284 @@
+package geographiclibgo
+
+import (
+	"math"
+	"testing"
+)
+
+func TestEllipsoidWgs84Constants(t *testing.T) {
+	e := Wgs84Ellipsoid()
+	testCases := []struct {
+		desc string
+		got  float64
+		want float64
+		thr  float64
+	}{
+		{desc: "minor radius", got: e.MinorRadius(), want: 6356752.314245, thr: 1e-6},
+		{desc: "quarter meridian", got: e.QuarterMeridian(), want: 10001965.729313, thr: 1e-6},
+		{desc: "authalic radius", got: e.AuthalicRadius(), want: 6371007.180918, thr: 1e-6},
+		{desc: "area", got: e.Area(), want: 510065621724088.5, thr: 1},
+		{desc: "volume", got: e.Volume(), want: 1.0832073198e21, thr: 1e11},
+		{desc: "second flattening", got: e.SecondFlattening(), want: 1 / 297.257223563, thr: 1e-15},
+		{desc: "third flattening", got: e.ThirdFlattening(), want: 1 / 595.514447126, thr: 1e-15},
+		{desc: "eccentricity squared", got: e.EccentricitySq(), want: 0.00669437999014, thr: 1e-14},
+		{desc: "second eccentricity squared", got: e.SecondEccentricitySq(), want: 0.00673949674228, thr: 1e-14},
+		{desc: "third eccentricity squared", got: e.ThirdEccentricitySq(), want: 0.00335843130273, thr: 1e-14},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			if !almost_equal(tC.got, tC.want, tC.thr) {
+				t.Errorf("%v = %v; want %v", tC.desc, tC.got, tC.want)
+			}
+		})
+	}
+
+	g := Wgs84()
+	ge := g.Ellipsoid()
+	if ge.Area() != e.Area() || ge.MinorRadius() != e.MinorRadius() {
+		t.Errorf("Geodesic.Ellipsoid() differs from Wgs84Ellipsoid()")
+	}
+}
+
+func TestEllipsoidAuxiliaryLatitudesRoundTrip(t *testing.T) {
+	for _, f := range []float64{WGS84_F, -1 / 150.0, 0, 0.1} {
+		e := NewEllipsoid(WGS84_A, f)
+		conversions := []struct {
+			desc    string
+			forward func(float64) float64
+			reverse func(float64) float64
+		}{
+			{"parametric", e.ParametricLatitude, e.InverseParametricLatitude},
+			{"geocentric", e.GeocentricLatitude, e.InverseGeocentricLatitude},
+			{"rectifying", e.RectifyingLatitude, e.InverseRectifyingLatitude},
+			{"authalic", e.AuthalicLatitude, e.InverseAuthalicLatitude},
+			{"conformal", e.ConformalLatitude, e.InverseConformalLatitude},
+			{"isometric", e.IsometricLatitude, e.InverseIsometricLatitude},
+		}
+		for _, c := range conversions {
+			for _, lat := range []float64{-90, -89.9, -60, -30, -1e-3, 0, 1e-3, 10, 45, 75, 89, 90} {
+				aux := c.forward(lat)
+				if lat == 0 && aux != 0 {
+					t.Errorf("f = %v: %v(0) = %v; want 0", f, c.desc, aux)
+				}
+				if c.desc != "isometric" && math.Abs(lat) == 90 && aux != lat {
+					t.Errorf("f = %v: %v(%v) = %v; want %v", f, c.desc, lat, aux, lat)
+				}
+				if got := c.reverse(aux); !almost_equal(got, lat, 1e-12) {
+					t.Errorf("f = %v: inverse %v(%v(%v)) = %v", f, c.desc, c.desc, lat, got)
+				}
+			}
+		}
+	}
+}
+
+func TestEllipsoidAuxiliaryLatitudes(t *testing.T) {
+	e := Wgs84Ellipsoid()
+	g := Wgs84()
+	mercator := NewLambertConformalConic(WGS84_A, WGS84_F, 0, 1)
+	for _, lat := range []float64{-75, -20, 5, 30, 45, 60, 85} {
+		beta := e.ParametricLatitude(lat)
+		if got := e.GeocentricLatitude(lat); !almost_equal(got, atand((1-WGS84_F)*tand(beta)), 1e-13) {
+			t.Errorf("geocentric(%v) = %v; want %v", lat, got, atand((1-WGS84_F)*tand(beta)))
+		}
+		// The rectifying latitude is proportional to the distance along the meridian
+		s := g.InverseCalcDistance(0, 0, lat, 0)
+		if got := e.MeridianDistance(lat); !almost_equal(got, math.Copysign(s, lat), 1e-8) {
+			t.Errorf("MeridianDistance(%v) = %v; want %v", lat, got, s)
+		}
+		if got := e.RectifyingLatitude(lat); !almost_equal(got, 90*math.Copysign(s, lat)/e.QuarterMeridian(), 1e-12) {
+			t.Errorf("rectifying(%v) = %v", lat, got)
+		}
+		// The isometric latitude is the northing of the Mercator projection
+		y := mercator.Forward(0, lat, 0).YM
+		if got := e.IsometricLatitude(lat); !almost_equal(got, y/WGS84_A*RAD2DEG, 1e-12) {
+			t.Errorf("isometric(%v) = %v; want %v", lat, got, y/WGS84_A*RAD2DEG)
+		}
+		// The conformal latitude is the Gudermannian of the isometric latitude
+		chi := math.Atan(math.Sinh(e.IsometricLatitude(lat)*DEG2RAD)) * RAD2DEG
+		if got := e.ConformalLatitude(lat); !almost_equal(got, chi, 1e-12) {
+			t.Errorf("conformal(%v) = %v; want %v", lat, got, chi)
+		}
+		// The auxiliary latitudes lie in the order chi < mu < theta on the ellipsoid, with
+		// the authalic and parametric latitudes between the rectifying and geographic ones
+		if !(math.Abs(e.GeocentricLatitude(lat)) < math.Abs(e.ConformalLatitude(lat)) &&
+			math.Abs(e.ConformalLatitude(lat)) < math.Abs(e.RectifyingLatitude(lat)) &&
+			math.Abs(e.RectifyingLatitude(lat)) < math.Abs(e.AuthalicLatitude(lat)) &&
+			math.Abs(e.AuthalicLatitude(lat)) < math.Abs(beta) &&
+			math.Abs(beta) < math.Abs(lat)) {
+			t.Errorf("auxiliary latitudes of %v out of order", lat)
+		}
+	}
+}
+
+func TestEllipsoidRadii(t *testing.T) {
+	e := Wgs84Ellipsoid()
+	a, b := e.EquatorialRadius(), e.MinorRadius()
+	if got := e.MeridionalCurvatureRadius(0); !almost_equal(got, b*b/a, 1e-8) {
+		t.Errorf("M(0) = %v; want %v", got, b*b/a)
+	}
+	if got := e.TransverseCurvatureRadius(0); got != a {
+		t.Errorf("N(0) = %v; want %v", got, a)
+	}
+	for _, r := range []float64{
+		e.MeridionalCurvatureRadius(90), e.TransverseCurvatureRadius(90),
+		e.NormalCurvatureRadius(90, 37),
+	} {
+		if !almost_equal(r, a*a/b, 1e-8) {
+			t.Errorf("radius at pole = %v; want %v", r, a*a/b)
+		}
+	}
+	lat := 40.0
+	if got, want := e.NormalCurvatureRadius(lat, 0), e.MeridionalCurvatureRadius(lat); !almost_equal(got, want, 1e-8) {
+		t.Errorf("NormalCurvatureRadius(%v, 0) = %v; want %v", lat, got, want)
+	}
+	if got, want := e.NormalCurvatureRadius(lat, 90), e.TransverseCurvatureRadius(lat); !almost_equal(got, want, 1e-8) {
+		t.Errorf("NormalCurvatureRadius(%v, 90) = %v; want %v", lat, got, want)
+	}
+
+	// The circle of latitude is where the geocentric coordinates put it
+	geocentric := Wgs84Geocentric()
+	for _, lat := range []float64{-60, 0, 33.3, 89} {
+		p := geocentric.Forward(lat, 0, 0)
+		if got := e.CircleRadius(lat); !almost_equal(got, p.XM, 1e-8) {
+			t.Errorf("CircleRadius(%v) = %v; want %v", lat, got, p.XM)
+		}
+		if got := e.CircleHeight(lat); !almost_equal(got, p.ZM, 1e-8) {
+			t.Errorf("CircleHeight(%v) = %v; want %v", lat, got, p.ZM)
+		}
+	}
+	if got := e.CircleRadius(90); got != 0 {
+		t.Errorf("CircleRadius(90) = %v; want 0", got)
+	}
+}
+
+func TestEllipsoidLatitudeBandArea(t *testing.T) {
+	e := Wgs84Ellipsoid()
+	if got := e.LatitudeBandArea(-90, 90); !almost_equal(got, e.Area(), 1e-1) {
+		t.Errorf("LatitudeBandArea(-90, 90) = %v; want %v", got, e.Area())
+	}
+	if got := e.LatitudeBandArea(0, 90); !almost_equal(got, e.Area()/2, 1e-1) {
+		t.Errorf("LatitudeBandArea(0, 90) = %v; want %v", got, e.Area()/2)
+	}
+	if a1, a2 := e.LatitudeBandArea(10, 20), e.LatitudeBandArea(20, 10); a1 != -a2 {
+		t.Errorf("LatitudeBandArea(10, 20) = %v, LatitudeBandArea(20, 10) = %v", a1, a2)
+	}
+
+	// Rhumb lines along meridians and parallels bound a lat/lon rectangle
+	polygon := NewPolygonAreaRhumb(Wgs84Rhumb(), false)
+	for _, p := range [][2]float64{{10, 0}, {10, 30}, {20, 30}, {20, 0}} {
+		polygon.AddPoint(p[0], p[1])
+	}
+	want := polygon.Compute(false, true).Area
+	if got := e.LatitudeBandArea(10, 20) * 30 / 360; !almost_equal(got, want, 1) {
+		t.Errorf("LatitudeBandArea(10, 20) * 30/360 = %v; want %v", got, want)
+	}
+}
+
+func TestEllipsoidRectangleArea(t *testing.T) {
+	e := Wgs84Ellipsoid()
+	// Rhumb lines along meridians and parallels bound a lat/lon rectangle
+	polygon := NewPolygonAreaRhumb(Wgs84Rhumb(), false)
+	testCases := []struct {
+		desc                   string
+		lat1, lon1, lat2, lon2 float64
+	}{
+		{desc: "northern", lat1: 10, lon1: 0, lat2: 20, lon2: 30},
+		{desc: "southern", lat1: -60, lon1: -100, lat2: -45, lon2: -80},
+		{desc: "straddling equator", lat1: -5, lon1: 40, lat2: 5, lon2: 45},
+		{desc: "across antimeridian", lat1: 50, lon1: 170, lat2: 60, lon2: -170},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			polygon.Clear()
+			polygon.AddPoint(tC.lat1, tC.lon1)
+			polygon.AddPoint(tC.lat1, tC.lon2)
+			polygon.AddPoint(tC.lat2, tC.lon2)
+			polygon.AddPoint(tC.lat2, tC.lon1)
+			want := math.Abs(polygon.Compute(false, true).Area)
+			if got := e.RectangleArea(tC.lat1, tC.lon1, tC.lat2, tC.lon2); !almost_equal(got, want, 1) {
+				t.Errorf("RectangleArea() = %v; want %v", got, want)
+			}
+			if got := e.RectangleArea(tC.lat2, tC.lon1, tC.lat1, tC.lon2); !almost_equal(got, want, 1) {
+				t.Errorf("RectangleArea() with latitudes swapped = %v; want %v", got, want)
+			}
+		})
+	}
+
+	// Rectangles which go all the way round cover the whole band between the parallels
+	globe := 4 * math.Pi * sq(e.AuthalicRadius())
+	if got := e.RectangleArea(-90, -180, 90, 180); !almost_equal(got, globe, 1e-12*globe) {
+		t.Errorf("RectangleArea() of the globe = %v; want %v", got, globe)
+	}
+	if got, want := e.RectangleArea(10, 0, 20, 360), e.LatitudeBandArea(10, 20); !almost_equal(got, want, 1e-12*want) {
+		t.Errorf("RectangleArea() of a full row = %v; want %v", got, want)
+	}
+	if got := e.RectangleArea(10, 30, 20, 30); got != 0 {
+		t.Errorf("RectangleArea() with equal longitudes = %v; want 0", got)
+	}
+
+	// A tiny cell next to the pole, where sin(xi) is very close to 1. Over such a small
+	// cell, the area is the product of the meridional and transverse arc lengths.
+	lat, dlat, dlon := 89.999, 1e-6, 1e-6
+	// The width of the cell as it is actually represented
+	dlat = (lat + dlat) - lat
+	want := e.MeridionalCurvatureRadius(lat+dlat/2) * dlat * DEG2RAD *
+		e.CircleRadius(lat+dlat/2) * dlon * DEG2RAD
+	if got := e.RectangleArea(lat, 0, lat+dlat, dlon); !almost_equal(got, want, 1e-9*want) {
+		t.Errorf("RectangleArea() near pole = %v; want %v", got, want)
+	}
+}
+
+func TestEllipsoidGridCellAreas(t *testing.T) {
+	e := Wgs84Ellipsoid()
+
+	// A global grid of 1 degree cells stored from north to south
+	nlat, nlon := 180, 360
+	areas := make([]float64, nlat*nlon)
+	if err := e.GridCellAreas(90, -1, 1, nlat, nlon, areas); err != nil {
+		t.Fatalf("GridCellAreas() error = %v", err)
+	}
+	total := Accumulator{}
+	for _, a := range areas {
+		total.Add(a)
+	}
+	if got := total.Sum(0); !almost_equal(got, e.Area(), 1e-12*e.Area()) {
+		t.Errorf("total area = %v; want %v", got, e.Area())
+	}
+	for _, ij := range [][2]int{{0, 0}, {45, 17}, {90, 359}, {179, 200}} {
+		i, j := ij[0], ij[1]
+		lat1 := 90 - float64(i)
+		want := e.RectangleArea(lat1, float64(j), lat1-1, float64(j+1))
+		if got := areas[i*nlon+j]; !almost_equal(got, want, 1e-12*want) {
+			t.Errorf("areas[%v][%v] = %v; want %v", i, j, got, want)
+		}
+	}
+
+	if err := e.GridCellAreas(0, 1, 1, 3, 4, make([]float64, 11)); err == nil {
+		t.Errorf("GridCellAreas() with short slice error = nil; want non-nil")
+	}
+	if err := e.GridCellAreas(0, 1, 1, -3, 4, areas); err == nil {
+		t.Errorf("GridCellAreas() with negative shape error = nil; want non-nil")
+	}
+}
+
+func BenchmarkEllipsoidAuthalicLatitude(b *testing.B) {
+	e := Wgs84Ellipsoid()
+	for i := 0; i < b.N; i++ {
+		e.AuthalicLatitude(45)
+	}
+}
+
+func BenchmarkEllipsoidInverseRectifyingLatitude(b *testing.B) {
+	e := Wgs84Ellipsoid()
+	for i := 0; i < b.N; i++ {
+		e.InverseRectifyingLatitude(45)
+	}
+}
+
+func BenchmarkEllipsoidGridCellAreas(b *testing.B) {
+	e := Wgs84Ellipsoid()
+	areas := make([]float64, 180*360)
+	for i := 0; i < b.N; i++ {
+		e.GridCellAreas(90, -1, 1, 180, 360, areas)
+	}
+}
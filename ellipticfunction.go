@@ -0,0 +1,781 @@
+package geographiclibgo
+
+import "math"
+
+// EllipticFunction computes elliptic integrals and functions. This is a port of the
+// EllipticFunction class from geographiclib, which uses Carlson's symmetric integrals
+// to evaluate the complete and incomplete elliptic integrals of the first, second, and
+// third kinds, and Bulirsch's algorithm for the Jacobi elliptic functions.
+//
+// The integrals are defined in terms of the parameter k2 (= k^2, the square of the
+// modulus) and, for the integrals of the third kind, the characteristic alpha2
+// (= alpha^2). Both may be negative, which is what the geodesic calculations on a
+// prolate ellipsoid need:
+//   - k2 must lie in (-inf, 1]
+//   - alpha2 must lie in (-inf, 1]
+//
+// The complementary values kp2 = 1 - k2 and alphap2 = 1 - alpha2 can be supplied
+// explicitly with NewEllipticFunctionWithComplements, which preserves accuracy when k2
+// or alpha2 is close to 1.
+type EllipticFunction struct {
+	k2      float64
+	kp2     float64
+	alpha2  float64
+	alphap2 float64
+	eps     float64
+	kc      float64
+	ec      float64
+	dc      float64
+	pic     float64
+	gc      float64
+	hc      float64
+}
+
+// Number of iterations used in sncndn and Einv. Convergence is quadratic, so this is
+// very generous.
+const _ELLIPTIC_NUM int = 13
+
+// NewEllipticFunction creates an EllipticFunction with parameter k2 and characteristic
+// alpha2. The complementary values are taken to be 1 - k2 and 1 - alpha2. Pass
+// alpha2 = 0 if you only need the integrals of the first and second kinds.
+func NewEllipticFunction(k2, alpha2 float64) EllipticFunction {
+	return NewEllipticFunctionWithComplements(k2, alpha2, 1-k2, 1-alpha2)
+}
+
+// NewEllipticFunctionWithComplements is the same as NewEllipticFunction but the user
+// specifies the complementary parameter kp2 and the complementary characteristic
+// alphap2 directly. The caller must ensure that k2 + kp2 = 1 and alpha2 + alphap2 = 1.
+func NewEllipticFunctionWithComplements(k2, alpha2, kp2, alphap2 float64) EllipticFunction {
+	e := EllipticFunction{}
+	e.Reset(k2, alpha2, kp2, alphap2)
+	return e
+}
+
+// Reset sets new values of k2, alpha2, kp2, and alphap2, and recomputes the complete
+// integrals
+func (e *EllipticFunction) Reset(k2, alpha2, kp2, alphap2 float64) {
+	e.k2 = k2
+	e.kp2 = kp2
+	e.alpha2 = alpha2
+	e.alphap2 = alphap2
+	e.eps = k2 / sq(math.Sqrt(kp2)+1)
+
+	// Values of complete elliptic integrals for k = 0,1 and alpha = 0,1
+	//         K     E     D
+	// k = 0:  pi/2  pi/2  pi/4
+	// k = 1:  inf   1     inf
+	//                    Pi    G     H
+	// k = 0, alpha = 0:  pi/2  pi/2  pi/4
+	// k = 1, alpha = 0:  inf   1     1
+	// k = 0, alpha = 1:  inf   inf   pi/2
+	// k = 1, alpha = 1:  inf   inf   inf
+	//
+	// Pi(0, k) = K(k)
+	// G(0, k) = E(k)
+	// H(0, k) = K(k) - D(k)
+	// Pi(alpha2, 0) = pi/(2*sqrt(1-alpha2))
+	// G(alpha2, 0) = pi/(2*sqrt(1-alpha2))
+	// H(alpha2, 0) = pi/(2*(1 + sqrt(1-alpha2)))
+	// Pi(alpha2, 1) = inf
+	// H(1, k) = K(k)
+	// G(alpha2, 1) = H(alpha2, 1) = RC(1, alphap2)
+	if e.kp2 != 0 {
+		// K = RF(0, kp2, 1)
+		e.kc = CarlsonRF0(e.kp2, 1)
+		// E = 2 RG(0, kp2, 1)
+		e.ec = 2 * CarlsonRG0(e.kp2, 1)
+		// D = RD(0, kp2, 1) / 3
+		e.dc = CarlsonRD(0, e.kp2, 1) / 3
+	} else {
+		e.kc = math.Inf(1)
+		e.ec = 1
+		e.dc = math.Inf(1)
+	}
+
+	if e.alpha2 != 0 {
+		// Pi = K + alpha2 RJ(0, kp2, 1, alphap2) / 3
+		rj := math.Inf(1)
+		if e.kp2 != 0 && e.alphap2 != 0 {
+			rj = CarlsonRJ(0, e.kp2, 1, e.alphap2)
+		}
+		// Only use rc if kp2 = 0.
+		rc := 0.0
+		if e.kp2 == 0 {
+			if e.alphap2 != 0 {
+				rc = CarlsonRC(1, e.alphap2)
+			} else {
+				rc = math.Inf(1)
+			}
+		}
+		if e.kp2 != 0 {
+			// Pi(alpha^2, k)
+			e.pic = e.kc + e.alpha2*rj/3
+			// G = K - (alpha2 - k2) RJ(0, kp2, 1, alphap2) / 3
+			e.gc = e.kc + (e.alpha2-e.k2)*rj/3
+			// H = K - alphap2 RJ(0, kp2, 1, alphap2) / 3
+			hrj := 0.0
+			if e.alphap2 != 0 {
+				hrj = e.alphap2 * rj
+			}
+			e.hc = e.kc - hrj/3
+		} else {
+			e.pic = math.Inf(1)
+			e.gc = rc
+			e.hc = rc
+		}
+	} else {
+		e.pic = e.kc
+		e.gc = e.ec
+		// Hc = Kc - Dc but this involves large cancellations if k2 is close to 1.
+		// So write (for alpha2 = 0)
+		//   Hc = int(cos(phi)^2/sqrt(1-k2*sin(phi)^2),phi,0,pi/2)
+		//      = 1/sqrt(1-k2) * int(sin(phi)^2/sqrt(1-k2/kp2*sin(phi)^2,...)
+		//      = 1/kp * D(i*k/kp)
+		// and use D(k) = RD(0, kp2, 1) / 3
+		// so Hc = 1/kp * RD(0, 1/kp2, 1) / 3
+		//       = kp2 * RD(0, 1, kp2) / 3
+		// For k2 = 1 and alpha2 = 0, we have Hc = int(cos(phi),...) = 1
+		if e.kp2 != 0 {
+			e.hc = e.kp2 * CarlsonRD(0, 1, e.kp2) / 3
+		} else {
+			e.hc = 1
+		}
+	}
+}
+
+// K2 returns the square of the modulus, k2
+func (e *EllipticFunction) K2() float64 {
+	return e.k2
+}
+
+// Kp2 returns the square of the complementary modulus, kp2 = 1 - k2
+func (e *EllipticFunction) Kp2() float64 {
+	return e.kp2
+}
+
+// Alpha2 returns the characteristic, alpha2
+func (e *EllipticFunction) Alpha2() float64 {
+	return e.alpha2
+}
+
+// Alphap2 returns the complementary characteristic, alphap2 = 1 - alpha2
+func (e *EllipticFunction) Alphap2() float64 {
+	return e.alphap2
+}
+
+// K returns the complete integral of the first kind, K(k)
+func (e *EllipticFunction) K() float64 {
+	return e.kc
+}
+
+// E returns the complete integral of the second kind, E(k)
+func (e *EllipticFunction) E() float64 {
+	return e.ec
+}
+
+// D returns Jahnke's complete integral, D(k) = (K(k) - E(k))/k2
+func (e *EllipticFunction) D() float64 {
+	return e.dc
+}
+
+// KE returns the difference K(k) - E(k), computed without cancellation
+func (e *EllipticFunction) KE() float64 {
+	return e.k2 * e.dc
+}
+
+// Pi returns the complete integral of the third kind, Pi(alpha2, k)
+func (e *EllipticFunction) Pi() float64 {
+	return e.pic
+}
+
+// G returns Legendre's complete geodesic longitude integral, G(alpha2, k)
+func (e *EllipticFunction) G() float64 {
+	return e.gc
+}
+
+// H returns Cayley's complete geodesic longitude difference integral, H(alpha2, k)
+func (e *EllipticFunction) H() float64 {
+	return e.hc
+}
+
+// Delta returns sqrt(1 - k2 sin^2(phi)) given sn = sin(phi) and cn = cos(phi)
+func (e *EllipticFunction) Delta(sn, cn float64) float64 {
+	if e.k2 < 0 {
+		return math.Sqrt(1 - e.k2*sn*sn)
+	}
+	return math.Sqrt(e.kp2 + e.k2*cn*cn)
+}
+
+// FSnCnDn returns the incomplete integral of the first kind, F(phi, k), in terms of
+// sn = sin(phi), cn = cos(phi), and dn = sqrt(1 - k2 sin^2(phi))
+func (e *EllipticFunction) FSnCnDn(sn, cn, dn float64) float64 {
+	// Carlson, eq. 4.5 and
+	// https://dlmf.nist.gov/19.25.E5
+	cn2 := cn * cn
+	dn2 := dn * dn
+	fi := e.K()
+	if cn2 != 0 {
+		fi = math.Abs(sn) * CarlsonRF(cn2, dn2, 1)
+	}
+	// Enforce usual trig-like symmetries
+	if math.Signbit(cn) {
+		fi = 2*e.K() - fi
+	}
+	return math.Copysign(fi, sn)
+}
+
+// ESnCnDn returns the incomplete integral of the second kind, E(phi, k), in terms of
+// sn = sin(phi), cn = cos(phi), and dn = sqrt(1 - k2 sin^2(phi))
+func (e *EllipticFunction) ESnCnDn(sn, cn, dn float64) float64 {
+	cn2 := cn * cn
+	dn2 := dn * dn
+	sn2 := sn * sn
+	ei := e.E()
+	if cn2 != 0 {
+		if e.k2 <= 0 {
+			// Carlson, eq. 4.6 and
+			// https://dlmf.nist.gov/19.25.E9
+			ei = CarlsonRF(cn2, dn2, 1) - e.k2*sn2*CarlsonRD(cn2, dn2, 1)/3
+		} else if e.kp2 >= 0 {
+			// https://dlmf.nist.gov/19.25.E10
+			ei = e.kp2*CarlsonRF(cn2, dn2, 1) +
+				e.k2*e.kp2*sn2*CarlsonRD(cn2, 1, dn2)/3 +
+				e.k2*math.Abs(cn)/dn
+		} else {
+			// https://dlmf.nist.gov/19.25.E11
+			ei = -e.kp2*sn2*CarlsonRD(dn2, 1, cn2)/3 + dn/math.Abs(cn)
+		}
+		ei *= math.Abs(sn)
+	}
+	// Enforce usual trig-like symmetries
+	if math.Signbit(cn) {
+		ei = 2*e.E() - ei
+	}
+	return math.Copysign(ei, sn)
+}
+
+// DSnCnDn returns Jahnke's incomplete integral, D(phi, k), in terms of sn = sin(phi),
+// cn = cos(phi), and dn = sqrt(1 - k2 sin^2(phi))
+func (e *EllipticFunction) DSnCnDn(sn, cn, dn float64) float64 {
+	// Carlson, eq. 4.8 and
+	// https://dlmf.nist.gov/19.25.E13
+	cn2 := cn * cn
+	dn2 := dn * dn
+	sn2 := sn * sn
+	di := e.D()
+	if cn2 != 0 {
+		di = math.Abs(sn) * sn2 * CarlsonRD(cn2, dn2, 1) / 3
+	}
+	// Enforce usual trig-like symmetries
+	if math.Signbit(cn) {
+		di = 2*e.D() - di
+	}
+	return math.Copysign(di, sn)
+}
+
+// PiSnCnDn returns the incomplete integral of the third kind, Pi(phi, alpha2, k), in
+// terms of sn = sin(phi), cn = cos(phi), and dn = sqrt(1 - k2 sin^2(phi))
+func (e *EllipticFunction) PiSnCnDn(sn, cn, dn float64) float64 {
+	// Carlson, eq. 4.7 and
+	// https://dlmf.nist.gov/19.25.E14
+	cn2 := cn * cn
+	dn2 := dn * dn
+	sn2 := sn * sn
+	pii := e.Pi()
+	if cn2 != 0 {
+		pii = math.Abs(sn) * (CarlsonRF(cn2, dn2, 1) +
+			e.alpha2*sn2*CarlsonRJ(cn2, dn2, 1, cn2+e.alphap2*sn2)/3)
+	}
+	// Enforce usual trig-like symmetries
+	if math.Signbit(cn) {
+		pii = 2*e.Pi() - pii
+	}
+	return math.Copysign(pii, sn)
+}
+
+// GSnCnDn returns Legendre's geodesic longitude integral, G(phi, alpha2, k), in terms of
+// sn = sin(phi), cn = cos(phi), and dn = sqrt(1 - k2 sin^2(phi))
+func (e *EllipticFunction) GSnCnDn(sn, cn, dn float64) float64 {
+	cn2 := cn * cn
+	dn2 := dn * dn
+	sn2 := sn * sn
+	gi := e.G()
+	if cn2 != 0 {
+		gi = math.Abs(sn) * (CarlsonRF(cn2, dn2, 1) +
+			(e.alpha2-e.k2)*sn2*CarlsonRJ(cn2, dn2, 1, cn2+e.alphap2*sn2)/3)
+	}
+	// Enforce usual trig-like symmetries
+	if math.Signbit(cn) {
+		gi = 2*e.G() - gi
+	}
+	return math.Copysign(gi, sn)
+}
+
+// HSnCnDn returns Cayley's geodesic longitude difference integral, H(phi, alpha2, k), in
+// terms of sn = sin(phi), cn = cos(phi), and dn = sqrt(1 - k2 sin^2(phi))
+func (e *EllipticFunction) HSnCnDn(sn, cn, dn float64) float64 {
+	cn2 := cn * cn
+	dn2 := dn * dn
+	sn2 := sn * sn
+	// WARNING: large cancellation if k2 = 1, alpha2 = 0, and phi near pi/2
+	hi := e.H()
+	if cn2 != 0 {
+		hi = math.Abs(sn) * (CarlsonRF(cn2, dn2, 1) -
+			e.alphap2*sn2*CarlsonRJ(cn2, dn2, 1, cn2+e.alphap2*sn2)/3)
+	}
+	// Enforce usual trig-like symmetries
+	if math.Signbit(cn) {
+		hi = 2*e.H() - hi
+	}
+	return math.Copysign(hi, sn)
+}
+
+// DeltaF returns the periodic part of the incomplete integral of the first kind,
+// F(phi, k) * (pi/2) / K(k) - phi, in terms of sn, cn, dn
+func (e *EllipticFunction) DeltaF(sn, cn, dn float64) float64 {
+	// Function is periodic with period pi
+	if math.Signbit(cn) {
+		cn = -cn
+		sn = -sn
+	}
+	return e.FSnCnDn(sn, cn, dn)*(math.Pi/2)/e.K() - math.Atan2(sn, cn)
+}
+
+// DeltaE returns the periodic part of the incomplete integral of the second kind,
+// E(phi, k) * (pi/2) / E(k) - phi, in terms of sn, cn, dn
+func (e *EllipticFunction) DeltaE(sn, cn, dn float64) float64 {
+	// Function is periodic with period pi
+	if math.Signbit(cn) {
+		cn = -cn
+		sn = -sn
+	}
+	return e.ESnCnDn(sn, cn, dn)*(math.Pi/2)/e.E() - math.Atan2(sn, cn)
+}
+
+// DeltaD returns the periodic part of Jahnke's incomplete integral,
+// D(phi, k) * (pi/2) / D(k) - phi, in terms of sn, cn, dn
+func (e *EllipticFunction) DeltaD(sn, cn, dn float64) float64 {
+	// Function is periodic with period pi
+	if math.Signbit(cn) {
+		cn = -cn
+		sn = -sn
+	}
+	return e.DSnCnDn(sn, cn, dn)*(math.Pi/2)/e.D() - math.Atan2(sn, cn)
+}
+
+// DeltaPi returns the periodic part of the incomplete integral of the third kind,
+// Pi(phi, alpha2, k) * (pi/2) / Pi(alpha2, k) - phi, in terms of sn, cn, dn
+func (e *EllipticFunction) DeltaPi(sn, cn, dn float64) float64 {
+	// Function is periodic with period pi
+	if math.Signbit(cn) {
+		cn = -cn
+		sn = -sn
+	}
+	return e.PiSnCnDn(sn, cn, dn)*(math.Pi/2)/e.Pi() - math.Atan2(sn, cn)
+}
+
+// DeltaG returns the periodic part of Legendre's geodesic longitude integral,
+// G(phi, alpha2, k) * (pi/2) / G(alpha2, k) - phi, in terms of sn, cn, dn
+func (e *EllipticFunction) DeltaG(sn, cn, dn float64) float64 {
+	// Function is periodic with period pi
+	if math.Signbit(cn) {
+		cn = -cn
+		sn = -sn
+	}
+	return e.GSnCnDn(sn, cn, dn)*(math.Pi/2)/e.G() - math.Atan2(sn, cn)
+}
+
+// DeltaH returns the periodic part of Cayley's geodesic longitude difference integral,
+// H(phi, alpha2, k) * (pi/2) / H(alpha2, k) - phi, in terms of sn, cn, dn
+func (e *EllipticFunction) DeltaH(sn, cn, dn float64) float64 {
+	// Function is periodic with period pi
+	if math.Signbit(cn) {
+		cn = -cn
+		sn = -sn
+	}
+	return e.HSnCnDn(sn, cn, dn)*(math.Pi/2)/e.H() - math.Atan2(sn, cn)
+}
+
+// F returns the incomplete integral of the first kind, F(phi, k), where phi is in
+// radians
+func (e *EllipticFunction) F(phi float64) float64 {
+	sn, cn := math.Sincos(phi)
+	dn := e.Delta(sn, cn)
+	if math.Abs(phi) < math.Pi {
+		return e.FSnCnDn(sn, cn, dn)
+	}
+	return (e.DeltaF(sn, cn, dn) + phi) * e.K() / (math.Pi / 2)
+}
+
+// EPhi returns the incomplete integral of the second kind, E(phi, k), where phi is in
+// radians
+func (e *EllipticFunction) EPhi(phi float64) float64 {
+	sn, cn := math.Sincos(phi)
+	dn := e.Delta(sn, cn)
+	if math.Abs(phi) < math.Pi {
+		return e.ESnCnDn(sn, cn, dn)
+	}
+	return (e.DeltaE(sn, cn, dn) + phi) * e.E() / (math.Pi / 2)
+}
+
+// EDeg returns the incomplete integral of the second kind, E(phi, k), where the angle
+// ang_deg = phi is in degrees. This is exact for multiples of 90 degrees.
+func (e *EllipticFunction) EDeg(ang_deg float64) float64 {
+	n := math.Ceil(ang_deg/360 - 0.5)
+	ang_deg -= 360 * n
+	sn, cn := sincosd(ang_deg)
+	return e.ESnCnDn(sn, cn, e.Delta(sn, cn)) + 4*e.E()*n
+}
+
+// DPhi returns Jahnke's incomplete integral, D(phi, k), where phi is in radians
+func (e *EllipticFunction) DPhi(phi float64) float64 {
+	sn, cn := math.Sincos(phi)
+	dn := e.Delta(sn, cn)
+	if math.Abs(phi) < math.Pi {
+		return e.DSnCnDn(sn, cn, dn)
+	}
+	return (e.DeltaD(sn, cn, dn) + phi) * e.D() / (math.Pi / 2)
+}
+
+// PiPhi returns the incomplete integral of the third kind, Pi(phi, alpha2, k), where
+// phi is in radians
+func (e *EllipticFunction) PiPhi(phi float64) float64 {
+	sn, cn := math.Sincos(phi)
+	dn := e.Delta(sn, cn)
+	if math.Abs(phi) < math.Pi {
+		return e.PiSnCnDn(sn, cn, dn)
+	}
+	return (e.DeltaPi(sn, cn, dn) + phi) * e.Pi() / (math.Pi / 2)
+}
+
+// GPhi returns Legendre's geodesic longitude integral, G(phi, alpha2, k), where phi is
+// in radians
+func (e *EllipticFunction) GPhi(phi float64) float64 {
+	sn, cn := math.Sincos(phi)
+	dn := e.Delta(sn, cn)
+	if math.Abs(phi) < math.Pi {
+		return e.GSnCnDn(sn, cn, dn)
+	}
+	return (e.DeltaG(sn, cn, dn) + phi) * e.G() / (math.Pi / 2)
+}
+
+// HPhi returns Cayley's geodesic longitude difference integral, H(phi, alpha2, k),
+// where phi is in radians
+func (e *EllipticFunction) HPhi(phi float64) float64 {
+	sn, cn := math.Sincos(phi)
+	dn := e.Delta(sn, cn)
+	if math.Abs(phi) < math.Pi {
+		return e.HSnCnDn(sn, cn, dn)
+	}
+	return (e.DeltaH(sn, cn, dn) + phi) * e.H() / (math.Pi / 2)
+}
+
+// Einv returns phi such that E(phi, k) = x, the inverse of the incomplete integral of
+// the second kind
+func (e *EllipticFunction) Einv(x float64) float64 {
+	tolJAC := math.Sqrt(get_epsilon() * 0.01)
+	n := math.Floor(x/(2*e.ec) + 0.5)
+	x -= 2 * e.ec * n // x now in [-ec, ec)
+	// Linear approximation
+	phi := math.Pi * x / (2 * e.ec) // phi in [-pi/2, pi/2)
+	// First order correction
+	phi -= e.eps * math.Sin(2*phi) / 2
+	// For kp2 close to zero use asin(x/ec) or
+	// J. P. Boyd, Applied Math. and Computation 218, 7005-7013 (2012)
+	// https://doi.org/10.1016/j.amc.2011.12.021
+	for i := 0; i < _ELLIPTIC_NUM; i++ {
+		sn, cn := math.Sincos(phi)
+		dn := e.Delta(sn, cn)
+		err := (e.ESnCnDn(sn, cn, dn) - x) / dn
+		phi -= err
+		if !(math.Abs(err) > tolJAC) {
+			break
+		}
+	}
+	return n*math.Pi + phi
+}
+
+// DeltaEinv returns the periodic part of the inverse of the incomplete integral of the
+// second kind, Einv(tau * E(k) / (pi/2)) - tau, in terms of stau = sin(tau) and
+// ctau = cos(tau)
+func (e *EllipticFunction) DeltaEinv(stau, ctau float64) float64 {
+	// Function is periodic with period pi
+	if math.Signbit(ctau) {
+		ctau = -ctau
+		stau = -stau
+	}
+	tau := math.Atan2(stau, ctau)
+	return e.Einv(tau*e.E()/(math.Pi/2)) - tau
+}
+
+// Sncndn returns the Jacobi elliptic functions sn(x, k), cn(x, k), and dn(x, k)
+func (e *EllipticFunction) Sncndn(x float64) (float64, float64, float64) {
+	// Bulirsch's sncndn routine, p 89.
+	tolJAC := math.Sqrt(get_epsilon() * 0.01)
+	var sn, cn, dn float64
+	if e.kp2 != 0 {
+		mc := e.kp2
+		d := 0.0
+		if math.Signbit(e.kp2) {
+			d = 1 - mc
+			mc /= -d
+			d = math.Sqrt(d)
+			x *= d
+		}
+		c := 0.0
+		var m, n [_ELLIPTIC_NUM]float64
+		l := 0
+		for a := 1.0; l < _ELLIPTIC_NUM; l++ {
+			// This converges quadratically.  Max 5 trips
+			m[l] = a
+			mc = math.Sqrt(mc)
+			n[l] = mc
+			c = (a + mc) / 2
+			if !(math.Abs(a-mc) > tolJAC*a) {
+				l++
+				break
+			}
+			mc *= a
+			a = c
+		}
+		x *= c
+		sn, cn = math.Sincos(x)
+		dn = 1
+		if sn != 0 {
+			a := cn / sn
+			c *= a
+			for l > 0 {
+				l--
+				b := m[l]
+				a *= c
+				c *= dn
+				dn = (n[l] + a) / (b + a)
+				a = c / b
+			}
+			a = 1 / math.Sqrt(c*c+1)
+			if math.Signbit(sn) {
+				sn = -a
+			} else {
+				sn = a
+			}
+			cn = c * sn
+			if math.Signbit(e.kp2) {
+				cn, dn = dn, cn
+				sn /= d
+			}
+		}
+	} else {
+		sn = math.Tanh(x)
+		cn = 1 / math.Cosh(x)
+		dn = cn
+	}
+	return sn, cn, dn
+}
+
+// CarlsonRF returns Carlson's symmetric integral of the first kind, RF(x, y, z)
+func CarlsonRF(x, y, z float64) float64 {
+	// Carlson, eqs 2.2 - 2.7
+	tolRF := math.Pow(3*get_epsilon()*0.01, 1/8.0)
+	A0 := (x + y + z) / 3
+	An := A0
+	Q := math.Max(math.Max(math.Abs(A0-x), math.Abs(A0-y)), math.Abs(A0-z)) / tolRF
+	x0 := x
+	y0 := y
+	z0 := z
+	mul := 1.0
+	for Q >= mul*math.Abs(An) {
+		// Max 6 trips
+		lam := math.Sqrt(x0)*math.Sqrt(y0) + math.Sqrt(y0)*math.Sqrt(z0) + math.Sqrt(z0)*math.Sqrt(x0)
+		An = (An + lam) / 4
+		x0 = (x0 + lam) / 4
+		y0 = (y0 + lam) / 4
+		z0 = (z0 + lam) / 4
+		mul *= 4
+	}
+	X := (A0 - x) / (mul * An)
+	Y := (A0 - y) / (mul * An)
+	Z := -(X + Y)
+	E2 := X*Y - Z*Z
+	E3 := X * Y * Z
+	// https://dlmf.nist.gov/19.36.E1
+	// Polynomial is
+	// (1 - E2/10 + E3/14 + E2^2/24 - 3*E2*E3/44
+	//    - 5*E2^3/208 + 3*E3^2/104 + E2^2*E3/16)
+	// convert to Horner form...
+	return (E3*(6930*E3+E2*(15015*E2-16380)+17160) +
+		E2*((10010-5775*E2)*E2-24024) + 240240) /
+		(240240 * math.Sqrt(An))
+}
+
+// CarlsonRF0 returns Carlson's complete symmetric integral of the first kind,
+// RF(0, x, y)
+func CarlsonRF0(x, y float64) float64 {
+	// Carlson, eqs 2.36 - 2.38
+	tolRG0 := 2.7 * math.Sqrt(get_epsilon()*0.01)
+	xn := math.Sqrt(x)
+	yn := math.Sqrt(y)
+	if xn < yn {
+		xn, yn = yn, xn
+	}
+	for math.Abs(xn-yn) > tolRG0*xn {
+		// Max 4 trips
+		t := (xn + yn) / 2
+		yn = math.Sqrt(xn * yn)
+		xn = t
+	}
+	return math.Pi / (xn + yn)
+}
+
+// CarlsonRC returns Carlson's degenerate symmetric integral, RC(x, y) = RF(x, y, y)
+func CarlsonRC(x, y float64) float64 {
+	// Defined only for y != 0 and x >= 0.
+	if !(x >= y) { // x < y  and catch nans
+		// https://dlmf.nist.gov/19.2.E18
+		return math.Atan(math.Sqrt((y-x)/x)) / math.Sqrt(y-x)
+	}
+	if x == y {
+		return 1 / math.Sqrt(y)
+	}
+	var arg float64
+	if y > 0 {
+		// https://dlmf.nist.gov/19.2.E19
+		// atanh(sqrt((x - y) / x))
+		arg = math.Sqrt((x - y) / y)
+	} else {
+		// https://dlmf.nist.gov/19.2.E20
+		// atanh(sqrt(x / (x - y)))
+		arg = math.Sqrt(-x / y)
+	}
+	return math.Asinh(arg) / math.Sqrt(x-y)
+}
+
+// CarlsonRG returns Carlson's symmetric integral of the second kind, RG(x, y, z)
+func CarlsonRG(x, y, z float64) float64 {
+	switch {
+	case x == 0:
+		return CarlsonRG0(y, z)
+	case y == 0:
+		return CarlsonRG0(z, x)
+	case z == 0:
+		return CarlsonRG0(x, y)
+	}
+	// Carlson, eq 1.7
+	return (z*CarlsonRF(x, y, z) - (x-z)*(y-z)*CarlsonRD(x, y, z)/3 + math.Sqrt(x*y/z)) / 2
+}
+
+// CarlsonRG0 returns Carlson's complete symmetric integral of the second kind,
+// RG(0, x, y)
+func CarlsonRG0(x, y float64) float64 {
+	// Carlson, eqs 2.36 - 2.39
+	tolRG0 := 2.7 * math.Sqrt(get_epsilon()*0.01)
+	x0 := math.Sqrt(math.Max(x, y))
+	y0 := math.Sqrt(math.Min(x, y))
+	xn := x0
+	yn := y0
+	s := 0.0
+	mul := 0.25
+	for math.Abs(xn-yn) > tolRG0*xn {
+		// Max 4 trips
+		t := (xn + yn) / 2
+		yn = math.Sqrt(xn * yn)
+		xn = t
+		mul *= 2
+		t = xn - yn
+		s += mul * t * t
+	}
+	return (sq((x0+y0)/2) - s) * math.Pi / (2 * (xn + yn))
+}
+
+// CarlsonRJ returns Carlson's symmetric integral of the third kind, RJ(x, y, z, p)
+func CarlsonRJ(x, y, z, p float64) float64 {
+	// Carlson, eqs 2.17 - 2.25
+	tolRD := math.Pow(0.2*(get_epsilon()*0.01), 1/8.0)
+	A0 := (x + y + z + 2*p) / 5
+	An := A0
+	delta := (p - x) * (p - y) * (p - z)
+	Q := math.Max(
+		math.Max(math.Abs(A0-x), math.Abs(A0-y)),
+		math.Max(math.Abs(A0-z), math.Abs(A0-p)),
+	) / tolRD
+	x0 := x
+	y0 := y
+	z0 := z
+	p0 := p
+	mul := 1.0
+	mul3 := 1.0
+	s := 0.0
+	for Q >= mul*math.Abs(An) {
+		// Max 7 trips
+		lam := math.Sqrt(x0)*math.Sqrt(y0) + math.Sqrt(y0)*math.Sqrt(z0) + math.Sqrt(z0)*math.Sqrt(x0)
+		d0 := (math.Sqrt(p0) + math.Sqrt(x0)) * (math.Sqrt(p0) + math.Sqrt(y0)) * (math.Sqrt(p0) + math.Sqrt(z0))
+		e0 := delta / (mul3 * sq(d0))
+		s += CarlsonRC(1, 1+e0) / (mul * d0)
+		An = (An + lam) / 4
+		x0 = (x0 + lam) / 4
+		y0 = (y0 + lam) / 4
+		z0 = (z0 + lam) / 4
+		p0 = (p0 + lam) / 4
+		mul *= 4
+		mul3 *= 64
+	}
+	X := (A0 - x) / (mul * An)
+	Y := (A0 - y) / (mul * An)
+	Z := (A0 - z) / (mul * An)
+	P := -(X + Y + Z) / 2
+	E2 := X*Y + X*Z + Y*Z - 3*P*P
+	E3 := X*Y*Z + 2*P*(E2+2*P*P)
+	E4 := (2*X*Y*Z + P*(E2+3*P*P)) * P
+	E5 := X * Y * Z * P * P
+	// https://dlmf.nist.gov/19.36.E2
+	// Polynomial is
+	// (1 - 3*E2/14 + E3/6 + 9*E2^2/88 - 3*E4/22 - 9*E2*E3/52 + 3*E5/26
+	//    - E2^3/16 + 3*E3^2/40 + 3*E2*E4/20 + 45*E2^2*E3/272
+	//    - 9*(E3*E4+E2*E5)/68)
+	return ((471240-540540*E2)*E5+
+		(612612*E2-540540*E3-556920)*E4+
+		E3*(306306*E3+E2*(675675*E2-706860)+680680)+
+		E2*((417690-255255*E2)*E2-875160)+4084080)/
+		(4084080*mul*An*math.Sqrt(An)) + 6*s
+}
+
+// CarlsonRD returns Carlson's degenerate symmetric integral of the third kind,
+// RD(x, y, z) = RJ(x, y, z, z)
+func CarlsonRD(x, y, z float64) float64 {
+	// Carlson, eqs 2.28 - 2.34
+	tolRD := math.Pow(0.2*(get_epsilon()*0.01), 1/8.0)
+	A0 := (x + y + 3*z) / 5
+	An := A0
+	Q := math.Max(math.Max(math.Abs(A0-x), math.Abs(A0-y)), math.Abs(A0-z)) / tolRD
+	x0 := x
+	y0 := y
+	z0 := z
+	mul := 1.0
+	s := 0.0
+	for Q >= mul*math.Abs(An) {
+		// Max 7 trips
+		lam := math.Sqrt(x0)*math.Sqrt(y0) + math.Sqrt(y0)*math.Sqrt(z0) + math.Sqrt(z0)*math.Sqrt(x0)
+		s += 1 / (mul * math.Sqrt(z0) * (z0 + lam))
+		An = (An + lam) / 4
+		x0 = (x0 + lam) / 4
+		y0 = (y0 + lam) / 4
+		z0 = (z0 + lam) / 4
+		mul *= 4
+	}
+	X := (A0 - x) / (mul * An)
+	Y := (A0 - y) / (mul * An)
+	Z := -(X + Y) / 3
+	E2 := X*Y - 6*Z*Z
+	E3 := (3*X*Y - 8*Z*Z) * Z
+	E4 := 3 * (X*Y - Z*Z) * Z * Z
+	E5 := X * Y * Z * Z * Z
+	// https://dlmf.nist.gov/19.36.E2
+	// Polynomial is
+	// (1 - 3*E2/14 + E3/6 + 9*E2^2/88 - 3*E4/22 - 9*E2*E3/52 + 3*E5/26
+	//    - E2^3/16 + 3*E3^2/40 + 3*E2*E4/20 + 45*E2^2*E3/272
+	//    - 9*(E3*E4+E2*E5)/68)
+	return ((471240-540540*E2)*E5+
+		(612612*E2-540540*E3-556920)*E4+
+		E3*(306306*E3+E2*(675675*E2-706860)+680680)+
+		E2*((417690-255255*E2)*E2-875160)+4084080)/
+		(4084080*mul*An*math.Sqrt(An)) + 3*s
+}
@@ -0,0 +1,131 @@
+package geographiclibgo
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCarlson(t *testing.T) {
+	// Test values from Carlson (1995), Numerical computation of real or complex
+	// elliptic integrals, Tables 1-3
+	testCases := []struct {
+		desc string
+		got  float64
+		want float64
+	}{
+		{desc: "RF(1, 2, 0)", got: CarlsonRF(1, 2, 0), want: 1.3110287771461},
+		{desc: "RF0(1, 2)", got: CarlsonRF0(1, 2), want: 1.3110287771461},
+		{desc: "RF(0.5, 1, 0)", got: CarlsonRF(0.5, 1, 0), want: 1.8540746773014},
+		{desc: "RF(2, 3, 4)", got: CarlsonRF(2, 3, 4), want: 0.58408284167715},
+		{desc: "RC(0, 1/4)", got: CarlsonRC(0, 0.25), want: math.Pi},
+		{desc: "RC(9/4, 2)", got: CarlsonRC(2.25, 2), want: math.Log(2)},
+		{desc: "RJ(0, 1, 2, 3)", got: CarlsonRJ(0, 1, 2, 3), want: 0.77688623778582},
+		{desc: "RJ(2, 3, 4, 5)", got: CarlsonRJ(2, 3, 4, 5), want: 0.14297579667157},
+		{desc: "RD(0, 2, 1)", got: CarlsonRD(0, 2, 1), want: 1.7972103521034},
+		{desc: "RD(2, 3, 4)", got: CarlsonRD(2, 3, 4), want: 0.16510527294261},
+		{desc: "RG(0, 16, 16)", got: CarlsonRG(0, 16, 16), want: math.Pi},
+		{desc: "RG(2, 3, 4)", got: CarlsonRG(2, 3, 4), want: 1.7255030280692},
+		{desc: "RG0(16, 16)", got: CarlsonRG0(16, 16), want: math.Pi},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			if !almost_equal(tC.got, tC.want, 1e-13) {
+				t.Errorf("%v = %v; want %v", tC.desc, tC.got, tC.want)
+			}
+		})
+	}
+}
+
+func BenchmarkCarlsonRF(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		CarlsonRF(2, 3, 4)
+	}
+}
+
+func TestEllipticFunctionComplete(t *testing.T) {
+	// Values from Abramowitz and Stegun, Table 17.1, with m = k2 = 0.5
+	e := NewEllipticFunction(0.5, 0)
+	if !almost_equal(e.K(), 1.854074677301372, 1e-14) {
+		t.Errorf("K() = %v; want %v", e.K(), 1.854074677301372)
+	}
+	if !almost_equal(e.E(), 1.350643881047675, 1e-14) {
+		t.Errorf("E() = %v; want %v", e.E(), 1.350643881047675)
+	}
+	// Legendre's relation, E K' + E' K - K K' = pi/2
+	ep := NewEllipticFunction(e.Kp2(), 0)
+	legendre := e.E()*ep.K() + ep.E()*e.K() - e.K()*ep.K()
+	if !almost_equal(legendre, math.Pi/2, 1e-14) {
+		t.Errorf("Legendre relation = %v; want %v", legendre, math.Pi/2)
+	}
+
+	// For k2 = 0 and alpha2 = 0 the integrals of all three kinds reduce to pi/2
+	z := NewEllipticFunction(0, 0)
+	for _, v := range []float64{z.K(), z.E(), z.Pi()} {
+		if !almost_equal(v, math.Pi/2, 1e-15) {
+			t.Errorf("complete integral with k2 = 0 = %v; want %v", v, math.Pi/2)
+		}
+	}
+}
+
+func TestEllipticFunctionIncomplete(t *testing.T) {
+	for _, k2 := range []float64{-3, -0.3, 0, 0.4, 0.9} {
+		e := NewEllipticFunction(k2, -0.2)
+
+		// The incomplete integrals at phi = pi/2 are the complete integrals
+		pairs := [][2]float64{
+			{e.F(math.Pi / 2), e.K()},
+			{e.EPhi(math.Pi / 2), e.E()},
+			{e.DPhi(math.Pi / 2), e.D()},
+			{e.PiPhi(math.Pi / 2), e.Pi()},
+			{e.GPhi(math.Pi / 2), e.G()},
+			{e.HPhi(math.Pi / 2), e.H()},
+			{e.EDeg(90), e.E()},
+		}
+		for _, p := range pairs {
+			if !almost_equal(p[0], p[1], 1e-14) {
+				t.Errorf("k2 = %v -- incomplete(pi/2) = %v; want %v", k2, p[0], p[1])
+			}
+		}
+
+		// Compare against numerical quadrature of the integrand of E(phi, k)
+		phi := 1.1
+		n := 2000
+		h := phi / float64(n)
+		quad := 0.0
+		for i := 0; i < n; i++ {
+			s := math.Sin((float64(i) + 0.5) * h)
+			quad += math.Sqrt(1-k2*s*s) * h
+		}
+		if !almost_equal(e.EPhi(phi), quad, 1e-6) {
+			t.Errorf("k2 = %v -- EPhi(%v) = %v; want %v", k2, phi, e.EPhi(phi), quad)
+		}
+
+		// Einv inverts E
+		x := e.EPhi(phi)
+		if !almost_equal(e.Einv(x), phi, 1e-14) {
+			t.Errorf("k2 = %v -- Einv(EPhi(%v)) = %v; want %v", k2, phi, e.Einv(x), phi)
+		}
+	}
+}
+
+func TestEllipticFunctionSncndn(t *testing.T) {
+	e := NewEllipticFunction(0.7, 0)
+	for _, phi := range []float64{-2.5, -0.3, 0, 0.8, 1.5, 4} {
+		sn, cn, dn := e.Sncndn(e.F(phi))
+		if !almost_equal(sn, math.Sin(phi), 1e-14) {
+			t.Errorf("sn(F(%v)) = %v; want %v", phi, sn, math.Sin(phi))
+		}
+		if !almost_equal(cn, math.Cos(phi), 1e-14) {
+			t.Errorf("cn(F(%v)) = %v; want %v", phi, cn, math.Cos(phi))
+		}
+		if !almost_equal(dn, e.Delta(math.Sin(phi), math.Cos(phi)), 1e-14) {
+			t.Errorf("dn(F(%v)) = %v; want %v", phi, dn, e.Delta(math.Sin(phi), math.Cos(phi)))
+		}
+	}
+}
+
+func BenchmarkNewEllipticFunction(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		NewEllipticFunction(0.7, -0.2)
+	}
+}
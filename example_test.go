@@ -62,3 +62,22 @@ func Example() {
 	// Wyoming perimeter is approximately 2028472 m
 	// Olympus Mons to Curiosity landing site is 5348380 m
 }
+
+func ExampleLookupEllipsoidByName() {
+	// The IAU reference ellipsoid for Mars is in the catalogue of reference ellipsoids
+	mars_ellipsoid, err := geodesic.LookupEllipsoidByName("Mars")
+	if err != nil {
+		panic(err)
+	}
+	fmt.Printf("%s: a = %0.0f m, b = %0.0f m\n", mars_ellipsoid.Name, mars_ellipsoid.A, mars_ellipsoid.MinorRadius())
+	mars := mars_ellipsoid.Geodesic()
+
+	// What is the distance from Olympus Mons (18.65, -133.8) to the Curiosity Rover's
+	// landing site (-4.47, 137.42)?
+	mars_distance := mars.InverseCalcDistance(18.65, -133.8, -4.47, 137.42)
+	fmt.Printf("Olympus Mons to Curiosity landing site is %0.0f m", mars_distance)
+
+	// Output:
+	// Mars: a = 3396190 m, b = 3376200 m
+	// Olympus Mons to Curiosity landing site is 5347910 m
+}
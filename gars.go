@@ -0,0 +1,171 @@
+package geographiclibgo
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Constants for GARS
+const (
+	_GARS_LONORIG int = -180
+	_GARS_LATORIG int = -90
+	_GARS_BASELON int = 10
+	_GARS_BASELAT int = 24
+	_GARS_LONLEN  int = 3
+	_GARS_LATLEN  int = 2
+	_GARS_BASELEN int = _GARS_LONLEN + _GARS_LATLEN
+	_GARS_MULT1   int = 2 // 30 minute cells
+	_GARS_MULT2   int = 2 // 15 minute quadrants
+	_GARS_MULT3   int = 3 // 5 minute keypads
+	_GARS_M       int = _GARS_MULT1 * _GARS_MULT2 * _GARS_MULT3
+	_GARS_MAXPREC int = 2
+	_GARS_MAXLEN  int = _GARS_BASELEN + _GARS_MAXPREC
+	_GARS_DIGITS      = "0123456789"
+	_GARS_LETTERS     = "ABCDEFGHJKLMNPQRSTUVWXYZ"
+)
+
+// GARSForward converts the point lat_deg, lon_deg to a Global Area Reference System
+// string, see https://en.wikipedia.org/wiki/Global_Area_Reference_System. The precision
+// prec in [0, 2] selects the size of the cell:
+//   - prec = 0 - 30 minute cell, e.g. 381NH
+//   - prec = 1 - 15 minute quadrant, e.g. 381NH3
+//   - prec = 2 - 5 minute keypad, e.g. 381NH35
+//
+// This returns an error if lat_deg is not in [-90, 90], and "INVALID" if lat_deg or
+// lon_deg is NaN.
+func GARSForward(lat_deg, lon_deg float64, prec int) (string, error) {
+	if math.Abs(lat_deg) > 90 {
+		return "", fmt.Errorf("latitude %vd not in [-90d, 90d]", lat_deg)
+	}
+	if math.IsNaN(lat_deg) || math.IsNaN(lon_deg) {
+		return "INVALID", nil
+	}
+	lon_deg = ang_normalize(lon_deg)
+	// lon_deg is now in [-180, 180)
+	if lon_deg == 180 {
+		lon_deg = -180
+	}
+	if lat_deg == 90 {
+		lat_deg *= 1 - get_epsilon()/2
+	}
+	if prec < 0 {
+		prec = 0
+	} else if prec > _GARS_MAXPREC {
+		prec = _GARS_MAXPREC
+	}
+	x := int(math.Floor(lon_deg*float64(_GARS_M))) - _GARS_LONORIG*_GARS_M
+	y := int(math.Floor(lat_deg*float64(_GARS_M))) - _GARS_LATORIG*_GARS_M
+	ilon := x * _GARS_MULT1 / _GARS_M
+	ilat := y * _GARS_MULT1 / _GARS_M
+	x -= ilon * _GARS_M / _GARS_MULT1
+	y -= ilat * _GARS_M / _GARS_MULT1
+	gars := make([]byte, _GARS_BASELEN+prec)
+	ilon++
+	for c := 0; c < _GARS_LONLEN; c++ {
+		gars[_GARS_LONLEN-c-1] = _GARS_DIGITS[ilon%_GARS_BASELON]
+		ilon /= _GARS_BASELON
+	}
+	for c := 0; c < _GARS_LATLEN; c++ {
+		gars[_GARS_LONLEN+_GARS_LATLEN-c-1] = _GARS_LETTERS[ilat%_GARS_BASELAT]
+		ilat /= _GARS_BASELAT
+	}
+	if prec > 0 {
+		ilon, ilat = x/_GARS_MULT3, y/_GARS_MULT3
+		gars[_GARS_BASELEN] = _GARS_DIGITS[_GARS_MULT2*(_GARS_MULT2-1-ilat)+ilon+1]
+		if prec > 1 {
+			ilon, ilat = x%_GARS_MULT3, y%_GARS_MULT3
+			gars[_GARS_BASELEN+1] = _GARS_DIGITS[_GARS_MULT3*(_GARS_MULT3-1-ilat)+ilon+1]
+		}
+	}
+	return string(gars), nil
+}
+
+// GARSReverse decodes a GARS string, which may be in upper or lower case, to its cell.
+// Strings starting with "INV" (e.g. the "INVALID" returned by GARSForward) give an
+// invalid cell.
+func GARSReverse(gars string) (GridCell, error) {
+	length := len(gars)
+	if length >= 3 && strings.ToUpper(gars[:3]) == "INV" {
+		return invalid_grid_cell(), nil
+	}
+	if length < _GARS_BASELEN {
+		return GridCell{}, fmt.Errorf("GARS must have at least 5 characters %s", gars)
+	}
+	if length > _GARS_MAXLEN {
+		return GridCell{}, fmt.Errorf("GARS can have at most 7 characters %s", gars)
+	}
+	prec := length - _GARS_BASELEN
+	ilon := 0
+	for c := 0; c < _GARS_LONLEN; c++ {
+		k := strings.IndexByte(_GARS_DIGITS, gars[c])
+		if k < 0 {
+			return GridCell{}, fmt.Errorf("GARS must start with 3 digits %s", gars)
+		}
+		ilon = ilon*_GARS_BASELON + k
+	}
+	if !(ilon >= 1 && ilon <= 720) {
+		return GridCell{}, fmt.Errorf("initial digits in GARS must lie in [1, 720] %s", gars)
+	}
+	ilon--
+	ilat := 0
+	for c := 0; c < _GARS_LATLEN; c++ {
+		k := mgrs_lookup(_GARS_LETTERS, gars[_GARS_LONLEN+c])
+		if k < 0 {
+			return GridCell{}, fmt.Errorf("illegal letters in GARS %s", gars[3:5])
+		}
+		ilat = ilat*_GARS_BASELAT + k
+	}
+	if !(ilat < 360) {
+		return GridCell{}, fmt.Errorf("GARS letters must lie in [AA, QZ] %s", gars)
+	}
+	unit := _GARS_MULT1
+	lat1 := ilat + _GARS_LATORIG*unit
+	lon1 := ilon + _GARS_LONORIG*unit
+	if prec > 0 {
+		k := strings.IndexByte(_GARS_DIGITS, gars[_GARS_BASELEN])
+		if !(k >= 1 && k <= _GARS_MULT2*_GARS_MULT2) {
+			return GridCell{}, fmt.Errorf("6th character in GARS must be in [1, 4] %s", gars)
+		}
+		k--
+		unit *= _GARS_MULT2
+		lat1 = _GARS_MULT2*lat1 + (_GARS_MULT2 - 1 - k/_GARS_MULT2)
+		lon1 = _GARS_MULT2*lon1 + k%_GARS_MULT2
+		if prec > 1 {
+			k = strings.IndexByte(_GARS_DIGITS, gars[_GARS_BASELEN+1])
+			if !(k >= 1) {
+				return GridCell{}, fmt.Errorf("7th character in GARS must be in [1, 9] %s", gars)
+			}
+			k--
+			unit *= _GARS_MULT3
+			lat1 = _GARS_MULT3*lat1 + (_GARS_MULT3 - 1 - k/_GARS_MULT3)
+			lon1 = _GARS_MULT3*lon1 + k%_GARS_MULT3
+		}
+	}
+	res := 1 / float64(unit)
+	return new_grid_cell(float64(lat1)/float64(unit), float64(lon1)/float64(unit), res, res, prec), nil
+}
+
+// GARSResolution returns the size of the cells [degrees] of a GARS string with
+// precision prec
+func GARSResolution(prec int) float64 {
+	switch {
+	case prec <= 0:
+		return 1 / float64(_GARS_MULT1)
+	case prec == 1:
+		return 1 / float64(_GARS_MULT1*_GARS_MULT2)
+	}
+	return 1 / float64(_GARS_M)
+}
+
+// GARSPrecision returns the smallest precision of a GARS string whose cells are no
+// larger than res_deg
+func GARSPrecision(res_deg float64) int {
+	res_deg = math.Abs(res_deg)
+	for prec := 0; prec < _GARS_MAXPREC; prec++ {
+		if GARSResolution(prec) <= res_deg {
+			return prec
+		}
+	}
+	return _GARS_MAXPREC
+}
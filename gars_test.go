@@ -0,0 +1,115 @@
+package geographiclibgo
+
+import (
+	"math"
+	"testing"
+)
+
+func TestGARSForward(t *testing.T) {
+	testCases := []struct {
+		desc string
+		lat  float64
+		lon  float64
+		prec int
+		want string
+	}{
+		{"30 minute cell", 38.8895, -77.0352, 0, "206LT"},
+		{"15 minute quadrant", 38.8895, -77.0352, 1, "206LT2"},
+		{"5 minute keypad", 38.8895, -77.0352, 2, "206LT26"},
+		{"precision capped", 38.8895, -77.0352, 5, "206LT26"},
+		{"negative precision", 38.8895, -77.0352, -1, "206LT"},
+		{"south west", -90, -180, 2, "001AA37"},
+		{"north east", 90, 179.99, 2, "720QZ23"},
+		{"antimeridian", 0, 180, 0, "001HN"},
+		{"nan", 0, math.NaN(), 2, "INVALID"},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			got, err := GARSForward(tC.lat, tC.lon, tC.prec)
+			if err != nil {
+				t.Fatalf("GARSForward() error = %v", err)
+			}
+			if got != tC.want {
+				t.Errorf("GARSForward(%v, %v, %v) = %q; want %q", tC.lat, tC.lon, tC.prec, got, tC.want)
+			}
+		})
+	}
+	if _, err := GARSForward(-90.5, 0, 0); err == nil {
+		t.Errorf("GARSForward(-90.5, 0, 0) succeeded; want error")
+	}
+}
+
+func TestGARSReverse(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		gars   string
+		center LatLon
+		res    float64
+		prec   int
+	}{
+		{"cell", "206LT", LatLon{38.75, -77.25}, 0.5, 0},
+		{"quadrant", "206lt2", LatLon{38.875, -77.125}, 0.25, 1},
+		{"keypad", "206LT26", LatLon{38.875, -77.25 + 5.0/24}, 1.0 / 12, 2},
+		{"first cell", "001AA", LatLon{-89.75, -179.75}, 0.5, 0},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			c, err := GARSReverse(tC.gars)
+			if err != nil {
+				t.Fatalf("GARSReverse() error = %v", err)
+			}
+			if !almost_equal(c.Center.LatDeg, tC.center.LatDeg, 1e-13) ||
+				!almost_equal(c.Center.LonDeg, tC.center.LonDeg, 1e-13) ||
+				c.LatResolutionDeg != tC.res || c.LonResolutionDeg != tC.res || c.Prec != tC.prec {
+				t.Errorf("GARSReverse(%q) = %+v; want %v, %v, %v", tC.gars, c, tC.center, tC.res, tC.prec)
+			}
+		})
+	}
+	if c, err := GARSReverse("INVALID"); err != nil || !math.IsNaN(c.Center.LonDeg) || c.Prec != -2 {
+		t.Errorf("GARSReverse(INVALID) = %+v, %v; want an invalid cell", c, err)
+	}
+	for _, s := range []string{"206L", "206LT261", "2x6LT", "000AA", "721AA", "206LI", "001RA", "206LT5", "206LT20"} {
+		if c, err := GARSReverse(s); err == nil {
+			t.Errorf("GARSReverse(%q) = %+v; want error", s, c)
+		}
+	}
+}
+
+func TestGARSRoundTrip(t *testing.T) {
+	for lat := -89.95; lat < 90; lat += 7.3 {
+		for lon := -179.9; lon < 180; lon += 11.9 {
+			for prec := 0; prec <= 2; prec++ {
+				s, err := GARSForward(lat, lon, prec)
+				if err != nil {
+					t.Fatalf("GARSForward() error = %v", err)
+				}
+				c, err := GARSReverse(s)
+				if err != nil {
+					t.Fatalf("GARSReverse(%q) error = %v", s, err)
+				}
+				if c.Prec != prec || c.LatResolutionDeg != GARSResolution(prec) ||
+					math.Abs(lat-c.Center.LatDeg) > c.LatResolutionDeg/2 ||
+					math.Abs(lon-c.Center.LonDeg) > c.LonResolutionDeg/2 {
+					t.Errorf("GARSReverse(%q) = %+v; want a cell containing %v, %v", s, c, lat, lon)
+				}
+				if s2, _ := GARSForward(c.Center.LatDeg, c.Center.LonDeg, prec); s2 != s {
+					t.Errorf("GARSForward(center of %q) = %q", s, s2)
+				}
+			}
+		}
+	}
+}
+
+func TestGARSPrecision(t *testing.T) {
+	for prec := 0; prec <= 2; prec++ {
+		if got := GARSPrecision(GARSResolution(prec)); got != prec {
+			t.Errorf("GARSPrecision(GARSResolution(%d)) = %d", prec, got)
+		}
+	}
+	if got := GARSPrecision(0.2); got != 2 {
+		t.Errorf("GARSPrecision(0.2) = %d; want 2", got)
+	}
+	if got := GARSPrecision(10); got != 0 {
+		t.Errorf("GARSPrecision(10) = %d; want 0", got)
+	}
+}
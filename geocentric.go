@@ -0,0 +1,270 @@
+package geographiclibgo
+
+import "math"
+
+// Cartesian is a point in a Cartesian coordinate system. For Geocentric, these are
+// earth-centered earth-fixed (ECEF) coordinates; for LocalCartesian, they are east,
+// north, and up.
+type Cartesian struct {
+	XM float64 // X coordinate [meters]
+	YM float64 // Y coordinate [meters]
+	ZM float64 // Z coordinate [meters]
+}
+
+// GeodeticPosition is a point given by its latitude, longitude, and height above the
+// ellipsoid
+type GeodeticPosition struct {
+	LatDeg  float64 // Latitude [degrees]
+	LonDeg  float64 // Longitude [degrees]
+	HeightM float64 // Height above the ellipsoid [meters]
+}
+
+// RotationMatrix is a 3x3 rotation matrix stored in row-major order. For Geocentric, it
+// converts a vector in the local east, north, up frame at a point to geocentric
+// coordinates: v_geocentric = M . v_enu. The columns are the east, north, and up unit
+// vectors in geocentric coordinates.
+type RotationMatrix [9]float64
+
+// Geocentric converts between geodetic coordinates (latitude, longitude, height) and
+// geocentric (ECEF) coordinates. The origin is at the center of the ellipsoid, the Z axis
+// is the axis of rotation pointing north, the X axis passes through latitude 0,
+// longitude 0, and the Y axis through latitude 0, longitude 90E.
+//
+// The conversion from geocentric to geodetic coordinates uses the closed-form solution of
+// H. Vermeille, Direct transformation from geocentric coordinates to geodetic coordinates,
+// J. Geodesy 76, 451-454 (2002), extended to handle the equatorial plane and prolate
+// ellipsoids. The error is less than 5 nm (for points within 10000 km of the surface of
+// the WGS84 ellipsoid).
+type Geocentric struct {
+	a      float64
+	f      float64
+	e2     float64
+	e2m    float64
+	e2a    float64
+	e4a    float64
+	maxrad float64
+}
+
+// NewGeocentric creates a Geocentric for the ellipsoid with equatorial radius a [meters]
+// and flattening f
+func NewGeocentric(a, f float64) Geocentric {
+	e2 := f * (2 - f)
+	return Geocentric{
+		a:      a,
+		f:      f,
+		e2:     e2,
+		e2m:    sq(1 - f),
+		e2a:    math.Abs(e2),
+		e4a:    sq(e2),
+		maxrad: 2 * a / get_epsilon(),
+	}
+}
+
+// Wgs84Geocentric is a convenience function that creates a Geocentric for the WGS84
+// ellipsoid
+func Wgs84Geocentric() Geocentric {
+	return NewGeocentric(WGS84_A, WGS84_F)
+}
+
+// EquatorialRadius returns the equatorial radius of the ellipsoid [meters]
+func (g *Geocentric) EquatorialRadius() float64 {
+	return g.a
+}
+
+// Flattening returns the flattening of the ellipsoid
+func (g *Geocentric) Flattening() float64 {
+	return g.f
+}
+
+// Forward converts the geodetic coordinates lat_deg, lon_deg, h_m to geocentric
+// coordinates
+//   - lat_deg - Latitude [degrees] [-90.,90.]
+//   - lon_deg - Longitude [degrees]
+//   - h_m - Height above the ellipsoid [meters]
+func (g *Geocentric) Forward(lat_deg, lon_deg, h_m float64) Cartesian {
+	c, _ := g._int_forward(lat_deg, lon_deg, h_m, false)
+	return c
+}
+
+// ForwardWithRotation is Forward, also returning the rotation matrix from the local east,
+// north, up frame at the point to geocentric coordinates
+func (g *Geocentric) ForwardWithRotation(lat_deg, lon_deg, h_m float64) (Cartesian, RotationMatrix) {
+	return g._int_forward(lat_deg, lon_deg, h_m, true)
+}
+
+// Reverse converts the geocentric coordinates x_m, y_m, z_m to geodetic coordinates. Of
+// the possible solutions, the one with the largest height is returned; the longitude of
+// a point on the Z axis is 0, and the origin maps to the north pole with
+// h = -b (or -a for a prolate ellipsoid).
+func (g *Geocentric) Reverse(x_m, y_m, z_m float64) GeodeticPosition {
+	p, _ := g._int_reverse(x_m, y_m, z_m, false)
+	return p
+}
+
+// ReverseWithRotation is Reverse, also returning the rotation matrix from the local east,
+// north, up frame at the point to geocentric coordinates
+func (g *Geocentric) ReverseWithRotation(x_m, y_m, z_m float64) (GeodeticPosition, RotationMatrix) {
+	return g._int_reverse(x_m, y_m, z_m, true)
+}
+
+func (g *Geocentric) _int_forward(lat, lon, h float64, wantm bool) (Cartesian, RotationMatrix) {
+	sphi, cphi := sincosd(lat_fix(lat))
+	slam, clam := sincosd(lon)
+	n := g.a / math.Sqrt(1-g.e2*sq(sphi))
+	z := (g.e2m*n + h) * sphi
+	x := (n + h) * cphi
+	y := x * slam
+	x *= clam
+	var m RotationMatrix
+	if wantm {
+		m = geocentric_rotation(sphi, cphi, slam, clam)
+	}
+	return Cartesian{XM: x, YM: y, ZM: z}, m
+}
+
+func (g *Geocentric) _int_reverse(x, y, z float64, wantm bool) (GeodeticPosition, RotationMatrix) {
+	rR := math.Hypot(x, y)
+	slam, clam := 0.0, 1.0
+	if rR != 0 {
+		slam, clam = y/rR, x/rR
+	}
+	h := math.Hypot(rR, z) // Distance to center of earth
+	var sphi, cphi float64
+	if h > g.maxrad {
+		// We are really far away (> 12 million light years); treat the earth as a point
+		// and h, above, is an acceptable approximation to the height. This avoids
+		// overflow, e.g., in the computation of disc below. It's possible that h has
+		// overflowed to inf; but that's OK.
+		//
+		// Treat the case x, y finite, but R overflows to +inf by scaling by 2.
+		rR = math.Hypot(x/2, y/2)
+		slam, clam = 0.0, 1.0
+		if rR != 0 {
+			slam, clam = (y/2)/rR, (x/2)/rR
+		}
+		hH := math.Hypot(z/2, rR)
+		sphi = (z / 2) / hH
+		cphi = rR / hH
+	} else if g.e4a == 0 {
+		// Treat the spherical case. Dealing with underflow in the general case with
+		// e2 = 0 is difficult. The origin maps to the north pole, as with the ellipsoid.
+		zz := z
+		if h == 0 {
+			zz = 1
+		}
+		hH := math.Hypot(zz, rR)
+		sphi = zz / hH
+		cphi = rR / hH
+		h -= g.a
+	} else {
+		// Treat prolate spheroids by swapping R and Z here and by switching the arguments
+		// to phi = atan2(...) at the end.
+		p := sq(rR / g.a)
+		q := g.e2m * sq(z/g.a)
+		r := (p + q - g.e4a) / 6
+		if g.f < 0 {
+			p, q = q, p
+		}
+		if !(g.e4a*q == 0 && r <= 0) {
+			// Avoid possible division by zero when r = 0 by multiplying equations for s
+			// and t by r^3 and r, resp.
+			sS := g.e4a * p * q / 4 // S = r^3 * s
+			r2 := sq(r)
+			r3 := r * r2
+			disc := sS * (2*r3 + sS)
+			u := r
+			if disc >= 0 {
+				t3 := sS + r3
+				// Pick the sign on the sqrt to maximize abs(T3). This minimizes loss of
+				// precision due to cancellation. The result is unchanged because of the way
+				// T is used in the definition of u. Then T3 = (r * t)^3.
+				if t3 < 0 {
+					t3 -= math.Sqrt(disc)
+				} else {
+					t3 += math.Sqrt(disc)
+				}
+				// N.B. Cbrt always returns the real root. Cbrt(-8) = -2.
+				tT := math.Cbrt(t3) // T = r * t
+				// T can be zero; but then r2 / T -> 0.
+				u += tT
+				if tT != 0 {
+					u += r2 / tT
+				}
+			} else {
+				// T is complex, but the way u is defined the result is real.
+				ang := math.Atan2(math.Sqrt(-disc), -(sS + r3))
+				// There are three possible cube roots. We choose the root which avoids
+				// cancellation. Note that disc < 0 implies that r < 0.
+				u += 2 * r * math.Cos(ang/3)
+			}
+			v := math.Sqrt(sq(u) + g.e4a*q) // guaranteed positive
+			// Avoid loss of accuracy when u < 0. Underflow doesn't occur in
+			// e4 * q / (v - u) because u ~ e^4 when q is small and u < 0.
+			uv := u + v // u+v, guaranteed positive
+			if u < 0 {
+				uv = g.e4a * q / (v - u)
+			}
+			// Need to guard against w going negative due to roundoff in uv - q.
+			w := math.Max(0, g.e2a*(uv-q)/(2*v))
+			// Rearrange expression for k to avoid loss of accuracy due to subtraction.
+			// Division by 0 not possible because uv > 0, w >= 0.
+			k := uv / (math.Sqrt(uv+sq(w)) + w)
+			k1, k2 := k, k+g.e2
+			if g.f < 0 {
+				k1, k2 = k-g.e2, k
+			}
+			d := k1 * rR / k2
+			hH := math.Hypot(z/k1, rR/k2)
+			sphi = (z / k1) / hH
+			cphi = (rR / k2) / hH
+			h = (1 - g.e2m/k1) * math.Hypot(d, z)
+		} else { // e4 * q == 0 && r <= 0
+			// This leads to k = 0 (oblate, equatorial plane) and k + e^2 = 0 (prolate,
+			// rotation axis) and the generation of 0/0 in the general formulas for phi and
+			// h. So handle this case by taking the limits:
+			// f > 0: z -> 0, k      ->   e2 * sqrt(q)/sqrt(e4 - p)
+			// f < 0: R -> 0, k + e2 -> - e2 * sqrt(q)/sqrt(e4 - p)
+			var zz, xx float64
+			if g.f >= 0 {
+				zz = math.Sqrt((g.e4a - p) / g.e2m)
+				xx = math.Sqrt(p)
+			} else {
+				zz = math.Sqrt(p / g.e2m)
+				xx = math.Sqrt(g.e4a - p)
+			}
+			hH := math.Hypot(zz, xx)
+			sphi = zz / hH
+			cphi = xx / hH
+			if z < 0 {
+				sphi = -sphi // for tiny negative z (not for prolate)
+			}
+			if g.f >= 0 {
+				h = -g.a * g.e2m * hH / g.e2a
+			} else {
+				h = -g.a * hH / g.e2a
+			}
+		}
+	}
+	var m RotationMatrix
+	if wantm {
+		m = geocentric_rotation(sphi, cphi, slam, clam)
+	}
+	return GeodeticPosition{LatDeg: atan2_deg(sphi, cphi), LonDeg: atan2_deg(slam, clam), HeightM: h}, m
+}
+
+// geocentric_rotation returns the rotation matrix from the local east, north, up frame
+// at latitude phi and longitude lam to geocentric coordinates
+func geocentric_rotation(sphi, cphi, slam, clam float64) RotationMatrix {
+	// This rotation matrix is given by the following quaternion operations
+	// qrot(lam, [0,0,1]) * qrot(phi, [0,-1,0]) * [1,1,1,1]/2
+	// or
+	// qrot(pi/2 + lam, [0,0,1]) * qrot(-pi/2 + phi , [-1,0,0])
+	// where
+	// qrot(t,v) = [cos(t/2), sin(t/2)*v[1], sin(t/2)*v[2], sin(t/2)*v[3]]
+	return RotationMatrix{
+		// Local X axis (east), Y axis (north), and Z axis (up) are the columns
+		-slam, -clam * sphi, clam * cphi,
+		clam, -slam * sphi, slam * cphi,
+		0, cphi, sphi,
+	}
+}
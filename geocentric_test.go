@@ -0,0 +1,100 @@
+package geographiclibgo
+
+import (
+	"math"
+	"testing"
+)
+
+func TestGeocentricForward(t *testing.T) {
+	// From the CartConvert documentation
+	g := Wgs84Geocentric()
+	got := g.Forward(33.3, 44.4, 6000)
+	want := Cartesian{3816209.60, 3737108.55, 3485109.57}
+	if !almost_equal(got.XM, want.XM, 0.005) || !almost_equal(got.YM, want.YM, 0.005) ||
+		!almost_equal(got.ZM, want.ZM, 0.005) {
+		t.Errorf("Forward(33.3, 44.4, 6000) = %v; want %v", got, want)
+	}
+}
+
+func TestGeocentricReverse(t *testing.T) {
+	// From the CartConvert documentation
+	g := Wgs84Geocentric()
+	got := g.Reverse(30000, 30000, 0)
+	if !almost_equal(got.LatDeg, 6.483, 0.0005) || !almost_equal(got.LonDeg, 45, 1e-12) ||
+		!almost_equal(got.HeightM, -6335709.73, 0.005) {
+		t.Errorf("Reverse(30000, 30000, 0) = %v; want {6.483 45 -6335709.73}", got)
+	}
+
+	testCases := []struct {
+		desc string
+		a, f float64
+	}{
+		{"WGS84", WGS84_A, WGS84_F},
+		{"sphere", 6.4e6, 0},
+		{"prolate", 6.4e6, -1 / 150.0},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			g := NewGeocentric(tC.a, tC.f)
+			for _, lat := range []float64{-90, -60, -1e-10, 0, 30, 89.9, 90} {
+				for _, h := range []float64{-1000, 0, 5000, 1e7} {
+					c := g.Forward(lat, 30, h)
+					p := g.Reverse(c.XM, c.YM, c.ZM)
+					if !almost_equal(p.LatDeg, lat, 1e-12) {
+						t.Errorf("(%v, %v): lat = %v", lat, h, p.LatDeg)
+					}
+					if math.Abs(lat) != 90 && !almost_equal(p.LonDeg, 30, 1e-12) {
+						t.Errorf("(%v, %v): lon = %v", lat, h, p.LonDeg)
+					}
+					if !almost_equal(p.HeightM, h, 1e-8) {
+						t.Errorf("(%v, %v): h = %v", lat, h, p.HeightM)
+					}
+				}
+			}
+		})
+	}
+
+	// The center of the ellipsoid maps to the north pole with h = -b
+	if p := g.Reverse(0, 0, 0); p.LatDeg != 90 || !almost_equal(p.HeightM, -WGS84_A*(1-WGS84_F), 1e-8) {
+		t.Errorf("Reverse(0, 0, 0) = %v; want {90 0 -b}", p)
+	}
+}
+
+func TestGeocentricRotation(t *testing.T) {
+	g := Wgs84Geocentric()
+	c, m := g.ForwardWithRotation(33.3, 44.4, 6000)
+	// The up vector points along the normal, so moving up 1 m changes x, y, z by the last
+	// column of m
+	c1 := g.Forward(33.3, 44.4, 6001)
+	for i, d := range []float64{c1.XM - c.XM, c1.YM - c.YM, c1.ZM - c.ZM} {
+		if !almost_equal(d, m[3*i+2], 1e-8) {
+			t.Errorf("up[%v] = %v; want %v", i, m[3*i+2], d)
+		}
+	}
+	// m is orthogonal
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			dot := m[i]*m[j] + m[i+3]*m[j+3] + m[i+6]*m[j+6]
+			want := 0.0
+			if i == j {
+				want = 1
+			}
+			if !almost_equal(dot, want, 1e-15) {
+				t.Errorf("column %v . column %v = %v; want %v", i, j, dot, want)
+			}
+		}
+	}
+	_, mr := g.ReverseWithRotation(c.XM, c.YM, c.ZM)
+	for i := range m {
+		if !almost_equal(mr[i], m[i], 1e-15) {
+			t.Errorf("reverse M[%v] = %v; want %v", i, mr[i], m[i])
+		}
+	}
+}
+
+func BenchmarkGeocentricReverse(b *testing.B) {
+	g := Wgs84Geocentric()
+	for i := 0; i < b.N; i++ {
+		g.Reverse(3816209.60, 3737108.55, 3485109.57)
+	}
+}
@@ -43,6 +43,11 @@
 // - DirectCalcAll -> calculates all of the above plus Area under the geodesic and the
 // arc length between point 1 and point 2
 //
+// Each of these has an ArcDirectCalc... counterpart (e.g. ArcDirectCalcLatLon) where the
+// second point is given by a12_deg, the arc length on the auxiliary sphere [degrees],
+// instead of by distance. ArcDirectCalcAll and ArcDirectCalcWithCapabilities also return
+// the distance s12 between the points.
+//
 // =====================================================================================
 // =====================================================================================
 // =====================================================================================
@@ -1175,6 +1180,160 @@ func (g *Geodesic) DirectCalcWithCapabilities(
 	}
 }
 
+// ArcDirectCalcLatLon gets the lat and lon of the second point, where the second point
+// is specified by the arc length on the auxiliary sphere. Takes inputs
+//   - lat1_deg - Latitude of 1st point [degrees] [-90.,90.]
+//   - lon1_deg - Longitude of 1st point [degrees] [-180., 180.]
+//   - azi1_deg - Azimuth at 1st point [degrees] [-180., 180.]
+//   - a12_deg - Arc length from 1st to 2nd point [degrees] Value may be negative
+func (g *Geodesic) ArcDirectCalcLatLon(lat1_deg, lon1_deg, azi1_deg, a12_deg float64) LatLon {
+	capabilities := LATITUDE | LONGITUDE
+	_, lat2, lon2, _, _, _, _, _, _, _ := g._gen_direct(
+		lat1_deg, lon1_deg, azi1_deg, true, a12_deg, capabilities,
+	)
+	return LatLon{LatDeg: lat2, LonDeg: lon2}
+}
+
+// ArcDirectCalcLatLonAzi gets the lat, lon, and azimuth of the second point, where the
+// second point is specified by the arc length on the auxiliary sphere. Takes inputs
+//   - lat1_deg - Latitude of 1st point [degrees] [-90.,90.]
+//   - lon1_deg - Longitude of 1st point [degrees] [-180., 180.]
+//   - azi1_deg - Azimuth at 1st point [degrees] [-180., 180.]
+//   - a12_deg - Arc length from 1st to 2nd point [degrees] Value may be negative
+func (g *Geodesic) ArcDirectCalcLatLonAzi(lat1_deg, lon1_deg, azi1_deg, a12_deg float64) LatLonAzi {
+	capabilities := LATITUDE | LONGITUDE | AZIMUTH
+	_, lat2, lon2, azi2, _, _, _, _, _, _ := g._gen_direct(
+		lat1_deg, lon1_deg, azi1_deg, true, a12_deg, capabilities,
+	)
+	return LatLonAzi{LatDeg: lat2, LonDeg: lon2, AziDeg: azi2}
+}
+
+// ArcDirectCalcLatLonAziReducedLength gets the lat, lon, azimuth, and reduced length of
+// geodesic of the second point, where the second point is specified by the arc length on
+// the auxiliary sphere. Takes inputs
+//   - lat1_deg - Latitude of 1st point [degrees] [-90.,90.]
+//   - lon1_deg - Longitude of 1st point [degrees] [-180., 180.]
+//   - azi1_deg - Azimuth at 1st point [degrees] [-180., 180.]
+//   - a12_deg - Arc length from 1st to 2nd point [degrees] Value may be negative
+func (g *Geodesic) ArcDirectCalcLatLonAziReducedLength(
+	lat1_deg, lon1_deg, azi1_deg, a12_deg float64,
+) LatLonAziReducedLength {
+	capabilities := LATITUDE | LONGITUDE | AZIMUTH | REDUCEDLENGTH
+	_, lat2, lon2, azi2, _, m12, _, _, _, _ := g._gen_direct(
+		lat1_deg, lon1_deg, azi1_deg, true, a12_deg, capabilities,
+	)
+	return LatLonAziReducedLength{LatDeg: lat2, LonDeg: lon2, AziDeg: azi2, ReducedLengthM: m12}
+}
+
+// ArcDirectCalcLatLonAziGeodesicScales gets the lat, lon, azimuth, and geodesic scales,
+// where the second point is specified by the arc length on the auxiliary sphere.
+// Takes inputs
+//   - lat1_deg - Latitude of 1st point [degrees] [-90.,90.]
+//   - lon1_deg - Longitude of 1st point [degrees] [-180., 180.]
+//   - azi1_deg - Azimuth at 1st point [degrees] [-180., 180.]
+//   - a12_deg - Arc length from 1st to 2nd point [degrees] Value may be negative
+func (g *Geodesic) ArcDirectCalcLatLonAziGeodesicScales(
+	lat1_deg, lon1_deg, azi1_deg, a12_deg float64,
+) LatLonAziGeodesicScales {
+	capabilities := LATITUDE | LONGITUDE | AZIMUTH | GEODESICSCALE
+	_, lat2, lon2, azi2, _, _, M12, M21, _, _ := g._gen_direct(
+		lat1_deg, lon1_deg, azi1_deg, true, a12_deg, capabilities,
+	)
+	return LatLonAziGeodesicScales{LatDeg: lat2, LonDeg: lon2, AziDeg: azi2, M12: M12, M21: M21}
+}
+
+// ArcDirectCalcLatLonAziReducedLengthGeodesicScales gets the lat, lon, azimuth, reduced
+// length, and geodesic scales, where the second point is specified by the arc length on
+// the auxiliary sphere. Takes inputs
+//   - lat1_deg - Latitude of 1st point [degrees] [-90.,90.]
+//   - lon1_deg - Longitude of 1st point [degrees] [-180., 180.]
+//   - azi1_deg - Azimuth at 1st point [degrees] [-180., 180.]
+//   - a12_deg - Arc length from 1st to 2nd point [degrees] Value may be negative
+func (g *Geodesic) ArcDirectCalcLatLonAziReducedLengthGeodesicScales(
+	lat1_deg, lon1_deg, azi1_deg, a12_deg float64,
+) LatLonAziReducedLengthGeodesicScales {
+	capabilities := LATITUDE | LONGITUDE | AZIMUTH | REDUCEDLENGTH | GEODESICSCALE
+	_, lat2, lon2, azi2, _, m12, M12, M21, _, _ := g._gen_direct(
+		lat1_deg, lon1_deg, azi1_deg, true, a12_deg, capabilities,
+	)
+	return LatLonAziReducedLengthGeodesicScales{
+		LatDeg:         lat2,
+		LonDeg:         lon2,
+		AziDeg:         azi2,
+		ReducedLengthM: m12,
+		M12:            M12,
+		M21:            M21,
+	}
+}
+
+// AllArcDirectResults contains all information that can be computed from the direct
+// method when the second point is given by arc length: latitude, longitude, azimuth,
+// distance, reduced length, geodesic scales, and area under the geodesic
+type AllArcDirectResults struct {
+	LatDeg         float64 // Latitude [degrees]
+	LonDeg         float64 // Longitude [degrees]
+	AziDeg         float64 // Azimuth [degrees]
+	DistanceM      float64 // Distance between point 1 and point 2 [meters]
+	ReducedLengthM float64 // Reduced length of the geodesic [meters]
+	M12            float64 // Geodesic scale of point 2 relative to point 1 [dimensionless]
+	M21            float64 // Geodesic scale of point 1 relative to point 2 [dimensionless]
+	S12M2          float64 // Area under the geodesic [meters^2]
+}
+
+// ArcDirectCalcAll calculates everything possible for the direct method, where the second
+// point is specified by the arc length on the auxiliary sphere. Takes inputs
+//   - lat1_deg - Latitude of 1st point [degrees] [-90.,90.]
+//   - lon1_deg - Longitude of 1st point [degrees] [-180., 180.]
+//   - azi1_deg - Azimuth at 1st point [degrees] [-180., 180.]
+//   - a12_deg - Arc length from 1st to 2nd point [degrees] Value may be negative
+func (g *Geodesic) ArcDirectCalcAll(lat1_deg, lon1_deg, azi1_deg, a12_deg float64) AllArcDirectResults {
+	capabilities := LATITUDE | LONGITUDE | AZIMUTH | DISTANCE | REDUCEDLENGTH | GEODESICSCALE | AREA
+	_, lat2, lon2, azi2, s12, m12, M12, M21, S12, _ := g._gen_direct(
+		lat1_deg, lon1_deg, azi1_deg, true, a12_deg, capabilities,
+	)
+	return AllArcDirectResults{
+		LatDeg:         lat2,
+		LonDeg:         lon2,
+		AziDeg:         azi2,
+		DistanceM:      s12,
+		ReducedLengthM: m12,
+		M12:            M12,
+		M21:            M21,
+		S12M2:          S12,
+	}
+}
+
+// ArcDirectCalcWithCapabilities allows the user to specify which capabilites they wish to
+// use, where the second point is specified by the arc length on the auxiliary sphere.
+// This function is useful if you want some other subset of capabilities than those offered
+// by the other ArcDirectCalc...() methods. Include DISTANCE in the capabilities to get the
+// distance between the points.
+// Takes inputs
+//   - lat1_deg - Latitude of 1st point [degrees] [-90.,90.]
+//   - lon1_deg - Longitude of 1st point [degrees] [-180., 180.]
+//   - azi1_deg - Azimuth at 1st point [degrees] [-180., 180.]
+//   - a12_deg - Arc length from 1st to 2nd point [degrees] Value may be negative
+//   - capabilities - One or more of the capabilities constant as defined in the file
+//     geodesiccapability.go. Usually, they are OR'd together, e.g. LATITUDE | LONGITUDE
+func (g *Geodesic) ArcDirectCalcWithCapabilities(
+	lat1_deg, lon1_deg, azi1_deg, a12_deg float64,
+	capabilities uint64,
+) AllArcDirectResults {
+	_, lat2, lon2, azi2, s12, m12, M12, M21, S12, _ := g._gen_direct(
+		lat1_deg, lon1_deg, azi1_deg, true, a12_deg, capabilities,
+	)
+	return AllArcDirectResults{
+		LatDeg:         lat2,
+		LonDeg:         lon2,
+		AziDeg:         azi2,
+		DistanceM:      s12,
+		ReducedLengthM: m12,
+		M12:            M12,
+		M21:            M21,
+		S12M2:          S12,
+	}
+}
+
 // InverseCalcDistance returns the distance from point 1 to point 2 in meters. Takes inputs
 // - lat1_deg latitude of point 1 [degrees].
 // - lon1_deg longitude of point 1 [degrees].
@@ -1422,7 +1581,7 @@ func (g *Geodesic) InverseLineWithCapabilities(
 	}
 
 	line := new_geodesic_line_all_options(*g, lat1_deg, lon1_deg, azi1, capabilities, salp1, calp1)
-	line.set_arc(a12)
+	line.SetArc(a12)
 	return line
 }
 
@@ -1444,9 +1603,9 @@ func (g *Geodesic) _gen_direct_line(
 		capabilities,
 	)
 	if arcmode {
-		line.set_arc(s12_a12_m)
+		line.SetArc(s12_a12_m)
 	} else {
-		line.set_distance(s12_a12_m)
+		line.SetDistance(s12_a12_m)
 	}
 	return line
 }
@@ -1462,6 +1621,17 @@ func (g *Geodesic) DirectLineWithCapabilities(
 	return g._gen_direct_line(lat1_deg, lon1_deg, azi1_deg, false, s12_m, capabilities)
 }
 
+// ArcDirectLineWithCapabilities defines a GeodesicLine struct in terms of the direct
+// geodesic problem specified in terms of spherical arc length.
+// This function sets point 3 of the GeodesicLine to correspond to point 2 of the
+// direct geodesic problem
+func (g *Geodesic) ArcDirectLineWithCapabilities(
+	lat1_deg, lon1_deg, azi1_deg, a12_deg float64,
+	capabilities uint64,
+) GeodesicLine {
+	return g._gen_direct_line(lat1_deg, lon1_deg, azi1_deg, true, a12_deg, capabilities)
+}
+
 // Line returns a GeodesicLine. This allows points along a geodesic starting at
 // lat1_deg, lon1_deg with azimuth azi1_deg to be found.
 func (g *Geodesic) LineWithCapabilities(
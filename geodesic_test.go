@@ -2241,6 +2241,62 @@ func BenchmarkInverse(b *testing.B) {
 	}
 }
 
+func BenchmarkInverseCalcDistanceBatch(b *testing.B) {
+	geod := Wgs84()
+	lat1, lon1, _, lat2, lon2, _, _ := batch_columns()
+	s12 := make([]float64, len(lat1))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		geod.InverseCalcDistanceBatch(lat1, lon1, lat2, lon2, s12)
+	}
+}
+
+func BenchmarkInverseCalcBatch(b *testing.B) {
+	geod := Wgs84()
+	lat1, lon1, _, lat2, lon2, _, _ := batch_columns()
+	results := make([]AllInverseResults, len(lat1))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		geod.InverseCalcBatch(lat1, lon1, lat2, lon2, DISTANCE|AZIMUTH, results)
+	}
+}
+
+func BenchmarkDirectCalcLatLonBatch(b *testing.B) {
+	geod := Wgs84()
+	lat1, lon1, azi1, _, _, s12, _ := batch_columns()
+	lat2 := make([]float64, len(lat1))
+	lon2 := make([]float64, len(lat1))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		geod.DirectCalcLatLonBatch(lat1, lon1, azi1, s12, lat2, lon2)
+	}
+}
+
+func BenchmarkDirectCalcBatch(b *testing.B) {
+	geod := Wgs84()
+	lat1, lon1, azi1, _, _, s12, _ := batch_columns()
+	results := make([]AllDirectResults, len(lat1))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		geod.DirectCalcBatch(lat1, lon1, azi1, s12, ALL, results)
+	}
+}
+
+func BenchmarkArcDirectCalcBatch(b *testing.B) {
+	geod := Wgs84()
+	lat1, lon1, azi1, _, _, _, a12 := batch_columns()
+	results := make([]AllArcDirectResults, len(lat1))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		geod.ArcDirectCalcBatch(lat1, lon1, azi1, a12, ALL, results)
+	}
+}
+
 func TestDirect20(t *testing.T) {
 	geod := Wgs84()
 
@@ -2299,6 +2355,126 @@ func BenchmarkDirect20(b *testing.B) {
 	}
 }
 
+func TestArcDirect20(t *testing.T) {
+	geod := Wgs84()
+
+	for row, tC := range test_cases {
+		lat1, lon1, azi1 := tC[0], tC[1], tC[2]
+		lat2, lon2, azi2 := tC[3], tC[4], tC[5]
+		s12, a12, m12 := tC[6], tC[7], tC[8]
+		M12, M21, S12 := tC[9], tC[10], tC[11]
+
+		dir := geod.ArcDirectCalcWithCapabilities(lat1, lon1, azi1, a12, ALL|LONG_UNROLL)
+
+		if !almost_equal(lat2, dir.LatDeg, 1e-13) {
+			t.Errorf("row %d -- ArcDirect() lat2 = %v; want %v", row, dir.LatDeg, lat2)
+		}
+
+		if !almost_equal(lon2, dir.LonDeg, 1e-13) {
+			t.Errorf("row %d -- ArcDirect() lon2 = %v; want %v", row, dir.LonDeg, lon2)
+		}
+
+		if !almost_equal(azi2, dir.AziDeg, 1e-13) {
+			t.Errorf("row %d -- ArcDirect() azi2 = %v; want %v", row, dir.AziDeg, azi2)
+		}
+
+		if !almost_equal(s12, dir.DistanceM, 1e-8) {
+			t.Errorf("row %d -- ArcDirect() s12 = %v; want %v", row, dir.DistanceM, s12)
+		}
+
+		if !almost_equal(m12, dir.ReducedLengthM, 1e-8) {
+			t.Errorf("row %d -- ArcDirect() m12 = %v; want %v", row, dir.ReducedLengthM, m12)
+		}
+
+		if !almost_equal(M12, dir.M12, 1e-15) {
+			t.Errorf("row %d -- ArcDirect() M12 = %v; want %v", row, dir.M12, M12)
+		}
+
+		if !almost_equal(M21, dir.M21, 1e-15) {
+			t.Errorf("row %d -- ArcDirect() M21 = %v; want %v", row, dir.M21, M21)
+		}
+
+		if !almost_equal(S12, dir.S12M2, 0.1) {
+			t.Errorf("row %d -- ArcDirect() S12 = %v; want %v", row, dir.S12M2, S12)
+		}
+	}
+}
+
+func BenchmarkArcDirect20(b *testing.B) {
+	geod := Wgs84()
+	for i := 0; i < b.N; i++ {
+		for _, tC := range test_cases {
+			lat1, lon1, azi1 := tC[0], tC[1], tC[2]
+			a12 := tC[7]
+
+			geod.ArcDirectCalcWithCapabilities(lat1, lon1, azi1, a12, ALL|LONG_UNROLL)
+
+		}
+	}
+}
+
+func TestArcDirectSubsets(t *testing.T) {
+	// Every ArcDirectCalc...() method should agree with ArcDirectCalcAll
+	geod := Wgs84()
+	lat1, lon1, azi1, a12 := 40.63972222, -73.77888889, 53.5, 52.6
+	all := geod.ArcDirectCalcAll(lat1, lon1, azi1, a12)
+
+	ll := geod.ArcDirectCalcLatLon(lat1, lon1, azi1, a12)
+	if !f64_equals(all.LatDeg, ll.LatDeg) || !f64_equals(all.LonDeg, ll.LonDeg) {
+		t.Errorf("ArcDirectCalcLatLon() = %v; want %v, %v", ll, all.LatDeg, all.LonDeg)
+	}
+
+	lla := geod.ArcDirectCalcLatLonAzi(lat1, lon1, azi1, a12)
+	if !f64_equals(all.AziDeg, lla.AziDeg) {
+		t.Errorf("ArcDirectCalcLatLonAzi() azi2 = %v; want %v", lla.AziDeg, all.AziDeg)
+	}
+
+	rl := geod.ArcDirectCalcLatLonAziReducedLength(lat1, lon1, azi1, a12)
+	if !f64_equals(all.ReducedLengthM, rl.ReducedLengthM) {
+		t.Errorf("ArcDirectCalcLatLonAziReducedLength() m12 = %v; want %v", rl.ReducedLengthM, all.ReducedLengthM)
+	}
+
+	gs := geod.ArcDirectCalcLatLonAziGeodesicScales(lat1, lon1, azi1, a12)
+	if !f64_equals(all.M12, gs.M12) || !f64_equals(all.M21, gs.M21) {
+		t.Errorf("ArcDirectCalcLatLonAziGeodesicScales() = %v, %v; want %v, %v", gs.M12, gs.M21, all.M12, all.M21)
+	}
+
+	rlgs := geod.ArcDirectCalcLatLonAziReducedLengthGeodesicScales(lat1, lon1, azi1, a12)
+	if !f64_equals(all.ReducedLengthM, rlgs.ReducedLengthM) || !f64_equals(all.M12, rlgs.M12) {
+		t.Errorf("ArcDirectCalcLatLonAziReducedLengthGeodesicScales() = %v; want %v", rlgs, all)
+	}
+
+	// Going the returned distance with the regular direct method should land on the same
+	// point
+	dir := geod.DirectCalcAll(lat1, lon1, azi1, all.DistanceM)
+	if !almost_equal(dir.LatDeg, all.LatDeg, 1e-13) || !almost_equal(dir.LonDeg, all.LonDeg, 1e-13) {
+		t.Errorf("DirectCalcAll() = %v, %v; want %v, %v", dir.LatDeg, dir.LonDeg, all.LatDeg, all.LonDeg)
+	}
+	if !almost_equal(dir.A12Deg, a12, 1e-13) {
+		t.Errorf("DirectCalcAll() a12 = %v; want %v", dir.A12Deg, a12)
+	}
+
+	// Without DISTANCE the distance is not computed
+	nodist := geod.ArcDirectCalcWithCapabilities(lat1, lon1, azi1, a12, LATITUDE)
+	if !math.IsNaN(nodist.DistanceM) {
+		t.Errorf("DistanceM = %v; want NaN", nodist.DistanceM)
+	}
+}
+
+func TestArcDirectLine(t *testing.T) {
+	geod := Wgs84()
+	line := geod.ArcDirectLineWithCapabilities(1, 2, 45, 90, STANDARD|DISTANCE_IN)
+	inv := geod.ArcDirectCalcAll(1, 2, 45, 90)
+
+	if !almost_equal(line.s13, inv.DistanceM, 1e-8) {
+		t.Errorf("s13 = %v; want %v", line.s13, inv.DistanceM)
+	}
+
+	if !f64_equals(line.a13, 90) {
+		t.Errorf("a13 = %v; want %v", line.a13, 90)
+	}
+}
+
 func TestGeodSolve0(t *testing.T) {
 	geod := Wgs84()
 	inv := geod.InverseCalcDistanceAzimuths(40.6, -73.8, 49.01666667, 2.55)
@@ -0,0 +1,167 @@
+package geographiclibgo
+
+import "fmt"
+
+// The batch methods solve many geodesic problems in one call. The inputs are parallel
+// slices, one element per problem, and the results are written to slices provided by
+// the caller, so that a batch makes no allocations; the slices can be reused from one
+// batch to the next. All of the slices passed to a method must have the same length,
+// otherwise an error is returned and no results are written.
+
+// check_batch_lengths returns an error unless each of the slices has length n
+func check_batch_lengths(n int, slices ...[]float64) error {
+	for _, s := range slices {
+		if len(s) != n {
+			return fmt.Errorf("batch slices have lengths %d and %d; want equal lengths", n, len(s))
+		}
+	}
+	return nil
+}
+
+// InverseCalcDistanceBatch sets s12_m[i] to the distance from point 1 to point 2 of the
+// i-th problem, as InverseCalcDistance does. Takes inputs
+//   - lat1_deg latitudes of point 1 [degrees].
+//   - lon1_deg longitudes of point 1 [degrees].
+//   - lat2_deg latitudes of point 2 [degrees].
+//   - lon2_deg longitudes of point 2 [degrees].
+//   - s12_m the slice into which the distances are written [meters].
+func (g *Geodesic) InverseCalcDistanceBatch(lat1_deg, lon1_deg, lat2_deg, lon2_deg, s12_m []float64) error {
+	if err := check_batch_lengths(len(s12_m), lat1_deg, lon1_deg, lat2_deg, lon2_deg); err != nil {
+		return err
+	}
+	for i := range s12_m {
+		_, s12, _, _, _, _, _, _ := g._gen_inverse_azi(lat1_deg[i], lon1_deg[i], lat2_deg[i], lon2_deg[i], DISTANCE)
+		s12_m[i] = s12
+	}
+	return nil
+}
+
+// InverseCalcBatch sets results[i] to the solution of the i-th inverse problem, as
+// InverseCalcWithCapabilities does. The fields not asked for in capabilities are NaN.
+// Takes inputs
+//   - lat1_deg latitudes of point 1 [degrees].
+//   - lon1_deg longitudes of point 1 [degrees].
+//   - lat2_deg latitudes of point 2 [degrees].
+//   - lon2_deg longitudes of point 2 [degrees].
+//   - capabilities - One or more of the capabilities constant as defined in the file
+//     geodesiccapability.go. Usually, they are OR'd together, e.g. DISTANCE | AZIMUTH
+//   - results the slice into which the results are written.
+func (g *Geodesic) InverseCalcBatch(
+	lat1_deg, lon1_deg, lat2_deg, lon2_deg []float64,
+	capabilities uint64,
+	results []AllInverseResults,
+) error {
+	if err := check_batch_lengths(len(results), lat1_deg, lon1_deg, lat2_deg, lon2_deg); err != nil {
+		return err
+	}
+	for i := range results {
+		a12, s12, azi1, azi2, m12, M12, M21, S12 := g._gen_inverse_azi(
+			lat1_deg[i], lon1_deg[i], lat2_deg[i], lon2_deg[i], capabilities,
+		)
+		results[i] = AllInverseResults{
+			DistanceM:      s12,
+			Azimuth1Deg:    azi1,
+			Azimuth2Deg:    azi2,
+			ArcLengthDeg:   a12,
+			ReducedLengthM: m12,
+			M12:            M12,
+			M21:            M21,
+			S12M2:          S12,
+		}
+	}
+	return nil
+}
+
+// DirectCalcLatLonBatch sets lat2_deg[i] and lon2_deg[i] to the position of point 2 of the
+// i-th direct problem, as DirectCalcLatLon does. Takes inputs
+//   - lat1_deg latitudes of point 1 [degrees] [-90.,90.]
+//   - lon1_deg longitudes of point 1 [degrees] [-180., 180.]
+//   - azi1_deg azimuths at point 1 [degrees] [-180., 180.]
+//   - s12_m distances from point 1 to point 2 [meters]. Values may be negative
+//   - lat2_deg the slice into which the latitudes of point 2 are written [degrees]
+//   - lon2_deg the slice into which the longitudes of point 2 are written [degrees]
+func (g *Geodesic) DirectCalcLatLonBatch(lat1_deg, lon1_deg, azi1_deg, s12_m, lat2_deg, lon2_deg []float64) error {
+	if err := check_batch_lengths(len(lat2_deg), lat1_deg, lon1_deg, azi1_deg, s12_m, lon2_deg); err != nil {
+		return err
+	}
+	for i := range lat2_deg {
+		_, lat2, lon2, _, _, _, _, _, _, _ := g._gen_direct(
+			lat1_deg[i], lon1_deg[i], azi1_deg[i], false, s12_m[i], LATITUDE|LONGITUDE,
+		)
+		lat2_deg[i], lon2_deg[i] = lat2, lon2
+	}
+	return nil
+}
+
+// DirectCalcBatch sets results[i] to the solution of the i-th direct problem, as
+// DirectCalcWithCapabilities does. The fields not asked for in capabilities are NaN.
+// Takes inputs
+//   - lat1_deg latitudes of point 1 [degrees] [-90.,90.]
+//   - lon1_deg longitudes of point 1 [degrees] [-180., 180.]
+//   - azi1_deg azimuths at point 1 [degrees] [-180., 180.]
+//   - s12_m distances from point 1 to point 2 [meters]. Values may be negative
+//   - capabilities - One or more of the capabilities constant as defined in the file
+//     geodesiccapability.go. Usually, they are OR'd together, e.g. LATITUDE | LONGITUDE
+//   - results the slice into which the results are written.
+func (g *Geodesic) DirectCalcBatch(
+	lat1_deg, lon1_deg, azi1_deg, s12_m []float64,
+	capabilities uint64,
+	results []AllDirectResults,
+) error {
+	if err := check_batch_lengths(len(results), lat1_deg, lon1_deg, azi1_deg, s12_m); err != nil {
+		return err
+	}
+	for i := range results {
+		a12, lat2, lon2, azi2, _, m12, M12, M21, S12, _ := g._gen_direct(
+			lat1_deg[i], lon1_deg[i], azi1_deg[i], false, s12_m[i], capabilities,
+		)
+		results[i] = AllDirectResults{
+			LatDeg:         lat2,
+			LonDeg:         lon2,
+			AziDeg:         azi2,
+			ReducedLengthM: m12,
+			M12:            M12,
+			M21:            M21,
+			S12M2:          S12,
+			A12Deg:         a12,
+		}
+	}
+	return nil
+}
+
+// ArcDirectCalcBatch sets results[i] to the solution of the i-th direct problem, where
+// point 2 is specified by the arc length on the auxiliary sphere, as
+// ArcDirectCalcWithCapabilities does. Include DISTANCE in the capabilities to get the
+// distances between the points. Takes inputs
+//   - lat1_deg latitudes of point 1 [degrees] [-90.,90.]
+//   - lon1_deg longitudes of point 1 [degrees] [-180., 180.]
+//   - azi1_deg azimuths at point 1 [degrees] [-180., 180.]
+//   - a12_deg arc lengths from point 1 to point 2 [degrees]. Values may be negative
+//   - capabilities - One or more of the capabilities constant as defined in the file
+//     geodesiccapability.go. Usually, they are OR'd together, e.g. LATITUDE | LONGITUDE
+//   - results the slice into which the results are written.
+func (g *Geodesic) ArcDirectCalcBatch(
+	lat1_deg, lon1_deg, azi1_deg, a12_deg []float64,
+	capabilities uint64,
+	results []AllArcDirectResults,
+) error {
+	if err := check_batch_lengths(len(results), lat1_deg, lon1_deg, azi1_deg, a12_deg); err != nil {
+		return err
+	}
+	for i := range results {
+		_, lat2, lon2, azi2, s12, m12, M12, M21, S12, _ := g._gen_direct(
+			lat1_deg[i], lon1_deg[i], azi1_deg[i], true, a12_deg[i], capabilities,
+		)
+		results[i] = AllArcDirectResults{
+			LatDeg:         lat2,
+			LonDeg:         lon2,
+			AziDeg:         azi2,
+			DistanceM:      s12,
+			ReducedLengthM: m12,
+			M12:            M12,
+			M21:            M21,
+			S12M2:          S12,
+		}
+	}
+	return nil
+}
@@ -0,0 +1,182 @@
+package geographiclibgo
+
+import (
+	"math"
+	"testing"
+)
+
+// batch_columns returns the columns of the 20 test cases in geodesic_test.go: lat1, lon1,
+// azi1, lat2, lon2, s12 and a12
+func batch_columns() (lat1, lon1, azi1, lat2, lon2, s12, a12 []float64) {
+	for _, tC := range test_cases {
+		lat1 = append(lat1, tC[0])
+		lon1 = append(lon1, tC[1])
+		azi1 = append(azi1, tC[2])
+		lat2 = append(lat2, tC[3])
+		lon2 = append(lon2, tC[4])
+		s12 = append(s12, tC[6])
+		a12 = append(a12, tC[7])
+	}
+	return
+}
+
+// inverse_results_equal tests if two AllInverseResults are equal, treating NaNs as equal
+func inverse_results_equal(want, got AllInverseResults) bool {
+	return f64_equals(want.DistanceM, got.DistanceM) && f64_equals(want.Azimuth1Deg, got.Azimuth1Deg) &&
+		f64_equals(want.Azimuth2Deg, got.Azimuth2Deg) && f64_equals(want.ArcLengthDeg, got.ArcLengthDeg) &&
+		f64_equals(want.ReducedLengthM, got.ReducedLengthM) && f64_equals(want.M12, got.M12) &&
+		f64_equals(want.M21, got.M21) && f64_equals(want.S12M2, got.S12M2)
+}
+
+func TestInverseCalcDistanceBatch(t *testing.T) {
+	geod := Wgs84()
+	lat1, lon1, _, lat2, lon2, s12, _ := batch_columns()
+	got := make([]float64, len(lat1))
+	if err := geod.InverseCalcDistanceBatch(lat1, lon1, lat2, lon2, got); err != nil {
+		t.Fatalf("InverseCalcDistanceBatch() error = %v", err)
+	}
+	for i := range got {
+		if !almost_equal(got[i], s12[i], 1e-8) || got[i] != geod.InverseCalcDistance(lat1[i], lon1[i], lat2[i], lon2[i]) {
+			t.Errorf("row %d -- InverseCalcDistanceBatch() s12 = %v; want %v", i, got[i], s12[i])
+		}
+	}
+}
+
+func TestInverseCalcBatch(t *testing.T) {
+	geod := Wgs84()
+	lat1, lon1, _, lat2, lon2, _, _ := batch_columns()
+	testCases := []struct {
+		desc         string
+		capabilities uint64
+	}{
+		{"distance", DISTANCE},
+		{"standard", STANDARD},
+		{"area", AREA | LONG_UNROLL},
+		{"all", ALL},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			got := make([]AllInverseResults, len(lat1))
+			if err := geod.InverseCalcBatch(lat1, lon1, lat2, lon2, tC.capabilities, got); err != nil {
+				t.Fatalf("InverseCalcBatch() error = %v", err)
+			}
+			for i := range got {
+				want := geod.InverseCalcWithCapabilities(lat1[i], lon1[i], lat2[i], lon2[i], tC.capabilities)
+				if !inverse_results_equal(want, got[i]) {
+					t.Errorf("row %d -- InverseCalcBatch() = %+v; want %+v", i, got[i], want)
+				}
+			}
+			if tC.capabilities == DISTANCE && !(math.IsNaN(got[0].Azimuth1Deg) && math.IsNaN(got[0].S12M2)) {
+				t.Errorf("InverseCalcBatch(DISTANCE) = %+v; want NaN for the other fields", got[0])
+			}
+		})
+	}
+}
+
+func TestDirectCalcLatLonBatch(t *testing.T) {
+	geod := Wgs84()
+	lat1, lon1, azi1, lat2, lon2, s12, _ := batch_columns()
+	gotlat := make([]float64, len(lat1))
+	gotlon := make([]float64, len(lat1))
+	if err := geod.DirectCalcLatLonBatch(lat1, lon1, azi1, s12, gotlat, gotlon); err != nil {
+		t.Fatalf("DirectCalcLatLonBatch() error = %v", err)
+	}
+	for i := range gotlat {
+		// The longitudes in the test cases are unrolled
+		if !almost_equal(gotlat[i], lat2[i], 1e-13) || !almost_equal(math.Remainder(gotlon[i]-lon2[i], 360), 0, 1e-13) {
+			t.Errorf("row %d -- DirectCalcLatLonBatch() = %v, %v; want %v, %v", i, gotlat[i], gotlon[i], lat2[i], lon2[i])
+		}
+	}
+}
+
+func TestDirectCalcBatch(t *testing.T) {
+	geod := Wgs84()
+	lat1, lon1, azi1, _, _, s12, a12 := batch_columns()
+	for _, capabilities := range []uint64{LATITUDE | LONGITUDE, STANDARD, ALL, ALL | LONG_UNROLL} {
+		got := make([]AllDirectResults, len(lat1))
+		if err := geod.DirectCalcBatch(lat1, lon1, azi1, s12, capabilities, got); err != nil {
+			t.Fatalf("DirectCalcBatch(%v) error = %v", capabilities, err)
+		}
+		for i := range got {
+			want := geod.DirectCalcWithCapabilities(lat1[i], lon1[i], azi1[i], s12[i], capabilities)
+			if !f64_equals(want.LatDeg, got[i].LatDeg) || !f64_equals(want.LonDeg, got[i].LonDeg) ||
+				!f64_equals(want.AziDeg, got[i].AziDeg) || !f64_equals(want.ReducedLengthM, got[i].ReducedLengthM) ||
+				!f64_equals(want.M12, got[i].M12) || !f64_equals(want.S12M2, got[i].S12M2) ||
+				!f64_equals(want.A12Deg, got[i].A12Deg) {
+				t.Errorf("row %d -- DirectCalcBatch(%v) = %+v; want %+v", i, capabilities, got[i], want)
+			}
+			if capabilities&ALL == ALL && !almost_equal(got[i].A12Deg, a12[i], 1e-13) {
+				t.Errorf("row %d -- DirectCalcBatch() a12 = %v; want %v", i, got[i].A12Deg, a12[i])
+			}
+		}
+	}
+}
+
+func TestArcDirectCalcBatch(t *testing.T) {
+	geod := Wgs84()
+	lat1, lon1, azi1, lat2, lon2, s12, a12 := batch_columns()
+	got := make([]AllArcDirectResults, len(lat1))
+	if err := geod.ArcDirectCalcBatch(lat1, lon1, azi1, a12, ALL|LONG_UNROLL, got); err != nil {
+		t.Fatalf("ArcDirectCalcBatch() error = %v", err)
+	}
+	for i := range got {
+		if !almost_equal(got[i].LatDeg, lat2[i], 1e-13) || !almost_equal(got[i].LonDeg, lon2[i], 1e-13) ||
+			!almost_equal(got[i].DistanceM, s12[i], 1e-8) {
+			t.Errorf("row %d -- ArcDirectCalcBatch() = %+v; want %v, %v, %v", i, got[i], lat2[i], lon2[i], s12[i])
+		}
+	}
+}
+
+func TestBatchLengths(t *testing.T) {
+	geod := Wgs84()
+	three := []float64{1, 2, 3}
+	two := []float64{1, 2}
+	if err := geod.InverseCalcDistanceBatch(three, three, three, two, make([]float64, 3)); err == nil {
+		t.Errorf("InverseCalcDistanceBatch() with a short input succeeded; want error")
+	}
+	if err := geod.InverseCalcBatch(three, three, three, three, ALL, make([]AllInverseResults, 2)); err == nil {
+		t.Errorf("InverseCalcBatch() with a short output succeeded; want error")
+	}
+	lat2 := []float64{7, 7, 7}
+	if err := geod.DirectCalcLatLonBatch(three, three, three, three, lat2, two); err == nil || lat2[0] != 7 {
+		t.Errorf("DirectCalcLatLonBatch() with a short output = %v, wrote %v; want error and no results", err, lat2)
+	}
+	if err := geod.DirectCalcBatch(three, two, three, three, ALL, make([]AllDirectResults, 3)); err == nil {
+		t.Errorf("DirectCalcBatch() with a short input succeeded; want error")
+	}
+	if err := geod.ArcDirectCalcBatch(three, three, three, three, ALL, make([]AllArcDirectResults, 4)); err == nil {
+		t.Errorf("ArcDirectCalcBatch() with a long output succeeded; want error")
+	}
+	// Empty batches are fine
+	if err := geod.InverseCalcDistanceBatch(nil, nil, nil, nil, nil); err != nil {
+		t.Errorf("InverseCalcDistanceBatch() of an empty batch error = %v", err)
+	}
+}
+
+func TestBatchAllocations(t *testing.T) {
+	geod := Wgs84()
+	lat1, lon1, azi1, lat2, lon2, s12, a12 := batch_columns()
+	n := len(lat1)
+	out1 := make([]float64, n)
+	out2 := make([]float64, n)
+	inv := make([]AllInverseResults, n)
+	dir := make([]AllDirectResults, n)
+	arc := make([]AllArcDirectResults, n)
+	testCases := []struct {
+		desc string
+		f    func()
+	}{
+		{"InverseCalcDistanceBatch", func() { geod.InverseCalcDistanceBatch(lat1, lon1, lat2, lon2, out1) }},
+		{"InverseCalcBatch", func() { geod.InverseCalcBatch(lat1, lon1, lat2, lon2, ALL, inv) }},
+		{"DirectCalcLatLonBatch", func() { geod.DirectCalcLatLonBatch(lat1, lon1, azi1, s12, out1, out2) }},
+		{"DirectCalcBatch", func() { geod.DirectCalcBatch(lat1, lon1, azi1, s12, ALL, dir) }},
+		{"ArcDirectCalcBatch", func() { geod.ArcDirectCalcBatch(lat1, lon1, azi1, a12, ALL, arc) }},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			if allocs := testing.AllocsPerRun(10, tC.f); allocs != 0 {
+				t.Errorf("%s made %v allocations; want 0", tC.desc, allocs)
+			}
+		})
+	}
+}
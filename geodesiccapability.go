@@ -12,6 +12,12 @@ const (
 	_CAP_MASK uint64 = _CAP_ALL
 	_OUT_ALL  uint64 = 0x7F80
 
+	// GeodesicExact and GeodesicLineExact reuse the bits above for their own integrals:
+	// E (distance), D (reduced length and geodesic scale), and H (longitude)
+	_CAP_E uint64 = _CAP_C1
+	_CAP_D uint64 = _CAP_C2
+	_CAP_H uint64 = _CAP_C3
+
 	// Includes LONG_UNROLL
 	OUT_MASK      uint64 = 0xFF80
 	EMPTY         uint64 = 0
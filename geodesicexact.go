@@ -0,0 +1,1361 @@
+package geographiclibgo
+
+import "math"
+
+// GeodesicExact solves the direct and inverse geodesic problems in terms of elliptic
+// integrals, rather than the series expansions used by Geodesic. The results are
+// accurate to round-off for any flattening, at the cost of being slower than Geodesic.
+// Use this when |f| is larger than about 0.02, e.g. for very oblate or prolate
+// ellipsoids.
+//
+// GeodesicExact offers the same DirectCalc...(), ArcDirectCalc...(), InverseCalc...(),
+// and ...Line...() methods as Geodesic, and returns the same result types.
+type GeodesicExact struct {
+	a     float64
+	f     float64
+	f1    float64
+	e2    float64
+	ep2   float64
+	n     float64
+	b     float64
+	c2    float64
+	etol2 float64
+
+	maxit1_ uint64
+	maxit2_ uint64
+
+	// Number of terms in the sine series for the area integrand, and the transform
+	// used to find them
+	nC4_ int
+	_fft dst
+
+	tiny_    float64
+	tol0_    float64
+	tol1_    float64
+	tol2_    float64
+	tolb_    float64
+	xthresh_ float64
+}
+
+// Largest number of terms allowed in the sine series for the area integrand
+const _MAX_NC4 int = 1 << 16
+
+func NewGeodesicExact(a, f float64) GeodesicExact {
+	var maxit1_ uint64 = 20
+	maxit2_ := maxit1_ + _DIGITS + 10
+	tiny_ := math.Sqrt(get_min_val())
+	tol0_ := get_epsilon()
+	tol1_ := 200.0 * tol0_
+	tol2_ := math.Sqrt(tol0_)
+	tolb_ := tol0_
+	xthresh_ := 1000.0 * tol2_
+
+	_f1 := 1.0 - f
+	_e2 := f * (2.0 - f)
+	_ep2 := _e2 / sq(_f1)
+	_n := f / (2.0 - f)
+	_b := a * _f1
+
+	// The authalic radius squared
+	to_mul := 1.0
+	if f > 0.0 {
+		to_mul = math.Asinh(math.Sqrt(_ep2)) / math.Sqrt(math.Abs(_e2))
+	} else if f < 0.0 {
+		to_mul = math.Atan(math.Sqrt(-_e2)) / math.Sqrt(math.Abs(_e2))
+	}
+	_c2 := (sq(a) + sq(_b)*to_mul) / 2.0
+	_etol2 := 0.1 * tol2_ / math.Sqrt(math.Max(math.Abs(f), 0.001)*math.Min((1.0-f/2.0), 1.0)/2.0)
+
+	nC4_ := exact_area_order(_ep2, tol0_)
+
+	return GeodesicExact{
+		a:     a,
+		f:     f,
+		f1:    _f1,
+		e2:    _e2,
+		ep2:   _ep2,
+		n:     _n,
+		b:     _b,
+		c2:    _c2,
+		etol2: _etol2,
+
+		maxit1_: maxit1_,
+		maxit2_: maxit2_,
+
+		nC4_: nC4_,
+		_fft: new_dst(nC4_),
+
+		tiny_:    tiny_,
+		tol0_:    tol0_,
+		tol1_:    tol1_,
+		tol2_:    tol2_,
+		tolb_:    tolb_,
+		xthresh_: xthresh_,
+	}
+}
+
+func Wgs84Exact() GeodesicExact {
+	return NewGeodesicExact(WGS84_A, WGS84_F)
+}
+
+// EquatorialRadius returns the equatorial radius of the ellipsoid [meters]
+func (g *GeodesicExact) EquatorialRadius() float64 {
+	return g.a
+}
+
+// Flattening returns the flattening of the ellipsoid
+func (g *GeodesicExact) Flattening() float64 {
+	return g.f
+}
+
+// exact_area_order finds the number of terms (a power of two) needed in the sine series
+// for the area integrand. The series converges most slowly for a meridional geodesic,
+// k2 = ep2, so the number of terms is doubled until the upper half of the coefficients
+// for that case are negligible. The integrand is only computed to a few ulps, so
+// "negligible" allows for that noise.
+func exact_area_order(ep2, tol float64) int {
+	n := 16
+	for ; n < _MAX_NC4; n *= 2 {
+		c := make([]float64, n)
+		fft := new_dst(n)
+		integrand := new_i4_integrand(ep2, ep2)
+		fft.transform(integrand.eval, c)
+		tail := 0.0
+		for _, v := range c[n/2:] {
+			tail = math.Max(tail, math.Abs(v))
+		}
+		if !(tail > 64.0*tol*math.Abs(c[0])) {
+			break
+		}
+	}
+	return n
+}
+
+// i4_integrand is the integrand of the area integral, I4, as a function of sigma
+type i4_integrand struct {
+	x       float64
+	tx      float64
+	tdx     float64
+	sx      float64
+	sx1     float64
+	sxx1    float64
+	asinhsx float64
+	k2      float64
+}
+
+func new_i4_integrand(ep2, k2 float64) i4_integrand {
+	sx := math.Sqrt(math.Abs(ep2)) // ep
+	sx1 := math.Sqrt(1.0 + ep2)    // 1/(1-f)
+	asinhsx := math.Asin(sx)
+	if ep2 > 0.0 {
+		asinhsx = math.Asinh(sx)
+	}
+	return i4_integrand{
+		x:       ep2,
+		tx:      i4_t(ep2),
+		tdx:     i4_td(ep2),
+		sx:      sx,
+		sx1:     sx1,
+		sxx1:    sx * sx1,
+		asinhsx: asinhsx, // atanh(e)
+		k2:      k2,
+	}
+}
+
+// asinhsqrt returns asinh(sqrt(x))/sqrt(x)
+func asinhsqrt(x float64) float64 {
+	switch {
+	case x == 0.0:
+		return 1.0
+	case x > 0.0:
+		return math.Asinh(math.Sqrt(x)) / math.Sqrt(x)
+	default:
+		// NaNs end up here
+		return math.Asin(math.Sqrt(-x)) / math.Sqrt(-x)
+	}
+}
+
+// i4_t returns x + (sqrt(1 + x) * asinh(sqrt(x))/sqrt(x) - 1)
+func i4_t(x float64) float64 {
+	return x + (math.Sqrt(1.0+x)*asinhsqrt(x) - 1.0)
+}
+
+// i4_td returns the derivative of i4_t
+func i4_td(x float64) float64 {
+	if x == 0.0 {
+		return 4.0 / 3.0
+	}
+	return 1.0 + (1.0-asinhsqrt(x)/math.Sqrt(1.0+x))/(2.0*x)
+}
+
+// dtx returns the divided difference (t(x) - t(y)) / (x - y)
+func (ig i4_integrand) dtx(y float64) float64 {
+	if ig.x == y {
+		return ig.tdx
+	}
+	if ig.x*y <= 0.0 {
+		return (ig.tx - i4_t(y)) / (ig.x - y)
+	}
+	sy := math.Sqrt(math.Abs(y))
+	sy1 := math.Sqrt(1.0 + y)
+	z := (ig.x - y) / (ig.sx*sy1 + sy*ig.sx1)
+	d1 := 2.0 * ig.sx * sy
+	d2 := 2.0 * (ig.x*sy*sy1 + y*ig.sxx1)
+	if ig.x > 0.0 {
+		return 1.0 + (math.Asinh(z)/z)/d1 - (ig.asinhsx+math.Asinh(sy))/d2
+	}
+	// NaNs fall through to here
+	return 1.0 - (math.Asin(z)/z)/d1 - (ig.asinhsx+math.Asin(sy))/d2
+}
+
+func (ig i4_integrand) eval(sig float64) float64 {
+	ssig := math.Sin(sig)
+	return -ig.dtx(ig.k2*sq(ssig)) * ssig / 2.0
+}
+
+// _C4f fills c with the nC4_ coefficients of the sine series for the area integrand
+func (g *GeodesicExact) _C4f(k2 float64, c []float64) {
+	g._fft.transform(new_i4_integrand(g.ep2, k2).eval, c)
+}
+
+// _dn returns sqrt(1 + ep2 * sbet^2), computed in a way that is accurate for both
+// oblate and prolate ellipsoids
+func (g *GeodesicExact) _dn(sbet, cbet float64) float64 {
+	if g.f >= 0.0 {
+		return math.Sqrt(1.0 + g.ep2*sq(sbet))
+	}
+	return math.Sqrt(1.0-g.e2*sq(cbet)) / g.f1
+}
+
+func (g *GeodesicExact) _Lengths(
+	E *EllipticFunction,
+	sig12, ssig1, csig1, dn1, ssig2, csig2, dn2, cbet1, cbet2 float64,
+	outmask uint64,
+) (float64, float64, float64, float64, float64) {
+	outmask &= OUT_MASK
+	s12b := math.NaN()
+	m12b := math.NaN()
+	m0 := math.NaN()
+	M12 := math.NaN()
+	M21 := math.NaN()
+
+	if outmask&DISTANCE != 0 {
+		// Missing a factor of b
+		s12b = E.E() / (math.Pi / 2.0) *
+			(sig12 + (E.DeltaE(ssig2, csig2, dn2) - E.DeltaE(ssig1, csig1, dn1)))
+	}
+
+	if outmask&(REDUCEDLENGTH|GEODESICSCALE) != 0 {
+		m0x := -E.K2() * E.D() / (math.Pi / 2.0)
+		J12 := m0x * (sig12 + (E.DeltaD(ssig2, csig2, dn2) - E.DeltaD(ssig1, csig1, dn1)))
+		if outmask&REDUCEDLENGTH != 0 {
+			m0 = m0x
+			// Missing a factor of b
+			m12b = dn2*(csig1*ssig2) - dn1*(ssig1*csig2) - csig1*csig2*J12
+		}
+		if outmask&GEODESICSCALE != 0 {
+			csig12 := csig1*csig2 + ssig1*ssig2
+			t := g.ep2 * (cbet1 - cbet2) * (cbet1 + cbet2) / (dn1 + dn2)
+			M12 = csig12 + (t*ssig2-csig2*J12)*ssig1/dn1
+			M21 = csig12 - (t*ssig1-csig1*J12)*ssig2/dn2
+		}
+	}
+	return s12b, m12b, m0, M12, M21
+}
+
+func (g *GeodesicExact) _InverseStart(
+	E *EllipticFunction,
+	sbet1, cbet1, dn1, sbet2, cbet2, dn2, lam12, slam12, clam12 float64,
+) (float64, float64, float64, float64, float64, float64) {
+	sig12 := -1.0
+	salp2 := math.NaN()
+	calp2 := math.NaN()
+	dnm := math.NaN()
+
+	var somg12 float64
+	var comg12 float64
+
+	sbet12 := sbet2*cbet1 - cbet2*sbet1
+	cbet12 := cbet2*cbet1 + sbet2*sbet1
+
+	sbet12a := sbet2 * cbet1
+	sbet12a += cbet2 * sbet1
+
+	shortline := cbet12 >= 0.0 && sbet12 < 0.5 && cbet2*lam12 < 0.5
+	if shortline {
+		sbetm2 := sq(sbet1 + sbet2)
+		sbetm2 /= sbetm2 + sq(cbet1+cbet2)
+		dnm = math.Sqrt(1.0 + g.ep2*sbetm2)
+		omg12 := lam12 / (g.f1 * dnm)
+		somg12 = math.Sin(omg12)
+		comg12 = math.Cos(omg12)
+	} else {
+		somg12 = slam12
+		comg12 = clam12
+	}
+
+	salp1 := cbet2 * somg12
+
+	calp1 := sbet12a - cbet2*sbet1*sq(somg12)/(1.0-comg12)
+	if comg12 >= 0.0 {
+		calp1 = sbet12 + cbet2*sbet1*sq(somg12)/(1.0+comg12)
+	}
+
+	ssig12 := math.Hypot(salp1, calp1)
+	csig12 := sbet1*sbet2 + cbet1*cbet2*comg12
+
+	if shortline && (ssig12 < g.etol2) {
+		salp2 = cbet1 * somg12
+		var to_mul float64
+		if comg12 >= 0.0 {
+			to_mul = sq(somg12) / (1.0 + comg12)
+		} else {
+			to_mul = 1.0 - comg12
+		}
+		calp2 = sbet12 - cbet1*sbet2*to_mul
+
+		salp2, calp2 = norm(salp2, calp2)
+		sig12 = math.Atan2(ssig12, csig12)
+	} else if math.Abs(g.n) > 0.1 || csig12 >= 0.0 || ssig12 >= 6.0*math.Abs(g.n)*math.Pi*sq(cbet1) {
+	} else {
+		var x float64
+		var y float64
+		var betscale float64
+		var lamscale float64
+		lam12x := math.Atan2(-slam12, -clam12)
+		if g.f >= 0.0 {
+			k2 := sq(sbet1) * g.ep2
+			E.Reset(-k2, -g.ep2, 1.0+k2, 1.0+g.ep2)
+			lamscale = g.e2 / g.f1 * cbet1 * 2.0 * E.H()
+			betscale = lamscale * cbet1
+			x = lam12x / lamscale
+			y = sbet12a / betscale
+		} else {
+			cbet12a := cbet2*cbet1 - sbet2*sbet1
+			bet12a := math.Atan2(sbet12, cbet12a)
+			_, m12b, m0, _, _ := g._Lengths(
+				E,
+				math.Pi+bet12a,
+				sbet1,
+				-cbet1,
+				dn1,
+				sbet2,
+				cbet2,
+				dn2,
+				cbet1,
+				cbet2,
+				REDUCEDLENGTH,
+			)
+			x = -1.0 + m12b/(cbet1*cbet2*m0*math.Pi)
+			if x < -0.01 {
+				betscale = sbet12a / x
+			} else {
+				betscale = -g.f * sq(cbet1) * math.Pi
+			}
+			lamscale = betscale / cbet1
+			y = lam12x / lamscale
+		}
+		if y > -g.tol1_ && x > -1.0-g.xthresh_ {
+			if g.f >= 0.0 {
+				salp1 = math.Min(-x, 1.0)
+				calp1 = -math.Sqrt(1.0 - sq(salp1))
+			} else {
+				var to_compare float64
+				if x > -g.tol1_ {
+					to_compare = 0.0
+				} else {
+					to_compare = -1.0
+				}
+				calp1 = math.Max(x, to_compare)
+				salp1 = math.Sqrt(1.0 - sq(calp1))
+			}
+		} else {
+			k := astroid(x, y)
+			var to_mul float64
+			if g.f >= 0.0 {
+				to_mul = -x * k / (1.0 + k)
+			} else {
+				to_mul = -y * (1.0 + k) / k
+			}
+
+			omg12a := lamscale * to_mul
+			somg12 = math.Sin(omg12a)
+			comg12 = -math.Cos(omg12a)
+			salp1 = cbet2 * somg12
+			calp1 = sbet12a - cbet2*sbet1*sq(somg12)/(1.0-comg12)
+		}
+	}
+
+	if !(salp1 <= 0.0) {
+		salp1, calp1 = norm(salp1, calp1)
+	} else {
+		salp1 = 1.0
+		calp1 = 0.0
+	}
+	return sig12, salp1, calp1, salp2, calp2, dnm
+}
+
+func (g *GeodesicExact) _Lambda12(
+	sbet1, cbet1, dn1, sbet2, cbet2, dn2, salp1, calp1, slam120, clam120 float64,
+	diffp bool,
+	E *EllipticFunction,
+) (float64, float64, float64, float64, float64, float64, float64, float64, float64, float64) {
+
+	if sbet1 == 0.0 && calp1 == 0.0 {
+		calp1 = -g.tiny_
+	}
+	salp0 := salp1 * cbet1
+	calp0 := math.Hypot(calp1, salp1*sbet1)
+
+	ssig1 := sbet1
+	somg1 := salp0 * sbet1
+	csig1 := calp1 * cbet1
+	comg1 := calp1 * cbet1
+	// Without normalization we have schi1 = somg1
+	cchi1 := g.f1 * dn1 * comg1
+	ssig1, csig1 = norm(ssig1, csig1)
+
+	var salp2 float64
+	if cbet2 != cbet1 {
+		salp2 = salp0 / cbet2
+	} else {
+		salp2 = salp1
+	}
+
+	var to_add float64
+	if cbet1 < -sbet1 {
+		to_add = (cbet2 - cbet1) * (cbet1 + cbet2)
+	} else {
+		to_add = (sbet1 - sbet2) * (sbet1 + sbet2)
+	}
+
+	calp2 := math.Abs(calp1)
+	if cbet2 != cbet1 || math.Abs(sbet2) != -sbet1 {
+		calp2 = math.Sqrt(sq(calp1*cbet1)+to_add) / cbet2
+	}
+
+	ssig2 := sbet2
+	somg2 := salp0 * sbet2
+	csig2 := calp2 * cbet2
+	comg2 := calp2 * cbet2
+	// Without normalization we have schi2 = somg2
+	cchi2 := g.f1 * dn2 * comg2
+	ssig2, csig2 = norm(ssig2, csig2)
+
+	sig12 := math.Atan2(math.Max(csig1*ssig2-ssig1*csig2, 0.0), csig1*csig2+ssig1*ssig2)
+	somg12 := math.Max((comg1*somg2 - somg1*comg2), 0.0)
+	comg12 := comg1*comg2 + somg1*somg2
+
+	k2 := sq(calp0) * g.ep2
+	E.Reset(-k2, -g.ep2, 1.0+k2, 1.0+g.ep2)
+
+	// chi12 = chi2 - chi1, limited to [0, pi]
+	schi12 := math.Max((cchi1*somg2 - somg1*cchi2), 0.0)
+	cchi12 := cchi1*cchi2 + somg1*somg2
+	// eta = chi12 - lam120
+	eta := math.Atan2(schi12*clam120-cchi12*slam120, cchi12*clam120+schi12*slam120)
+	deta12 := -g.e2 / g.f1 * salp0 * E.H() / (math.Pi / 2.0) *
+		(sig12 + (E.DeltaH(ssig2, csig2, dn2) - E.DeltaH(ssig1, csig1, dn1)))
+	lam12 := eta + deta12
+	// domg12 = deta12 + chi12 - omg12
+	domg12 := deta12 + math.Atan2(schi12*comg12-cchi12*somg12, cchi12*comg12+schi12*somg12)
+
+	var dlam12 float64
+	if diffp {
+		if calp2 == 0.0 {
+			dlam12 = -2.0 * g.f1 * dn1 / sbet1
+		} else {
+			_, res, _, _, _ := g._Lengths(
+				E,
+				sig12,
+				ssig1,
+				csig1,
+				dn1,
+				ssig2,
+				csig2,
+				dn2,
+				cbet1,
+				cbet2,
+				REDUCEDLENGTH,
+			)
+			dlam12 = res
+			dlam12 *= g.f1 / (calp2 * cbet2)
+		}
+	} else {
+		dlam12 = math.NaN()
+	}
+	return lam12, salp2, calp2, sig12, ssig1, csig1, ssig2, csig2, domg12, dlam12
+}
+
+func (g *GeodesicExact) _gen_inverse_azi(
+	lat1, lon1, lat2, lon2 float64,
+	outmask uint64,
+) (
+	a12 float64,
+	s12 float64,
+	azi1 float64,
+	azi2 float64,
+	m12 float64,
+	M12 float64,
+	M21 float64,
+	S12 float64,
+) {
+
+	azi1 = math.NaN()
+	azi2 = math.NaN()
+	outmask &= OUT_MASK
+
+	a12, s12, salp1, calp1, salp2, calp2, m12, M12, M21, S12 := g._gen_inverse(
+		lat1, lon1, lat2, lon2, outmask,
+	)
+
+	if outmask&AZIMUTH != 0 {
+		azi1 = atan2_deg(salp1, calp1)
+		azi2 = atan2_deg(salp2, calp2)
+	}
+	return a12, s12, azi1, azi2, m12, M12, M21, S12
+}
+
+func (g *GeodesicExact) _gen_inverse(lat1, lon1, lat2, lon2 float64, outmask uint64) (
+	a12 float64,
+	s12 float64,
+	salp1 float64,
+	calp1 float64,
+	salp2 float64,
+	calp2 float64,
+	m12 float64,
+	M12 float64,
+	M21 float64,
+	S12 float64,
+) {
+	a12 = math.NaN()
+	s12 = math.NaN()
+	m12 = math.NaN()
+	M12 = math.NaN()
+	M21 = math.NaN()
+	S12 = math.NaN()
+	outmask &= OUT_MASK
+
+	lon12, lon12s := ang_diff(lon1, lon2)
+	var lonsign float64
+	if lon12 >= 0.0 {
+		lonsign = 1.0
+	} else {
+		lonsign = -1.0
+	}
+
+	lon12 = lonsign * ang_round(lon12)
+	lon12s = ang_round((180.0 - lon12) - lonsign*lon12s)
+	lam12 := lon12 * DEG2RAD
+	var slam12 float64
+	var clam12 float64
+	if lon12 > 90.0 {
+		slam12, clam12 = sincosd(lon12s)
+		clam12 = -clam12
+	} else {
+		slam12, clam12 = sincosd(lon12)
+	}
+	lat1 = ang_round(lat_fix(lat1))
+	lat2 = ang_round(lat_fix(lat2))
+
+	var swapp float64
+	if math.Abs(lat1) < math.Abs(lat2) {
+		swapp = -1.0
+	} else {
+		swapp = 1.0
+	}
+
+	if swapp < 0.0 {
+		lonsign *= -1.0
+		lat2, lat1 = lat1, lat2
+	}
+
+	var latsign float64
+	if lat1 < 0.0 {
+		latsign = 1.0
+	} else {
+		latsign = -1.0
+	}
+	lat1 *= latsign
+	lat2 *= latsign
+
+	sbet1, cbet1 := sincosd(lat1)
+	sbet1 *= g.f1
+
+	sbet1, cbet1 = norm(sbet1, cbet1)
+	cbet1 = math.Max(cbet1, g.tiny_)
+
+	sbet2, cbet2 := sincosd(lat2)
+	sbet2 *= g.f1
+
+	sbet2, cbet2 = norm(sbet2, cbet2)
+	cbet2 = math.Max(cbet2, g.tiny_)
+
+	if cbet1 < -sbet1 {
+		if cbet2 == cbet1 {
+			if sbet2 < 0.0 {
+				sbet2 = sbet1
+			} else {
+				sbet2 = -sbet1
+			}
+		}
+	} else if math.Abs(sbet2) == -sbet1 {
+		cbet2 = cbet1
+	}
+
+	dn1 := g._dn(sbet1, cbet1)
+	dn2 := g._dn(sbet2, cbet2)
+
+	meridian := lat1 == -90.0 || slam12 == 0.0
+	calp1 = 0.0
+	salp1 = 0.0
+	calp2 = 0.0
+	salp2 = 0.0
+	ssig1 := 0.0
+	csig1 := 0.0
+	ssig2 := 0.0
+	csig2 := 0.0
+	var sig12 float64
+	s12x := 0.0
+	m12x := 0.0
+
+	E := NewEllipticFunction(-g.ep2, 0.0)
+
+	if meridian {
+		calp1 = clam12
+		salp1 = slam12
+		calp2 = 1.0
+		salp2 = 0.0
+
+		ssig1 = sbet1
+		csig1 = calp1 * cbet1
+		ssig2 = sbet2
+		csig2 = calp2 * cbet2
+
+		sig12 = math.Atan2(math.Max((csig1*ssig2-ssig1*csig2), 0.0), csig1*csig2+ssig1*ssig2)
+		res1, res2, _, res4, res5 := g._Lengths(
+			&E,
+			sig12,
+			ssig1,
+			csig1,
+			dn1,
+			ssig2,
+			csig2,
+			dn2,
+			cbet1,
+			cbet2,
+			outmask|DISTANCE|REDUCEDLENGTH,
+		)
+		s12x = res1
+		m12x = res2
+		M12 = res4
+		M21 = res5
+
+		if sig12 < g.tol2_ || m12x >= 0.0 {
+			if sig12 < 3.0*g.tiny_ || (sig12 < g.tol0_ && (s12x < 0.0 || m12x < 0.0)) {
+				sig12 = 0.0
+				m12x = 0.0
+				s12x = 0.0
+			}
+			m12x *= g.b
+			s12x *= g.b
+			a12 = sig12 * RAD2DEG
+		} else {
+			meridian = false
+		}
+	}
+
+	somg12 := 2.0
+	comg12 := 0.0
+	omg12 := 0.0
+	var dnm float64
+
+	if !meridian && sbet1 == 0.0 && (g.f <= 0.0 || lon12s >= g.f*180.0) {
+		calp1 = 0.0
+		calp2 = 0.0
+		salp1 = 1.0
+		salp2 = 1.0
+
+		s12x = g.a * lam12
+		sig12 = lam12 / g.f1
+		omg12 = lam12 / g.f1
+		m12x = g.b * math.Sin(sig12)
+		if outmask&GEODESICSCALE != 0 {
+			M12 = math.Cos(sig12)
+			M21 = math.Cos(sig12)
+		}
+		a12 = lon12 / g.f1
+	} else if !meridian {
+		sig12, salp1, calp1, salp2, calp2, dnm = g._InverseStart(
+			&E, sbet1, cbet1, dn1, sbet2, cbet2, dn2, lam12, slam12, clam12,
+		)
+
+		if sig12 >= 0.0 {
+			s12x = sig12 * g.b * dnm
+			m12x = sq(dnm) * g.b * math.Sin(sig12/dnm)
+			if outmask&GEODESICSCALE != 0 {
+				M12 = math.Cos(sig12 / dnm)
+				M21 = math.Cos(sig12 / dnm)
+			}
+			a12 = sig12 * RAD2DEG
+			omg12 = lam12 / (g.f1 * dnm)
+		} else {
+			tripn := false
+			tripb := false
+			salp1a := g.tiny_
+			calp1a := 1.0
+			salp1b := g.tiny_
+			calp1b := -1.0
+			domg12 := 0.0
+
+			for numit := uint64(0); numit < g.maxit2_; numit++ {
+				var v, dv float64
+				v, salp2, calp2, sig12, ssig1, csig1, ssig2, csig2, domg12, dv = g._Lambda12(
+					sbet1,
+					cbet1,
+					dn1,
+					sbet2,
+					cbet2,
+					dn2,
+					salp1,
+					calp1,
+					slam12,
+					clam12,
+					numit < g.maxit1_,
+					&E,
+				)
+
+				var to_mul float64
+				if tripn {
+					to_mul = 8.0
+				} else {
+					to_mul = 1.0
+				}
+				if tripb || !(math.Abs(v) >= to_mul*g.tol0_) {
+					break
+				}
+				if v > 0.0 && (numit > g.maxit1_ || calp1/salp1 > calp1b/salp1b) {
+					salp1b = salp1
+					calp1b = calp1
+				} else if v < 0.0 && (numit > g.maxit1_ || calp1/salp1 < calp1a/salp1a) {
+					salp1a = salp1
+					calp1a = calp1
+				}
+				if numit < g.maxit1_ && dv > 0.0 {
+					dalp1 := -v / dv
+					sdalp1 := math.Sin(dalp1)
+					cdalp1 := math.Cos(dalp1)
+					nsalp1 := salp1*cdalp1 + calp1*sdalp1
+					if nsalp1 > 0.0 && math.Abs(dalp1) < math.Pi {
+						calp1 = calp1*cdalp1 - salp1*sdalp1
+						salp1 = nsalp1
+						salp1, calp1 = norm(salp1, calp1)
+						tripn = math.Abs(v) <= 16.0*g.tol0_
+						continue
+					}
+				}
+
+				salp1 = (salp1a + salp1b) / 2.0
+				calp1 = (calp1a + calp1b) / 2.0
+				salp1, calp1 = norm(salp1, calp1)
+				tripn = false
+				tripb = math.Abs(salp1a-salp1)+(calp1a-calp1) < g.tolb_ || math.Abs(salp1-salp1b)+(calp1-calp1b) < g.tolb_
+			}
+			var to_cmp uint64
+			if outmask&(REDUCEDLENGTH|GEODESICSCALE) != 0 {
+				to_cmp = DISTANCE
+			} else {
+				to_cmp = EMPTY
+			}
+
+			lengthmask := outmask | to_cmp
+			s12x, m12x, _, M12, M21 = g._Lengths(
+				&E, sig12, ssig1, csig1, dn1, ssig2, csig2, dn2, cbet1, cbet2, lengthmask,
+			)
+
+			m12x *= g.b
+			s12x *= g.b
+			a12 = sig12 * RAD2DEG
+			if outmask&AREA != 0 {
+				sdomg12 := math.Sin(domg12)
+				cdomg12 := math.Cos(domg12)
+				somg12 = slam12*cdomg12 - clam12*sdomg12
+				comg12 = clam12*cdomg12 + slam12*sdomg12
+			}
+		}
+	}
+
+	if outmask&DISTANCE != 0 {
+		s12 = 0.0 + s12x
+	}
+	if outmask&REDUCEDLENGTH != 0 {
+		m12 = 0.0 + m12x
+	}
+
+	if outmask&AREA != 0 {
+		salp0 := salp1 * cbet1
+		calp0 := math.Hypot(calp1, salp1*sbet1)
+		if calp0 != 0.0 && salp0 != 0.0 {
+			ssig1 = sbet1
+			csig1 = calp1 * cbet1
+			ssig2 = sbet2
+			csig2 = calp2 * cbet2
+			k2 := sq(calp0) * g.ep2
+			A4 := sq(g.a) * calp0 * salp0 * g.e2
+			ssig1, csig1 = norm(ssig1, csig1)
+			ssig2, csig2 = norm(ssig2, csig2)
+			C4a := make([]float64, g.nC4_)
+			g._C4f(k2, C4a)
+			B41 := dst_integral(ssig1, csig1, C4a)
+			B42 := dst_integral(ssig2, csig2, C4a)
+			S12 = A4 * (B42 - B41)
+		} else {
+			S12 = 0.0
+		}
+
+		if !meridian && somg12 > 1.0 {
+			somg12, comg12 = math.Sincos(omg12)
+		}
+
+		var alp12 float64
+		if !meridian && comg12 > -0.7071 && sbet2-sbet1 < 1.75 {
+			domg12 := 1.0 + comg12
+			dbet1 := 1.0 + cbet1
+			dbet2 := 1.0 + cbet2
+			alp12 = 2.0 * math.Atan2(somg12*(sbet1*dbet2+sbet2*dbet1), domg12*(sbet1*sbet2+dbet1*dbet2))
+		} else {
+			salp12 := salp2*calp1 - calp2*salp1
+			calp12 := calp2*calp1 + salp2*salp1
+
+			if salp12 == 0.0 && calp12 < 0.0 {
+				salp12 = g.tiny_ * calp1
+				calp12 = -1.0
+			}
+			alp12 = math.Atan2(salp12, calp12)
+		}
+		S12 += g.c2 * alp12
+		S12 *= swapp * lonsign * latsign
+		S12 += 0.0
+	}
+
+	if swapp < 0.0 {
+		salp2, salp1 = salp1, salp2
+		calp2, calp1 = calp1, calp2
+		if outmask&GEODESICSCALE != 0 {
+			M21, M12 = M12, M21
+		}
+	}
+	salp1 *= swapp * lonsign
+	calp1 *= swapp * latsign
+	salp2 *= swapp * lonsign
+	calp2 *= swapp * latsign
+
+	return a12, s12, salp1, calp1, salp2, calp2, m12, M12, M21, S12
+}
+
+// _gen_direct returns (a12, lat2, lon2, azi2, s12, m12, M12, M21, S12, outmask)
+func (g *GeodesicExact) _gen_direct(
+	lat1 float64,
+	lon1 float64,
+	azi1 float64,
+	arcmode bool,
+	s12_a12 float64,
+	outmask uint64,
+) (float64, float64, float64, float64, float64, float64, float64, float64, float64, uint64) {
+	if !arcmode {
+		outmask |= DISTANCE_IN
+	}
+
+	line := NewGeodesicLineExactWithCapability(*g, lat1, lon1, azi1, outmask)
+	a12, lat2, lon2, azi2, s12, m12, M12, M21, S12 := line._gen_position(arcmode, s12_a12, outmask)
+
+	return a12, lat2, lon2, azi2, s12, m12, M12, M21, S12, outmask
+}
+
+// DirectCalcLatLon gets the lat and lon of the second point, based on input
+//   - lat1_deg - Latitude of 1st point [degrees] [-90.,90.]
+//   - lon1_deg - Longitude of 1st point [degrees] [-180., 180.]
+//   - azi1_deg - Azimuth at 1st point [degrees] [-180., 180.]
+//   - s12_m - Distance from 1st to 2nd point [meters] Value may be negative
+func (g *GeodesicExact) DirectCalcLatLon(lat1_deg, lon1_deg, azi1_deg, s12_m float64) LatLon {
+	capabilities := LATITUDE | LONGITUDE
+	_, lat2, lon2, _, _, _, _, _, _, _ := g._gen_direct(
+		lat1_deg, lon1_deg, azi1_deg, false, s12_m, capabilities,
+	)
+	return LatLon{LatDeg: lat2, LonDeg: lon2}
+}
+
+// DirectCalcLatLonAzi gets the lat, lon, and azimuth of the second point, based on input
+//   - lat1_deg - Latitude of 1st point [degrees] [-90.,90.]
+//   - lon1_deg - Longitude of 1st point [degrees] [-180., 180.]
+//   - azi1_deg - Azimuth at 1st point [degrees] [-180., 180.]
+//   - s12_m - Distance from 1st to 2nd point [meters] Value may be negative
+func (g *GeodesicExact) DirectCalcLatLonAzi(lat1_deg, lon1_deg, azi1_deg, s12_m float64) LatLonAzi {
+	capabilities := LATITUDE | LONGITUDE | AZIMUTH
+	_, lat2, lon2, azi2, _, _, _, _, _, _ := g._gen_direct(
+		lat1_deg, lon1_deg, azi1_deg, false, s12_m, capabilities,
+	)
+	return LatLonAzi{LatDeg: lat2, LonDeg: lon2, AziDeg: azi2}
+}
+
+// DirectCalcLatLonAziReducedLength gets the lat, lon, azimuth, and reduced length of geodesic
+// of the second point, based on input
+//   - lat1_deg - Latitude of 1st point [degrees] [-90.,90.]
+//   - lon1_deg - Longitude of 1st point [degrees] [-180., 180.]
+//   - azi1_deg - Azimuth at 1st point [degrees] [-180., 180.]
+//   - s12_m - Distance from 1st to 2nd point [meters] Value may be negative
+func (g *GeodesicExact) DirectCalcLatLonAziReducedLength(lat1_deg, lon1_deg, azi1_deg, s12_m float64) LatLonAziReducedLength {
+	capabilities := LATITUDE | LONGITUDE | AZIMUTH | REDUCEDLENGTH
+	_, lat2, lon2, azi2, _, m12, _, _, _, _ := g._gen_direct(
+		lat1_deg, lon1_deg, azi1_deg, false, s12_m, capabilities,
+	)
+	return LatLonAziReducedLength{LatDeg: lat2, LonDeg: lon2, AziDeg: azi2, ReducedLengthM: m12}
+}
+
+// DirectCalcLatLonAziGeodesicScales gets the lat, lon, azimuth, and geodesic scales,
+// based on input
+//   - lat1_deg - Latitude of 1st point [degrees] [-90.,90.]
+//   - lon1_deg - Longitude of 1st point [degrees] [-180., 180.]
+//   - azi1_deg - Azimuth at 1st point [degrees] [-180., 180.]
+//   - s12_m - Distance from 1st to 2nd point [meters] Value may be negative
+func (g *GeodesicExact) DirectCalcLatLonAziGeodesicScales(lat1_deg, lon1_deg, azi1_deg, s12_m float64) LatLonAziGeodesicScales {
+	capabilities := LATITUDE | LONGITUDE | AZIMUTH | GEODESICSCALE
+	_, lat2, lon2, azi2, _, _, M12, M21, _, _ := g._gen_direct(
+		lat1_deg, lon1_deg, azi1_deg, false, s12_m, capabilities,
+	)
+	return LatLonAziGeodesicScales{LatDeg: lat2, LonDeg: lon2, AziDeg: azi2, M12: M12, M21: M21}
+}
+
+// DirectCalcLatLonAziReducedLengthGeodesicScales gets the lat, lon, azimuth, reduced length,
+// and geodesic scales based on input
+//   - lat1_deg - Latitude of 1st point [degrees] [-90.,90.]
+//   - lon1_deg - Longitude of 1st point [degrees] [-180., 180.]
+//   - azi1_deg - Azimuth at 1st point [degrees] [-180., 180.]
+//   - s12_m - Distance from 1st to 2nd point [meters] Value may be negative
+func (g *GeodesicExact) DirectCalcLatLonAziReducedLengthGeodesicScales(
+	lat1_deg, lon1_deg, azi1_deg, s12_m float64,
+) LatLonAziReducedLengthGeodesicScales {
+	capabilities := LATITUDE | LONGITUDE | AZIMUTH | REDUCEDLENGTH | GEODESICSCALE
+	_, lat2, lon2, azi2, _, m12, M12, M21, _, _ := g._gen_direct(
+		lat1_deg, lon1_deg, azi1_deg, false, s12_m, capabilities,
+	)
+	return LatLonAziReducedLengthGeodesicScales{
+		LatDeg:         lat2,
+		LonDeg:         lon2,
+		AziDeg:         azi2,
+		ReducedLengthM: m12,
+		M12:            M12,
+		M21:            M21,
+	}
+}
+
+// DirectCalcAll calculates everything possible for the direct method. Takes inputs
+//   - lat1_deg - Latitude of 1st point [degrees] [-90.,90.]
+//   - lon1_deg - Longitude of 1st point [degrees] [-180., 180.]
+//   - azi1_deg - Azimuth at 1st point [degrees] [-180., 180.]
+//   - s12_m - Distance from 1st to 2nd point [meters] Value may be negative
+func (g *GeodesicExact) DirectCalcAll(lat1_deg, lon1_deg, azi1_deg, s12_m float64) AllDirectResults {
+	capabilities := LATITUDE | LONGITUDE | AZIMUTH | REDUCEDLENGTH | GEODESICSCALE | AREA
+	return g.DirectCalcWithCapabilities(lat1_deg, lon1_deg, azi1_deg, s12_m, capabilities)
+}
+
+// DirectCalcWithCapabilities allows the user to specify which capabilites they wish to use.
+// This function is useful if you want some other subset of capabilities than those offered
+// by the other DirectCalc...() methods.
+// Takes inputs
+//   - lat1_deg - Latitude of 1st point [degrees] [-90.,90.]
+//   - lon1_deg - Longitude of 1st point [degrees] [-180., 180.]
+//   - azi1_deg - Azimuth at 1st point [degrees] [-180., 180.]
+//   - capabilities - One or more of the capabilities constant as defined in the file
+//     geodesiccapability.go. Usually, they are OR'd together, e.g. LATITUDE | LONGITUDE
+func (g *GeodesicExact) DirectCalcWithCapabilities(
+	lat1_deg, lon1_deg, azi1_deg, s12_m float64,
+	capabilities uint64,
+) AllDirectResults {
+	a12, lat2, lon2, azi2, _, m12, M12, M21, S12, _ := g._gen_direct(
+		lat1_deg, lon1_deg, azi1_deg, false, s12_m, capabilities,
+	)
+	return AllDirectResults{
+		LatDeg:         lat2,
+		LonDeg:         lon2,
+		AziDeg:         azi2,
+		ReducedLengthM: m12,
+		M12:            M12,
+		M21:            M21,
+		S12M2:          S12,
+		A12Deg:         a12,
+	}
+}
+
+// ArcDirectCalcLatLon gets the lat and lon of the second point, where the second point
+// is specified by the arc length on the auxiliary sphere. Takes inputs
+//   - lat1_deg - Latitude of 1st point [degrees] [-90.,90.]
+//   - lon1_deg - Longitude of 1st point [degrees] [-180., 180.]
+//   - azi1_deg - Azimuth at 1st point [degrees] [-180., 180.]
+//   - a12_deg - Arc length from 1st to 2nd point [degrees] Value may be negative
+func (g *GeodesicExact) ArcDirectCalcLatLon(lat1_deg, lon1_deg, azi1_deg, a12_deg float64) LatLon {
+	capabilities := LATITUDE | LONGITUDE
+	_, lat2, lon2, _, _, _, _, _, _, _ := g._gen_direct(
+		lat1_deg, lon1_deg, azi1_deg, true, a12_deg, capabilities,
+	)
+	return LatLon{LatDeg: lat2, LonDeg: lon2}
+}
+
+// ArcDirectCalcLatLonAzi gets the lat, lon, and azimuth of the second point, where the
+// second point is specified by the arc length on the auxiliary sphere. Takes inputs
+//   - lat1_deg - Latitude of 1st point [degrees] [-90.,90.]
+//   - lon1_deg - Longitude of 1st point [degrees] [-180., 180.]
+//   - azi1_deg - Azimuth at 1st point [degrees] [-180., 180.]
+//   - a12_deg - Arc length from 1st to 2nd point [degrees] Value may be negative
+func (g *GeodesicExact) ArcDirectCalcLatLonAzi(lat1_deg, lon1_deg, azi1_deg, a12_deg float64) LatLonAzi {
+	capabilities := LATITUDE | LONGITUDE | AZIMUTH
+	_, lat2, lon2, azi2, _, _, _, _, _, _ := g._gen_direct(
+		lat1_deg, lon1_deg, azi1_deg, true, a12_deg, capabilities,
+	)
+	return LatLonAzi{LatDeg: lat2, LonDeg: lon2, AziDeg: azi2}
+}
+
+// ArcDirectCalcLatLonAziReducedLength gets the lat, lon, azimuth, and reduced length of
+// geodesic of the second point, where the second point is specified by the arc length on
+// the auxiliary sphere. Takes inputs
+//   - lat1_deg - Latitude of 1st point [degrees] [-90.,90.]
+//   - lon1_deg - Longitude of 1st point [degrees] [-180., 180.]
+//   - azi1_deg - Azimuth at 1st point [degrees] [-180., 180.]
+//   - a12_deg - Arc length from 1st to 2nd point [degrees] Value may be negative
+func (g *GeodesicExact) ArcDirectCalcLatLonAziReducedLength(
+	lat1_deg, lon1_deg, azi1_deg, a12_deg float64,
+) LatLonAziReducedLength {
+	capabilities := LATITUDE | LONGITUDE | AZIMUTH | REDUCEDLENGTH
+	_, lat2, lon2, azi2, _, m12, _, _, _, _ := g._gen_direct(
+		lat1_deg, lon1_deg, azi1_deg, true, a12_deg, capabilities,
+	)
+	return LatLonAziReducedLength{LatDeg: lat2, LonDeg: lon2, AziDeg: azi2, ReducedLengthM: m12}
+}
+
+// ArcDirectCalcLatLonAziGeodesicScales gets the lat, lon, azimuth, and geodesic scales,
+// where the second point is specified by the arc length on the auxiliary sphere.
+// Takes inputs
+//   - lat1_deg - Latitude of 1st point [degrees] [-90.,90.]
+//   - lon1_deg - Longitude of 1st point [degrees] [-180., 180.]
+//   - azi1_deg - Azimuth at 1st point [degrees] [-180., 180.]
+//   - a12_deg - Arc length from 1st to 2nd point [degrees] Value may be negative
+func (g *GeodesicExact) ArcDirectCalcLatLonAziGeodesicScales(
+	lat1_deg, lon1_deg, azi1_deg, a12_deg float64,
+) LatLonAziGeodesicScales {
+	capabilities := LATITUDE | LONGITUDE | AZIMUTH | GEODESICSCALE
+	_, lat2, lon2, azi2, _, _, M12, M21, _, _ := g._gen_direct(
+		lat1_deg, lon1_deg, azi1_deg, true, a12_deg, capabilities,
+	)
+	return LatLonAziGeodesicScales{LatDeg: lat2, LonDeg: lon2, AziDeg: azi2, M12: M12, M21: M21}
+}
+
+// ArcDirectCalcLatLonAziReducedLengthGeodesicScales gets the lat, lon, azimuth, reduced
+// length, and geodesic scales, where the second point is specified by the arc length on
+// the auxiliary sphere. Takes inputs
+//   - lat1_deg - Latitude of 1st point [degrees] [-90.,90.]
+//   - lon1_deg - Longitude of 1st point [degrees] [-180., 180.]
+//   - azi1_deg - Azimuth at 1st point [degrees] [-180., 180.]
+//   - a12_deg - Arc length from 1st to 2nd point [degrees] Value may be negative
+func (g *GeodesicExact) ArcDirectCalcLatLonAziReducedLengthGeodesicScales(
+	lat1_deg, lon1_deg, azi1_deg, a12_deg float64,
+) LatLonAziReducedLengthGeodesicScales {
+	capabilities := LATITUDE | LONGITUDE | AZIMUTH | REDUCEDLENGTH | GEODESICSCALE
+	_, lat2, lon2, azi2, _, m12, M12, M21, _, _ := g._gen_direct(
+		lat1_deg, lon1_deg, azi1_deg, true, a12_deg, capabilities,
+	)
+	return LatLonAziReducedLengthGeodesicScales{
+		LatDeg:         lat2,
+		LonDeg:         lon2,
+		AziDeg:         azi2,
+		ReducedLengthM: m12,
+		M12:            M12,
+		M21:            M21,
+	}
+}
+
+// ArcDirectCalcAll calculates everything possible for the direct method, where the second
+// point is specified by the arc length on the auxiliary sphere. Takes inputs
+//   - lat1_deg - Latitude of 1st point [degrees] [-90.,90.]
+//   - lon1_deg - Longitude of 1st point [degrees] [-180., 180.]
+//   - azi1_deg - Azimuth at 1st point [degrees] [-180., 180.]
+//   - a12_deg - Arc length from 1st to 2nd point [degrees] Value may be negative
+func (g *GeodesicExact) ArcDirectCalcAll(lat1_deg, lon1_deg, azi1_deg, a12_deg float64) AllArcDirectResults {
+	capabilities := LATITUDE | LONGITUDE | AZIMUTH | DISTANCE | REDUCEDLENGTH | GEODESICSCALE | AREA
+	return g.ArcDirectCalcWithCapabilities(lat1_deg, lon1_deg, azi1_deg, a12_deg, capabilities)
+}
+
+// ArcDirectCalcWithCapabilities allows the user to specify which capabilites they wish to
+// use, where the second point is specified by the arc length on the auxiliary sphere.
+// Include DISTANCE in the capabilities to get the distance between the points.
+// Takes inputs
+//   - lat1_deg - Latitude of 1st point [degrees] [-90.,90.]
+//   - lon1_deg - Longitude of 1st point [degrees] [-180., 180.]
+//   - azi1_deg - Azimuth at 1st point [degrees] [-180., 180.]
+//   - a12_deg - Arc length from 1st to 2nd point [degrees] Value may be negative
+//   - capabilities - One or more of the capabilities constant as defined in the file
+//     geodesiccapability.go. Usually, they are OR'd together, e.g. LATITUDE | LONGITUDE
+func (g *GeodesicExact) ArcDirectCalcWithCapabilities(
+	lat1_deg, lon1_deg, azi1_deg, a12_deg float64,
+	capabilities uint64,
+) AllArcDirectResults {
+	_, lat2, lon2, azi2, s12, m12, M12, M21, S12, _ := g._gen_direct(
+		lat1_deg, lon1_deg, azi1_deg, true, a12_deg, capabilities,
+	)
+	return AllArcDirectResults{
+		LatDeg:         lat2,
+		LonDeg:         lon2,
+		AziDeg:         azi2,
+		DistanceM:      s12,
+		ReducedLengthM: m12,
+		M12:            M12,
+		M21:            M21,
+		S12M2:          S12,
+	}
+}
+
+// InverseCalcDistance returns the distance from point 1 to point 2 in meters. Takes inputs
+// - lat1_deg latitude of point 1 [degrees].
+// - lon1_deg longitude of point 1 [degrees].
+// - lat2_deg latitude of point 2 [degrees].
+// - lon2_deg longitude of point 2 [degrees].
+func (g *GeodesicExact) InverseCalcDistance(lat1_deg, lon1_deg, lat2_deg, lon2_deg float64) float64 {
+	capabilities := DISTANCE
+	_, s12, _, _, _, _, _, _ := g._gen_inverse_azi(lat1_deg, lon1_deg, lat2_deg, lon2_deg, capabilities)
+
+	return s12
+}
+
+// InverseCalcDistanceArcLength returns the distance from one point to the next, and the
+// arc length between the points. Takes inputs
+// - lat1_deg latitude of point 1 [degrees].
+// - lon1_deg longitude of point 1 [degrees].
+// - lat2_deg latitude of point 2 [degrees].
+// - lon2_deg longitude of point 2 [degrees].
+func (g *GeodesicExact) InverseCalcDistanceArcLength(lat1_deg, lon1_deg, lat2_deg, lon2_deg float64) DistanceArcLength {
+	capabilities := DISTANCE
+	a12, s12, _, _, _, _, _, _ := g._gen_inverse_azi(lat1_deg, lon1_deg, lat2_deg, lon2_deg, capabilities)
+
+	return DistanceArcLength{DistanceM: s12, ArcLengthDeg: a12}
+}
+
+// InverseCalcDistanceAzimuths returns the distance from one point to the next, and the
+// azimuths. Takes inputs
+// - lat1_deg latitude of point 1 [degrees].
+// - lon1_deg longitude of point 1 [degrees].
+// - lat2_deg latitude of point 2 [degrees].
+// - lon2_deg longitude of point 2 [degrees].
+func (g *GeodesicExact) InverseCalcDistanceAzimuths(lat1_deg, lon1_deg, lat2_deg, lon2_deg float64) DistanceAzimuths {
+	capabilities := DISTANCE | AZIMUTH
+	_, s12, azi1, azi2, _, _, _, _ := g._gen_inverse_azi(lat1_deg, lon1_deg, lat2_deg, lon2_deg, capabilities)
+
+	return DistanceAzimuths{DistanceM: s12, Azimuth1Deg: azi1, Azimuth2Deg: azi2}
+}
+
+// InverseCalcAzimuthsArcLength returns the azimuth at point 1, the azimuth at point 2,
+// and the arc length between the points. Takes inputs
+// - lat1_deg latitude of point 1 [degrees].
+// - lon1_deg longitude of point 1 [degrees].
+// - lat2_deg latitude of point 2 [degrees].
+// - lon2_deg longitude of point 2 [degrees].
+func (g *GeodesicExact) InverseCalcAzimuthsArcLength(
+	lat1_deg, lon1_deg, lat2_deg, lon2_deg float64,
+) AzimuthsArcLength {
+	capabilities := AZIMUTH
+	a12, _, azi1, azi2, _, _, _, _ := g._gen_inverse_azi(lat1_deg, lon1_deg, lat2_deg, lon2_deg, capabilities)
+
+	return AzimuthsArcLength{Azimuth1Deg: azi1, Azimuth2Deg: azi2, ArcLengthDeg: a12}
+}
+
+// InverseCalcDistanceAzimuthsArcLength returns the distance from one point to the next,
+// the azimuth at point 1, the azimuth at point 2, and the arc length between the points.
+// Takes inputs
+// - lat1_deg latitude of point 1 [degrees].
+// - lon1_deg longitude of point 1 [degrees].
+// - lat2_deg latitude of point 2 [degrees].
+// - lon2_deg longitude of point 2 [degrees].
+func (g *GeodesicExact) InverseCalcDistanceAzimuthsArcLength(
+	lat1_deg, lon1_deg, lat2_deg, lon2_deg float64,
+) DistanceAzimuthsArcLength {
+	capabilities := DISTANCE | AZIMUTH
+	a12, s12, azi1, azi2, _, _, _, _ := g._gen_inverse_azi(lat1_deg, lon1_deg, lat2_deg, lon2_deg, capabilities)
+
+	return DistanceAzimuthsArcLength{
+		DistanceM: s12, Azimuth1Deg: azi1, Azimuth2Deg: azi2, ArcLengthDeg: a12,
+	}
+}
+
+// InverseCalcDistanceAzimuthsArcLengthReducedLength returns the distance from one point
+// to the next, the azimuth at point 1, the azimuth at point 2, the arc length
+// between the points, and the reduced length of the geodesic.
+// Takes inputs
+// - lat1_deg latitude of point 1 [degrees].
+// - lon1_deg longitude of point 1 [degrees].
+// - lat2_deg latitude of point 2 [degrees].
+// - lon2_deg longitude of point 2 [degrees].
+func (g *GeodesicExact) InverseCalcDistanceAzimuthsArcLengthReducedLength(
+	lat1_deg, lon1_deg, lat2_deg, lon2_deg float64,
+) DistanceAzimuthsArcLengthReducedLength {
+	capabilities := DISTANCE | AZIMUTH | REDUCEDLENGTH
+	a12, s12, azi1, azi2, m12, _, _, _ := g._gen_inverse_azi(
+		lat1_deg, lon1_deg, lat2_deg, lon2_deg, capabilities,
+	)
+
+	return DistanceAzimuthsArcLengthReducedLength{
+		DistanceM:      s12,
+		Azimuth1Deg:    azi1,
+		Azimuth2Deg:    azi2,
+		ArcLengthDeg:   a12,
+		ReducedLengthM: m12,
+	}
+}
+
+// InverseCalcDistanceAzimuthsArcLengthReducedLengthScales returns everything described
+// by the `DistanceAzimuthsArcLengthReducedLengthScales` type.
+// Takes inputs
+// - lat1_deg latitude of point 1 [degrees].
+// - lon1_deg longitude of point 1 [degrees].
+// - lat2_deg latitude of point 2 [degrees].
+// - lon2_deg longitude of point 2 [degrees].
+func (g *GeodesicExact) InverseCalcDistanceAzimuthsArcLengthReducedLengthScales(
+	lat1_deg, lon1_deg, lat2_deg, lon2_deg float64,
+) DistanceAzimuthsArcLengthReducedLengthScales {
+	capabilities := DISTANCE | AZIMUTH | REDUCEDLENGTH | GEODESICSCALE
+	a12, s12, azi1, azi2, m12, M12, M21, _ := g._gen_inverse_azi(
+		lat1_deg, lon1_deg, lat2_deg, lon2_deg, capabilities,
+	)
+
+	return DistanceAzimuthsArcLengthReducedLengthScales{
+		DistanceM:      s12,
+		Azimuth1Deg:    azi1,
+		Azimuth2Deg:    azi2,
+		ArcLengthDeg:   a12,
+		ReducedLengthM: m12,
+		M12:            M12,
+		M21:            M21,
+	}
+}
+
+// InverseCalcAll returns everything described in the `AllInverseResults` results type.
+// Takes inputs
+// - lat1_deg latitude of point 1 [degrees].
+// - lon1_deg longitude of point 1 [degrees].
+// - lat2_deg latitude of point 2 [degrees].
+// - lon2_deg longitude of point 2 [degrees].
+func (g *GeodesicExact) InverseCalcAll(
+	lat1_deg, lon1_deg, lat2_deg, lon2_deg float64,
+) AllInverseResults {
+	capabilities := DISTANCE | AZIMUTH | REDUCEDLENGTH | GEODESICSCALE | AREA
+	return g.InverseCalcWithCapabilities(lat1_deg, lon1_deg, lat2_deg, lon2_deg, capabilities)
+}
+
+// InverseCalcWithCapabilities allows the user to specify which capabilites they wish to use.
+// This function is useful if you want some other subset of capabilities than those offered
+// by the other InverseCalc...() methods.
+// Takes inputs
+//   - lat1_deg latitude of point 1 [degrees].
+//   - lon1_deg longitude of point 1 [degrees].
+//   - lat2_deg latitude of point 2 [degrees].
+//   - lon2_deg longitude of point 2 [degrees].
+//   - capabilities - One or more of the capabilities constant as defined in the file
+//     geodesiccapability.go. Usually, they are OR'd together, e.g. LATITUDE | LONGITUDE
+func (g *GeodesicExact) InverseCalcWithCapabilities(
+	lat1_deg, lon1_deg, lat2_deg, lon2_deg float64,
+	capabilities uint64,
+) AllInverseResults {
+	a12, s12, azi1, azi2, m12, M12, M21, S12 := g._gen_inverse_azi(
+		lat1_deg, lon1_deg, lat2_deg, lon2_deg, capabilities,
+	)
+
+	return AllInverseResults{
+		DistanceM:      s12,
+		Azimuth1Deg:    azi1,
+		Azimuth2Deg:    azi2,
+		ArcLengthDeg:   a12,
+		ReducedLengthM: m12,
+		M12:            M12,
+		M21:            M21,
+		S12M2:          S12,
+	}
+}
+
+// InverseLineWithCapabilities: define a GeodesicLineExact struct in terms of the inverse
+// geodesic problem.
+// This function sets point 3 of the GeodesicLineExact to correspond to point 2 of the
+// inverse geodesic problem.
+func (g *GeodesicExact) InverseLineWithCapabilities(
+	lat1_deg, lon1_deg, lat2_deg, lon2_deg float64,
+	capabilities uint64,
+) GeodesicLineExact {
+	a12, _, salp1, calp1, _, _, _, _, _, _ := g._gen_inverse(lat1_deg, lon1_deg, lat2_deg, lon2_deg, 0)
+	azi1 := atan2_deg(salp1, calp1)
+
+	if capabilities&(OUT_MASK&DISTANCE_IN) != 0 {
+		capabilities |= DISTANCE
+	}
+
+	line := new_geodesic_line_exact_all_options(*g, lat1_deg, lon1_deg, azi1, capabilities, salp1, calp1)
+	line.SetArc(a12)
+	return line
+}
+
+func (g *GeodesicExact) _gen_direct_line(
+	lat1_deg, lon1_deg, azi1_deg float64,
+	arcmode bool,
+	s12_a12_m float64,
+	capabilities uint64,
+) GeodesicLineExact {
+	// Automatically supply DISTANCE_IN if necessary
+	if !arcmode {
+		capabilities |= DISTANCE_IN
+	}
+	line := NewGeodesicLineExactWithCapability(
+		*g,
+		lat1_deg,
+		lon1_deg,
+		azi1_deg,
+		capabilities,
+	)
+	if arcmode {
+		line.SetArc(s12_a12_m)
+	} else {
+		line.SetDistance(s12_a12_m)
+	}
+	return line
+}
+
+// DirectLineWithCapabilities defines a GeodesicLineExact struct in terms of the direct
+// geodesic problem specified in terms of distance.
+// This function sets point 3 of the GeodesicLineExact to correspond to point 2 of the
+// direct geodesic problem
+func (g *GeodesicExact) DirectLineWithCapabilities(
+	lat1_deg, lon1_deg, azi1_deg, s12_m float64,
+	capabilities uint64,
+) GeodesicLineExact {
+	return g._gen_direct_line(lat1_deg, lon1_deg, azi1_deg, false, s12_m, capabilities)
+}
+
+// ArcDirectLineWithCapabilities defines a GeodesicLineExact struct in terms of the direct
+// geodesic problem specified in terms of spherical arc length.
+// This function sets point 3 of the GeodesicLineExact to correspond to point 2 of the
+// direct geodesic problem
+func (g *GeodesicExact) ArcDirectLineWithCapabilities(
+	lat1_deg, lon1_deg, azi1_deg, a12_deg float64,
+	capabilities uint64,
+) GeodesicLineExact {
+	return g._gen_direct_line(lat1_deg, lon1_deg, azi1_deg, true, a12_deg, capabilities)
+}
+
+// LineWithCapabilities returns a GeodesicLineExact. This allows points along a geodesic
+// starting at lat1_deg, lon1_deg with azimuth azi1_deg to be found.
+func (g *GeodesicExact) LineWithCapabilities(
+	lat1_deg, lon1_deg, azi1_deg float64,
+	capabilities uint64,
+) GeodesicLineExact {
+	return NewGeodesicLineExactWithCapability(
+		*g,
+		lat1_deg,
+		lon1_deg,
+		azi1_deg,
+		capabilities,
+	)
+}
@@ -0,0 +1,332 @@
+package geographiclibgo
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDstTransform(t *testing.T) {
+	// A function with a known, finite, odd-harmonic sine series
+	want := []float64{1.5, -0.25, 0, 0.125, 0, 0, 0, 0}
+	f := func(x float64) float64 {
+		return want[0]*math.Sin(x) + want[1]*math.Sin(3*x) + want[3]*math.Sin(7*x)
+	}
+	got := make([]float64, len(want))
+	d := new_dst(len(want))
+	d.transform(f, got)
+	for i := range want {
+		if !almost_equal(got[i], want[i], 1e-15) {
+			t.Errorf("transform() F[%d] = %v; want %v", i, got[i], want[i])
+		}
+	}
+
+	x := 0.7
+	if !almost_equal(dst_eval(math.Sin(x), math.Cos(x), got), f(x), 1e-15) {
+		t.Errorf("dst_eval() = %v; want %v", dst_eval(math.Sin(x), math.Cos(x), got), f(x))
+	}
+	integral := -want[0]*math.Cos(x) - want[1]/3*math.Cos(3*x) - want[3]/7*math.Cos(7*x)
+	if !almost_equal(dst_integral(math.Sin(x), math.Cos(x), got), integral, 1e-15) {
+		t.Errorf("dst_integral() = %v; want %v", dst_integral(math.Sin(x), math.Cos(x), got), integral)
+	}
+}
+
+func TestInverseExact20(t *testing.T) {
+	geod := Wgs84Exact()
+
+	for row, tC := range test_cases {
+		lat1, lon1, azi1 := tC[0], tC[1], tC[2]
+		lat2, lon2, azi2 := tC[3], tC[4], tC[5]
+		s12, a12, m12 := tC[6], tC[7], tC[8]
+		M12, M21, S12 := tC[9], tC[10], tC[11]
+
+		inv := geod.InverseCalcAll(lat1, lon1, lat2, lon2)
+
+		if !almost_equal(azi1, inv.Azimuth1Deg, 1e-13) {
+			t.Errorf("row %d -- Inverse() azi1 = %v; want %v", row, inv.Azimuth1Deg, azi1)
+		}
+
+		if !almost_equal(azi2, inv.Azimuth2Deg, 1e-13) {
+			t.Errorf("row %d -- Inverse() azi2 = %v; want %v", row, inv.Azimuth2Deg, azi2)
+		}
+
+		if !almost_equal(s12, inv.DistanceM, 1e-7) {
+			t.Errorf("row %d -- Inverse() s12 = %v; want %v", row, inv.DistanceM, s12)
+		}
+
+		if !almost_equal(a12, inv.ArcLengthDeg, 1e-13) {
+			t.Errorf("row %d -- Inverse() a12 = %v; want %v", row, inv.ArcLengthDeg, a12)
+		}
+
+		if !almost_equal(m12, inv.ReducedLengthM, 1e-8) {
+			t.Errorf("row %d -- Inverse() m12 = %v; want %v", row, inv.ReducedLengthM, m12)
+		}
+
+		if !almost_equal(M12, inv.M12, 1e-15) {
+			t.Errorf("row %d -- Inverse() M12 = %v; want %v", row, inv.M12, M12)
+		}
+
+		if !almost_equal(M21, inv.M21, 1e-15) {
+			t.Errorf("row %d -- Inverse() M21 = %v; want %v", row, inv.M21, M21)
+		}
+
+		if !almost_equal(S12, inv.S12M2, 0.1) {
+			t.Errorf("row %d -- Inverse() S12 = %v; want %v", row, inv.S12M2, S12)
+		}
+	}
+}
+
+func BenchmarkInverseExact20(b *testing.B) {
+	geod := Wgs84Exact()
+	for i := 0; i < b.N; i++ {
+		for _, tC := range test_cases {
+			geod.InverseCalcAll(tC[0], tC[1], tC[3], tC[4])
+		}
+	}
+}
+
+func TestDirectExact20(t *testing.T) {
+	geod := Wgs84Exact()
+
+	for row, tC := range test_cases {
+		lat1, lon1, azi1 := tC[0], tC[1], tC[2]
+		lat2, lon2, azi2 := tC[3], tC[4], tC[5]
+		s12, a12, m12 := tC[6], tC[7], tC[8]
+		M12, M21, S12 := tC[9], tC[10], tC[11]
+
+		dir := geod.DirectCalcWithCapabilities(lat1, lon1, azi1, s12, ALL|LONG_UNROLL)
+
+		if !almost_equal(lat2, dir.LatDeg, 1e-13) {
+			t.Errorf("row %d -- Direct() lat2 = %v; want %v", row, dir.LatDeg, lat2)
+		}
+
+		if !almost_equal(lon2, dir.LonDeg, 1e-13) {
+			t.Errorf("row %d -- Direct() lon2 = %v; want %v", row, dir.LonDeg, lon2)
+		}
+
+		if !almost_equal(azi2, dir.AziDeg, 1e-13) {
+			t.Errorf("row %d -- Direct() azi2 = %v; want %v", row, dir.AziDeg, azi2)
+		}
+
+		if !almost_equal(a12, dir.A12Deg, 1e-13) {
+			t.Errorf("row %d -- Direct() a12 = %v; want %v", row, dir.A12Deg, a12)
+		}
+
+		if !almost_equal(m12, dir.ReducedLengthM, 1e-8) {
+			t.Errorf("row %d -- Direct() m12 = %v; want %v", row, dir.ReducedLengthM, m12)
+		}
+
+		if !almost_equal(M12, dir.M12, 1e-14) {
+			t.Errorf("row %d -- Direct() M12 = %v; want %v", row, dir.M12, M12)
+		}
+
+		if !almost_equal(M21, dir.M21, 1e-14) {
+			t.Errorf("row %d -- Direct() M21 = %v; want %v", row, dir.M21, M21)
+		}
+
+		if !almost_equal(S12, dir.S12M2, 0.1) {
+			t.Errorf("row %d -- Direct() S12 = %v; want %v", row, dir.S12M2, S12)
+		}
+	}
+}
+
+func BenchmarkDirectExact20(b *testing.B) {
+	geod := Wgs84Exact()
+	for i := 0; i < b.N; i++ {
+		for _, tC := range test_cases {
+			geod.DirectCalcWithCapabilities(tC[0], tC[1], tC[2], tC[6], ALL|LONG_UNROLL)
+		}
+	}
+}
+
+func TestDirectExact100(t *testing.T) {
+	geod := Wgs84Exact()
+	filename := "test_fixtures/GeodTest-100.dat"
+	test_cases, err := read_dot_dat(filename)
+	if err != nil {
+		t.Errorf("Could not read file %v", filename)
+	}
+
+	// Some of these are nearly antipodal, so small errors in the input are magnified in
+	// the longitude. Use the same thresholds as TestDirect100
+	for row_num, tc := range test_cases {
+		res := geod.DirectCalcAll(tc.lat1, tc.lon1, tc.azi1, tc.s12_dist)
+
+		if !almost_equal(res.LatDeg, tc.lat2, float64EqualityThreshold) {
+			t.Errorf("Row: %d -- Direct() Lat2 = %v; want %v", row_num, res.LatDeg, tc.lat2)
+		}
+
+		if !almost_equal(res.LonDeg, tc.lon2, float64EqualityThreshold) {
+			t.Errorf("Row: %d -- Direct() Lon2 = %v; want %v", row_num, res.LonDeg, tc.lon2)
+		}
+
+		if !almost_equal(res.AziDeg, tc.azi2, float64EqualityThreshold) {
+			t.Errorf("Row: %d -- Direct() AziDeg = %v; want %v", row_num, res.AziDeg, tc.azi2)
+		}
+
+		if !almost_equal(res.ReducedLengthM, tc.m12, float64EqualityThreshold) {
+			t.Errorf("Row: %d -- Direct() ReducedLengthM = %v; want %v", row_num, res.ReducedLengthM, tc.m12)
+		}
+
+		if !almost_equal(res.S12M2, tc.s12_area, 8.8e2) {
+			t.Errorf("Row: %d -- Direct() S12M2 = %v; want %v", row_num, res.S12M2, tc.s12_area)
+		}
+
+		if !almost_equal(res.A12Deg, tc.a12, float64EqualityThreshold) {
+			t.Errorf("Row: %d -- Direct() A12Deg = %v; want %v", row_num, res.A12Deg, tc.a12)
+		}
+	}
+}
+
+// exact_reference_cases are geodesics on ellipsoids with a = 6.4e6 m and flattening f:
+// f, lat1, lon1, azi1, lat2, lon2, azi2, s12, a12. They were computed to 40 digits by
+// Gauss-Legendre quadrature of the integrals for the distance and the longitude, Karney
+// (2013), eqs. (7) and (8), which is independent of the sine series used here.
+var exact_reference_cases = [][9]float64{
+	{0.2, 40, 10, 30, 62.627197343793171, 34.683826551186591, 49.82428044370382, 3000000, 29.558179703754096},
+	{0.2, -25, -120, 140, -52.44159920712152, -18.262430968756437, 60.351079936959373, 9000000, 89.465257092571235},
+	{0.2, 75, 170, 95, 34.589148795163446, -119.28035894752446, 158.81768835002998, 6000000, 57.717962302252502},
+	{0.2, -3, 45, 80, 11.058069189508165, 152.58975254448674, 95.192342064761888, 12000000, 133.63795142747009},
+	{-0.5, 40, 10, 30, 59.955979569429743, 48.034522792909954, 59.830706164145411, 3000000, 23.563559297839756},
+	{-0.5, -25, -120, 140, -42.205740669461591, -3.4756568102259511, 62.787695771781586, 9000000, 66.181180963583031},
+	{-0.5, 75, 170, 95, 30.01892115890454, -109.07420126094723, 166.59592067568738, 6000000, 47.408402034780721},
+	{-0.5, -3, 45, 80, 5.3776844604233212, 151.05250617420714, 82.532140723328425, 12000000, 71.728486185037298},
+	{1.0 / 150, 40, 10, 30, 60.869096747941576, 37.499270771083431, 51.717399700659413, 3000000, 26.929409846021731},
+	{1.0 / 150, -25, -120, 140, -49.056138093395923, -15.03874088718724, 62.455606545546594, 9000000, 80.833331317728664},
+	{1.0 / 150, 75, 170, 95, 33.649067642403288, -115.50228724730309, 161.87855851912036, 6000000, 53.826759369289967},
+	{1.0 / 150, -3, 45, 80, 10.49529904131745, 152.19485454699904, 90.267237194328402, 12000000, 108.14023691604139},
+}
+
+func TestGeodesicExactReference(t *testing.T) {
+	for row, tC := range exact_reference_cases {
+		f, lat1, lon1, azi1 := tC[0], tC[1], tC[2], tC[3]
+		lat2, lon2, azi2, s12, a12 := tC[4], tC[5], tC[6], tC[7], tC[8]
+		geod := NewGeodesicExact(6.4e6, f)
+
+		dir := geod.DirectCalcAll(lat1, lon1, azi1, s12)
+		if !almost_equal(dir.LatDeg, lat2, 1e-13) || !almost_equal(ang_diff_deg(dir.LonDeg, lon2), 0, 1e-13) ||
+			!almost_equal(dir.AziDeg, azi2, 1e-13) || !almost_equal(dir.A12Deg, a12, 1e-13) {
+			t.Errorf("f = %v row %d -- Direct() = %v, %v, %v, %v; want %v, %v, %v, %v",
+				f, row, dir.LatDeg, dir.LonDeg, dir.AziDeg, dir.A12Deg, lat2, lon2, azi2, a12)
+		}
+
+		inv := geod.InverseCalcAll(lat1, lon1, lat2, lon2)
+		if !almost_equal(inv.DistanceM, s12, 1e-8) || !almost_equal(inv.Azimuth1Deg, azi1, 1e-13) ||
+			!almost_equal(inv.Azimuth2Deg, azi2, 1e-13) || !almost_equal(inv.ArcLengthDeg, a12, 1e-13) {
+			t.Errorf("f = %v row %d -- Inverse() = %v, %v, %v, %v; want %v, %v, %v, %v",
+				f, row, inv.DistanceM, inv.Azimuth1Deg, inv.Azimuth2Deg, inv.ArcLengthDeg, s12, azi1, azi2, a12)
+		}
+	}
+}
+
+func TestGeodesicExactQuarterMeridian(t *testing.T) {
+	// The quarter meridian is a * E(e2). This is evaluated with a different parameter to
+	// the one used internally, which is b * E(-ep2)
+	a := 6.4e6
+	for _, f := range []float64{0.5, 0.1, 0, -0.1, -0.5, -2} {
+		geod := NewGeodesicExact(a, f)
+		e := NewEllipticFunction(f*(2-f), 0)
+		want := a * e.E()
+
+		got := geod.InverseCalcDistance(0, 0, 90, 0)
+		if !almost_equal(got, want, 1e-6) {
+			t.Errorf("f = %v -- quarter meridian = %v; want %v", f, got, want)
+		}
+
+		dir := geod.DirectCalcLatLon(0, 0, 0, want)
+		if !almost_equal(dir.LatDeg, 90, 1e-12) {
+			t.Errorf("f = %v -- DirectCalcLatLon() lat2 = %v; want %v", f, dir.LatDeg, 90.0)
+		}
+	}
+}
+
+func TestGeodesicExactRoundTrip(t *testing.T) {
+	// Solving the inverse problem and then the direct problem should get back to the
+	// second point, even for flattenings where Geodesic is not accurate
+	for _, f := range []float64{0.9, 0.5, 0.2, -0.2, -0.5, -2} {
+		geod := NewGeodesicExact(6.4e6, f)
+		for row, tC := range test_cases {
+			lat1, lon1 := tC[0], tC[1]
+			lat2, lon2 := tC[3], tC[4]
+
+			inv := geod.InverseCalcAll(lat1, lon1, lat2, lon2)
+			dir := geod.DirectCalcAll(lat1, lon1, inv.Azimuth1Deg, inv.DistanceM)
+
+			if !almost_equal(dir.LatDeg, lat2, 1e-10) {
+				t.Errorf("f = %v row %d -- Direct() lat2 = %v; want %v", f, row, dir.LatDeg, lat2)
+			}
+			if !almost_equal(ang_diff_deg(dir.LonDeg, lon2), 0, 1e-10) {
+				t.Errorf("f = %v row %d -- Direct() lon2 = %v; want %v", f, row, dir.LonDeg, lon2)
+			}
+			if !almost_equal(dir.AziDeg, inv.Azimuth2Deg, 1e-9) {
+				t.Errorf("f = %v row %d -- Direct() azi2 = %v; want %v", f, row, dir.AziDeg, inv.Azimuth2Deg)
+			}
+			if !almost_equal(dir.A12Deg, inv.ArcLengthDeg, 1e-10) {
+				t.Errorf("f = %v row %d -- Direct() a12 = %v; want %v", f, row, dir.A12Deg, inv.ArcLengthDeg)
+			}
+			if !almost_equal(dir.ReducedLengthM, inv.ReducedLengthM, 1e-3) {
+				t.Errorf("f = %v row %d -- Direct() m12 = %v; want %v", f, row, dir.ReducedLengthM, inv.ReducedLengthM)
+			}
+			if !almost_equal(dir.S12M2, inv.S12M2, math.Max(1, 1e-12*math.Abs(inv.S12M2))) {
+				t.Errorf("f = %v row %d -- Direct() S12 = %v; want %v", f, row, dir.S12M2, inv.S12M2)
+			}
+		}
+	}
+}
+
+// ang_diff_deg returns y - x reduced to [-180, 180]
+func ang_diff_deg(x, y float64) float64 {
+	d, _ := ang_diff(x, y)
+	return d
+}
+
+func TestGeodesicExactMatchesSeries(t *testing.T) {
+	// For small flattening the series expansion is accurate, so both should agree
+	for _, f := range []float64{1.0 / 150, -1.0 / 150} {
+		geod := NewGeodesic(6.4e6, f)
+		geode := NewGeodesicExact(6.4e6, f)
+		for row, tC := range test_cases {
+			inv := geod.InverseCalcAll(tC[0], tC[1], tC[3], tC[4])
+			inve := geode.InverseCalcAll(tC[0], tC[1], tC[3], tC[4])
+			if !almost_equal(inv.DistanceM, inve.DistanceM, 1e-6) {
+				t.Errorf("f = %v row %d -- Inverse() s12 = %v; want %v", f, row, inve.DistanceM, inv.DistanceM)
+			}
+			if !almost_equal(inv.Azimuth1Deg, inve.Azimuth1Deg, 1e-11) {
+				t.Errorf("f = %v row %d -- Inverse() azi1 = %v; want %v", f, row, inve.Azimuth1Deg, inv.Azimuth1Deg)
+			}
+			if !almost_equal(inv.S12M2, inve.S12M2, 1) {
+				t.Errorf("f = %v row %d -- Inverse() S12 = %v; want %v", f, row, inve.S12M2, inv.S12M2)
+			}
+		}
+	}
+}
+
+func TestGeodesicExactArcDirect(t *testing.T) {
+	geod := NewGeodesicExact(6.4e6, -0.5)
+	dir := geod.DirectCalcAll(30, 10, 40, 5e6)
+	arc := geod.ArcDirectCalcAll(30, 10, 40, dir.A12Deg)
+	if !almost_equal(arc.DistanceM, 5e6, 1e-6) {
+		t.Errorf("ArcDirectCalcAll() s12 = %v; want %v", arc.DistanceM, 5e6)
+	}
+	if !almost_equal(arc.LatDeg, dir.LatDeg, 1e-12) {
+		t.Errorf("ArcDirectCalcAll() lat2 = %v; want %v", arc.LatDeg, dir.LatDeg)
+	}
+	if !almost_equal(arc.LonDeg, dir.LonDeg, 1e-12) {
+		t.Errorf("ArcDirectCalcAll() lon2 = %v; want %v", arc.LonDeg, dir.LonDeg)
+	}
+	if !almost_equal(arc.S12M2, dir.S12M2, 1e-3) {
+		t.Errorf("ArcDirectCalcAll() S12 = %v; want %v", arc.S12M2, dir.S12M2)
+	}
+}
+
+func TestGeodesicExactEquator(t *testing.T) {
+	// Along the equator of an oblate ellipsoid the geodesic is the equator itself
+	geod := NewGeodesicExact(6.4e6, 0.3)
+	inv := geod.InverseCalcDistanceAzimuths(0, 0, 0, 60)
+	want := 6.4e6 * 60 * DEG2RAD
+	if !almost_equal(inv.DistanceM, want, 1e-6) {
+		t.Errorf("InverseCalcDistanceAzimuths() s12 = %v; want %v", inv.DistanceM, want)
+	}
+	if !almost_equal(inv.Azimuth1Deg, 90, 1e-13) {
+		t.Errorf("InverseCalcDistanceAzimuths() azi1 = %v; want %v", inv.Azimuth1Deg, 90.0)
+	}
+}
@@ -432,10 +432,45 @@ type PositionResult struct {
 // whatever capabilities are handed in. Any results not asked for with the capabilities
 // will be math.NaN()
 func (g GeodesicLine) PositionWithCapabilities(s12_m float64, capabilities uint64) PositionResult {
-	a12, lat2, lon2, azi2, s12, m12, M12, M21, S12 := g._gen_position(false, s12_m, capabilities)
+	return g.GenPosition(false, s12_m, capabilities)
+}
+
+// ArcPositionStandard finds the position on the line given a12_deg, the arc length on the
+// auxiliary sphere [degrees]. It uses the STANDARD capabilities, and returns a
+// PositionResultStandard struct
+func (g GeodesicLine) ArcPositionStandard(a12_deg float64) PositionResultStandard {
+	outmask := STANDARD
+	_, lat2, lon2, azi2, s12, _, _, _, _ := g._gen_position(true, a12_deg, outmask)
+
+	return PositionResultStandard{
+		Lat1Deg:   g.lat1,
+		Lon1Deg:   g.lon1,
+		Azi1Deg:   g.azi1,
+		Lat2Deg:   lat2,
+		Lon2Deg:   lon2,
+		Azi2Deg:   azi2,
+		DistanceM: s12,
+	}
+}
+
+// ArcPositionWithCapabilities finds the position on the line given a12_deg, the arc
+// length on the auxiliary sphere [degrees]. It uses whatever capabilities are handed in.
+// Any results not asked for with the capabilities will be math.NaN()
+func (g GeodesicLine) ArcPositionWithCapabilities(a12_deg float64, capabilities uint64) PositionResult {
+	return g.GenPosition(true, a12_deg, capabilities)
+}
+
+// GenPosition is the general position function that PositionWithCapabilities and
+// ArcPositionWithCapabilities are built on. If arcmode is true, s12_a12 is the arc length
+// on the auxiliary sphere [degrees]; otherwise it is the distance [meters], which requires
+// the line to have been created with the DISTANCE_IN capability. Any results not asked for
+// with the capabilities, or not supported by the capabilities of the line, will be
+// math.NaN()
+func (g GeodesicLine) GenPosition(arcmode bool, s12_a12 float64, capabilities uint64) PositionResult {
+	a12, lat2, lon2, azi2, s12, m12, M12, M21, S12 := g._gen_position(arcmode, s12_a12, capabilities)
 
 	outlon1 := g.lon1
-	if capabilities&LONG_UNROLL != 0 {
+	if capabilities&LONG_UNROLL == 0 {
 		outlon1 = ang_normalize(g.lon1)
 	}
 
@@ -455,15 +490,77 @@ func (g GeodesicLine) PositionWithCapabilities(s12_m float64, capabilities uint6
 	}
 }
 
-// set_arc: specify the position of point 3 in terms of arc length `a13`, the spherical
-// arc length from point 1 to point 3 in degrees
-func (g *GeodesicLine) set_arc(a13 float64) {
-	g.a13 = a13
+// SetArc specifies the position of point 3 in terms of arc length a13_deg, the spherical
+// arc length from point 1 to point 3 [degrees]
+func (g *GeodesicLine) SetArc(a13_deg float64) {
+	g.a13 = a13_deg
 	_, _, _, _, g.s13, _, _, _, _ = g._gen_position(true, g.a13, DISTANCE)
 }
 
-// set_distance specifies the position of point 3 in terms of distance
-func (g *GeodesicLine) set_distance(s13_m float64) {
+// SetDistance specifies the position of point 3 in terms of distance s13_m [meters]
+func (g *GeodesicLine) SetDistance(s13_m float64) {
 	g.s13 = s13_m
 	g.a13, _, _, _, _, _, _, _, _ = g._gen_position(false, g.s13, 0)
 }
+
+// Distance returns the distance from point 1 to point 3 [meters]. This is math.NaN() if
+// point 3 has not been set, or if it was set by arc length and the line does not have
+// the DISTANCE capability
+func (g GeodesicLine) Distance() float64 {
+	return g.s13
+}
+
+// Arc returns the arc length from point 1 to point 3 [degrees]. This is math.NaN() if
+// point 3 has not been set
+func (g GeodesicLine) Arc() float64 {
+	return g.a13
+}
+
+// Latitude returns the latitude of point 1 [degrees]
+func (g GeodesicLine) Latitude() float64 {
+	return g.lat1
+}
+
+// Longitude returns the longitude of point 1 [degrees]
+func (g GeodesicLine) Longitude() float64 {
+	return g.lon1
+}
+
+// Azimuth returns the azimuth of the line at point 1 [degrees]
+func (g GeodesicLine) Azimuth() float64 {
+	return g.azi1
+}
+
+// EquatorialAzimuth returns the azimuth of the line as it crosses the equator [degrees]
+func (g GeodesicLine) EquatorialAzimuth() float64 {
+	return atan2_deg(g._salp0, g._calp0)
+}
+
+// EquatorialArc returns the arc length from the northward equatorial crossing of the line
+// to point 1 [degrees]
+func (g GeodesicLine) EquatorialArc() float64 {
+	return atan2_deg(g._ssig1, g._csig1)
+}
+
+// EquatorialRadius returns the equatorial radius of the ellipsoid [meters]
+func (g GeodesicLine) EquatorialRadius() float64 {
+	return g.a
+}
+
+// Flattening returns the flattening of the ellipsoid
+func (g GeodesicLine) Flattening() float64 {
+	return g.f
+}
+
+// Capabilities returns the capabilities the line was created with. This always includes
+// LATITUDE, AZIMUTH, and LONG_UNROLL
+func (g GeodesicLine) Capabilities() uint64 {
+	return g.caps
+}
+
+// HasCapabilities reports whether the line was created with all of the capabilities
+// given in testcaps
+func (g GeodesicLine) HasCapabilities(testcaps uint64) bool {
+	testcaps &= _OUT_ALL
+	return g.caps&testcaps == testcaps
+}
@@ -124,3 +124,140 @@ func Benchmark_gen_position(b *testing.B) {
 		gl._gen_position(false, 150.0, 3979)
 	}
 }
+
+func TestGeodesicLineAccessors(t *testing.T) {
+	geod := Wgs84()
+	line := NewGeodesicLineWithCapability(geod, 10, 380, 30, STANDARD)
+
+	if !f64_equals(line.Latitude(), 10) {
+		t.Errorf("Latitude() = %v; want %v", line.Latitude(), 10)
+	}
+	if !f64_equals(line.Longitude(), 380) {
+		t.Errorf("Longitude() = %v; want %v", line.Longitude(), 380)
+	}
+	if !f64_equals(line.Azimuth(), 30) {
+		t.Errorf("Azimuth() = %v; want %v", line.Azimuth(), 30)
+	}
+	if !f64_equals(line.EquatorialRadius(), geod.a) {
+		t.Errorf("EquatorialRadius() = %v; want %v", line.EquatorialRadius(), geod.a)
+	}
+	if !f64_equals(line.Flattening(), geod.f) {
+		t.Errorf("Flattening() = %v; want %v", line.Flattening(), geod.f)
+	}
+	if line.Capabilities() != STANDARD|LATITUDE|AZIMUTH|LONG_UNROLL {
+		t.Errorf("Capabilities() = %v; want %v", line.Capabilities(), STANDARD|LONG_UNROLL)
+	}
+	if !line.HasCapabilities(LATITUDE | LONGITUDE | DISTANCE) {
+		t.Errorf("HasCapabilities(LATITUDE | LONGITUDE | DISTANCE) = false; want true")
+	}
+	if line.HasCapabilities(DISTANCE_IN) {
+		t.Errorf("HasCapabilities(DISTANCE_IN) = true; want false")
+	}
+	if !math.IsNaN(line.Distance()) || !math.IsNaN(line.Arc()) {
+		t.Errorf("Distance(), Arc() = %v, %v; want NaN, NaN", line.Distance(), line.Arc())
+	}
+
+	// The equatorial crossing of the line, and the arc length from it to point 1, put us
+	// back at point 1
+	eq := NewGeodesicLineWithCapability(geod, 0, 0, line.EquatorialAzimuth(), STANDARD)
+	pos := eq.ArcPositionStandard(line.EquatorialArc())
+	if !almost_equal(pos.Lat2Deg, 10, 1e-12) {
+		t.Errorf("lat2 = %v; want %v", pos.Lat2Deg, 10)
+	}
+	if !almost_equal(pos.Azi2Deg, 30, 1e-12) {
+		t.Errorf("azi2 = %v; want %v", pos.Azi2Deg, 30)
+	}
+}
+
+func TestGeodesicLineSetDistanceSetArc(t *testing.T) {
+	geod := Wgs84()
+	inv := geod.InverseCalcAll(40.6, -73.8, 49.01666667, 2.55)
+	line := geod.InverseLineWithCapabilities(40.6, -73.8, 49.01666667, 2.55, STANDARD|DISTANCE_IN)
+
+	// The inverse line sets point 3 to point 2 of the inverse problem
+	if !almost_equal(line.Arc(), inv.ArcLengthDeg, 1e-13) {
+		t.Errorf("Arc() = %v; want %v", line.Arc(), inv.ArcLengthDeg)
+	}
+	if !almost_equal(line.Distance(), inv.DistanceM, 1e-8) {
+		t.Errorf("Distance() = %v; want %v", line.Distance(), inv.DistanceM)
+	}
+
+	line.SetDistance(1e6)
+	if !f64_equals(line.Distance(), 1e6) {
+		t.Errorf("Distance() = %v; want %v", line.Distance(), 1e6)
+	}
+	a13 := line.Arc()
+
+	line.SetArc(a13)
+	if !almost_equal(line.Distance(), 1e6, 1e-8) {
+		t.Errorf("Distance() = %v; want %v", line.Distance(), 1e6)
+	}
+	if !f64_equals(line.Arc(), a13) {
+		t.Errorf("Arc() = %v; want %v", line.Arc(), a13)
+	}
+}
+
+func TestGeodesicLineArcPosition(t *testing.T) {
+	geod := Wgs84()
+	line := NewGeodesicLineWithCapability(geod, 40.63972222, -73.77888889, 53.5, ALL|DISTANCE_IN)
+
+	dist := line.PositionWithCapabilities(5850e3, ALL)
+	arc := line.ArcPositionWithCapabilities(dist.ArcLengthDeg, ALL)
+
+	if !almost_equal(arc.Lat2Deg, dist.Lat2Deg, 1e-13) {
+		t.Errorf("lat2 = %v; want %v", arc.Lat2Deg, dist.Lat2Deg)
+	}
+	if !almost_equal(arc.Lon2Deg, dist.Lon2Deg, 1e-13) {
+		t.Errorf("lon2 = %v; want %v", arc.Lon2Deg, dist.Lon2Deg)
+	}
+	if !almost_equal(arc.Azi2Deg, dist.Azi2Deg, 1e-13) {
+		t.Errorf("azi2 = %v; want %v", arc.Azi2Deg, dist.Azi2Deg)
+	}
+	if !almost_equal(arc.DistanceM, 5850e3, 1e-8) {
+		t.Errorf("s12 = %v; want %v", arc.DistanceM, 5850e3)
+	}
+	if !almost_equal(arc.ReducedLengthM, dist.ReducedLengthM, 1e-8) {
+		t.Errorf("m12 = %v; want %v", arc.ReducedLengthM, dist.ReducedLengthM)
+	}
+	if !almost_equal(arc.M12, dist.M12, 1e-15) {
+		t.Errorf("M12 = %v; want %v", arc.M12, dist.M12)
+	}
+	if !almost_equal(arc.S12M2, dist.S12M2, 0.1) {
+		t.Errorf("S12 = %v; want %v", arc.S12M2, dist.S12M2)
+	}
+
+	std := line.ArcPositionStandard(dist.ArcLengthDeg)
+	if !almost_equal(std.DistanceM, 5850e3, 1e-8) {
+		t.Errorf("s12 = %v; want %v", std.DistanceM, 5850e3)
+	}
+
+	gen := line.GenPosition(false, 5850e3, ALL)
+	if gen != dist {
+		t.Errorf("GenPosition() = %v; want %v", gen, dist)
+	}
+
+	// Arc mode works even without DISTANCE_IN
+	incapable := NewGeodesicLineWithCapability(geod, 40.63972222, -73.77888889, 53.5, LATITUDE)
+	pos := incapable.ArcPositionWithCapabilities(dist.ArcLengthDeg, LATITUDE)
+	if !almost_equal(pos.Lat2Deg, dist.Lat2Deg, 1e-13) {
+		t.Errorf("lat2 = %v; want %v", pos.Lat2Deg, dist.Lat2Deg)
+	}
+	if !math.IsNaN(pos.Lon2Deg) {
+		t.Errorf("lon2 = %v; want NaN", pos.Lon2Deg)
+	}
+}
+
+func TestGenPositionLongUnroll(t *testing.T) {
+	geod := Wgs84()
+	line := NewGeodesicLine(geod, 0, 400, 90)
+
+	unrolled := line.GenPosition(false, 1e6, STANDARD|LONG_UNROLL)
+	if !f64_equals(unrolled.Lon1Deg, 400) {
+		t.Errorf("lon1 = %v; want %v", unrolled.Lon1Deg, 400)
+	}
+
+	rolled := line.GenPosition(false, 1e6, STANDARD)
+	if !f64_equals(rolled.Lon1Deg, 40) {
+		t.Errorf("lon1 = %v; want %v", rolled.Lon1Deg, 40)
+	}
+}
@@ -0,0 +1,520 @@
+package geographiclibgo
+
+import "math"
+
+// GeodesicLineExact is the GeodesicLine counterpart of GeodesicExact. It computes points
+// along a geodesic using elliptic integrals, so it is accurate for any flattening.
+type GeodesicLineExact struct {
+	tiny_  float64
+	_E     EllipticFunction
+	_E0    float64
+	_E1    float64
+	_D0    float64
+	_D1    float64
+	_H0    float64
+	_H1    float64
+	_A4    float64
+	_B41   float64
+	_C4a   []float64
+	_b     float64
+	_c2    float64
+	_calp0 float64
+	_cchi1 float64
+	_csig1 float64
+	_comg1 float64
+	_ctau1 float64
+	_dn1   float64
+	_e2    float64
+	_f1    float64
+	_k2    float64
+	_salp0 float64
+	_somg1 float64
+	_ssig1 float64
+	_stau1 float64
+	a13    float64
+	a      float64
+	azi1   float64
+	calp1  float64
+	caps   uint64
+	f      float64
+	lat1   float64
+	lon1   float64
+	s13    float64
+	salp1  float64
+}
+
+// NewGeodesicLineExact creates a GeodesicLineExact, with `caps` of STANDARD | DISTANCE_IN
+func NewGeodesicLineExact(
+	geod GeodesicExact,
+	lat1, lon1, azi1 float64,
+) GeodesicLineExact {
+	// Specify default `caps`
+	caps := STANDARD | DISTANCE_IN
+
+	return new_geodesic_line_exact_all_options(
+		geod,
+		lat1,
+		lon1,
+		azi1,
+		caps,
+		math.NaN(),
+		math.NaN(),
+	)
+}
+
+// NewGeodesicLineExactWithCapability is the same as NewGeodesicLineExact but the user
+// specifies a `caps` field.
+func NewGeodesicLineExactWithCapability(
+	geod GeodesicExact,
+	lat1, lon1, azi1 float64,
+	caps uint64,
+) GeodesicLineExact {
+	return new_geodesic_line_exact_all_options(
+		geod,
+		lat1,
+		lon1,
+		azi1,
+		caps,
+		math.NaN(),
+		math.NaN(),
+	)
+}
+
+// new_geodesic_line_exact_all_options is the same as NewGeodesicLineExact but the user
+// specifies a `caps` field.
+// If you do not wish to specify `salp1` and/or `calp1`, set them as math.NaN()
+func new_geodesic_line_exact_all_options(
+	geod GeodesicExact,
+	lat1, lon1, azi1 float64,
+	caps uint64,
+	salp1, calp1 float64,
+) GeodesicLineExact {
+	tiny_ := geod.tiny_
+
+	a := geod.a
+	f := geod.f
+	_b := geod.b
+	_c2 := geod.c2
+	_f1 := geod.f1
+	_e2 := geod.e2
+	caps |= LATITUDE | AZIMUTH | LONG_UNROLL
+
+	if math.IsNaN(salp1) || math.IsNaN(calp1) {
+		azi1 = ang_normalize(azi1)
+		salp1, calp1 = sincosd(ang_round(azi1))
+	}
+
+	lat1 = lat_fix(lat1)
+
+	sbet1, cbet1 := sincosd(ang_round(lat1))
+	sbet1 *= _f1
+	sbet1, cbet1 = norm(sbet1, cbet1)
+	cbet1 = math.Max(tiny_, cbet1)
+	_dn1 := geod._dn(sbet1, cbet1)
+	_salp0 := salp1 * cbet1
+	_calp0 := math.Hypot(calp1, salp1*sbet1)
+	_ssig1 := sbet1
+	_somg1 := _salp0 * sbet1
+
+	var _csig1 float64
+	if sbet1 != 0.0 || calp1 != 0.0 {
+		_csig1 = cbet1 * calp1
+	} else {
+		_csig1 = 1.0
+	}
+
+	_comg1 := _csig1
+	// Without normalization we have schi1 = somg1
+	_cchi1 := _f1 * _dn1 * _comg1
+	_ssig1, _csig1 = norm(_ssig1, _csig1)
+
+	_k2 := sq(_calp0) * geod.ep2
+	_E := NewEllipticFunctionWithComplements(-_k2, -geod.ep2, 1.0+_k2, 1.0+geod.ep2)
+
+	_E0 := 0.0
+	_E1 := 0.0
+	_stau1 := 0.0
+	_ctau1 := 0.0
+	if caps&_CAP_E != 0 {
+		_E0 = _E.E() / (math.Pi / 2.0)
+		_E1 = _E.DeltaE(_ssig1, _csig1, _dn1)
+		s := math.Sin(_E1)
+		c := math.Cos(_E1)
+		// tau1 = sig1 + B11
+		_stau1 = _ssig1*c + _csig1*s
+		_ctau1 = _csig1*c - _ssig1*s
+	}
+
+	_D0 := 0.0
+	_D1 := 0.0
+	if caps&_CAP_D != 0 {
+		_D0 = _E.D() / (math.Pi / 2.0)
+		_D1 = _E.DeltaD(_ssig1, _csig1, _dn1)
+	}
+
+	_H0 := 0.0
+	_H1 := 0.0
+	if caps&_CAP_H != 0 {
+		_H0 = _E.H() / (math.Pi / 2.0)
+		_H1 = _E.DeltaH(_ssig1, _csig1, _dn1)
+	}
+
+	_A4 := 0.0
+	_B41 := 0.0
+	var _C4a []float64
+	if caps&_CAP_C4 != 0 {
+		_A4 = sq(a) * _calp0 * _salp0 * _e2
+		if _A4 != 0.0 {
+			_C4a = make([]float64, geod.nC4_)
+			geod._C4f(_k2, _C4a)
+			_B41 = dst_integral(_ssig1, _csig1, _C4a)
+		}
+	}
+
+	s13 := math.NaN()
+	a13 := math.NaN()
+
+	return GeodesicLineExact{
+		tiny_:  tiny_,
+		_E:     _E,
+		_E0:    _E0,
+		_E1:    _E1,
+		_D0:    _D0,
+		_D1:    _D1,
+		_H0:    _H0,
+		_H1:    _H1,
+		_A4:    _A4,
+		_B41:   _B41,
+		_C4a:   _C4a,
+		_b:     _b,
+		_c2:    _c2,
+		_calp0: _calp0,
+		_cchi1: _cchi1,
+		_csig1: _csig1,
+		_comg1: _comg1,
+		_ctau1: _ctau1,
+		_dn1:   _dn1,
+		_e2:    _e2,
+		_f1:    _f1,
+		_k2:    _k2,
+		_salp0: _salp0,
+		_somg1: _somg1,
+		_ssig1: _ssig1,
+		_stau1: _stau1,
+		a:      a,
+		a13:    a13,
+		azi1:   azi1,
+		calp1:  calp1,
+		caps:   caps,
+		f:      f,
+		lat1:   lat1,
+		lon1:   lon1,
+		s13:    s13,
+		salp1:  salp1,
+	}
+}
+
+func (g GeodesicLineExact) _gen_position(arcmode bool, s12_a12 float64, outmask uint64) (
+	a12 float64,
+	lat2 float64,
+	lon2 float64,
+	azi2 float64,
+	s12 float64,
+	m12 float64,
+	M12 float64,
+	M21 float64,
+	S12 float64,
+) {
+	a12 = math.NaN()
+	lat2 = math.NaN()
+	lon2 = math.NaN()
+	azi2 = math.NaN()
+	s12 = math.NaN()
+	m12 = math.NaN()
+	M12 = math.NaN()
+	M21 = math.NaN()
+	S12 = math.NaN()
+
+	outmask &= g.caps & OUT_MASK
+	if !(arcmode || (g.caps&(OUT_MASK&DISTANCE_IN) != 0)) {
+		return a12, lat2, lon2, azi2, s12, m12, M12, M21, S12
+	}
+
+	E2 := 0.0
+	AB1 := 0.0
+	var sig12 float64
+	var ssig12 float64
+	var csig12 float64
+
+	if arcmode {
+		sig12 = s12_a12 * DEG2RAD
+		ssig12, csig12 = sincosd(s12_a12)
+	} else {
+		tau12 := s12_a12 / (g._b * g._E0)
+		s := math.Sin(tau12)
+		c := math.Cos(tau12)
+		// tau2 = tau1 + tau12
+		E2 = -g._E.DeltaEinv(g._stau1*c+g._ctau1*s, g._ctau1*c-g._stau1*s)
+		sig12 = tau12 - (E2 - g._E1)
+		ssig12 = math.Sin(sig12)
+		csig12 = math.Cos(sig12)
+	}
+
+	ssig2 := g._ssig1*csig12 + g._csig1*ssig12
+	csig2 := g._csig1*csig12 - g._ssig1*ssig12
+	dn2 := g._E.Delta(ssig2, csig2)
+	if outmask&(DISTANCE|REDUCEDLENGTH|GEODESICSCALE) != 0 {
+		if arcmode {
+			E2 = g._E.DeltaE(ssig2, csig2, dn2)
+		}
+		AB1 = g._E0 * (E2 - g._E1)
+	}
+
+	sbet2 := g._calp0 * ssig2
+	cbet2 := math.Hypot(g._salp0, g._calp0*csig2)
+	if cbet2 == 0.0 {
+		cbet2 = g.tiny_
+		csig2 = g.tiny_
+	}
+	salp2 := g._salp0
+	calp2 := g._calp0 * csig2
+
+	if outmask&DISTANCE != 0 {
+		if arcmode {
+			s12 = g._b * (g._E0*sig12 + AB1)
+		} else {
+			s12 = s12_a12
+		}
+	}
+
+	if outmask&LONGITUDE != 0 {
+		somg2 := g._salp0 * ssig2
+		comg2 := csig2
+
+		E := math.Copysign(1, g._salp0)
+		// Without normalization we have schi2 = somg2
+		cchi2 := g._f1 * dn2 * comg2
+
+		var chi12 float64
+		if outmask&LONG_UNROLL != 0 {
+			chi12 = E * (sig12 - (math.Atan2(ssig2, csig2) - math.Atan2(g._ssig1, g._csig1)) + (math.Atan2((E*somg2), cchi2) - math.Atan2((E*g._somg1), g._cchi1)))
+		} else {
+			chi12 = math.Atan2((somg2*g._cchi1 - cchi2*g._somg1), (cchi2*g._cchi1 + somg2*g._somg1))
+		}
+		lam12 := chi12 - g._e2/g._f1*g._salp0*g._H0*(sig12+(g._E.DeltaH(ssig2, csig2, dn2)-g._H1))
+		lon12 := lam12 * RAD2DEG
+
+		if outmask&LONG_UNROLL != 0 {
+			lon2 = g.lon1 + lon12
+		} else {
+			lon2 = ang_normalize(
+				ang_normalize(g.lon1) + ang_normalize(lon12),
+			)
+		}
+	}
+
+	if outmask&LATITUDE != 0 {
+		lat2 = atan2_deg(sbet2, g._f1*cbet2)
+	}
+
+	if outmask&AZIMUTH != 0 {
+		azi2 = atan2_deg(salp2, calp2)
+	}
+
+	if outmask&(REDUCEDLENGTH|GEODESICSCALE) != 0 {
+		J12 := g._k2 * g._D0 * (sig12 + (g._E.DeltaD(ssig2, csig2, dn2) - g._D1))
+		if outmask&REDUCEDLENGTH != 0 {
+			m12 = g._b * ((dn2*(g._csig1*ssig2) - g._dn1*(g._ssig1*csig2)) - g._csig1*csig2*J12)
+		}
+		if outmask&GEODESICSCALE != 0 {
+			t := g._k2 * (ssig2 - g._ssig1) * (ssig2 + g._ssig1) / (g._dn1 + dn2)
+			M12 = csig12 + (t*ssig2-csig2*J12)*g._ssig1/g._dn1
+			M21 = csig12 - (t*g._ssig1-g._csig1*J12)*ssig2/dn2
+		}
+	}
+
+	if outmask&AREA != 0 {
+		B42 := 0.0
+		if g._A4 != 0.0 {
+			B42 = dst_integral(ssig2, csig2, g._C4a)
+		}
+		var salp12 float64
+		var calp12 float64
+		if g._calp0 == 0.0 || g._salp0 == 0.0 {
+			salp12 = salp2*g.calp1 - calp2*g.salp1
+			calp12 = calp2*g.calp1 + salp2*g.salp1
+		} else {
+			var to_mul float64
+			if csig12 <= 0.0 {
+				to_mul = g._csig1*(1.0-csig12) + ssig12*g._ssig1
+			} else {
+				to_mul = ssig12 * (g._csig1*ssig12/(1.0+csig12) + g._ssig1)
+			}
+			salp12 = g._calp0 * g._salp0 * to_mul
+
+			calp12 = sq(g._salp0) + sq(g._calp0)*g._csig1*csig2
+		}
+		S12 = g._c2*math.Atan2(salp12, calp12) + g._A4*(B42-g._B41)
+	}
+
+	if arcmode {
+		a12 = s12_a12
+	} else {
+		a12 = sig12 * RAD2DEG
+	}
+	return a12, lat2, lon2, azi2, s12, m12, M12, M21, S12
+}
+
+// PositionStandard finds the position on the line given s12_m [meters]. It uses the
+// STANDARD capabilities, and returns a PositionResultStandard struct
+func (g GeodesicLineExact) PositionStandard(s12_m float64) PositionResultStandard {
+	outmask := STANDARD
+	_, lat2, lon2, azi2, _, _, _, _, _ := g._gen_position(false, s12_m, outmask)
+
+	return PositionResultStandard{
+		Lat1Deg:   g.lat1,
+		Lon1Deg:   g.lon1,
+		Azi1Deg:   g.azi1,
+		Lat2Deg:   lat2,
+		Lon2Deg:   lon2,
+		Azi2Deg:   azi2,
+		DistanceM: s12_m,
+	}
+}
+
+// PositionWithCapabilities finds the position on the line given s12_m [meters]. It uses
+// whatever capabilities are handed in. Any results not asked for with the capabilities
+// will be math.NaN()
+func (g GeodesicLineExact) PositionWithCapabilities(s12_m float64, capabilities uint64) PositionResult {
+	return g.GenPosition(false, s12_m, capabilities)
+}
+
+// ArcPositionStandard finds the position on the line given a12_deg, the arc length on the
+// auxiliary sphere [degrees]. It uses the STANDARD capabilities, and returns a
+// PositionResultStandard struct
+func (g GeodesicLineExact) ArcPositionStandard(a12_deg float64) PositionResultStandard {
+	outmask := STANDARD
+	_, lat2, lon2, azi2, s12, _, _, _, _ := g._gen_position(true, a12_deg, outmask)
+
+	return PositionResultStandard{
+		Lat1Deg:   g.lat1,
+		Lon1Deg:   g.lon1,
+		Azi1Deg:   g.azi1,
+		Lat2Deg:   lat2,
+		Lon2Deg:   lon2,
+		Azi2Deg:   azi2,
+		DistanceM: s12,
+	}
+}
+
+// ArcPositionWithCapabilities finds the position on the line given a12_deg, the arc
+// length on the auxiliary sphere [degrees]. It uses whatever capabilities are handed in.
+// Any results not asked for with the capabilities will be math.NaN()
+func (g GeodesicLineExact) ArcPositionWithCapabilities(a12_deg float64, capabilities uint64) PositionResult {
+	return g.GenPosition(true, a12_deg, capabilities)
+}
+
+// GenPosition is the general position function that PositionWithCapabilities and
+// ArcPositionWithCapabilities are built on. If arcmode is true, s12_a12 is the arc length
+// on the auxiliary sphere [degrees]; otherwise it is the distance [meters], which requires
+// the line to have been created with the DISTANCE_IN capability. Any results not asked for
+// with the capabilities, or not supported by the capabilities of the line, will be
+// math.NaN()
+func (g GeodesicLineExact) GenPosition(arcmode bool, s12_a12 float64, capabilities uint64) PositionResult {
+	a12, lat2, lon2, azi2, s12, m12, M12, M21, S12 := g._gen_position(arcmode, s12_a12, capabilities)
+
+	outlon1 := g.lon1
+	if capabilities&LONG_UNROLL == 0 {
+		outlon1 = ang_normalize(g.lon1)
+	}
+
+	return PositionResult{
+		Lat1Deg:        g.lat1,
+		Lon1Deg:        outlon1,
+		Azi1Deg:        g.azi1,
+		Lat2Deg:        lat2,
+		Lon2Deg:        lon2,
+		Azi2Deg:        azi2,
+		DistanceM:      s12,
+		ArcLengthDeg:   a12,
+		ReducedLengthM: m12,
+		M12:            M12,
+		M21:            M21,
+		S12M2:          S12,
+	}
+}
+
+// SetArc specifies the position of point 3 in terms of arc length a13_deg, the spherical
+// arc length from point 1 to point 3 [degrees]
+func (g *GeodesicLineExact) SetArc(a13_deg float64) {
+	g.a13 = a13_deg
+	_, _, _, _, g.s13, _, _, _, _ = g._gen_position(true, g.a13, DISTANCE)
+}
+
+// SetDistance specifies the position of point 3 in terms of distance s13_m [meters]
+func (g *GeodesicLineExact) SetDistance(s13_m float64) {
+	g.s13 = s13_m
+	g.a13, _, _, _, _, _, _, _, _ = g._gen_position(false, g.s13, 0)
+}
+
+// Distance returns the distance from point 1 to point 3 [meters]. This is math.NaN() if
+// point 3 has not been set, or if it was set by arc length and the line does not have
+// the DISTANCE capability
+func (g GeodesicLineExact) Distance() float64 {
+	return g.s13
+}
+
+// Arc returns the arc length from point 1 to point 3 [degrees]. This is math.NaN() if
+// point 3 has not been set
+func (g GeodesicLineExact) Arc() float64 {
+	return g.a13
+}
+
+// Latitude returns the latitude of point 1 [degrees]
+func (g GeodesicLineExact) Latitude() float64 {
+	return g.lat1
+}
+
+// Longitude returns the longitude of point 1 [degrees]
+func (g GeodesicLineExact) Longitude() float64 {
+	return g.lon1
+}
+
+// Azimuth returns the azimuth of the line at point 1 [degrees]
+func (g GeodesicLineExact) Azimuth() float64 {
+	return g.azi1
+}
+
+// EquatorialAzimuth returns the azimuth of the line as it crosses the equator [degrees]
+func (g GeodesicLineExact) EquatorialAzimuth() float64 {
+	return atan2_deg(g._salp0, g._calp0)
+}
+
+// EquatorialArc returns the arc length from the northward equatorial crossing of the line
+// to point 1 [degrees]
+func (g GeodesicLineExact) EquatorialArc() float64 {
+	return atan2_deg(g._ssig1, g._csig1)
+}
+
+// EquatorialRadius returns the equatorial radius of the ellipsoid [meters]
+func (g GeodesicLineExact) EquatorialRadius() float64 {
+	return g.a
+}
+
+// Flattening returns the flattening of the ellipsoid
+func (g GeodesicLineExact) Flattening() float64 {
+	return g.f
+}
+
+// Capabilities returns the capabilities the line was created with. This always includes
+// LATITUDE, AZIMUTH, and LONG_UNROLL
+func (g GeodesicLineExact) Capabilities() uint64 {
+	return g.caps
+}
+
+// HasCapabilities reports whether the line was created with all of the capabilities
+// given in testcaps
+func (g GeodesicLineExact) HasCapabilities(testcaps uint64) bool {
+	testcaps &= _OUT_ALL
+	return g.caps&testcaps == testcaps
+}
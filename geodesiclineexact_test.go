@@ -0,0 +1,98 @@
+package geographiclibgo
+
+import (
+	"math"
+	"testing"
+)
+
+func TestGeodesicLineExactPosition(t *testing.T) {
+	geod := NewGeodesicExact(6.4e6, 0.5)
+	inv := geod.InverseCalcAll(-20, 10, 50, 100)
+	line := geod.InverseLineWithCapabilities(-20, 10, 50, 100, ALL)
+
+	if !almost_equal(line.Distance(), inv.DistanceM, 1e-6) {
+		t.Errorf("Distance() = %v; want %v", line.Distance(), inv.DistanceM)
+	}
+	if !almost_equal(line.Arc(), inv.ArcLengthDeg, 1e-12) {
+		t.Errorf("Arc() = %v; want %v", line.Arc(), inv.ArcLengthDeg)
+	}
+
+	pos := line.PositionWithCapabilities(line.Distance(), ALL)
+	if !almost_equal(pos.Lat2Deg, 50, 1e-12) {
+		t.Errorf("PositionWithCapabilities() lat2 = %v; want %v", pos.Lat2Deg, 50.0)
+	}
+	if !almost_equal(pos.Lon2Deg, 100, 1e-12) {
+		t.Errorf("PositionWithCapabilities() lon2 = %v; want %v", pos.Lon2Deg, 100.0)
+	}
+	if !almost_equal(pos.Azi2Deg, inv.Azimuth2Deg, 1e-12) {
+		t.Errorf("PositionWithCapabilities() azi2 = %v; want %v", pos.Azi2Deg, inv.Azimuth2Deg)
+	}
+	if !almost_equal(pos.ReducedLengthM, inv.ReducedLengthM, 1e-6) {
+		t.Errorf("PositionWithCapabilities() m12 = %v; want %v", pos.ReducedLengthM, inv.ReducedLengthM)
+	}
+	if !almost_equal(pos.M12, inv.M12, 1e-14) {
+		t.Errorf("PositionWithCapabilities() M12 = %v; want %v", pos.M12, inv.M12)
+	}
+	if !almost_equal(pos.S12M2, inv.S12M2, 1e-1) {
+		t.Errorf("PositionWithCapabilities() S12 = %v; want %v", pos.S12M2, inv.S12M2)
+	}
+
+	arc := line.ArcPositionStandard(line.Arc())
+	if !almost_equal(arc.DistanceM, inv.DistanceM, 1e-6) {
+		t.Errorf("ArcPositionStandard() s12 = %v; want %v", arc.DistanceM, inv.DistanceM)
+	}
+	if !almost_equal(arc.Lat2Deg, 50, 1e-12) {
+		t.Errorf("ArcPositionStandard() lat2 = %v; want %v", arc.Lat2Deg, 50.0)
+	}
+}
+
+func BenchmarkGeodesicLineExactPosition(b *testing.B) {
+	geod := NewGeodesicExact(6.4e6, 0.5)
+	line := geod.InverseLineWithCapabilities(-20, 10, 50, 100, ALL)
+	for i := 0; i < b.N; i++ {
+		line.PositionWithCapabilities(1e6, ALL)
+	}
+}
+
+func TestGeodesicLineExactCapabilities(t *testing.T) {
+	geod := Wgs84Exact()
+	line := NewGeodesicLineExactWithCapability(geod, 10, 20, 30, LATITUDE|LONGITUDE)
+
+	if !line.HasCapabilities(LATITUDE | LONGITUDE | AZIMUTH) {
+		t.Errorf("HasCapabilities(LATITUDE | LONGITUDE | AZIMUTH) = false; want true")
+	}
+	if line.HasCapabilities(DISTANCE_IN) {
+		t.Errorf("HasCapabilities(DISTANCE_IN) = true; want false")
+	}
+
+	// Without DISTANCE_IN, positions can only be found by arc length
+	pos := line.PositionWithCapabilities(1e6, LATITUDE)
+	if !math.IsNaN(pos.Lat2Deg) {
+		t.Errorf("PositionWithCapabilities() lat2 = %v; want NaN", pos.Lat2Deg)
+	}
+	arc := line.ArcPositionWithCapabilities(10, LATITUDE)
+	if math.IsNaN(arc.Lat2Deg) {
+		t.Errorf("ArcPositionWithCapabilities() lat2 = NaN; want a number")
+	}
+	if !math.IsNaN(arc.ReducedLengthM) {
+		t.Errorf("ArcPositionWithCapabilities() m12 = %v; want NaN", arc.ReducedLengthM)
+	}
+}
+
+func TestGeodesicLineExactMatchesSeries(t *testing.T) {
+	line := NewGeodesicLine(Wgs84(), 40, -75, 50)
+	linee := NewGeodesicLineExact(Wgs84Exact(), 40, -75, 50)
+	for _, s12 := range []float64{-1e7, -1e3, 0, 1e5, 5e6, 1.9e7} {
+		pos := line.PositionStandard(s12)
+		pose := linee.PositionStandard(s12)
+		if !almost_equal(pos.Lat2Deg, pose.Lat2Deg, 1e-12) {
+			t.Errorf("s12 = %v -- PositionStandard() lat2 = %v; want %v", s12, pose.Lat2Deg, pos.Lat2Deg)
+		}
+		if !almost_equal(pos.Lon2Deg, pose.Lon2Deg, 1e-12) {
+			t.Errorf("s12 = %v -- PositionStandard() lon2 = %v; want %v", s12, pose.Lon2Deg, pos.Lon2Deg)
+		}
+		if !almost_equal(pos.Azi2Deg, pose.Azi2Deg, 1e-12) {
+			t.Errorf("s12 = %v -- PositionStandard() azi2 = %v; want %v", s12, pose.Azi2Deg, pos.Azi2Deg)
+		}
+	}
+}
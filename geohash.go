@@ -0,0 +1,147 @@
+package geographiclibgo
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Constants for Geohash
+const (
+	_GEOHASH_MAXLEN int    = 18 // Maximum length, a resolution of about 1 um
+	_GEOHASH_DIGITS string = "0123456789BCDEFGHJKMNPQRSTUVWXYZ"
+)
+
+// GeohashForward converts the point lat_deg, lon_deg to a Geohash of the given length in
+// [0, 18], see https://en.wikipedia.org/wiki/Geohash. Each character adds 5 bits,
+// alternately refining the longitude and the latitude, and the characters are in lower
+// case. A length of 0 gives the empty string. This returns an error if lat_deg is not
+// in [-90, 90], and "invalid" if lat_deg or lon_deg is NaN.
+func GeohashForward(lat_deg, lon_deg float64, length int) (string, error) {
+	// shift = 2^45, and lon/loneps + shift and lat/lateps + shift are in [0, 2^46)
+	const shift float64 = 0x1p45
+	const loneps float64 = 180 / shift
+	const lateps float64 = 90 / shift
+	const mask uint64 = 1 << 45
+	if math.Abs(lat_deg) > 90 {
+		return "", fmt.Errorf("latitude %vd not in [-90d, 90d]", lat_deg)
+	}
+	if math.IsNaN(lat_deg) || math.IsNaN(lon_deg) {
+		return "invalid", nil
+	}
+	if lat_deg == 90 {
+		lat_deg -= lateps / 2
+	}
+	lon_deg = ang_normalize(lon_deg)
+	// lon_deg is now in [-180, 180)
+	if lon_deg == 180 {
+		lon_deg = -180
+	}
+	if length < 0 {
+		length = 0
+	} else if length > _GEOHASH_MAXLEN {
+		length = _GEOHASH_MAXLEN
+	}
+	ulon := uint64(math.Floor(lon_deg/loneps) + shift)
+	ulat := uint64(math.Floor(lat_deg/lateps) + shift)
+	geohash := make([]byte, length)
+	b := 0
+	for i := 0; i < 5*length; {
+		if i&1 == 0 {
+			b <<= 1
+			if ulon&mask != 0 {
+				b++
+			}
+			ulon <<= 1
+		} else {
+			b <<= 1
+			if ulat&mask != 0 {
+				b++
+			}
+			ulat <<= 1
+		}
+		i++
+		if i%5 == 0 {
+			// Setting bit 5 converts the letters to lower case and leaves the digits
+			geohash[i/5-1] = _GEOHASH_DIGITS[b] | 0x20
+			b = 0
+		}
+	}
+	return string(geohash), nil
+}
+
+// GeohashReverse decodes a Geohash, which may be in upper or lower case, to its cell.
+// Only the first 18 characters are used. Strings starting with "inv" or "nan" (e.g. the
+// "invalid" returned by GeohashForward) give an invalid cell.
+func GeohashReverse(geohash string) (GridCell, error) {
+	length := len(geohash)
+	if length > _GEOHASH_MAXLEN {
+		length = _GEOHASH_MAXLEN
+	}
+	if length >= 3 {
+		prefix := strings.ToUpper(geohash[:3])
+		if prefix == "INV" || prefix == "NAN" {
+			return invalid_grid_cell(), nil
+		}
+	}
+	var ulon, ulat uint64
+	j := 0
+	for k := 0; k < length; k++ {
+		b := mgrs_lookup(_GEOHASH_DIGITS, geohash[k])
+		if b < 0 {
+			return GridCell{}, fmt.Errorf("illegal character in geohash %s", geohash)
+		}
+		for m := 16; m > 0; m >>= 1 {
+			bit := uint64(0)
+			if b&m != 0 {
+				bit = 1
+			}
+			if j == 0 {
+				ulon = ulon<<1 + bit
+			} else {
+				ulat = ulat<<1 + bit
+			}
+			j ^= 1
+		}
+	}
+	latres, lonres := GeohashResolution(length)
+	return new_grid_cell(float64(ulat)*latres-90, float64(ulon)*lonres-180, latres, lonres, length), nil
+}
+
+// GeohashResolution returns the extents in latitude and longitude [degrees] of the
+// cells of a Geohash of the given length
+func GeohashResolution(length int) (latres_deg, lonres_deg float64) {
+	if length < 0 {
+		length = 0
+	} else if length > _GEOHASH_MAXLEN {
+		length = _GEOHASH_MAXLEN
+	}
+	return math.Ldexp(180, -(5 * length / 2)), math.Ldexp(360, -(5*length - 5*length/2))
+}
+
+// GeohashLength returns the shortest length of a Geohash whose cells are no larger than
+// res_deg in either latitude or longitude
+func GeohashLength(res_deg float64) int {
+	return GeohashLengthLatLon(res_deg, res_deg)
+}
+
+// GeohashLengthLatLon returns the shortest length of a Geohash whose cells are no larger
+// than latres_deg in latitude and lonres_deg in longitude
+func GeohashLengthLatLon(latres_deg, lonres_deg float64) int {
+	latres_deg, lonres_deg = math.Abs(latres_deg), math.Abs(lonres_deg)
+	for length := 0; length < _GEOHASH_MAXLEN; length++ {
+		latres, lonres := GeohashResolution(length)
+		if latres <= latres_deg && lonres <= lonres_deg {
+			return length
+		}
+	}
+	return _GEOHASH_MAXLEN
+}
+
+// GeohashDecimalPrecision returns the number of digits after the decimal point needed
+// to give the center of a Geohash of the given length in degrees to the resolution of
+// the Geohash
+func GeohashDecimalPrecision(length int) int {
+	latres, _ := GeohashResolution(length)
+	return -int(math.Floor(math.Log10(latres)))
+}
@@ -0,0 +1,138 @@
+package geographiclibgo
+
+import (
+	"math"
+	"testing"
+)
+
+func TestGeohashForward(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		lat    float64
+		lon    float64
+		length int
+		want   string
+	}{
+		// From https://en.wikipedia.org/wiki/Geohash
+		{"wikipedia", 57.64911, 10.40744, 11, "u4pruydqqvj"},
+		{"wikipedia short", 42.605, -5.603, 5, "ezs42"},
+		{"empty", 42.605, -5.603, 0, ""},
+		{"negative length", 42.605, -5.603, -3, ""},
+		{"length capped", 0, 0, 30, "s00000000000000000"},
+		{"south west", -90, -180, 4, "0000"},
+		{"north pole", 90, 0, 3, "upb"},
+		{"antimeridian", 10, 180, 4, "81b0"},
+		{"wrapped longitude", 10, -180, 4, "81b0"},
+		{"nan", math.NaN(), 0, 5, "invalid"},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			got, err := GeohashForward(tC.lat, tC.lon, tC.length)
+			if err != nil {
+				t.Fatalf("GeohashForward() error = %v", err)
+			}
+			if got != tC.want {
+				t.Errorf("GeohashForward(%v, %v, %v) = %q; want %q", tC.lat, tC.lon, tC.length, got, tC.want)
+			}
+		})
+	}
+	if _, err := GeohashForward(91, 0, 5); err == nil {
+		t.Errorf("GeohashForward(91, 0, 5) succeeded; want error")
+	}
+}
+
+func TestGeohashReverse(t *testing.T) {
+	c, err := GeohashReverse("EZS42")
+	if err != nil {
+		t.Fatalf("GeohashReverse() error = %v", err)
+	}
+	if c.Prec != 5 || c.LatResolutionDeg != 180.0/4096 || c.LonResolutionDeg != 360.0/8192 {
+		t.Errorf("GeohashReverse(EZS42) = %+v", c)
+	}
+	south, west, north, east := c.Bounds()
+	if south != 42.5830078125 || north != 42.626953125 || west != -5.625 || east != -5.5810546875 {
+		t.Errorf("Bounds() = %v, %v, %v, %v", south, west, north, east)
+	}
+	if c.Center.LatDeg != (south+north)/2 || c.Center.LonDeg != (west+east)/2 {
+		t.Errorf("Center = %v", c.Center)
+	}
+
+	c, err = GeohashReverse("")
+	if err != nil || c.Center != (LatLon{0, 0}) || c.LatResolutionDeg != 180 || c.LonResolutionDeg != 360 {
+		t.Errorf("GeohashReverse(\"\") = %+v, %v", c, err)
+	}
+	for _, s := range []string{"invalid", "NaN"} {
+		c, err := GeohashReverse(s)
+		if err != nil || !math.IsNaN(c.Center.LatDeg) || c.Prec != -2 {
+			t.Errorf("GeohashReverse(%q) = %+v, %v; want an invalid cell", s, c, err)
+		}
+	}
+	for _, s := range []string{"ezs4a", "u4pr i", "ezs42-"} {
+		if _, err := GeohashReverse(s); err == nil {
+			t.Errorf("GeohashReverse(%q) succeeded; want error", s)
+		}
+	}
+	// Only the first 18 characters are used
+	c1, _ := GeohashReverse("s00000000000000000")
+	c2, _ := GeohashReverse("s00000000000000000zzz")
+	if c1 != c2 {
+		t.Errorf("GeohashReverse of a long string = %+v; want %+v", c2, c1)
+	}
+}
+
+func TestGeohashRoundTrip(t *testing.T) {
+	for lat := -89.9; lat < 90; lat += 13.7 {
+		for lon := -179.3; lon < 180; lon += 29.1 {
+			for length := 0; length <= 18; length++ {
+				s, err := GeohashForward(lat, lon, length)
+				if err != nil {
+					t.Fatalf("GeohashForward() error = %v", err)
+				}
+				c, err := GeohashReverse(s)
+				if err != nil {
+					t.Fatalf("GeohashReverse(%q) error = %v", s, err)
+				}
+				if c.Prec != length ||
+					math.Abs(lat-c.Center.LatDeg) > c.LatResolutionDeg/2 ||
+					math.Abs(lon-c.Center.LonDeg) > c.LonResolutionDeg/2 {
+					t.Errorf("GeohashReverse(%q) = %+v; want a cell containing %v, %v", s, c, lat, lon)
+				}
+				// The center of the cell encodes to the same string
+				if s2, _ := GeohashForward(c.Center.LatDeg, c.Center.LonDeg, length); s2 != s {
+					t.Errorf("GeohashForward(center of %q) = %q", s, s2)
+				}
+			}
+		}
+	}
+}
+
+func TestGeohashResolution(t *testing.T) {
+	for length := 0; length <= 18; length++ {
+		latres, lonres := GeohashResolution(length)
+		if !(lonres == latres || lonres == 2*latres) {
+			t.Errorf("GeohashResolution(%d) = %v, %v", length, latres, lonres)
+		}
+		if got := GeohashLength(lonres); got != length {
+			t.Errorf("GeohashLength(%v) = %d; want %d", lonres, got, length)
+		}
+		if got := GeohashLengthLatLon(latres, lonres); got != length {
+			t.Errorf("GeohashLengthLatLon(%v, %v) = %d; want %d", latres, lonres, got, length)
+		}
+	}
+	if got := GeohashLength(1e-3); got != 8 {
+		t.Errorf("GeohashLength(1e-3) = %d; want 8", got)
+	}
+	if got := GeohashLength(0); got != 18 {
+		t.Errorf("GeohashLength(0) = %d; want 18", got)
+	}
+	// Length 5 has a latitude resolution of 0.044 degrees
+	if got := GeohashDecimalPrecision(5); got != 2 {
+		t.Errorf("GeohashDecimalPrecision(5) = %d; want 2", got)
+	}
+}
+
+func BenchmarkGeohashForward(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		GeohashForward(57.64911, 10.40744, 11)
+	}
+}
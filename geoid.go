@@ -0,0 +1,442 @@
+package geographiclibgo
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Geoid computes the height of the geoid above the WGS84 ellipsoid by interpolating a
+// gridded geoid model, such as EGM84, EGM96 or EGM2008, stored in the PGM format used
+// by GeographicLib (e.g. egm96-5.pgm). The data files are not bundled with this
+// library; they can be downloaded from
+// https://geographiclib.sourceforge.io/C++/doc/geoid.html.
+//
+// The grid is read on demand through an io.ReaderAt, so even the largest models use
+// very little memory. Height is safe for concurrent use provided that the underlying
+// io.ReaderAt is (as *os.File is).
+//
+// This is a port of the Geoid class from GeographicLib by Charles Karney.
+type Geoid struct {
+	r           io.ReaderAt
+	closer      io.Closer
+	cubic       bool
+	width       int
+	height      int
+	datastart   int64
+	offset      float64
+	scale       float64
+	maxerror    float64
+	rmserror    float64
+	description string
+	datetime    string
+	rlonres     float64
+	rlatres     float64
+}
+
+// The cubic interpolation is a least-squares fit of a cubic polynomial to the 12-point
+// stencil
+//
+//	.   1   1   .
+//	1   2   2   1
+//	1   2   2   1
+//	.   1   1   .
+//
+// with the weights shown. The stencil for cell (ix, iy) covers the points
+// _GEOID_STENCIL relative to (ix, iy). Next to the poles, the polynomial is further
+// constrained to be independent of longitude along the pole row. Row j of a
+// coefficient table gives the contribution of stencil point j to the 10 coefficients
+// of the polynomial in the order 1, x, y, x^2, x*y, y^2, x^3, x^2*y, x*y^2, y^3. Each
+// table is scaled by the matching _GEOID_C0 value.
+const (
+	_GEOID_NTERMS      int     = 10
+	_GEOID_STENCILSIZE int     = 12
+	_GEOID_C0          float64 = 240
+	_GEOID_C0N         float64 = 372
+	_GEOID_C0S         float64 = 372
+)
+
+var _GEOID_STENCIL = [_GEOID_STENCILSIZE][2]int{
+	{0, -1}, {1, -1},
+	{-1, 0}, {0, 0}, {1, 0}, {2, 0},
+	{-1, 1}, {0, 1}, {1, 1}, {2, 1},
+	{0, 2}, {1, 2},
+}
+
+var _GEOID_C3 = [_GEOID_STENCILSIZE * _GEOID_NTERMS]float64{
+	9, -18, -88, 0, 96, 90, 0, 0, -60, -20,
+	-9, 18, 8, 0, -96, 30, 0, 0, 60, -20,
+	9, -88, -18, 90, 96, 0, -20, -60, 0, 0,
+	186, -42, -42, -150, -96, -150, 60, 60, 60, 60,
+	54, 162, -78, 30, -24, -90, -60, 60, -60, 60,
+	-9, -32, 18, 30, 24, 0, 20, -60, 0, 0,
+	-9, 8, 18, 30, -96, 0, -20, 60, 0, 0,
+	54, -78, 162, -90, -24, 30, 60, -60, 60, -60,
+	-54, 78, 78, 90, 144, 90, -60, -60, -60, -60,
+	9, -8, -18, -30, -24, 0, 20, 60, 0, 0,
+	-9, 18, -32, 0, 24, 30, 0, 0, -60, 20,
+	9, -18, -8, 0, -24, -30, 0, 0, 60, 20,
+}
+
+var _GEOID_C3N = [_GEOID_STENCILSIZE * _GEOID_NTERMS]float64{
+	0, 0, -131, 0, 138, 144, 0, 0, -102, -31,
+	0, 0, 7, 0, -138, 42, 0, 0, 102, -31,
+	62, 0, -31, 0, 0, -62, 0, 0, 0, 31,
+	124, 0, -62, 0, 0, -124, 0, 0, 0, 62,
+	124, 0, -62, 0, 0, -124, 0, 0, 0, 62,
+	62, 0, -31, 0, 0, -62, 0, 0, 0, 31,
+	0, 0, 45, 0, -183, -9, 0, 93, 18, 0,
+	0, 0, 216, 0, 33, 87, 0, -93, 12, -93,
+	0, 0, 156, 0, 153, 99, 0, -93, -12, -93,
+	0, 0, -45, 0, -3, 9, 0, 93, -18, 0,
+	0, 0, -55, 0, 48, 42, 0, 0, -84, 31,
+	0, 0, -7, 0, -48, -42, 0, 0, 84, 31,
+}
+
+var _GEOID_C3S = [_GEOID_STENCILSIZE * _GEOID_NTERMS]float64{
+	18, -36, -122, 0, 120, 135, 0, 0, -84, -31,
+	-18, 36, -2, 0, -120, 51, 0, 0, 84, -31,
+	36, -165, -27, 93, 147, -9, 0, -93, 18, 0,
+	210, 45, -111, -93, -57, -192, 0, 93, 12, 93,
+	162, 141, -75, -93, -129, -180, 0, 93, -12, 93,
+	-36, -21, 27, 93, 39, 9, 0, -93, -18, 0,
+	0, 0, 62, 0, 0, 31, 0, 0, 0, -31,
+	0, 0, 124, 0, 0, 62, 0, 0, 0, -62,
+	0, 0, 124, 0, 0, 62, 0, 0, 0, -62,
+	0, 0, 62, 0, 0, 31, 0, 0, 0, -31,
+	-18, 36, -64, 0, 66, 51, 0, 0, -102, 31,
+	18, -36, 2, 0, -66, -51, 0, 0, 102, 31,
+}
+
+// NewGeoid reads the header of a geoid model in PGM format from r, which holds size
+// bytes. If cubic is true, heights are computed by cubic interpolation, otherwise by
+// bilinear interpolation. The grid itself is read from r as needed, so r must remain
+// valid for as long as the Geoid is used.
+func NewGeoid(r io.ReaderAt, size int64, cubic bool) (Geoid, error) {
+	g := Geoid{
+		r:           r,
+		cubic:       cubic,
+		offset:      math.NaN(),
+		scale:       0,
+		maxerror:    -1,
+		rmserror:    -1,
+		description: "NONE",
+		datetime:    "UNKNOWN",
+	}
+
+	br := bufio.NewReader(io.NewSectionReader(r, 0, size))
+	var pos int64
+	next_line := func() (string, int64, error) {
+		start := pos
+		s, err := br.ReadString('\n')
+		pos += int64(len(s))
+		if err != nil && (err != io.EOF || len(s) == 0) {
+			return "", start, err
+		}
+		return strings.TrimRight(s, "\r\n"), start, nil
+	}
+
+	s, _, err := next_line()
+	if err != nil || s != "P5" {
+		return Geoid{}, errors.New("geoid file not in PGM format")
+	}
+	have_size := false
+	for !have_size {
+		s, _, err = next_line()
+		if err != nil {
+			return Geoid{}, errors.New("EOF before end of geoid file header")
+		}
+		fields := strings.Fields(s)
+		if len(fields) == 0 {
+			continue
+		}
+		if s[0] != '#' {
+			if len(fields) < 2 {
+				return Geoid{}, errors.New("error reading geoid raster size")
+			}
+			if g.width, err = strconv.Atoi(fields[0]); err != nil {
+				return Geoid{}, errors.New("error reading geoid raster size")
+			}
+			if g.height, err = strconv.Atoi(fields[1]); err != nil {
+				return Geoid{}, errors.New("error reading geoid raster size")
+			}
+			have_size = true
+			continue
+		}
+		if len(fields) < 2 || fields[0] != "#" {
+			continue
+		}
+		key := fields[1]
+		switch key {
+		case "Description", "DateTime":
+			v := strings.TrimSpace(s[strings.Index(s, key)+len(key):])
+			if v == "" {
+				break
+			}
+			if key == "Description" {
+				g.description = v
+			} else {
+				g.datetime = v
+			}
+		case "Offset", "Scale":
+			if len(fields) < 3 {
+				return Geoid{}, fmt.Errorf("error reading geoid %s", key)
+			}
+			v, err := strconv.ParseFloat(fields[2], 64)
+			if err != nil {
+				return Geoid{}, fmt.Errorf("error reading geoid %s: %v", key, err)
+			}
+			if key == "Offset" {
+				g.offset = v
+			} else {
+				g.scale = v
+			}
+		case "MaxBilinearError", "RMSBilinearError", "MaxCubicError", "RMSCubicError":
+			if strings.Contains(key, "Cubic") != cubic || len(fields) < 3 {
+				break
+			}
+			if v, err := strconv.ParseFloat(fields[2], 64); err == nil {
+				if strings.HasPrefix(key, "Max") {
+					g.maxerror = v
+				} else {
+					g.rmserror = v
+				}
+			}
+		}
+	}
+
+	// The maximum value follows the raster size, separated from the data by a single
+	// whitespace character.
+	for {
+		var start int64
+		s, start, err = next_line()
+		if err != nil {
+			return Geoid{}, errors.New("error reading geoid maxval")
+		}
+		fields := strings.Fields(s)
+		if len(fields) == 0 {
+			continue
+		}
+		maxval, err := strconv.Atoi(fields[0])
+		if err != nil {
+			return Geoid{}, errors.New("error reading geoid maxval")
+		}
+		if maxval != 0xffff {
+			return Geoid{}, fmt.Errorf("incorrect geoid maxval %d; want %d", maxval, 0xffff)
+		}
+		g.datastart = start + int64(strings.Index(s, fields[0])+len(fields[0])) + 1
+		break
+	}
+
+	if math.IsNaN(g.offset) || math.IsInf(g.offset, 0) {
+		return Geoid{}, errors.New("geoid offset not set")
+	}
+	if g.scale == 0 {
+		return Geoid{}, errors.New("geoid scale not set")
+	}
+	if g.scale < 0 {
+		return Geoid{}, errors.New("geoid scale must be positive")
+	}
+	if g.height < 2 || g.width < 2 {
+		return Geoid{}, fmt.Errorf("geoid raster size %d x %d too small", g.width, g.height)
+	}
+	if g.width&1 != 0 {
+		return Geoid{}, fmt.Errorf("geoid raster width %d is odd", g.width)
+	}
+	if g.height&1 == 0 {
+		return Geoid{}, fmt.Errorf("geoid raster height %d is even", g.height)
+	}
+	if want := g.datastart + 2*int64(g.width)*int64(g.height); size != want {
+		return Geoid{}, fmt.Errorf("geoid file has length %d; want %d", size, want)
+	}
+	g.rlonres = float64(g.width) / 360.0
+	g.rlatres = float64(g.height-1) / 180.0
+	return g, nil
+}
+
+// NewGeoidFromFile opens the geoid model in the PGM file at path. See NewGeoid. The
+// file stays open until Close is called.
+func NewGeoidFromFile(path string, cubic bool) (Geoid, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return Geoid{}, err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return Geoid{}, err
+	}
+	g, err := NewGeoid(f, info.Size(), cubic)
+	if err != nil {
+		f.Close()
+		return Geoid{}, fmt.Errorf("%s: %v", path, err)
+	}
+	g.closer = f
+	return g, nil
+}
+
+// Close closes the file opened by NewGeoidFromFile. It does nothing for a Geoid
+// created with NewGeoid.
+func (g *Geoid) Close() error {
+	if g.closer == nil {
+		return nil
+	}
+	err := g.closer.Close()
+	g.closer = nil
+	return err
+}
+
+// _rawval returns the raw grid value at column ix and row iy, where row 0 is the north
+// pole. Columns wrap around in longitude and rows beyond the poles are reflected
+// across them.
+func (g *Geoid) _rawval(ix, iy int) (float64, error) {
+	if ix < 0 {
+		ix += g.width
+	} else if ix >= g.width {
+		ix -= g.width
+	}
+	if iy < 0 || iy >= g.height {
+		if iy < 0 {
+			iy = -iy
+		} else {
+			iy = 2*(g.height-1) - iy
+		}
+		if ix < g.width/2 {
+			ix += g.width / 2
+		} else {
+			ix -= g.width / 2
+		}
+	}
+	var buf [2]byte
+	off := g.datastart + 2*(int64(iy)*int64(g.width)+int64(ix))
+	if n, err := g.r.ReadAt(buf[:], off); n < len(buf) {
+		return 0, err
+	}
+	return float64(uint16(buf[0])<<8 | uint16(buf[1])), nil
+}
+
+// Height returns the height of the geoid above the ellipsoid in meters (the geoid
+// undulation N) at latitude lat_deg and longitude lon_deg. The error is only non-nil
+// if reading the grid fails. NaN is returned if lat_deg is not in [-90, 90].
+func (g *Geoid) Height(lat_deg, lon_deg float64) (float64, error) {
+	lat := lat_fix(lat_deg)
+	lon := ang_normalize(lon_deg)
+	if math.IsNaN(lat) || math.IsNaN(lon) {
+		return math.NaN(), nil
+	}
+	fx := lon * g.rlonres
+	fy := -lat * g.rlatres
+	ix := int(math.Floor(fx))
+	iy := int(math.Floor(fy))
+	if iymax := (g.height-1)/2 - 1; iy > iymax {
+		iy = iymax
+	}
+	fx -= float64(ix)
+	fy -= float64(iy)
+	iy += (g.height - 1) / 2
+	if ix < 0 {
+		ix += g.width
+	} else if ix >= g.width {
+		ix -= g.width
+	}
+
+	if !g.cubic {
+		var v [4]float64
+		for k := range v {
+			var err error
+			if v[k], err = g._rawval(ix+k%2, iy+k/2); err != nil {
+				return math.NaN(), err
+			}
+		}
+		a := (1-fx)*v[0] + fx*v[1]
+		b := (1-fx)*v[2] + fx*v[3]
+		c := (1-fy)*a + fy*b
+		return g.offset + g.scale*c, nil
+	}
+
+	var v [_GEOID_STENCILSIZE]float64
+	for k, d := range _GEOID_STENCIL {
+		var err error
+		if v[k], err = g._rawval(ix+d[0], iy+d[1]); err != nil {
+			return math.NaN(), err
+		}
+	}
+	c3, c0 := &_GEOID_C3, _GEOID_C0
+	if iy == 0 {
+		c3, c0 = &_GEOID_C3N, _GEOID_C0N
+	} else if iy == g.height-2 {
+		c3, c0 = &_GEOID_C3S, _GEOID_C0S
+	}
+	var t [_GEOID_NTERMS]float64
+	for i := range t {
+		for j := range v {
+			t[i] += v[j] * c3[_GEOID_NTERMS*j+i]
+		}
+		t[i] /= c0
+	}
+	h := t[0] + fx*(t[1]+fx*(t[3]+fx*t[6])) +
+		fy*(t[2]+fx*(t[4]+fx*t[7])+
+			fy*(t[5]+fx*t[8]+fy*t[9]))
+	return g.offset + g.scale*h, nil
+}
+
+// EllipsoidToGeoid converts the height h_m above the ellipsoid at latitude lat_deg and
+// longitude lon_deg to the orthometric height H = h - N above the geoid.
+func (g *Geoid) EllipsoidToGeoid(lat_deg, lon_deg, h_m float64) (float64, error) {
+	n, err := g.Height(lat_deg, lon_deg)
+	if err != nil {
+		return math.NaN(), err
+	}
+	return h_m - n, nil
+}
+
+// GeoidToEllipsoid converts the orthometric height H_m above the geoid at latitude
+// lat_deg and longitude lon_deg to the height h = H + N above the ellipsoid.
+func (g *Geoid) GeoidToEllipsoid(lat_deg, lon_deg, H_m float64) (float64, error) {
+	n, err := g.Height(lat_deg, lon_deg)
+	if err != nil {
+		return math.NaN(), err
+	}
+	return H_m + n, nil
+}
+
+// Description returns the description from the header of the geoid file, or "NONE".
+func (g *Geoid) Description() string { return g.description }
+
+// DateTime returns the date of the geoid file from its header, or "UNKNOWN".
+func (g *Geoid) DateTime() string { return g.datetime }
+
+// Interpolation returns "cubic" or "bilinear".
+func (g *Geoid) Interpolation() string {
+	if g.cubic {
+		return "cubic"
+	}
+	return "bilinear"
+}
+
+// MaxError returns the estimated maximum interpolation error in meters, as given in
+// the header of the geoid file for the interpolation method in use, or -1 if unknown.
+func (g *Geoid) MaxError() float64 { return g.maxerror }
+
+// RMSError returns the estimated RMS interpolation error in meters, as given in the
+// header of the geoid file for the interpolation method in use, or -1 if unknown.
+func (g *Geoid) RMSError() float64 { return g.rmserror }
+
+// Offset returns the offset in meters used to convert the raw grid values to heights.
+func (g *Geoid) Offset() float64 { return g.offset }
+
+// Scale returns the scale in meters used to convert the raw grid values to heights.
+func (g *Geoid) Scale() float64 { return g.scale }
+
+// EquatorialRadius returns the equatorial radius of the WGS84 ellipsoid, to which the
+// geoid heights refer.
+func (g *Geoid) EquatorialRadius() float64 { return WGS84_A }
+
+// Flattening returns the flattening of the WGS84 ellipsoid, to which the geoid heights
+// refer.
+func (g *Geoid) Flattening() float64 { return WGS84_F }
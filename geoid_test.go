@@ -0,0 +1,320 @@
+package geographiclibgo
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"testing"
+)
+
+const geoid_fixture = "test_fixtures/geoid-synthetic.pgm"
+
+// geoid_fixture_height is the undulation from which the synthetic fixture was built.
+// The fixture has a 45 degree grid with a scale of 3 mm.
+func geoid_fixture_height(lat, lon float64) float64 {
+	return 20*math.Cos(lat*DEG2RAD)*math.Sin(lon*DEG2RAD) + 10*math.Sin(lat*DEG2RAD)
+}
+
+// make_geoid_pgm builds an in-memory geoid with offset -50 m and scale 1 cm, where raw
+// gives the value at column ix and row iy (row 0 is the north pole).
+func make_geoid_pgm(width, height int, raw func(ix, iy int) uint16) []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "P5\n# Offset -50\n# Scale 0.01\n%d %d\n65535\n", width, height)
+	for iy := 0; iy < height; iy++ {
+		for ix := 0; ix < width; ix++ {
+			v := raw(ix, iy)
+			b.WriteByte(byte(v >> 8))
+			b.WriteByte(byte(v))
+		}
+	}
+	return b.Bytes()
+}
+
+func new_test_geoid(t testing.TB, data []byte, cubic bool) Geoid {
+	g, err := NewGeoid(bytes.NewReader(data), int64(len(data)), cubic)
+	if err != nil {
+		t.Fatalf("NewGeoid: %v", err)
+	}
+	return g
+}
+
+func TestGeoidFromFile(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		cubic    bool
+		interp   string
+		maxerror float64
+		rmserror float64
+	}{
+		{"bilinear", false, "bilinear", 12.5, 4.25},
+		{"cubic", true, "cubic", 3.5, 1.125},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			g, err := NewGeoidFromFile(geoid_fixture, tC.cubic)
+			if err != nil {
+				t.Fatalf("NewGeoidFromFile: %v", err)
+			}
+			defer g.Close()
+			want_desc := "Synthetic test geoid, N = 20 cos(lat) sin(lon) + 10 sin(lat)"
+			if g.Description() != want_desc {
+				t.Errorf("Description() = %q; want %q", g.Description(), want_desc)
+			}
+			if g.DateTime() != "2026-10-16 00:00:00" {
+				t.Errorf("DateTime() = %q; want %q", g.DateTime(), "2026-10-16 00:00:00")
+			}
+			if g.Interpolation() != tC.interp {
+				t.Errorf("Interpolation() = %q; want %q", g.Interpolation(), tC.interp)
+			}
+			if g.MaxError() != tC.maxerror {
+				t.Errorf("MaxError() = %v; want %v", g.MaxError(), tC.maxerror)
+			}
+			if g.RMSError() != tC.rmserror {
+				t.Errorf("RMSError() = %v; want %v", g.RMSError(), tC.rmserror)
+			}
+			if g.Offset() != -108 || g.Scale() != 0.003 {
+				t.Errorf("Offset(), Scale() = %v, %v; want -108, 0.003", g.Offset(), g.Scale())
+			}
+			if g.EquatorialRadius() != WGS84_A || g.Flattening() != WGS84_F {
+				t.Errorf("ellipsoid = %v, %v; want WGS84", g.EquatorialRadius(), g.Flattening())
+			}
+		})
+	}
+}
+
+func TestGeoidFromFileMissing(t *testing.T) {
+	if _, err := NewGeoidFromFile("test_fixtures/no-such-geoid.pgm", false); err == nil {
+		t.Errorf("NewGeoidFromFile succeeded for a missing file")
+	}
+}
+
+func TestGeoidFixtureNodes(t *testing.T) {
+	g, err := NewGeoidFromFile(geoid_fixture, false)
+	if err != nil {
+		t.Fatalf("NewGeoidFromFile: %v", err)
+	}
+	defer g.Close()
+	// Bilinear interpolation reproduces the grid values, which are within half a
+	// quantum of the model.
+	for lat := -90.0; lat <= 90; lat += 45 {
+		for lon := -180.0; lon <= 180; lon += 45 {
+			got, err := g.Height(lat, lon)
+			if err != nil {
+				t.Fatalf("Height(%v, %v): %v", lat, lon, err)
+			}
+			want := geoid_fixture_height(lat, lon)
+			if !almost_equal(got, want, 0.0015+1e-9) {
+				t.Errorf("Height(%v, %v) = %v; want %v", lat, lon, got, want)
+			}
+		}
+	}
+	// Midway between the nodes the bilinear interpolant is the mean of the corners
+	got, _ := g.Height(22.5, 67.5)
+	want := 0.0
+	for _, ll := range [][2]float64{{0, 45}, {0, 90}, {45, 45}, {45, 90}} {
+		h, _ := g.Height(ll[0], ll[1])
+		want += h / 4
+	}
+	if !almost_equal(got, want, 1e-12) {
+		t.Errorf("Height(22.5, 67.5) = %v; want %v", got, want)
+	}
+}
+
+func TestGeoidBilinearExact(t *testing.T) {
+	// A bilinear function of the grid indices is reproduced exactly away from the
+	// wrap-around in longitude.
+	width, height := 16, 9
+	f := func(x, y float64) float64 { return 30000 + 50*x + 70*y + 3*x*y }
+	data := make_geoid_pgm(width, height, func(ix, iy int) uint16 {
+		return uint16(f(float64(ix), float64(iy)))
+	})
+	g := new_test_geoid(t, data, false)
+	for lat := -89.5; lat < 90; lat += 7.3 {
+		for lon := 0.0; lon < 337.5; lon += 11.7 {
+			x, y := lon*16/360, 4-lat*8/180
+			want := -50 + 0.01*f(x, y)
+			got, err := g.Height(lat, lon)
+			if err != nil {
+				t.Fatalf("Height(%v, %v): %v", lat, lon, err)
+			}
+			if !almost_equal(got, want, 1e-9) {
+				t.Errorf("Height(%v, %v) = %v; want %v", lat, lon, got, want)
+			}
+		}
+	}
+}
+
+func TestGeoidCubicExact(t *testing.T) {
+	// The cubic least-squares fit reproduces a cubic polynomial in the grid indices
+	// wherever the stencil avoids the poles and the wrap-around in longitude.
+	width, height := 16, 9
+	f := func(x, y float64) float64 {
+		return 20000 + 40*x - 60*y + 7*x*x - 4*x*y + 9*y*y +
+			2*x*x*x - 3*x*x*y + 5*x*y*y - y*y*y
+	}
+	data := make_geoid_pgm(width, height, func(ix, iy int) uint16 {
+		return uint16(f(float64(ix), float64(iy)))
+	})
+	g := new_test_geoid(t, data, true)
+	// Cells with 1 <= ix <= 13 and 1 <= iy <= 6
+	for lat := -65.0; lat < 67.5; lat += 6.1 {
+		for lon := 22.5; lon < 315; lon += 9.7 {
+			x, y := lon*16/360, 4-lat*8/180
+			want := -50 + 0.01*f(x, y)
+			got, err := g.Height(lat, lon)
+			if err != nil {
+				t.Fatalf("Height(%v, %v): %v", lat, lon, err)
+			}
+			if !almost_equal(got, want, 1e-9) {
+				t.Errorf("Height(%v, %v) = %v; want %v", lat, lon, got, want)
+			}
+		}
+	}
+}
+
+func TestGeoidPoles(t *testing.T) {
+	// With arbitrary data, the geoid height at a pole must not depend on longitude
+	width, height := 12, 7
+	data := make_geoid_pgm(width, height, func(ix, iy int) uint16 {
+		if iy == 0 {
+			return 41000
+		} else if iy == height-1 {
+			return 17000
+		}
+		return uint16(30000 + 997*((ix*7+iy*13)%11))
+	})
+	testCases := []struct {
+		desc  string
+		cubic bool
+	}{
+		{"bilinear", false},
+		{"cubic", true},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			g := new_test_geoid(t, data, tC.cubic)
+			north, _ := g.Height(90, 0)
+			south, _ := g.Height(-90, 0)
+			for lon := -180.0; lon <= 180; lon += 13 {
+				if got, _ := g.Height(90, lon); !almost_equal(got, north, 1e-9) {
+					t.Errorf("Height(90, %v) = %v; want %v", lon, got, north)
+				}
+				if got, _ := g.Height(-90, lon); !almost_equal(got, south, 1e-9) {
+					t.Errorf("Height(-90, %v) = %v; want %v", lon, got, south)
+				}
+			}
+			if !tC.cubic && (north != 360 || south != 120) {
+				t.Errorf("pole heights = %v, %v; want 360, 120", north, south)
+			}
+		})
+	}
+}
+
+func TestGeoidLongitudeWrap(t *testing.T) {
+	for _, cubic := range []bool{false, true} {
+		g, err := NewGeoidFromFile(geoid_fixture, cubic)
+		if err != nil {
+			t.Fatalf("NewGeoidFromFile: %v", err)
+		}
+		for _, ll := range [][2]float64{{10, -1}, {-33, 359}, {71, 180}, {-5, 0}, {40, 720.5}} {
+			h1, _ := g.Height(ll[0], ll[1])
+			h2, _ := g.Height(ll[0], ll[1]-360)
+			if !almost_equal(h1, h2, 1e-12) {
+				t.Errorf("cubic=%v: Height(%v, %v) = %v; Height at lon - 360 = %v",
+					cubic, ll[0], ll[1], h1, h2)
+			}
+		}
+		g.Close()
+	}
+}
+
+func TestGeoidHeightConversion(t *testing.T) {
+	g, err := NewGeoidFromFile(geoid_fixture, true)
+	if err != nil {
+		t.Fatalf("NewGeoidFromFile: %v", err)
+	}
+	defer g.Close()
+	lat, lon, h := 37.5, 127.25, 250.0
+	n, _ := g.Height(lat, lon)
+	H, err := g.EllipsoidToGeoid(lat, lon, h)
+	if err != nil {
+		t.Fatalf("EllipsoidToGeoid: %v", err)
+	}
+	if H != h-n {
+		t.Errorf("EllipsoidToGeoid = %v; want %v", H, h-n)
+	}
+	h2, err := g.GeoidToEllipsoid(lat, lon, H)
+	if err != nil {
+		t.Fatalf("GeoidToEllipsoid: %v", err)
+	}
+	if !almost_equal(h2, h, 1e-12) {
+		t.Errorf("GeoidToEllipsoid = %v; want %v", h2, h)
+	}
+}
+
+func TestGeoidNaN(t *testing.T) {
+	g, err := NewGeoidFromFile(geoid_fixture, true)
+	if err != nil {
+		t.Fatalf("NewGeoidFromFile: %v", err)
+	}
+	defer g.Close()
+	for _, ll := range [][2]float64{{91, 0}, {math.NaN(), 0}, {0, math.NaN()}, {0, math.Inf(1)}} {
+		if got, err := g.Height(ll[0], ll[1]); !math.IsNaN(got) || err != nil {
+			t.Errorf("Height(%v, %v) = %v, %v; want NaN, nil", ll[0], ll[1], got, err)
+		}
+	}
+}
+
+func TestGeoidErrors(t *testing.T) {
+	data := func(header string, n int) []byte {
+		return append([]byte(header), make([]byte, 2*n)...)
+	}
+	testCases := []struct {
+		desc string
+		data []byte
+	}{
+		{"not pgm", data("P2\n# Offset 0\n# Scale 1\n4 3\n65535\n", 12)},
+		{"empty", []byte{}},
+		{"no size", []byte("P5\n# Offset 0\n# Scale 1\n")},
+		{"bad size", data("P5\n# Offset 0\n# Scale 1\n4\n65535\n", 12)},
+		{"bad maxval", data("P5\n# Offset 0\n# Scale 1\n4 3\n255\n", 12)},
+		{"no offset", data("P5\n# Scale 1\n4 3\n65535\n", 12)},
+		{"no scale", data("P5\n# Offset 0\n4 3\n65535\n", 12)},
+		{"negative scale", data("P5\n# Offset 0\n# Scale -1\n4 3\n65535\n", 12)},
+		{"odd width", data("P5\n# Offset 0\n# Scale 1\n5 3\n65535\n", 15)},
+		{"even height", data("P5\n# Offset 0\n# Scale 1\n4 4\n65535\n", 16)},
+		{"too small", data("P5\n# Offset 0\n# Scale 1\n4 1\n65535\n", 4)},
+		{"short", data("P5\n# Offset 0\n# Scale 1\n4 3\n65535\n", 11)},
+		{"long", data("P5\n# Offset 0\n# Scale 1\n4 3\n65535\n", 13)},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			_, err := NewGeoid(bytes.NewReader(tC.data), int64(len(tC.data)), false)
+			if err == nil {
+				t.Errorf("NewGeoid succeeded; want error")
+			}
+		})
+	}
+}
+
+func BenchmarkGeoidHeightBilinear(b *testing.B) {
+	g, err := NewGeoidFromFile(geoid_fixture, false)
+	if err != nil {
+		b.Fatalf("NewGeoidFromFile: %v", err)
+	}
+	defer g.Close()
+	for i := 0; i < b.N; i++ {
+		g.Height(37.5, 127.25)
+	}
+}
+
+func BenchmarkGeoidHeightCubic(b *testing.B) {
+	g, err := NewGeoidFromFile(geoid_fixture, true)
+	if err != nil {
+		b.Fatalf("NewGeoidFromFile: %v", err)
+	}
+	defer g.Close()
+	for i := 0; i < b.N; i++ {
+		g.Height(37.5, 127.25)
+	}
+}
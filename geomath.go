@@ -1,6 +1,10 @@
 package geographiclibgo
 
-import "math"
+import (
+	"math"
+	"strconv"
+	"strings"
+)
 
 const _DIGITS uint64 = 53
 const _TWO float64 = 2.0
@@ -101,6 +105,29 @@ func ang_normalize(x float64) float64 {
 	}
 }
 
+// AngNormalize reduces the angle x_deg to (-180, 180] [degrees]
+func AngNormalize(x_deg float64) float64 {
+	return ang_normalize(x_deg)
+}
+
+// ParseFraction parses a number which may be written as a fraction, e.g.
+// 1/298.257223563, as flattenings often are
+func ParseFraction(val string) (float64, error) {
+	i := strings.IndexByte(val, '/')
+	if i < 0 {
+		return strconv.ParseFloat(val, 64)
+	}
+	num, err := strconv.ParseFloat(val[:i], 64)
+	if err != nil {
+		return 0, err
+	}
+	den, err := strconv.ParseFloat(val[i+1:], 64)
+	if err != nil {
+		return 0, err
+	}
+	return num / den, nil
+}
+
 // lat_fix: replace angles outside [-90,90] with NaN
 func lat_fix(x float64) float64 {
 	if math.Abs(x) > 90.0 {
@@ -181,6 +208,32 @@ func sincosd(x float64) (float64, float64) {
 	return s, c
 }
 
+// sincosde: compute sine and cosine of x + t in degrees, where t is a small correction to
+// x, e.g. the error term returned by ang_diff
+func sincosde(x, t float64) (float64, float64) {
+	// Reduce x exactly to [-45, 45] before adding t and converting to radians
+	r := math.Remainder(x, 90.0)
+	q := int(math.Round((x-r)/90.0)) & 3
+	d := ang_round(r+t) * DEG2RAD
+	s := math.Sin(d)
+	c := math.Cos(d)
+
+	switch q {
+	case 1:
+		s, c = c, -s
+	case 2:
+		s, c = -s, -c
+	case 3:
+		s, c = -c, s
+	}
+
+	c += 0.0
+	if s == 0 {
+		s = math.Copysign(s, x)
+	}
+	return s, c
+}
+
 // atan2_deg: compute the arc tangent of y/x in degrees
 func atan2_deg(y_deg, x_deg float64) float64 {
 	// First convert to radians.
@@ -199,6 +252,70 @@ func eatanhe(x float64, es float64) float64 {
 	}
 }
 
+// tand: compute the tangent of x in degrees. At +/-90 degrees a large finite value is
+// returned, rather than +/-Inf, so that the result can still be used in arithmetic.
+func tand(x float64) float64 {
+	overflow := 1.0 / sq(get_epsilon())
+	s, c := sincosd(x)
+	if c != 0 {
+		return s / c
+	} else if s < 0 {
+		return -overflow
+	} else {
+		return overflow
+	}
+}
+
+// atand: compute the arc tangent of x in degrees
+func atand(x float64) float64 {
+	return math.Atan(x) * RAD2DEG
+}
+
+// taupf: compute tan(chi), where chi is the conformal latitude, given tau = tan(phi), where
+// phi is the geographic latitude. es is the signed eccentricity, sign(e2) * sqrt(|e2|).
+func taupf(tau, es float64) float64 {
+	// Need this test, otherwise tau = +/-Inf gives taup = NaN
+	if math.IsInf(tau, 0) {
+		return tau
+	}
+	tau1 := math.Hypot(1.0, tau)
+	sig := math.Sinh(eatanhe(tau/tau1, es))
+	return math.Hypot(1.0, sig)*tau - sig*tau1
+}
+
+// tauf: compute tan(phi), where phi is the geographic latitude, given taup = tan(chi), where
+// chi is the conformal latitude. This inverts taupf using Newton's method. es is the
+// signed eccentricity, sign(e2) * sqrt(|e2|).
+func tauf(taup, es float64) float64 {
+	numit := 5
+	// min iterations = 1, max iterations = 2; mean = 1.95
+	tol := math.Sqrt(get_epsilon()) / 10.0
+	taumax := 2.0 / math.Sqrt(get_epsilon())
+	e2m := 1.0 - es*math.Abs(es)
+	// To lowest order in e^2, taup = (1 - e^2) * tau = e2m * tau; so use tau = taup/e2m
+	// as a starting guess. For large tau, taup = exp(-es*atanh(es)) * tau, so use this as
+	// the initial guess for |taup| > 70 (approx |phi| > 89 degrees).
+	tau := taup / e2m
+	if math.Abs(taup) > 70.0 {
+		tau = taup * math.Exp(eatanhe(1.0, es))
+	}
+	stol := tol * math.Max(1.0, math.Abs(taup))
+	// Handles +/-Inf and NaN
+	if !(math.Abs(tau) < taumax) {
+		return tau
+	}
+	for i := 0; i < numit; i++ {
+		taupa := taupf(tau, es)
+		dtau := (taup - taupa) * (1.0 + e2m*sq(tau)) /
+			(e2m * math.Hypot(1.0, tau) * math.Hypot(1.0, taupa))
+		tau += dtau
+		if !(math.Abs(dtau) >= stol) {
+			break
+		}
+	}
+	return tau
+}
+
 // sin_cos_series: functions that used to be inside Geodesic
 func sin_cos_series(sinp bool, sinx float64, cosx float64, c []float64) float64 {
 	k := len(c)
@@ -553,3 +553,78 @@ func TestAtan2_deg(t *testing.T) {
 		})
 	}
 }
+
+func TestSincosde(t *testing.T) {
+	testCases := []struct {
+		desc string
+		x, t float64
+	}{
+		{"zero", 0, 0},
+		{"first quadrant", 30, 1e-14},
+		{"second quadrant", 120, -1e-14},
+		{"third quadrant", -135, 0},
+		{"fourth quadrant", -60, 1e-14},
+		{"large", 1000, 0},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			s, c := sincosde(tC.x, tC.t)
+			ws, wc := sincosd(tC.x + tC.t)
+			if !almost_equal(s, ws, 1e-15) || !almost_equal(c, wc, 1e-15) {
+				t.Errorf("sincosde(%v, %v) = %v, %v; want %v, %v", tC.x, tC.t, s, c, ws, wc)
+			}
+		})
+	}
+
+	// Exact results at multiples of 90 degrees
+	if s, c := sincosde(90, 0); s != 1 || c != 0 {
+		t.Errorf("sincosde(90, 0) = %v, %v; want 1, 0", s, c)
+	}
+	if s, c := sincosde(-180, 0); s != 0 || c != -1 {
+		t.Errorf("sincosde(-180, 0) = %v, %v; want 0, -1", s, c)
+	}
+}
+
+func TestParseFraction(t *testing.T) {
+	testCases := []struct {
+		desc string
+		s    string
+		want float64
+		ok   bool
+	}{
+		{"number", "6378137", 6378137, true},
+		{"exponent", "6.4e6", 6.4e6, true},
+		{"fraction", "1/4", 0.25, true},
+		{"negative fraction", "-1/150", -1.0 / 150, true},
+		{"bad numerator", "x/150", 0, false},
+		{"bad denominator", "1/", 0, false},
+		{"not a number", "abc", 0, false},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			got, err := ParseFraction(tC.s)
+			if (err == nil) != tC.ok || (tC.ok && got != tC.want) {
+				t.Errorf("ParseFraction(%q) = %v, %v; want %v", tC.s, got, err, tC.want)
+			}
+		})
+	}
+}
+
+func TestAngNormalize(t *testing.T) {
+	testCases := []struct {
+		x    float64
+		want float64
+	}{
+		{0, 0},
+		{190, -170},
+		{-180, 180},
+		{540, 180},
+		{-190, 170},
+		{720.5, 0.5},
+	}
+	for _, tC := range testCases {
+		if got := AngNormalize(tC.x); got != tC.want {
+			t.Errorf("AngNormalize(%v) = %v; want %v", tC.x, got, tC.want)
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package geographiclibgo
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Constants for Georef
+const (
+	_GEOREF_TILE    int = 15 // Size of the 15 degree tiles
+	_GEOREF_LONORIG int = -180
+	_GEOREF_LATORIG int = -90
+	_GEOREF_BASE    int = 10
+	_GEOREF_BASELEN int = 4
+	_GEOREF_MAXPREC int = 11 // Maximum precision is 1e-9 minutes
+	_GEOREF_MAXLEN  int = _GEOREF_BASELEN + 2*_GEOREF_MAXPREC
+	_GEOREF_DIGITS      = "0123456789"
+	_GEOREF_LONTILE     = "ABCDEFGHJKLMNPQRSTUVWXYZ"
+	_GEOREF_LATTILE     = "ABCDEFGHJKLM"
+	_GEOREF_DEGREES     = "ABCDEFGHJKLMNPQ"
+)
+
+// GeorefForward converts the point lat_deg, lon_deg to a World Geographic Reference
+// System string, see https://en.wikipedia.org/wiki/Georef. The precision prec in
+// [-1, 11] selects the size of the cell:
+//   - prec = -1 - 15 degree tile, e.g. SK
+//   - prec = 0 - 1 degree, e.g. SKNA
+//   - prec = 2 - 1 minute, e.g. SKNA2322
+//   - prec = 3 - 0.1 minute, e.g. SKNA234220
+//   - prec = 11 - 1e-9 minute, the maximum
+//
+// prec = 1 is treated as 2. This returns an error if lat_deg is not in [-90, 90], and
+// "INVALID" if lat_deg or lon_deg is NaN.
+func GeorefForward(lat_deg, lon_deg float64, prec int) (string, error) {
+	if math.Abs(lat_deg) > 90 {
+		return "", fmt.Errorf("latitude %vd not in [-90d, 90d]", lat_deg)
+	}
+	if math.IsNaN(lat_deg) || math.IsNaN(lon_deg) {
+		return "INVALID", nil
+	}
+	lon_deg = ang_normalize(lon_deg)
+	// lon_deg is now in [-180, 180)
+	if lon_deg == 180 {
+		lon_deg = -180
+	}
+	if lat_deg == 90 {
+		lat_deg *= 1 - get_epsilon()/2
+	}
+	if prec < -1 {
+		prec = -1
+	} else if prec > _GEOREF_MAXPREC {
+		prec = _GEOREF_MAXPREC
+	}
+	// Disallow prec = 1
+	if prec == 1 {
+		prec++
+	}
+	// The unit of x and y is 1e-9 minutes
+	const m int64 = 60000000000
+	x := int64(math.Floor(lon_deg*float64(m))) - int64(_GEOREF_LONORIG)*m
+	y := int64(math.Floor(lat_deg*float64(m))) - int64(_GEOREF_LATORIG)*m
+	ilon, ilat := int(x/m), int(y/m)
+	georef := make([]byte, _GEOREF_BASELEN+2*prec)
+	georef[0] = _GEOREF_LONTILE[ilon/_GEOREF_TILE]
+	georef[1] = _GEOREF_LATTILE[ilat/_GEOREF_TILE]
+	if prec >= 0 {
+		georef[2] = _GEOREF_DEGREES[ilon%_GEOREF_TILE]
+		georef[3] = _GEOREF_DEGREES[ilat%_GEOREF_TILE]
+		if prec > 0 {
+			x -= m * int64(ilon)
+			y -= m * int64(ilat)
+			d := int64(math.Pow(float64(_GEOREF_BASE), float64(_GEOREF_MAXPREC-prec)))
+			x /= d
+			y /= d
+			for c := prec - 1; c >= 0; c-- {
+				georef[_GEOREF_BASELEN+c] = _GEOREF_DIGITS[x%int64(_GEOREF_BASE)]
+				x /= int64(_GEOREF_BASE)
+				georef[_GEOREF_BASELEN+c+prec] = _GEOREF_DIGITS[y%int64(_GEOREF_BASE)]
+				y /= int64(_GEOREF_BASE)
+			}
+		}
+	}
+	return string(georef), nil
+}
+
+// GeorefReverse decodes a Georef string, which may be in upper or lower case, to its
+// cell. Strings starting with "INV" (e.g. the "INVALID" returned by GeorefForward) give
+// an invalid cell.
+func GeorefReverse(georef string) (GridCell, error) {
+	length := len(georef)
+	if length >= 3 && strings.ToUpper(georef[:3]) == "INV" {
+		return invalid_grid_cell(), nil
+	}
+	if length < _GEOREF_BASELEN-2 {
+		return GridCell{}, fmt.Errorf("georef must start with at least 2 letters %s", georef)
+	}
+	prec := (2+length-_GEOREF_BASELEN)/2 - 1
+	k := mgrs_lookup(_GEOREF_LONTILE, georef[0])
+	if k < 0 {
+		return GridCell{}, fmt.Errorf("bad longitude tile letter in georef %s", georef)
+	}
+	lon1 := int64(k + _GEOREF_LONORIG/_GEOREF_TILE)
+	k = mgrs_lookup(_GEOREF_LATTILE, georef[1])
+	if k < 0 {
+		return GridCell{}, fmt.Errorf("bad latitude tile letter in georef %s", georef)
+	}
+	lat1 := int64(k + _GEOREF_LATORIG/_GEOREF_TILE)
+	unit := int64(1)
+	if length > 2 {
+		unit *= int64(_GEOREF_TILE)
+		k = mgrs_lookup(_GEOREF_DEGREES, georef[2])
+		if k < 0 {
+			return GridCell{}, fmt.Errorf("bad longitude degree letter in georef %s", georef)
+		}
+		lon1 = lon1*int64(_GEOREF_TILE) + int64(k)
+		if length < 4 {
+			return GridCell{}, fmt.Errorf("missing latitude degree letter in georef %s", georef)
+		}
+		k = mgrs_lookup(_GEOREF_DEGREES, georef[3])
+		if k < 0 {
+			return GridCell{}, fmt.Errorf("bad latitude degree letter in georef %s", georef)
+		}
+		lat1 = lat1*int64(_GEOREF_TILE) + int64(k)
+		if prec > 0 {
+			digits := georef[_GEOREF_BASELEN:]
+			if strings.Trim(digits, _GEOREF_DIGITS) != "" {
+				return GridCell{}, fmt.Errorf("non digits in trailing portion of georef %s", digits)
+			}
+			if length%2 != 0 {
+				return GridCell{}, fmt.Errorf("georef must end with an even number of digits %s", digits)
+			}
+			if prec == 1 {
+				return GridCell{}, fmt.Errorf("georef needs at least 4 digits for minutes %s", digits)
+			}
+			if prec > _GEOREF_MAXPREC {
+				return GridCell{}, fmt.Errorf("more than %d digits in georef %s", 2*_GEOREF_MAXPREC, digits)
+			}
+			for i := 0; i < prec; i++ {
+				// The first digit of the minutes is base 6
+				m := _GEOREF_BASE
+				if i == 0 {
+					m = 6
+				}
+				unit *= int64(m)
+				x := int64(digits[i] - '0')
+				y := int64(digits[i+prec] - '0')
+				if i == 0 && !(x < int64(m) && y < int64(m)) {
+					return GridCell{}, fmt.Errorf("minutes terms in georef must be less than 60 %s", digits)
+				}
+				lon1 = int64(m)*lon1 + x
+				lat1 = int64(m)*lat1 + y
+			}
+		}
+	}
+	res := float64(_GEOREF_TILE) / float64(unit)
+	return new_grid_cell(
+		float64(int64(_GEOREF_TILE)*lat1)/float64(unit), float64(int64(_GEOREF_TILE)*lon1)/float64(unit),
+		res, res, prec,
+	), nil
+}
+
+// GeorefResolution returns the size of the cells [degrees] of a Georef string with
+// precision prec
+func GeorefResolution(prec int) float64 {
+	if prec < 0 {
+		return float64(_GEOREF_TILE)
+	} else if prec == 0 {
+		return 1
+	}
+	// prec = 1 is treated as 2
+	if prec < 2 {
+		prec = 2
+	}
+	return 1 / (60 * math.Pow(float64(_GEOREF_BASE), float64(prec-2)))
+}
+
+// GeorefPrecision returns the smallest precision of a Georef string whose cells are no
+// larger than res_deg
+func GeorefPrecision(res_deg float64) int {
+	res_deg = math.Abs(res_deg)
+	for prec := -1; prec < _GEOREF_MAXPREC; prec++ {
+		if prec == 1 {
+			continue
+		}
+		if GeorefResolution(prec) <= res_deg {
+			return prec
+		}
+	}
+	return _GEOREF_MAXPREC
+}
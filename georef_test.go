@@ -0,0 +1,124 @@
+package geographiclibgo
+
+import (
+	"math"
+	"testing"
+)
+
+func TestGeorefForward(t *testing.T) {
+	testCases := []struct {
+		desc string
+		lat  float64
+		lon  float64
+		prec int
+		want string
+	}{
+		{"15 degree tile", 38.8895, -77.0352, -1, "GJ"},
+		{"1 degree", 38.8895, -77.0352, 0, "GJNJ"},
+		{"1 minute", 38.8895, -77.0352, 2, "GJNJ5753"},
+		{"precision 1 is 2", 38.8895, -77.0352, 1, "GJNJ5753"},
+		{"0.1 minute", 38.8895, -77.0352, 3, "GJNJ578533"},
+		{"precision capped", 0, 0, 20, "NGAA0000000000000000000000"},
+		{"south west", -90, -180, 0, "AAAA"},
+		{"north east", 90, 179.999, 2, "ZMQQ5959"},
+		{"antimeridian", 0, 180, -1, "AG"},
+		{"nan", math.NaN(), 0, 2, "INVALID"},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			got, err := GeorefForward(tC.lat, tC.lon, tC.prec)
+			if err != nil {
+				t.Fatalf("GeorefForward() error = %v", err)
+			}
+			if got != tC.want {
+				t.Errorf("GeorefForward(%v, %v, %v) = %q; want %q", tC.lat, tC.lon, tC.prec, got, tC.want)
+			}
+		})
+	}
+	if _, err := GeorefForward(95, 0, 0); err == nil {
+		t.Errorf("GeorefForward(95, 0, 0) succeeded; want error")
+	}
+}
+
+func TestGeorefReverse(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		georef string
+		center LatLon
+		res    float64
+		prec   int
+	}{
+		{"tile", "GJ", LatLon{37.5, -82.5}, 15, -1},
+		{"degree", "gjnj", LatLon{38.5, -77.5}, 1, 0},
+		{"minute", "GJNJ5753", LatLon{38 + 53.5/60, -78 + 57.5/60}, 1.0 / 60, 2},
+		{"tenth minute", "GJNJ578533", LatLon{38 + 53.35/60, -78 + 57.85/60}, 1.0 / 600, 3},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			c, err := GeorefReverse(tC.georef)
+			if err != nil {
+				t.Fatalf("GeorefReverse() error = %v", err)
+			}
+			if !almost_equal(c.Center.LatDeg, tC.center.LatDeg, 1e-13) ||
+				!almost_equal(c.Center.LonDeg, tC.center.LonDeg, 1e-13) ||
+				!almost_equal(c.LatResolutionDeg, tC.res, 1e-16) || c.LonResolutionDeg != c.LatResolutionDeg ||
+				c.Prec != tC.prec {
+				t.Errorf("GeorefReverse(%q) = %+v; want %v, %v, %v", tC.georef, c, tC.center, tC.res, tC.prec)
+			}
+		})
+	}
+	if c, err := GeorefReverse("invalid"); err != nil || !math.IsNaN(c.Center.LatDeg) || c.Prec != -2 {
+		t.Errorf("GeorefReverse(invalid) = %+v, %v; want an invalid cell", c, err)
+	}
+	for _, s := range []string{
+		"G", "IJ", "GN", "GJN", "GJNI", "GJIJ", "GJNJ57", "GJNJ575", "GJNJ57a3", "GJNJ7753",
+		"GJNJ000000000000000000000000",
+	} {
+		if c, err := GeorefReverse(s); err == nil {
+			t.Errorf("GeorefReverse(%q) = %+v; want error", s, c)
+		}
+	}
+}
+
+func TestGeorefRoundTrip(t *testing.T) {
+	for lat := -89.95; lat < 90; lat += 7.3 {
+		for lon := -179.9; lon < 180; lon += 11.9 {
+			for prec := -1; prec <= 11; prec++ {
+				s, err := GeorefForward(lat, lon, prec)
+				if err != nil {
+					t.Fatalf("GeorefForward() error = %v", err)
+				}
+				c, err := GeorefReverse(s)
+				if err != nil {
+					t.Fatalf("GeorefReverse(%q) error = %v", s, err)
+				}
+				want := prec
+				if want == 1 {
+					want = 2
+				}
+				if c.Prec != want || !almost_equal(c.LatResolutionDeg, GeorefResolution(prec), 1e-16) ||
+					math.Abs(lat-c.Center.LatDeg) > c.LatResolutionDeg/2+1e-12 ||
+					math.Abs(lon-c.Center.LonDeg) > c.LonResolutionDeg/2+1e-12 {
+					t.Errorf("GeorefReverse(%q) = %+v; want a cell containing %v, %v", s, c, lat, lon)
+				}
+			}
+		}
+	}
+}
+
+func TestGeorefPrecision(t *testing.T) {
+	for _, prec := range []int{-1, 0, 2, 3, 7, 11} {
+		if got := GeorefPrecision(GeorefResolution(prec)); got != prec {
+			t.Errorf("GeorefPrecision(GeorefResolution(%d)) = %d", prec, got)
+		}
+	}
+	if got := GeorefPrecision(20); got != -1 {
+		t.Errorf("GeorefPrecision(20) = %d; want -1", got)
+	}
+	if got := GeorefPrecision(0.5); got != 2 {
+		t.Errorf("GeorefPrecision(0.5) = %d; want 2", got)
+	}
+	if GeorefResolution(1) != GeorefResolution(2) {
+		t.Errorf("GeorefResolution(1) = %v; want %v", GeorefResolution(1), GeorefResolution(2))
+	}
+}
@@ -0,0 +1,114 @@
+package geographiclibgo
+
+import "math"
+
+// The maximum number of iterations in Gnomonic.Reverse
+const _GNOMONIC_NUMIT int = 10
+
+// Gnomonic is the ellipsoidal gnomonic projection centered at an arbitrary position on the
+// ellipsoid, following C. F. F. Karney, Algorithms for geodesics, J. Geodesy 87, 43-55
+// (2013), Sec. 8. The projection of a point P is given by the reduced length m and
+// geodesic scale M of the geodesic from the center to P: the distance of the projected
+// point from the origin is m/M and its bearing is the azimuth of the geodesic at the
+// center.
+//
+// On a sphere, geodesics project to straight lines. On an ellipsoid, this is nearly true:
+// within 1000 km of the center, the deviation of the projection of a geodesic from a
+// straight line is less than 1.9 m on the WGS84 ellipsoid. This makes the projection
+// useful for solving geometric problems, such as finding the intersection of two
+// geodesics or the shortest path through waypoints, as problems in plane geometry,
+// followed by iterating on the center of the projection.
+//
+// The reciprocal scale returned by Forward and Reverse is the reciprocal of the azimuthal
+// scale, M. The radial scale is 1/M^2. Points at least 90 degrees from the center (for
+// which M <= 0) are not projected and give NaN coordinates.
+type Gnomonic struct {
+	eps   float64
+	earth Geodesic
+}
+
+// NewGnomonic creates a Gnomonic projection for the ellipsoid of the geodesic calculator
+// earth
+func NewGnomonic(earth Geodesic) Gnomonic {
+	return Gnomonic{
+		eps:   0.01 * math.Sqrt(get_epsilon()),
+		earth: earth,
+	}
+}
+
+// EquatorialRadius returns the equatorial radius of the ellipsoid [meters]
+func (p *Gnomonic) EquatorialRadius() float64 {
+	return p.earth.a
+}
+
+// Flattening returns the flattening of the ellipsoid
+func (p *Gnomonic) Flattening() float64 {
+	return p.earth.f
+}
+
+// Forward projects the point lat_deg, lon_deg onto the map centered at lat0_deg,
+// lon0_deg. If the point is too far from the center (M <= 0), the coordinates are NaN.
+//   - lat0_deg, lon0_deg - Center of the projection [degrees]
+//   - lat_deg, lon_deg - The point [degrees]
+func (p *Gnomonic) Forward(lat0_deg, lon0_deg, lat_deg, lon_deg float64) GeodesicProjectionForwardResult {
+	_, _, azi0, azi, m, M, _, _ := p.earth._gen_inverse_azi(
+		lat0_deg, lon0_deg, lat_deg, lon_deg, AZIMUTH|REDUCEDLENGTH|GEODESICSCALE,
+	)
+	x, y := math.NaN(), math.NaN()
+	if M > 0 {
+		rho := m / M
+		x, y = sincosd(azi0)
+		x *= rho
+		y *= rho
+	}
+	return GeodesicProjectionForwardResult{XM: x, YM: y, AziDeg: azi, ReciprocalScale: M}
+}
+
+// Reverse finds the point at x_m, y_m on the map centered at lat0_deg, lon0_deg. This
+// uses Newton's method to solve m/M = hypot(x_m, y_m) along the geodesic from the center
+// with azimuth atan2(x_m, y_m). If this fails to converge, the result is NaN.
+//   - lat0_deg, lon0_deg - Center of the projection [degrees]
+//   - x_m, y_m - Easting and northing of the point [meters]
+func (p *Gnomonic) Reverse(lat0_deg, lon0_deg, x_m, y_m float64) GeodesicProjectionReverseResult {
+	a := p.earth.a
+	azi0 := atan2_deg(x_m, y_m)
+	rho := math.Hypot(x_m, y_m)
+	s := a * math.Atan(rho/a)
+	little := rho <= a
+	if !little {
+		rho = 1 / rho
+	}
+	line := p.earth.LineWithCapabilities(
+		lat0_deg, lon0_deg, azi0,
+		LATITUDE|LONGITUDE|AZIMUTH|DISTANCE_IN|REDUCEDLENGTH|GEODESICSCALE,
+	)
+	trip := false
+	var lat1, lon1, azi1, M float64
+	for count := 0; count < _GNOMONIC_NUMIT; count++ {
+		var m float64
+		_, lat1, lon1, azi1, _, m, M, _, _ = line._gen_position(
+			false, s, LATITUDE|LONGITUDE|AZIMUTH|REDUCEDLENGTH|GEODESICSCALE,
+		)
+		if trip {
+			break
+		}
+		// If little, solve rho(s) = rho with drho(s)/ds = 1/M^2, else solve
+		// 1/rho(s) = 1/rho with d(1/rho(s))/ds = -1/m^2
+		var ds float64
+		if little {
+			ds = (m - rho*M) * M
+		} else {
+			ds = (rho*m - M) * m
+		}
+		s -= ds
+		// Reversed test to allow escape with NaNs
+		if !(math.Abs(ds) >= p.eps*a) {
+			trip = true
+		}
+	}
+	if !trip {
+		nan := math.NaN()
+		return GeodesicProjectionReverseResult{LatDeg: nan, LonDeg: nan, AziDeg: nan, ReciprocalScale: nan}
+	}
+	return GeodesicProjectionReverseResult{LatDeg: lat1, LonDeg: lon1, AziDeg: azi1, ReciprocalScale: M}
+}
@@ -0,0 +1,77 @@
+package geographiclibgo
+
+import (
+	"math"
+	"testing"
+)
+
+func TestGnomonicRoundTrip(t *testing.T) {
+	p := NewGnomonic(Wgs84())
+	lat0, lon0 := 42.0, -71.0
+	for _, pt := range []LatLon{{42, -71}, {43, -70}, {10, -60}, {80, 20}, {40, -10}} {
+		fwd := p.Forward(lat0, lon0, pt.LatDeg, pt.LonDeg)
+		rev := p.Reverse(lat0, lon0, fwd.XM, fwd.YM)
+		if !almost_equal(rev.LatDeg, pt.LatDeg, 1e-11) || !almost_equal(rev.LonDeg, pt.LonDeg, 1e-11) {
+			t.Errorf("Reverse(Forward(%v)) = (%v, %v)", pt, rev.LatDeg, rev.LonDeg)
+		}
+		if !almost_equal(rev.AziDeg, fwd.AziDeg, 1e-10) {
+			t.Errorf("%v: reverse azi = %v; want %v", pt, rev.AziDeg, fwd.AziDeg)
+		}
+		if !almost_equal(rev.ReciprocalScale, fwd.ReciprocalScale, 1e-12) {
+			t.Errorf("%v: reverse rk = %v; want %v", pt, rev.ReciprocalScale, fwd.ReciprocalScale)
+		}
+	}
+
+	// Points more than a quadrant from the center are not projected
+	if got := p.Forward(lat0, lon0, -lat0, lon0+180); !math.IsNaN(got.XM) || !math.IsNaN(got.YM) {
+		t.Errorf("Forward(antipode) = (%v, %v); want NaN", got.XM, got.YM)
+	}
+}
+
+func TestGnomonicSphere(t *testing.T) {
+	// On a sphere, the distance from the origin is R tan(c), where c is the angular
+	// distance from the center, and great circles are straight lines
+	rR := 6371000.0
+	g := NewGeodesic(rR, 0)
+	p := NewGnomonic(g)
+	got := p.Forward(0, 0, 0, 45)
+	if !almost_equal(got.XM, rR, 1e-6) || !almost_equal(got.YM, 0, 1e-6) {
+		t.Errorf("Forward(0, 45) = (%v, %v); want (%v, 0)", got.XM, got.YM, rR)
+	}
+
+	line := g.InverseLineWithCapabilities(30, -20, 50, 30, STANDARD|DISTANCE_IN)
+	a := p.Forward(40, 0, 30, -20)
+	b := p.Forward(40, 0, 50, 30)
+	for _, f := range []float64{0.25, 0.5, 0.75} {
+		pos := line.PositionStandard(f * line.Distance())
+		c := p.Forward(40, 0, pos.Lat2Deg, pos.Lon2Deg)
+		// Cross product of (b - a) and (c - a) vanishes for collinear points
+		cross := (b.XM-a.XM)*(c.YM-a.YM) - (b.YM-a.YM)*(c.XM-a.XM)
+		if d := cross / math.Hypot(b.XM-a.XM, b.YM-a.YM); !almost_equal(d, 0, 1e-6) {
+			t.Errorf("distance from straight line at %v = %v; want 0", f, d)
+		}
+	}
+}
+
+func TestGnomonicEllipsoidStraightness(t *testing.T) {
+	// Within 1000 km of the center, geodesics project to lines which are straight to
+	// within a few meters on the WGS84 ellipsoid
+	g := Wgs84()
+	p := NewGnomonic(g)
+	line := g.InverseLineWithCapabilities(35, -5, 40, 5, STANDARD|DISTANCE_IN)
+	a := p.Forward(38, 0, 35, -5)
+	b := p.Forward(38, 0, 40, 5)
+	pos := line.PositionStandard(0.5 * line.Distance())
+	c := p.Forward(38, 0, pos.Lat2Deg, pos.Lon2Deg)
+	cross := (b.XM-a.XM)*(c.YM-a.YM) - (b.YM-a.YM)*(c.XM-a.XM)
+	if d := math.Abs(cross / math.Hypot(b.XM-a.XM, b.YM-a.YM)); !(d < 2) {
+		t.Errorf("distance from straight line = %v; want < 2", d)
+	}
+}
+
+func BenchmarkGnomonicReverse(b *testing.B) {
+	p := NewGnomonic(Wgs84())
+	for i := 0; i < b.N; i++ {
+		p.Reverse(42, -71, 100000, 200000)
+	}
+}
@@ -0,0 +1,380 @@
+package geographiclibgo
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// _GRAVITY_IDLENGTH is the length of the ID which starts a gravity coefficient file
+const _GRAVITY_IDLENGTH int = 8
+
+// GravityModel evaluates a spherical harmonic model of the earth's gravity field, such
+// as EGM84, EGM96, or EGM2008. The models are read from the files distributed with
+// GeographicLib, which consist of a metadata file (e.g. egm96.egm) and a binary
+// coefficient file with the same name followed by ".cof". These can be downloaded from
+// https://geographiclib.sourceforge.io/C++/doc/gravity.html.
+//
+// Each model refers to a normal gravity field (see NormalGravity), which is subtracted
+// to give the disturbing potential, the gravity disturbance, and the geoid height.
+// Positions are geodetic coordinates on the ellipsoid of the normal gravity field.
+//
+// This is a port of the GravityModel class from GeographicLib by Charles Karney.
+type GravityModel struct {
+	name          string
+	description   string
+	date          string
+	id            string
+	amodel        float64
+	GMmodel       float64
+	zeta0         float64
+	corrmult      float64
+	dzonal0       float64
+	norm          SphericalNormalization
+	nmax          int
+	mmax          int
+	earth         NormalGravity
+	gravitational SphericalHarmonic
+	disturbing    SphericalHarmonic1
+	correction    SphericalHarmonic
+}
+
+// NewGravityModel reads a gravity model from its metadata file, meta, and its
+// coefficient file, coeffs, in the formats used by GeographicLib
+func NewGravityModel(meta io.Reader, coeffs io.Reader) (GravityModel, error) {
+	gm := GravityModel{
+		description: "NONE",
+		date:        "UNKNOWN",
+		amodel:      math.NaN(),
+		GMmodel:     math.NaN(),
+		corrmult:    1,
+		norm:        FullNormalization,
+	}
+	if err := gm._read_metadata(meta); err != nil {
+		return GravityModel{}, err
+	}
+
+	br := bufio.NewReader(coeffs)
+	if err := read_model_id(br, gm.id); err != nil {
+		return GravityModel{}, err
+	}
+	c, err := read_spherical_coeffs(br)
+	if err != nil {
+		return GravityModel{}, fmt.Errorf("error reading gravity coefficients: %v", err)
+	}
+	if c.n < 0 {
+		return GravityModel{}, errors.New("degree and order must be at least 0")
+	}
+	if c.c[0] != 0 {
+		return GravityModel{}, errors.New("the degree 0 term should be zero")
+	}
+	// Include the 1/r term in the sum
+	c.c[0] = 1
+	gm.nmax, gm.mmax = c.n, c.m
+	gm.gravitational = NewSphericalHarmonic(c, gm.amodel, gm.norm)
+
+	// The zonal coefficients of the normal potential, scaled to the mass constant and
+	// radius of the model. The 0th term should be 1 + dzonal0. Instead set it to 1 to
+	// give exact cancellation with the (0,0) term of the model and account for dzonal0
+	// separately.
+	mult := gm.earth.GM / gm.GMmodel
+	amult := sq(gm.earth.a / gm.amodel)
+	zonal := []float64{1}
+	gm.dzonal0 = (gm.earth.GM - gm.GMmodel) / gm.GMmodel
+	for n := 2; n <= c.n; n += 2 {
+		// Only include as many normal zonal terms as matter. This works because the
+		// coefficients of the (smooth) normal potential decay much more rapidly than
+		// those of the (bumpy) model. Typically this goes out to n = 18.
+		mult *= amult
+		r := c.c[n]
+		s := -mult * gm.earth.Jn(n) / math.Sqrt(float64(2*n+1))
+		if r-s == r {
+			break
+		}
+		zonal = append(zonal, 0, s)
+	}
+	z, err := NewSphericalCoefficients(zonal, nil, len(zonal)-1, 0)
+	if err != nil {
+		return GravityModel{}, err
+	}
+	if gm.disturbing, err = NewSphericalHarmonic1(c, z, gm.amodel, gm.norm); err != nil {
+		return GravityModel{}, err
+	}
+
+	// The correction to convert the height anomaly to the geoid height
+	cc, err := read_spherical_coeffs(br)
+	if err != nil {
+		return GravityModel{}, fmt.Errorf("error reading correction coefficients: %v", err)
+	}
+	if cc.n < 0 {
+		cc, _ = NewSphericalCoefficients([]float64{0}, nil, 0, 0)
+	}
+	cc.c[0] += gm.zeta0 / gm.corrmult
+	gm.correction = NewSphericalHarmonic(cc, 1, gm.norm)
+
+	if err := check_model_eof(br); err != nil {
+		return GravityModel{}, err
+	}
+	return gm, nil
+}
+
+// NewGravityModelFromFile reads the gravity model whose metadata file is at path (e.g.
+// /usr/local/share/GeographicLib/gravity/egm96.egm). The coefficients are read from
+// path + ".cof".
+func NewGravityModelFromFile(path string) (GravityModel, error) {
+	meta, err := os.Open(path)
+	if err != nil {
+		return GravityModel{}, err
+	}
+	defer meta.Close()
+	coeffs, err := os.Open(path + ".cof")
+	if err != nil {
+		return GravityModel{}, err
+	}
+	defer coeffs.Close()
+	gm, err := NewGravityModel(meta, coeffs)
+	if err != nil {
+		return GravityModel{}, fmt.Errorf("%s: %v", path, err)
+	}
+	return gm, nil
+}
+
+func (gm *GravityModel) _read_metadata(meta io.Reader) error {
+	sc := bufio.NewScanner(meta)
+	if !sc.Scan() || len(sc.Text()) < 6 || !strings.HasPrefix(sc.Text(), "EGMF-") {
+		return errors.New("no EGMF-n header in gravity model metadata")
+	}
+	if version := strings.TrimSpace(sc.Text()[5:]); version != "1" {
+		return fmt.Errorf("unknown gravity model version %s", version)
+	}
+	a, GM, omega, f, J2 := math.NaN(), math.NaN(), math.NaN(), math.NaN(), math.NaN()
+	for sc.Scan() {
+		key, val, ok := parse_model_line(sc.Text())
+		if !ok {
+			continue
+		}
+		var err error
+		switch key {
+		case "Name":
+			gm.name = val
+		case "Description":
+			gm.description = val
+		case "ReleaseDate":
+			gm.date = val
+		case "ModelRadius":
+			gm.amodel, err = strconv.ParseFloat(val, 64)
+		case "ModelMass":
+			gm.GMmodel, err = strconv.ParseFloat(val, 64)
+		case "AngularVelocity":
+			omega, err = strconv.ParseFloat(val, 64)
+		case "ReferenceRadius":
+			a, err = strconv.ParseFloat(val, 64)
+		case "ReferenceMass":
+			GM, err = strconv.ParseFloat(val, 64)
+		case "ReferenceFlattening":
+			f, err = ParseFraction(val)
+		case "ReferenceDynamicalFormFactor":
+			J2, err = ParseFraction(val)
+		case "HeightOffset":
+			gm.zeta0, err = ParseFraction(val)
+		case "CorrectionMultiplier":
+			gm.corrmult, err = ParseFraction(val)
+		case "Normalization":
+			gm.norm, err = parse_model_normalization(val)
+		case "ByteOrder":
+			err = check_model_byte_order(val)
+		case "ID":
+			gm.id = val
+		}
+		if err != nil {
+			return fmt.Errorf("bad %s in gravity model metadata: %v", key, err)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return err
+	}
+	is_finite := func(x float64) bool { return !math.IsNaN(x) && !math.IsInf(x, 0) }
+	if !(is_finite(gm.amodel) && gm.amodel > 0) {
+		return errors.New("model radius must be positive")
+	}
+	if !(is_finite(gm.GMmodel) && gm.GMmodel > 0) {
+		return errors.New("model mass constant must be positive")
+	}
+	if !(is_finite(gm.corrmult) && gm.corrmult > 0) {
+		return errors.New("correction multiplier must be positive")
+	}
+	if !is_finite(gm.zeta0) {
+		return errors.New("height offset must be finite")
+	}
+	if len(gm.id) != _GRAVITY_IDLENGTH {
+		return fmt.Errorf("invalid ID %q", gm.id)
+	}
+	if !(is_finite(a) && a > 0) {
+		return errors.New("reference radius must be positive")
+	}
+	if !(is_finite(GM) && GM > 0) {
+		return errors.New("reference mass constant must be positive")
+	}
+	if !is_finite(omega) {
+		return errors.New("angular velocity must be finite")
+	}
+	switch {
+	case is_finite(f) && is_finite(J2):
+		return errors.New("cannot specify both the flattening and J2")
+	case is_finite(f):
+		if !(f < 1) {
+			return errors.New("reference flattening must be less than 1")
+		}
+		gm.earth = NewNormalGravity(a, GM, omega, f)
+	case is_finite(J2):
+		gm.earth = NewNormalGravityJ2(a, GM, omega, J2)
+		if math.IsNaN(gm.earth.Flattening()) {
+			return errors.New("no flattening matches the reference J2")
+		}
+	default:
+		return errors.New("neither the reference flattening nor J2 is given")
+	}
+	return nil
+}
+
+// _disturbing_potential returns the disturbing potential T = W - U at the geocentric
+// point x, y, z together with its gradient if gradp. If correct, T includes the term
+// due to the difference of the mass constants of the model and the normal field.
+func (gm *GravityModel) _disturbing_potential(x, y, z float64, gradp, correct bool) (T, dx, dy, dz float64) {
+	if gm.dzonal0 == 0 {
+		correct = false
+	}
+	invR := 1.0
+	if correct {
+		invR = 1 / math.Sqrt(sq(x)+sq(y)+sq(z))
+	}
+	if gradp {
+		T, dx, dy, dz = gm.disturbing.Gradient(-1, x, y, z)
+		f := gm.GMmodel / gm.amodel
+		dx, dy, dz = dx*f, dy*f, dz*f
+		if correct {
+			k := gm.GMmodel * gm.dzonal0 * invR * invR * invR
+			dx, dy, dz = dx+x*k, dy+y*k, dz+z*k
+		}
+	} else {
+		T = gm.disturbing.Value(-1, x, y, z)
+	}
+	dzonal0 := 0.0
+	if correct {
+		dzonal0 = gm.dzonal0
+	}
+	T = (T/gm.amodel - dzonal0*invR) * gm.GMmodel
+	return T, dx, dy, dz
+}
+
+// Gravity returns the acceleration due to gravity (gravitational plus centrifugal) and
+// the gravity potential W at latitude lat_deg, longitude lon_deg, and height h_m above
+// the ellipsoid
+func (gm *GravityModel) Gravity(lat_deg, lon_deg, h_m float64) GravityResult {
+	c, m := gm.earth.earth.ForwardWithRotation(lat_deg, lon_deg, h_m)
+	V, gx, gy, gz := gm.gravitational.Gradient(c.XM, c.YM, c.ZM)
+	f := gm.GMmodel / gm.amodel
+	omega2 := gm.earth.omega2
+	W := V*f + omega2*(sq(c.XM)+sq(c.YM))/2
+	gx, gy, gz = gx*f+omega2*c.XM, gy*f+omega2*c.YM, gz*f
+	return GravityResult{
+		EastMPerS2:       m[0]*gx + m[3]*gy + m[6]*gz,
+		NorthMPerS2:      m[1]*gx + m[4]*gy + m[7]*gz,
+		UpMPerS2:         m[2]*gx + m[5]*gy + m[8]*gz,
+		PotentialM2PerS2: W,
+	}
+}
+
+// Disturbance returns the gravity disturbance (the difference between the acceleration
+// due to gravity and normal gravity) and the disturbing potential T = W - U at latitude
+// lat_deg, longitude lon_deg, and height h_m above the ellipsoid
+func (gm *GravityModel) Disturbance(lat_deg, lon_deg, h_m float64) GravityResult {
+	c, m := gm.earth.earth.ForwardWithRotation(lat_deg, lon_deg, h_m)
+	T, dx, dy, dz := gm._disturbing_potential(c.XM, c.YM, c.ZM, true, true)
+	return GravityResult{
+		EastMPerS2:       m[0]*dx + m[3]*dy + m[6]*dz,
+		NorthMPerS2:      m[1]*dx + m[4]*dy + m[7]*dz,
+		UpMPerS2:         m[2]*dx + m[5]*dy + m[8]*dz,
+		PotentialM2PerS2: T,
+	}
+}
+
+// GeoidHeight returns the height of the geoid above the ellipsoid at latitude lat_deg
+// and longitude lon_deg [meters]. This evaluates the full spherical harmonic series and
+// is much slower than interpolating a grid with Geoid.
+func (gm *GravityModel) GeoidHeight(lat_deg, lon_deg float64) float64 {
+	c := gm.earth.earth.Forward(lat_deg, lon_deg, 0)
+	gamma0 := gm.earth.SurfaceGravity(lat_deg)
+	T, _, _, _ := gm._disturbing_potential(c.XM, c.YM, c.ZM, false, false)
+	invR := 1 / math.Sqrt(sq(c.XM)+sq(c.YM)+sq(c.ZM))
+	// The height offset has been included in the correction
+	correction := gm.corrmult * gm.correction.Value(invR*c.XM, invR*c.YM, invR*c.ZM)
+	return T/gamma0 + correction
+}
+
+// Name returns the name of the model
+func (gm *GravityModel) Name() string {
+	return gm.name
+}
+
+// Description returns the description of the model, or "NONE"
+func (gm *GravityModel) Description() string {
+	return gm.description
+}
+
+// DateTime returns the release date of the model, or "UNKNOWN"
+func (gm *GravityModel) DateTime() string {
+	return gm.date
+}
+
+// Degree returns the maximum degree of the spherical harmonic series
+func (gm *GravityModel) Degree() int {
+	return gm.nmax
+}
+
+// Order returns the maximum order of the spherical harmonic series
+func (gm *GravityModel) Order() int {
+	return gm.mmax
+}
+
+// ReferenceRadius returns the reference radius of the spherical harmonic series of the
+// model [meters]
+func (gm *GravityModel) ReferenceRadius() float64 {
+	return gm.amodel
+}
+
+// MassConstant returns the mass constant GM of the model [m^3/s^2]
+func (gm *GravityModel) MassConstant() float64 {
+	return gm.GMmodel
+}
+
+// ReferenceMassConstant returns the mass constant GM of the normal gravity field
+// [m^3/s^2]
+func (gm *GravityModel) ReferenceMassConstant() float64 {
+	return gm.earth.GM
+}
+
+// AngularVelocity returns the angular velocity of the earth [rad/s]
+func (gm *GravityModel) AngularVelocity() float64 {
+	return gm.earth.omega
+}
+
+// EquatorialRadius returns the equatorial radius of the ellipsoid [meters]
+func (gm *GravityModel) EquatorialRadius() float64 {
+	return gm.earth.a
+}
+
+// Flattening returns the flattening of the ellipsoid
+func (gm *GravityModel) Flattening() float64 {
+	return gm.earth.f
+}
+
+// ReferenceEllipsoid returns the normal gravity field to which the model refers
+func (gm *GravityModel) ReferenceEllipsoid() NormalGravity {
+	return gm.earth
+}
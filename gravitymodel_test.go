@@ -0,0 +1,291 @@
+package geographiclibgo
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// test_gravity_meta is the metadata for a synthetic gravity model referred to the WGS84
+// normal gravity field. ModelRadius and ModelMass are filled in by make_gravity_model.
+const test_gravity_meta = `EGMF-1
+# A synthetic model for testing
+Name                    testegm
+Description             Synthetic gravity model
+ReleaseDate             2026-10-16
+ModelRadius             %v
+ModelMass               %v
+AngularVelocity         7292115e-11
+ReferenceRadius         6378137
+ReferenceMass           3986004.418e8
+ReferenceFlattening     1/298.257223563
+HeightOffset            -0.41
+CorrectionMultiplier    2
+Normalization           full
+ByteOrder               little
+ID                      TESTEGM1
+`
+
+const (
+	test_gravity_n   = 20
+	test_gravity_m   = 4
+	test_gravity_c22 = 2.4e-6
+	test_gravity_s22 = -1.4e-6
+	test_gravity_cc0 = 0.5
+)
+
+// make_gravity_model returns the metadata and coefficient files of a degree 20 model
+// with radius a and mass constant GM. Its zonal terms are those of the WGS84 normal
+// field; if c22 it also has the degree 2, order 2 terms test_gravity_c22 and
+// test_gravity_s22. The correction is the constant test_gravity_cc0.
+func make_gravity_model(a, GM float64, c22 bool) (string, []byte) {
+	earth := Wgs84NormalGravity()
+	N, M := test_gravity_n, test_gravity_m
+	size := spherical_coeff_size(N, M)
+	C, S := make([]float64, size), make([]float64, size-(N+1))
+	for n := 2; n <= N; n += 2 {
+		C[n] = -earth.Jn(n) * earth.MassConstant() / GM *
+			math.Pow(earth.EquatorialRadius()/a, float64(n)) / math.Sqrt(float64(2*n+1))
+	}
+	if c22 {
+		k := 2*N - 1 + 2 // index of (2, 2)
+		C[k], S[k-(N+1)] = test_gravity_c22, test_gravity_s22
+	}
+	var b bytes.Buffer
+	b.WriteString("TESTEGM1")
+	write_spherical_coeffs(&b, C, S, N, M)
+	write_spherical_coeffs(&b, []float64{test_gravity_cc0}, nil, 0, 0)
+	return fmt.Sprintf(test_gravity_meta, a, GM), b.Bytes()
+}
+
+func new_test_gravity_model(t testing.TB, a, GM float64, c22 bool) GravityModel {
+	meta, coeffs := make_gravity_model(a, GM, c22)
+	gm, err := NewGravityModel(strings.NewReader(meta), bytes.NewReader(coeffs))
+	if err != nil {
+		t.Fatalf("NewGravityModel: %v", err)
+	}
+	return gm
+}
+
+var test_gravity_points = [][3]float64{
+	{0, 0, 0}, {27, -80, 100}, {-45, 135, 3000}, {60, 10, -50}, {89.5, -170, 0}, {-33, 18, 4e5},
+}
+
+func TestGravityModelNormal(t *testing.T) {
+	// A model with the same zonal terms as the normal field has no disturbance
+	earth := Wgs84NormalGravity()
+	gm := new_test_gravity_model(t, earth.EquatorialRadius(), earth.MassConstant(), false)
+	for _, pt := range test_gravity_points {
+		g := gm.Gravity(pt[0], pt[1], pt[2])
+		want := earth.Gravity(pt[0], pt[2])
+		if !almost_equal(g.PotentialM2PerS2, want.PotentialM2PerS2, 1e-7) ||
+			!almost_equal(g.EastMPerS2, want.EastMPerS2, 1e-12) ||
+			!almost_equal(g.NorthMPerS2, want.NorthMPerS2, 1e-12) ||
+			!almost_equal(g.UpMPerS2, want.UpMPerS2, 1e-12) {
+			t.Errorf("Gravity(%v) = %+v; want %+v", pt, g, want)
+		}
+		d := gm.Disturbance(pt[0], pt[1], pt[2])
+		if math.Abs(d.PotentialM2PerS2) > 1e-7 || math.Abs(d.EastMPerS2) > 1e-12 ||
+			math.Abs(d.NorthMPerS2) > 1e-12 || math.Abs(d.UpMPerS2) > 1e-12 {
+			t.Errorf("Disturbance(%v) = %+v; want 0", pt, d)
+		}
+		// Only the correction and the height offset remain
+		N := gm.GeoidHeight(pt[0], pt[1])
+		if !almost_equal(N, 2*test_gravity_cc0-0.41, 1e-9) {
+			t.Errorf("GeoidHeight(%v, %v) = %v; want %v", pt[0], pt[1], N, 2*test_gravity_cc0-0.41)
+		}
+	}
+}
+
+func TestGravityModelMassConstant(t *testing.T) {
+	// The disturbing potential includes the difference of the mass constants
+	earth := Wgs84NormalGravity()
+	GM := earth.MassConstant() * (1 + 1e-9)
+	gm := new_test_gravity_model(t, 6378136.3, GM, false)
+	ec := earth.Earth()
+	for _, pt := range test_gravity_points {
+		c := ec.Forward(pt[0], pt[1], pt[2])
+		r := math.Sqrt(sq(c.XM) + sq(c.YM) + sq(c.ZM))
+		d := gm.Disturbance(pt[0], pt[1], pt[2])
+		want := (GM - earth.MassConstant()) / r
+		if !almost_equal(d.PotentialM2PerS2, want, 1e-7) {
+			t.Errorf("T(%v) = %v; want %v", pt, d.PotentialM2PerS2, want)
+		}
+		N := gm.GeoidHeight(pt[0], pt[1])
+		if !almost_equal(N, 2*test_gravity_cc0-0.41, 1e-9) {
+			t.Errorf("GeoidHeight(%v, %v) = %v; want %v", pt[0], pt[1], N, 2*test_gravity_cc0-0.41)
+		}
+	}
+}
+
+func TestGravityModelTesseral(t *testing.T) {
+	// The geoid height of a degree 2, order 2 term given in closed form
+	earth := Wgs84NormalGravity()
+	GM, a := earth.MassConstant(), earth.EquatorialRadius()
+	gm := new_test_gravity_model(t, a, GM, true)
+	ec := earth.Earth()
+	for _, pt := range test_gravity_points {
+		c := ec.Forward(pt[0], pt[1], 0)
+		p := math.Hypot(c.XM, c.YM)
+		r := math.Hypot(p, c.ZM)
+		// The fully normalized P22(sin(psi)) = sqrt(15)/2 cos(psi)^2
+		p22 := math.Sqrt(15) / 2 * sq(p/r)
+		lam := pt[1] * DEG2RAD
+		T := GM / a * math.Pow(a/r, 3) * p22 *
+			(test_gravity_c22*math.Cos(2*lam) + test_gravity_s22*math.Sin(2*lam))
+		want := T/earth.SurfaceGravity(pt[0]) + 2*test_gravity_cc0 - 0.41
+		if got := gm.GeoidHeight(pt[0], pt[1]); !almost_equal(got, want, 1e-8) {
+			t.Errorf("GeoidHeight(%v, %v) = %v; want %v", pt[0], pt[1], got, want)
+		}
+	}
+}
+
+func TestGravityModelDisturbance(t *testing.T) {
+	// The disturbance is the difference between gravity and normal gravity
+	earth := Wgs84NormalGravity()
+	gm := new_test_gravity_model(t, 6378136.3, earth.MassConstant()*(1-3e-9), true)
+	for _, pt := range test_gravity_points {
+		g := gm.Gravity(pt[0], pt[1], pt[2])
+		n := earth.Gravity(pt[0], pt[2])
+		d := gm.Disturbance(pt[0], pt[1], pt[2])
+		if !almost_equal(d.PotentialM2PerS2, g.PotentialM2PerS2-n.PotentialM2PerS2, 1e-7) ||
+			!almost_equal(d.EastMPerS2, g.EastMPerS2-n.EastMPerS2, 1e-12) ||
+			!almost_equal(d.NorthMPerS2, g.NorthMPerS2-n.NorthMPerS2, 1e-12) ||
+			!almost_equal(d.UpMPerS2, g.UpMPerS2-n.UpMPerS2, 1e-12) {
+			t.Errorf("Disturbance(%v) = %+v; want %+v - %+v", pt, d, g, n)
+		}
+	}
+}
+
+func TestGravityModelGradient(t *testing.T) {
+	// The upward acceleration is the derivative of the potential with height
+	earth := Wgs84NormalGravity()
+	gm := new_test_gravity_model(t, 6378136.3, earth.MassConstant(), true)
+	for _, pt := range test_gravity_points {
+		g := gm.Gravity(pt[0], pt[1], pt[2])
+		d := 0.5
+		w1 := gm.Gravity(pt[0], pt[1], pt[2]+d).PotentialM2PerS2
+		w2 := gm.Gravity(pt[0], pt[1], pt[2]-d).PotentialM2PerS2
+		if want := (w1 - w2) / (2 * d); !almost_equal(g.UpMPerS2, want, 1e-7) {
+			t.Errorf("up gravity at %v = %v; want %v", pt, g.UpMPerS2, want)
+		}
+	}
+}
+
+func TestGravityModelMetadata(t *testing.T) {
+	gm := new_test_gravity_model(t, 6378136.3, 3986004.415e8, false)
+	if gm.Name() != "testegm" || gm.Description() != "Synthetic gravity model" ||
+		gm.DateTime() != "2026-10-16" {
+		t.Errorf("Name, Description, DateTime = %q, %q, %q", gm.Name(), gm.Description(), gm.DateTime())
+	}
+	if gm.Degree() != test_gravity_n || gm.Order() != test_gravity_m {
+		t.Errorf("Degree, Order = %d, %d; want %d, %d", gm.Degree(), gm.Order(), test_gravity_n, test_gravity_m)
+	}
+	if gm.ReferenceRadius() != 6378136.3 || gm.MassConstant() != 3986004.415e8 ||
+		gm.ReferenceMassConstant() != 3986004.418e8 || gm.AngularVelocity() != 7292115e-11 {
+		t.Errorf("constants = %v, %v, %v, %v", gm.ReferenceRadius(), gm.MassConstant(),
+			gm.ReferenceMassConstant(), gm.AngularVelocity())
+	}
+	if gm.EquatorialRadius() != WGS84_A || !almost_equal(gm.Flattening(), WGS84_F, 1e-18) {
+		t.Errorf("ellipsoid = %v, %v", gm.EquatorialRadius(), gm.Flattening())
+	}
+	earth := gm.ReferenceEllipsoid()
+	if earth.MassConstant() != 3986004.418e8 {
+		t.Errorf("ReferenceEllipsoid().MassConstant() = %v", earth.MassConstant())
+	}
+}
+
+func TestGravityModelFromFile(t *testing.T) {
+	meta, coeffs := make_gravity_model(WGS84_A, 3986004.418e8, true)
+	path := filepath.Join(t.TempDir(), "testegm.egm")
+	if err := os.WriteFile(path, []byte(meta), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path+".cof", coeffs, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	gm, err := NewGravityModelFromFile(path)
+	if err != nil {
+		t.Fatalf("NewGravityModelFromFile: %v", err)
+	}
+	want := new_test_gravity_model(t, WGS84_A, 3986004.418e8, true)
+	if got, want := gm.GeoidHeight(12, 34), want.GeoidHeight(12, 34); got != want {
+		t.Errorf("GeoidHeight = %v; want %v", got, want)
+	}
+	if _, err := NewGravityModelFromFile(filepath.Join(t.TempDir(), "missing.egm")); err == nil {
+		t.Errorf("NewGravityModelFromFile succeeded on a missing file")
+	}
+}
+
+func TestGravityModelErrors(t *testing.T) {
+	meta, coeffs := make_gravity_model(WGS84_A, 3986004.418e8, false)
+	testCases := []struct {
+		desc   string
+		meta   string
+		coeffs []byte
+	}{
+		{"bad header", strings.Replace(meta, "EGMF-1", "WMMF-1", 1), coeffs},
+		{"bad version", strings.Replace(meta, "EGMF-1", "EGMF-2", 1), coeffs},
+		{"bad radius", strings.Replace(meta, "ModelRadius", "ModelRadius -1 #", 1), coeffs},
+		{"bad mass", strings.Replace(meta, "ModelMass", "ModelMass x #", 1), coeffs},
+		{"bad multiplier", strings.Replace(meta, "CorrectionMultiplier    2", "CorrectionMultiplier 0", 1), coeffs},
+		{"both f and J2", meta + "ReferenceDynamicalFormFactor 108263e-8\n", coeffs},
+		{"no f or J2", strings.Replace(meta, "ReferenceFlattening", "#", 1), coeffs},
+		{"no reference mass", strings.Replace(meta, "ReferenceMass", "#", 1), coeffs},
+		{"big endian", strings.Replace(meta, "little", "big", 1), coeffs},
+		{"bad ID", strings.Replace(meta, "TESTEGM1", "TEST", 1), coeffs},
+		{"ID mismatch", meta, append([]byte("TESTEGM2"), coeffs[8:]...)},
+		{"truncated", meta, coeffs[:len(coeffs)-1]},
+		{"extra data", meta, append(append([]byte{}, coeffs...), 0)},
+		{"degree 0 term", meta, func() []byte {
+			var b bytes.Buffer
+			b.WriteString("TESTEGM1")
+			write_spherical_coeffs(&b, []float64{1}, nil, 0, 0)
+			write_spherical_coeffs(&b, nil, nil, -1, -1)
+			return b.Bytes()
+		}()},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			_, err := NewGravityModel(strings.NewReader(tC.meta), bytes.NewReader(tC.coeffs))
+			if err == nil {
+				t.Errorf("NewGravityModel succeeded; want error")
+			}
+		})
+	}
+}
+
+func TestGravityModelJ2(t *testing.T) {
+	// A model may refer to a normal field given by J2 and have no correction
+	meta, _ := make_gravity_model(WGS84_A, 3986005e8, false)
+	meta = strings.Replace(meta, "ReferenceFlattening     1/298.257223563",
+		"ReferenceDynamicalFormFactor 108263e-8", 1)
+	meta = strings.Replace(meta, "ReferenceMass           3986004.418e8", "ReferenceMass 3986005e8", 1)
+	var b bytes.Buffer
+	b.WriteString("TESTEGM1")
+	write_spherical_coeffs(&b, []float64{0}, nil, 0, 0)
+	write_spherical_coeffs(&b, nil, nil, -1, -1)
+	gm, err := NewGravityModel(strings.NewReader(meta), bytes.NewReader(b.Bytes()))
+	if err != nil {
+		t.Fatalf("NewGravityModel: %v", err)
+	}
+	if !almost_equal(1/gm.Flattening(), 298.257222101, 1e-9) {
+		t.Errorf("1/f = %v; want 298.257222101", 1/gm.Flattening())
+	}
+	// A degree 0 model has no disturbing potential and the height offset is applied
+	// without a correction
+	if N := gm.GeoidHeight(30, 0); !almost_equal(N, -0.41, 1e-15) {
+		t.Errorf("GeoidHeight(30, 0) = %v; want -0.41", N)
+	}
+}
+
+func BenchmarkGravityModelGeoidHeight(b *testing.B) {
+	gm := new_test_gravity_model(b, WGS84_A, 3986004.418e8, true)
+	for i := 0; i < b.N; i++ {
+		gm.GeoidHeight(45, 10)
+	}
+}
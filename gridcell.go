@@ -0,0 +1,51 @@
+package geographiclibgo
+
+import "math"
+
+// GridCell is a latitude/longitude cell of a grid reference system, as decoded by
+// GeohashReverse, GARSReverse, and GeorefReverse. The cell for an invalid string (e.g.
+// "INVALID") has a NaN center and resolutions and Prec = -2.
+type GridCell struct {
+	Center           LatLon  // Center of the cell [degrees]
+	LatResolutionDeg float64 // Extent of the cell in latitude [degrees]
+	LonResolutionDeg float64 // Extent of the cell in longitude [degrees]
+	Prec             int     // Length of a Geohash, or precision of a GARS or Georef string
+}
+
+// new_grid_cell returns the cell with south-west corner lat_deg, lon_deg and the given
+// resolutions
+func new_grid_cell(lat_deg, lon_deg, latres_deg, lonres_deg float64, prec int) GridCell {
+	return GridCell{
+		Center:           LatLon{LatDeg: lat_deg + latres_deg/2, LonDeg: lon_deg + lonres_deg/2},
+		LatResolutionDeg: latres_deg,
+		LonResolutionDeg: lonres_deg,
+		Prec:             prec,
+	}
+}
+
+// invalid_grid_cell returns the cell for an invalid string
+func invalid_grid_cell() GridCell {
+	nan := math.NaN()
+	return GridCell{Center: LatLon{LatDeg: nan, LonDeg: nan}, LatResolutionDeg: nan, LonResolutionDeg: nan, Prec: -2}
+}
+
+// Bounds returns the latitudes of the south and north edges and the longitudes of the
+// west and east edges of the cell [degrees]
+func (c GridCell) Bounds() (south_deg, west_deg, north_deg, east_deg float64) {
+	return c.Center.LatDeg - c.LatResolutionDeg/2, c.Center.LonDeg - c.LonResolutionDeg/2,
+		c.Center.LatDeg + c.LatResolutionDeg/2, c.Center.LonDeg + c.LonResolutionDeg/2
+}
+
+// Area returns the area and perimeter of the cell on the ellipsoid of g, computed with a
+// PolygonArea whose vertices are the corners of the cell. The edges of this polygon are
+// geodesics, so for large cells the north and south edges bulge toward the pole; use
+// Ellipsoid's RectangleArea for the area bounded by the parallels.
+func (c GridCell) Area(g Geodesic) PolygonResult {
+	south, west, north, east := c.Bounds()
+	p := NewPolygonArea(g, false)
+	p.AddPoint(south, west)
+	p.AddPoint(south, east)
+	p.AddPoint(north, east)
+	p.AddPoint(north, west)
+	return p.Compute(false, true)
+}
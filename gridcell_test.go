@@ -0,0 +1,59 @@
+package geographiclibgo
+
+import (
+	"math"
+	"testing"
+)
+
+func TestGridCellArea(t *testing.T) {
+	// Small cells have nearly the area of the rectangle bounded by parallels
+	g := Wgs84()
+	e := Wgs84Ellipsoid()
+	for _, code := range []string{"ezs42", "u4pruyd", "0", "zzzzz"} {
+		c, err := GeohashReverse(code)
+		if err != nil {
+			t.Fatalf("GeohashReverse(%q) error = %v", code, err)
+		}
+		r := c.Area(g)
+		south, west, north, east := c.Bounds()
+		want := e.RectangleArea(south, west, north, east)
+		thr := 1e-5
+		if code == "0" {
+			// A 45 degree cell, whose geodesic edges cut off a lot of the rectangle
+			thr = 0.1
+		}
+		if r.Num != 4 || math.Abs(r.Area-want) > thr*want {
+			t.Errorf("%q: Area = %+v; want area %v", code, r, want)
+		}
+		// The perimeter is nearly that of the rectangle
+		p := NewPolygonArea(g, true)
+		p.AddPoint(south, west)
+		p.AddPoint(south, east)
+		p.AddPoint(north, east)
+		p.AddPoint(north, west)
+		p.AddPoint(south, west)
+		if perim := p.Compute(false, true).Perimeter; !almost_equal(r.Perimeter, perim, 1e-6) {
+			t.Errorf("%q: perimeter = %v; want %v", code, r.Perimeter, perim)
+		}
+	}
+}
+
+func TestGridCellAreaByLatitude(t *testing.T) {
+	// Cells of the same code size shrink toward the poles
+	g := Wgs84()
+	var prev float64
+	for _, lat := range []float64{0.1, 30.1, 60.1, 89.9} {
+		s, _ := GARSForward(lat, 10, 2)
+		c, _ := GARSReverse(s)
+		area := c.Area(g).Area
+		if lat > 1 && !(area < prev) {
+			t.Errorf("area at %v = %v; want less than %v", lat, area, prev)
+		}
+		prev = area
+	}
+	// A 1 minute Georef cell at the equator is about 1.855 km by 1.843 km
+	c, _ := GeorefReverse("NGAA0000")
+	if area := c.Area(g).Area; !almost_equal(area, 1855.3*1843.1, 1e-3*area) {
+		t.Errorf("area of NGAA0000 = %v", area)
+	}
+}
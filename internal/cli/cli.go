@@ -0,0 +1,47 @@
+// Package cli holds the helpers shared by the commands in cmd: opening their input and
+// output and formatting results as GeographicLib's utilities do.
+package cli
+
+import (
+	"io"
+	"os"
+	"strings"
+
+	geographiclibgo "github.com/natemcintosh/geographiclib-go"
+)
+
+// FormatFixed formats x with prec digits after the decimal point, giving "nan" for NaN
+// as GeographicLib does
+func FormatFixed(x float64, prec int) string {
+	return geographiclibgo.DMSEncode(x, geographiclibgo.DMSDegree, prec, geographiclibgo.DMSNumber, 0)
+}
+
+// nop_write_closer is a Writer with a Close method which does nothing
+type nop_write_closer struct {
+	io.Writer
+}
+
+func (nop_write_closer) Close() error { return nil }
+
+// OpenInput returns the input chosen by the --input-string and --input-file options:
+// instring, with ";" separating the lines, if it is not empty; otherwise stdin if infile
+// is "-"; otherwise the file infile. The caller must close it.
+func OpenInput(stdin io.Reader, instring, infile string) (io.ReadCloser, error) {
+	if instring != "" {
+		return io.NopCloser(strings.NewReader(strings.ReplaceAll(instring, ";", "\n"))), nil
+	}
+	if infile == "-" {
+		return io.NopCloser(stdin), nil
+	}
+	return os.Open(infile)
+}
+
+// OpenOutput returns the output chosen by the --output-file option: stdout if outfile is
+// "-", otherwise the file outfile, which is created or truncated. The caller must close
+// it.
+func OpenOutput(stdout io.Writer, outfile string) (io.WriteCloser, error) {
+	if outfile == "-" {
+		return nop_write_closer{stdout}, nil
+	}
+	return os.Create(outfile)
+}
@@ -0,0 +1,89 @@
+package cli
+
+import (
+	"bytes"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFormatFixed(t *testing.T) {
+	testCases := []struct {
+		desc string
+		x    float64
+		prec int
+		want string
+	}{
+		{"rounded", 5853226.2557, 0, "5853226"},
+		{"decimals", 53.470215, 5, "53.47022"},
+		{"negative", -0.5, 3, "-0.500"},
+		{"nan", math.NaN(), 3, "nan"},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			if got := FormatFixed(tC.x, tC.prec); got != tC.want {
+				t.Errorf("FormatFixed(%v, %d) = %q; want %q", tC.x, tC.prec, got, tC.want)
+			}
+		})
+	}
+}
+
+func TestOpenInput(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "in.txt")
+	if err := os.WriteFile(path, []byte("from file\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	testCases := []struct {
+		desc     string
+		instring string
+		infile   string
+		want     string
+	}{
+		{"stdin", "", "-", "from stdin\n"},
+		{"string", "a;b", "-", "a\nb"},
+		{"file", "", path, "from file\n"},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			in, err := OpenInput(strings.NewReader("from stdin\n"), tC.instring, tC.infile)
+			if err != nil {
+				t.Fatalf("OpenInput() error = %v", err)
+			}
+			defer in.Close()
+			if got, err := io.ReadAll(in); err != nil || string(got) != tC.want {
+				t.Errorf("OpenInput() read %q, %v; want %q", got, err, tC.want)
+			}
+		})
+	}
+	if _, err := OpenInput(nil, "", filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Errorf("OpenInput() of a missing file succeeded; want error")
+	}
+}
+
+func TestOpenOutput(t *testing.T) {
+	var stdout bytes.Buffer
+	out, err := OpenOutput(&stdout, "-")
+	if err != nil {
+		t.Fatalf("OpenOutput(-) error = %v", err)
+	}
+	io.WriteString(out, "to stdout")
+	if err := out.Close(); err != nil || stdout.String() != "to stdout" {
+		t.Errorf("OpenOutput(-) wrote %q, Close() = %v", stdout.String(), err)
+	}
+
+	path := filepath.Join(t.TempDir(), "out.txt")
+	if out, err = OpenOutput(&stdout, path); err != nil {
+		t.Fatalf("OpenOutput(%q) error = %v", path, err)
+	}
+	io.WriteString(out, "to file")
+	out.Close()
+	if got, err := os.ReadFile(path); err != nil || string(got) != "to file" {
+		t.Errorf("OpenOutput(%q) wrote %q, %v", path, got, err)
+	}
+	if _, err := OpenOutput(nil, filepath.Join(t.TempDir(), "missing", "out.txt")); err == nil {
+		t.Errorf("OpenOutput() in a missing directory succeeded; want error")
+	}
+}
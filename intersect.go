@@ -0,0 +1,733 @@
+package geographiclibgo
+
+import (
+	"errors"
+	"math"
+	"sort"
+)
+
+// INTERSECT_CAPS are the capabilities a GeodesicLine must have to be passed to the
+// ...Lines() methods of Intersect
+const INTERSECT_CAPS uint64 = LATITUDE | LONGITUDE | AZIMUTH | REDUCEDLENGTH |
+	GEODESICSCALE | DISTANCE_IN
+
+// Maximum number of iterations for the basic intersection solver
+const _INTERSECT_NUMIT int = 100
+
+// Intersect finds the intersections of two geodesics, X and Y. An intersection is given
+// by the signed displacements, x along X and y along Y, from the starting points of the
+// geodesics to the intersection. The methods are ports of the Intersect class in
+// GeographicLib, described in C. F. F. Karney, Geodesic intersections, J. Surveying
+// Eng. 150(3), 04024005 (2024), https://doi.org/10.1061/JSUED2.SUENG-1483
+//
+//   - Closest() finds the intersection closest to a given point in the (x, y) plane
+//   - Next() finds the next intersection of two geodesics starting at the same point
+//   - Segment() finds the intersection of two geodesic segments
+//   - All() finds all the intersections within a given distance of a point
+//
+// If the geodesics are coincident, i.e. they lie on top of one another, the Coincidence
+// field of the result is +1 if the geodesics are parallel and -1 if they are
+// antiparallel. Otherwise it is 0.
+type Intersect struct {
+	g Geodesic
+	a float64
+	f float64
+	// The authalic radius
+	rR float64
+	// Half the circumference of the authalic sphere, used to normalize intersections
+	d     float64
+	eps   float64
+	tol   float64
+	delta float64
+	// Lengths describing the spacing of the intersections of geodesics
+	t1 float64
+	t2 float64
+	t3 float64
+	t4 float64
+	t5 float64
+	// The spacings of the starting points for the searches in Closest(), Next(), and
+	// All()
+	d1 float64
+	d2 float64
+	d3 float64
+}
+
+// xpoint is a point in the (x, y) plane of displacements along geodesics X and Y,
+// together with the coincidence indicator c
+type xpoint struct {
+	x float64
+	y float64
+	c int
+}
+
+func (p xpoint) add(q xpoint) xpoint {
+	p.x += q.x
+	p.y += q.y
+	if q.c != 0 {
+		p.c = q.c
+	}
+	return p
+}
+
+// dist returns the L1 distance of p from the origin
+func (p xpoint) dist() float64 {
+	return math.Abs(p.x) + math.Abs(p.y)
+}
+
+// dist_to returns the L1 distance between p and q
+func (p xpoint) dist_to(q xpoint) float64 {
+	return math.Abs(p.x-q.x) + math.Abs(p.y-q.y)
+}
+
+// eq returns true if p and q are within delta of one another in both x and y
+func (p xpoint) eq(q xpoint, delta float64) bool {
+	return math.Abs(p.x-q.x) <= delta && math.Abs(p.y-q.y) <= delta
+}
+
+// NewIntersect creates an Intersect for the ellipsoid of g. An error is returned if the
+// ellipsoid is too eccentric for the intersection algorithms to be reliable; this
+// happens when roughly f < -1/3 or f > 2/5.
+func NewIntersect(g Geodesic) (Intersect, error) {
+	eps := get_epsilon()
+	rR := math.Sqrt(g.c2)
+	d := rR * math.Pi
+	in := Intersect{
+		g:     g,
+		a:     g.a,
+		f:     g.f,
+		rR:    rR,
+		d:     d,
+		eps:   3.0 * eps,
+		tol:   d * math.Pow(eps, 3.0/4.0),
+		delta: d * math.Pow(eps, 1.0/5.0),
+	}
+
+	in.t1 = in.a * (1.0 - in.f) * math.Pi
+	in.t4 = in.t1
+	in.t2 = 2.0 * in._distpolar(90.0)
+	_, t5, _, _, _, _, _, _, _, _ := in.g._gen_inverse(0.0, 0.0, 90.0, 0.0, DISTANCE)
+	in.t5 = 2.0 * t5
+	if in.f > 0.0 {
+		in.t3 = in._distoblique()
+		in.t4 = in.t1
+	} else {
+		in.t3 = in.t5
+		in.t4 = in._polarb()
+		in.t1, in.t2 = in.t2, in.t1
+	}
+	in.d1 = in.t2 / 2.0
+	in.d2 = 2.0 * in.t3 / 3.0
+	in.d3 = in.t4 - in.delta
+	if !(in.d1 < in.d3 && in.d2 < in.d3 && in.d2 < 2.0*in.t1) {
+		return Intersect{}, errors.New("ellipsoid too eccentric for Intersect")
+	}
+	return in, nil
+}
+
+// IntersectResult is an intersection of geodesics X and Y
+type IntersectResult struct {
+	DistanceXM  float64 // Displacement along geodesic X to the intersection [meters]
+	DistanceYM  float64 // Displacement along geodesic Y to the intersection [meters]
+	Coincidence int     // +1 or -1 if the geodesics are parallel or antiparallel, else 0
+}
+
+// IntersectSegmentResult is an intersection of two geodesic segments. SegMode is 0 if
+// the intersection lies within both segments. Otherwise it is 3*kx + ky, where kx is -1
+// if the intersection lies before the start of segment X, +1 if it lies beyond its end,
+// and 0 otherwise; ky is defined similarly for segment Y.
+type IntersectSegmentResult struct {
+	DistanceXM  float64 // Displacement along segment X to the intersection [meters]
+	DistanceYM  float64 // Displacement along segment Y to the intersection [meters]
+	Coincidence int     // +1 or -1 if the segments are parallel or antiparallel, else 0
+	SegMode     int     // 0 if the intersection lies within both segments
+}
+
+func (p xpoint) result() IntersectResult {
+	return IntersectResult{DistanceXM: p.x, DistanceYM: p.y, Coincidence: p.c}
+}
+
+// Closest finds the intersection of geodesics X and Y which is closest to their starting
+// points, measured by the L1 distance |x| + |y|.
+//   - latX_deg, lonX_deg, aziX_deg - starting point and azimuth of geodesic X [degrees]
+//   - latY_deg, lonY_deg, aziY_deg - starting point and azimuth of geodesic Y [degrees]
+func (in *Intersect) Closest(
+	latX_deg, lonX_deg, aziX_deg, latY_deg, lonY_deg, aziY_deg float64,
+) IntersectResult {
+	lineX := in.g.LineWithCapabilities(latX_deg, lonX_deg, aziX_deg, INTERSECT_CAPS)
+	lineY := in.g.LineWithCapabilities(latY_deg, lonY_deg, aziY_deg, INTERSECT_CAPS)
+	return in.ClosestLines(lineX, lineY, 0.0, 0.0)
+}
+
+// ClosestLines finds the intersection of lineX and lineY which is closest to the point
+// (x0_m, y0_m) [meters], measured by the L1 distance |x - x0_m| + |y - y0_m|. The lines
+// must have been created with at least INTERSECT_CAPS.
+func (in *Intersect) ClosestLines(lineX, lineY GeodesicLine, x0_m, y0_m float64) IntersectResult {
+	return in._ClosestInt(lineX, lineY, xpoint{x: x0_m, y: y0_m}).result()
+}
+
+// Next finds the next intersection of geodesics X and Y which both start at the point
+// latX_deg, lonX_deg, i.e. the closest intersection excluding the starting point.
+//   - latX_deg, lonX_deg - the common starting point [degrees]
+//   - aziX_deg, aziY_deg - the azimuths of geodesics X and Y [degrees]
+func (in *Intersect) Next(latX_deg, lonX_deg, aziX_deg, aziY_deg float64) IntersectResult {
+	lineX := in.g.LineWithCapabilities(latX_deg, lonX_deg, aziX_deg, INTERSECT_CAPS)
+	lineY := in.g.LineWithCapabilities(latX_deg, lonX_deg, aziY_deg, INTERSECT_CAPS)
+	return in.NextLines(lineX, lineY)
+}
+
+// NextLines is the same as Next, but for lineX and lineY, which must start at the same
+// point and have been created with at least INTERSECT_CAPS
+func (in *Intersect) NextLines(lineX, lineY GeodesicLine) IntersectResult {
+	return in._NextInt(lineX, lineY).result()
+}
+
+// Segment finds the intersection of the geodesic segment X, from (latX1_deg, lonX1_deg)
+// to (latX2_deg, lonX2_deg), and the geodesic segment Y, from (latY1_deg, lonY1_deg) to
+// (latY2_deg, lonY2_deg). If the segments intersect, that intersection is returned and
+// SegMode is 0. Otherwise the intersection closest to the segments is returned.
+func (in *Intersect) Segment(
+	latX1_deg, lonX1_deg, latX2_deg, lonX2_deg,
+	latY1_deg, lonY1_deg, latY2_deg, lonY2_deg float64,
+) IntersectSegmentResult {
+	lineX := in.g.InverseLineWithCapabilities(
+		latX1_deg, lonX1_deg, latX2_deg, lonX2_deg, INTERSECT_CAPS,
+	)
+	lineY := in.g.InverseLineWithCapabilities(
+		latY1_deg, lonY1_deg, latY2_deg, lonY2_deg, INTERSECT_CAPS,
+	)
+	return in.SegmentLines(lineX, lineY)
+}
+
+// SegmentLines is the same as Segment, but the segments run from the start of lineX and
+// lineY to their point 3, e.g. as set by InverseLineWithCapabilities() or SetDistance().
+// The lines must have been created with at least INTERSECT_CAPS.
+func (in *Intersect) SegmentLines(lineX, lineY GeodesicLine) IntersectSegmentResult {
+	p, segmode := in._SegmentInt(lineX, lineY)
+	return IntersectSegmentResult{
+		DistanceXM:  p.x,
+		DistanceYM:  p.y,
+		Coincidence: p.c,
+		SegMode:     segmode,
+	}
+}
+
+// All finds all the intersections of geodesics X and Y whose L1 distance from their
+// starting points, |x| + |y|, is no more than maxdist_m [meters]. The intersections are
+// sorted by this distance.
+//   - latX_deg, lonX_deg, aziX_deg - starting point and azimuth of geodesic X [degrees]
+//   - latY_deg, lonY_deg, aziY_deg - starting point and azimuth of geodesic Y [degrees]
+func (in *Intersect) All(
+	latX_deg, lonX_deg, aziX_deg, latY_deg, lonY_deg, aziY_deg float64,
+	maxdist_m float64,
+) []IntersectResult {
+	lineX := in.g.LineWithCapabilities(latX_deg, lonX_deg, aziX_deg, INTERSECT_CAPS)
+	lineY := in.g.LineWithCapabilities(latY_deg, lonY_deg, aziY_deg, INTERSECT_CAPS)
+	return in.AllLines(lineX, lineY, maxdist_m, 0.0, 0.0)
+}
+
+// AllLines finds all the intersections of lineX and lineY whose L1 distance from the
+// point (x0_m, y0_m) [meters] is no more than maxdist_m [meters], sorted by this
+// distance. The lines must have been created with at least INTERSECT_CAPS.
+func (in *Intersect) AllLines(
+	lineX, lineY GeodesicLine,
+	maxdist_m, x0_m, y0_m float64,
+) []IntersectResult {
+	p0 := xpoint{x: x0_m, y: y0_m}
+	v := in._AllInt0(lineX, lineY, math.Max(0.0, maxdist_m), p0)
+	sort.Slice(v, func(i, j int) bool {
+		di := v[i].dist_to(p0)
+		dj := v[j].dist_to(p0)
+		if di != dj {
+			return di < dj
+		}
+		if v[i].x != v[j].x {
+			return v[i].x < v[j].x
+		}
+		return v[i].y < v[j].y
+	})
+	results := make([]IntersectResult, len(v))
+	for i, p := range v {
+		results[i] = p.result()
+	}
+	return results
+}
+
+// _Spherical finds the correction to p, an estimate of the intersection, by treating the
+// triangle formed by the two points on the geodesics and the intersection as spherical
+func (in *Intersect) _Spherical(lineX, lineY GeodesicLine, p xpoint) xpoint {
+	_, latX, lonX, aziX, _, _, _, _, _ := lineX._gen_position(
+		false, p.x, LATITUDE|LONGITUDE|AZIMUTH,
+	)
+	_, latY, lonY, aziY, _, _, _, _, _ := lineY._gen_position(
+		false, p.y, LATITUDE|LONGITUDE|AZIMUTH,
+	)
+	_, z, aziXa, aziYa, _, _, _, _ := in.g._gen_inverse_azi(
+		latX, lonX, latY, lonY, DISTANCE|AZIMUTH,
+	)
+	sinz := math.Sin(z / in.rR)
+	cosz := math.Cos(z / in.rR)
+
+	// X = interior angle at X, Y = exterior angle at Y
+	X, dX := ang_diff(aziX, aziXa)
+	Y, dY := ang_diff(aziY, aziYa)
+	XY, dXY := ang_diff(X, Y)
+	// Flip the triangle if necessary, so that the angles are positive
+	s := math.Copysign(1.0, XY+(dXY+dY-dX))
+	sinX, cosX := sincosde(s*X, s*dX)
+	sinY, cosY := sincosde(s*Y, s*dY)
+
+	var q xpoint
+	if z <= in.eps*in.rR {
+		// Already at the intersection; determine whether the lines are parallel or
+		// antiparallel
+		if math.Abs(sinX-sinY) <= in.eps && math.Abs(cosX-cosY) <= in.eps {
+			q.c = 1
+		} else if math.Abs(sinX+sinY) <= in.eps && math.Abs(cosX+cosY) <= in.eps {
+			q.c = -1
+		}
+	} else if math.Abs(sinX) <= in.eps && math.Abs(sinY) <= in.eps {
+		// Coincident geodesics; place the intersection at the midpoint
+		if cosX*cosY > 0 {
+			q.c = 1
+		} else {
+			q.c = -1
+		}
+		q.x = cosX * z / 2.0
+		q.y = -cosY * z / 2.0
+	} else {
+		// The general case. sinz < 0, i.e. z > pi*R, needs to be handled correctly, or
+		// _Basic can fail to converge.
+		q.x = in.rR * math.Atan2(sinY*sinz, sinY*cosX*cosz-cosY*sinX)
+		q.y = in.rR * math.Atan2(sinX*sinz, -sinX*cosY*cosz+cosX*sinY)
+	}
+	return q
+}
+
+// _Basic finds an intersection by iterating _Spherical, starting at p0
+func (in *Intersect) _Basic(lineX, lineY GeodesicLine, p0 xpoint) xpoint {
+	q := p0
+	for n := 0; n < _INTERSECT_NUMIT; n++ {
+		dq := in._Spherical(lineX, lineY, q)
+		q = q.add(dq)
+		// Also break if dq is NaN
+		if q.c != 0 || !(dq.dist() > in.tol) {
+			break
+		}
+	}
+	return q
+}
+
+// _ClosestInt finds the intersection closest to p0 by running _Basic from p0 and from
+// the four points a distance d1 from p0 along the axes
+func (in *Intersect) _ClosestInt(lineX, lineY GeodesicLine, p0 xpoint) xpoint {
+	ix := [5]float64{0, 1, -1, 0, 0}
+	iy := [5]float64{0, 0, 0, 1, -1}
+	var skip [5]bool
+	// The best intersection so far
+	q := xpoint{x: math.NaN(), y: math.NaN()}
+	for n := range ix {
+		if skip[n] {
+			continue
+		}
+		qx := in._Basic(lineX, lineY, p0.add(xpoint{x: ix[n] * in.d1, y: iy[n] * in.d1}))
+		qx = fixcoincident(p0, qx, qx.c)
+		// Don't redo an intersection
+		if q.eq(qx, in.delta) {
+			continue
+		}
+		if qx.dist_to(p0) < in.t1 {
+			q = qx
+			break
+		}
+		if n == 0 || qx.dist_to(p0) < q.dist_to(p0) {
+			q = qx
+		}
+		for m := n + 1; m < len(ix); m++ {
+			start := p0.add(xpoint{x: ix[m] * in.d1, y: iy[m] * in.d1})
+			skip[m] = skip[m] || qx.dist_to(start) < 2.0*in.t1-in.d1-in.delta
+		}
+	}
+	return q
+}
+
+// _NextInt finds the intersection closest to, but not at, the origin, for two lines
+// which start at the same point
+func (in *Intersect) _NextInt(lineX, lineY GeodesicLine) xpoint {
+	ix := [8]float64{-1, -1, 1, 1, -2, 0, 2, 0}
+	iy := [8]float64{-1, 1, -1, 1, 0, 2, 0, -2}
+	var skip [8]bool
+	// Used to exclude the origin
+	z := xpoint{}
+	// The best intersection so far
+	q := xpoint{x: math.Inf(1)}
+	for n := range ix {
+		if skip[n] {
+			continue
+		}
+		qx := in._Basic(lineX, lineY, xpoint{x: ix[n] * in.d2, y: iy[n] * in.d2})
+		qx = fixcoincident(z, qx, qx.c)
+		zerop := z.eq(qx, in.delta)
+		if qx.c == 0 && zerop {
+			continue
+		}
+		if qx.c != 0 && zerop {
+			// The lines are coincident; the next intersections are at the conjugate
+			// points
+			for sgn := -1.0; sgn <= 1.0; sgn += 2.0 {
+				s := in._ConjugateDist(lineX, sgn*in.d, false, 0.0, 1.0, 1.0)
+				qa := xpoint{x: s, y: float64(qx.c) * s, c: qx.c}
+				if qa.dist() < q.dist() {
+					q = qa
+				}
+			}
+		} else if qx.dist() < q.dist() {
+			q = qx
+		}
+		for sgn := -1.0; sgn <= 1.0; sgn += 2.0 {
+			qy := qx
+			if qx.c != 0 {
+				qy = qx.add(xpoint{x: sgn * in.d2, y: float64(qx.c) * sgn * in.d2})
+			}
+			for m := n + 1; m < len(ix); m++ {
+				start := xpoint{x: ix[m] * in.d2, y: iy[m] * in.d2}
+				skip[m] = skip[m] || qy.dist_to(start) < 2.0*in.t1-in.d2-in.delta
+			}
+		}
+	}
+	return q
+}
+
+// _SegmentInt finds the intersection of the segments from the starts of lineX and lineY
+// to their point 3, along with the segment mode
+func (in *Intersect) _SegmentInt(lineX, lineY GeodesicLine) (xpoint, int) {
+	sx := lineX.Distance()
+	sy := lineY.Distance()
+	// p0 is the center of the [0,sx] x [0,sy] rectangle
+	p0 := xpoint{x: sx / 2.0, y: sy / 2.0}
+	q := in._ClosestInt(lineX, lineY, p0)
+	q = fixsegment(sx, sy, q)
+	segmode := segmentmode(sx, sy, q)
+	// If the segments are long, the search from the center may have missed an
+	// intersection within the rectangle; so also search from its corners
+	if segmode != 0 && sx+sy > in.t1 {
+		ix := [4]float64{0, 1, 0, 1}
+		iy := [4]float64{0, 0, 1, 1}
+		for n := range ix {
+			qx := in._Basic(lineX, lineY, xpoint{x: ix[n] * sx, y: iy[n] * sy})
+			qx = fixcoincident(p0, qx, qx.c)
+			qx = fixsegment(sx, sy, qx)
+			if segmentmode(sx, sy, qx) == 0 {
+				return qx, 0
+			}
+		}
+	}
+	return q, segmode
+}
+
+// _AllInt0 finds all the intersections within maxdist of p0, in no particular order
+func (in *Intersect) _AllInt0(lineX, lineY GeodesicLine, maxdist float64, p0 xpoint) []xpoint {
+	maxdistx := maxdist + in.delta
+	// Process an m x m set of tiles, adding the center tile if m is even
+	m := int(math.Ceil(maxdistx / in.d3))
+	m2 := m*m + (m-1)%2
+	// The range of i and j is [-n, n] in steps of 2
+	n := m - 1
+	d3 := maxdistx / float64(m)
+	start := make([]xpoint, 0, m2)
+	skip := make([]bool, m2)
+	start = append(start, p0)
+	for i := -n; i <= n; i += 2 {
+		for j := -n; j <= n; j += 2 {
+			if !(i == 0 && j == 0) {
+				start = append(start, p0.add(xpoint{
+					x: d3 * float64(i+j) / 2.0,
+					y: d3 * float64(i-j) / 2.0,
+				}))
+			}
+		}
+	}
+
+	// The intersections found, and the closest points on the lines of coincident
+	// intersections
+	var r, c []xpoint
+	find := func(set []xpoint, p xpoint) bool {
+		for _, q := range set {
+			if q.eq(p, in.delta) {
+				return true
+			}
+		}
+		return false
+	}
+	insert := func(set []xpoint, p xpoint) []xpoint {
+		if find(set, p) {
+			return set
+		}
+		return append(set, p)
+	}
+
+	c0 := 0
+	var added []xpoint
+	for k := range start {
+		if skip[k] {
+			continue
+		}
+		q := in._Basic(lineX, lineY, start[k])
+		// Skip an intersection already found, or one on a line of coincident
+		// intersections already processed
+		if find(r, q) || (c0 != 0 && find(c, fixcoincident(p0, q, q.c))) {
+			continue
+		}
+		added = added[:0]
+		if q.c != 0 {
+			c0 = q.c
+			q = fixcoincident(p0, q, q.c)
+			c = insert(c, q)
+			// Eliminate the existing intersections on this line
+			kept := r[:0]
+			for _, qp := range r {
+				if !fixcoincident(p0, qp, c0).eq(q, in.delta) {
+					kept = append(kept, qp)
+				}
+			}
+			r = kept
+			s0 := q.x
+			_, _, _, _, _, m12, M12, M21, _ := lineX._gen_position(
+				false, s0, REDUCEDLENGTH|GEODESICSCALE,
+			)
+			// Add the line of conjugate points
+			for sgn := -1.0; sgn <= 1.0; sgn += 2.0 {
+				sa := 0.0
+				for {
+					sa = in._ConjugateDist(lineX, s0+sa+sgn*in.d, false, m12, M12, M21) - s0
+					qc := q.add(xpoint{x: sa, y: float64(c0) * sa})
+					added = append(added, qc)
+					r = insert(r, qc)
+					if !(qc.dist_to(p0) <= maxdistx) {
+						break
+					}
+				}
+			}
+		}
+		added = append(added, q)
+		r = insert(r, q)
+		for _, qp := range added {
+			for l := k + 1; l < m2; l++ {
+				skip[l] = skip[l] || qp.dist_to(start[l]) < in.d1
+			}
+		}
+	}
+
+	// Trim the intersections to maxdist
+	v := make([]xpoint, 0, len(r))
+	for _, q := range r {
+		if q.dist_to(p0) <= maxdist {
+			v = append(v, q)
+		}
+	}
+	return v
+}
+
+// _ConjugateDist finds the distance s3 along line from point 1 to point 3, near the given
+// s3, such that point 3 is conjugate to point 2 (m23 = 0), or semi-conjugate to point 2
+// (M23 = 0) if semi is true. Point 2 is specified by m12, M12, and M21, its reduced
+// length and geodesic scales relative to point 1; use 0, 1, 1 for point 2 = point 1.
+func (in *Intersect) _ConjugateDist(
+	line GeodesicLine,
+	s3 float64,
+	semi bool,
+	m12, M12, M21 float64,
+) float64 {
+	// semi = false: solve for m23 = 0 using dm23/ds3 = M32
+	// semi = true : solve for M23 = 0 using dM23/ds3 = - (1 - M23*M32)/m23
+	s := s3
+	for i := 0; i < _INTERSECT_NUMIT; i++ {
+		_, _, _, _, _, m13, M13, M31, _ := line._gen_position(
+			false, s, REDUCEDLENGTH|GEODESICSCALE,
+		)
+		// The addition rules for reduced length and geodesic scale
+		m23 := m13*M12 - m12*M13
+		M23 := M13 * M21
+		if m12 != 0 {
+			M23 += (1.0 - M12*M21) * m13 / m12
+		}
+		M32 := M31 * M12
+		if m13 != 0 {
+			M32 += (1.0 - M13*M31) * m12 / m13
+		}
+		var ds float64
+		if semi {
+			ds = m23 * M23 / (1.0 - M23*M32)
+		} else {
+			ds = -m23 / M32
+		}
+		s += ds
+		if !(math.Abs(ds) > in.tol) {
+			break
+		}
+	}
+	return s
+}
+
+// _distpolar finds the distance from latitude lat1 along a meridian, heading north, to the
+// point which is semi-conjugate to the start
+func (in *Intersect) _distpolar(lat1 float64) float64 {
+	line := in.g.LineWithCapabilities(lat1, 0.0, 0.0, REDUCEDLENGTH|GEODESICSCALE|DISTANCE_IN)
+	return in._ConjugateDist(line, (1.0+in.f/2.0)*in.a*math.Pi/2.0, true, 0.0, 1.0, 1.0)
+}
+
+// _polarb finds twice the largest value of _distpolar, for prolate ellipsoids, by
+// fitting quadratics to successive triples of latitudes
+func (in *Intersect) _polarb() float64 {
+	if in.f == 0.0 {
+		return in.d
+	}
+	lat0, lat1, lat2 := 63.0, 65.0, 64.0
+	s0 := in._distpolar(lat0)
+	s1 := in._distpolar(lat1)
+	s2 := in._distpolar(lat2)
+	sx := s2
+	// Solve for ds(lat)/dlat = 0 with a quadratic fit
+	for i := 0; i < 10; i++ {
+		den := (lat1-lat0)*s2 + (lat0-lat2)*s1 + (lat2-lat1)*s0
+		// Also break if den is NaN
+		if !(den < 0 || den > 0) {
+			break
+		}
+		latn := ((lat1-lat0)*(lat1+lat0)*s2 + (lat0-lat2)*(lat0+lat2)*s1 +
+			(lat2-lat1)*(lat2+lat1)*s0) / (2.0 * den)
+		lat0, s0 = lat1, s1
+		lat1, s1 = lat2, s2
+		lat2 = latn
+		s2 = in._distpolar(lat2)
+		if (in.f < 0 && s2 < sx) || (in.f > 0 && s2 > sx) {
+			sx = s2
+		}
+	}
+	return 2.0 * sx
+}
+
+// _conjdist finds the conjugate distance s for a geodesic starting on the equator with
+// azimuth azi, and ds, the amount by which the length of the loop formed where the
+// geodesic intersects itself exceeds 2*s
+func (in *Intersect) _conjdist(azi float64) (float64, float64) {
+	line := in.g.LineWithCapabilities(0.0, 0.0, azi, INTERSECT_CAPS)
+	s := in._ConjugateDist(line, in.d, false, 0.0, 1.0, 1.0)
+	p := in._Basic(line, line, xpoint{x: s / 2.0, y: -3.0 * s / 2.0})
+	return s, p.dist() - 2.0*s
+}
+
+// _distoblique finds the conjugate distance of the oblique geodesic whose self
+// intersection loop is twice this distance long, for oblate ellipsoids, using the secant
+// method on the azimuth
+func (in *Intersect) _distoblique() float64 {
+	if in.f == 0.0 {
+		return in.d
+	}
+	azi0, azi1 := 46.0, 44.0
+	_, ds0 := in._conjdist(azi0)
+	s, ds1 := in._conjdist(azi1)
+	sx := s
+	dsx := math.Abs(ds1)
+	for i := 0; i < 10 && ds1 != ds0; i++ {
+		azin := (azi0*ds1 - azi1*ds0) / (ds1 - ds0)
+		azi0, ds0 = azi1, ds1
+		azi1 = azin
+		s, ds1 = in._conjdist(azi1)
+		if math.Abs(ds1) < dsx {
+			sx = s
+			dsx = math.Abs(ds1)
+			if ds1 == 0 {
+				break
+			}
+		}
+	}
+	return sx
+}
+
+// fixcoincident moves p, an intersection on a line of coincident intersections with
+// indicator c, along that line to the point closest to p0
+func fixcoincident(p0, p xpoint, c int) xpoint {
+	if c == 0 {
+		return p
+	}
+	cf := float64(c)
+	s := ((p0.x + cf*p0.y) - (p.x + cf*p.y)) / 2.0
+	return p.add(xpoint{x: s, y: cf * s})
+}
+
+// fixsegment moves p, an intersection of coincident segments of lengths sx and sy, along
+// the line of coincident intersections so that it lies within both segments, if
+// possible. Otherwise it is placed as close as possible to the segments.
+func fixsegment(sx, sy float64, p xpoint) xpoint {
+	if p.c == 0 {
+		return p
+	}
+	c := float64(p.c)
+	// The points where the line of intersections crosses x = 0, x = sx, y = 0, and
+	// y = sy, and the displacements needed to get there
+	pya := p.y - c*p.x
+	sa := -p.x
+	pyb := p.y - c*(p.x-sx)
+	sb := sx - p.x
+	pxc := p.x - c*p.y
+	sc := c * -p.y
+	pxd := p.x - c*(p.y-sy)
+	sd := c * (sy - p.y)
+	ga := 0 <= pya && pya <= sy
+	gb := 0 <= pyb && pyb <= sy
+	gc := 0 <= pxc && pxc <= sx
+	gd := 0 <= pxd && pxd <= sx
+
+	var s float64
+	// Test opposite sides of the rectangle first
+	switch {
+	case ga && gb:
+		s = (sa + sb) / 2.0
+	case gc && gd:
+		s = (sc + sd) / 2.0
+	case ga && gc:
+		s = (sa + sc) / 2.0
+	case ga && gd:
+		s = (sa + sd) / 2.0
+	case gb && gc:
+		s = (sb + sc) / 2.0
+	case gb && gd:
+		s = (sb + sd) / 2.0
+	default:
+		// The intersection is not within the segments; move it to the nearest corner
+		if p.c > 0 {
+			// Consider the corners [0, sy] and [sx, 0]
+			if math.Abs((p.x-p.y)+sy) < math.Abs((p.x-p.y)-sx) {
+				s = (sy - (p.x + p.y)) / 2.0
+			} else {
+				s = (sx - (p.x + p.y)) / 2.0
+			}
+		} else {
+			// Consider the corners [0, 0] and [sx, sy]
+			if math.Abs(p.x+p.y) < math.Abs((p.x+p.y)-(sx+sy)) {
+				s = -(p.x - p.y) / 2.0
+			} else {
+				s = ((sx - sy) - (p.x - p.y)) / 2.0
+			}
+		}
+	}
+	return p.add(xpoint{x: s, y: c * s})
+}
+
+// segmentmode returns 3*kx + ky, where kx is -1, 0, or 1 as p.x lies before, within, or
+// beyond [0, sx], and ky is defined similarly for p.y and [0, sy]
+func segmentmode(sx, sy float64, p xpoint) int {
+	kx := 0
+	if p.x < 0 {
+		kx = -1
+	} else if p.x > sx {
+		kx = 1
+	}
+	ky := 0
+	if p.y < 0 {
+		ky = -1
+	} else if p.y > sy {
+		ky = 1
+	}
+	return 3*kx + ky
+}
@@ -0,0 +1,222 @@
+package geographiclibgo
+
+import (
+	"math"
+	"testing"
+)
+
+// check_intersection verifies that the points a distance x along lineX and y along lineY
+// coincide
+func check_intersection(t *testing.T, name string, lineX, lineY GeodesicLine, x, y float64) {
+	t.Helper()
+	_, latX, lonX, _, _, _, _, _, _ := lineX._gen_position(false, x, LATITUDE|LONGITUDE)
+	_, latY, lonY, _, _, _, _, _, _ := lineY._gen_position(false, y, LATITUDE|LONGITUDE)
+	g := Wgs84()
+	s12 := g.InverseCalcDistance(latX, lonX, latY, lonY)
+	if !(s12 < 1e-6) {
+		t.Errorf("%s: points at x = %v and y = %v are %v m apart; want 0", name, x, y, s12)
+	}
+}
+
+func wgs84_intersect(t *testing.T) Intersect {
+	in, err := NewIntersect(Wgs84())
+	if err != nil {
+		t.Fatalf("NewIntersect(Wgs84()) error = %v; want nil", err)
+	}
+	return in
+}
+
+func TestNewIntersectTooEccentric(t *testing.T) {
+	for _, f := range []float64{-0.5, 0.5} {
+		if _, err := NewIntersect(NewGeodesic(6.4e6, f)); err == nil {
+			t.Errorf("NewIntersect with f = %v error = nil; want an error", f)
+		}
+	}
+	for _, f := range []float64{-0.25, -1.0 / 150, 0, 1.0 / 150, 0.25} {
+		if _, err := NewIntersect(NewGeodesic(6.4e6, f)); err != nil {
+			t.Errorf("NewIntersect with f = %v error = %v; want nil", f, err)
+		}
+	}
+}
+
+func TestIntersectClosest(t *testing.T) {
+	in := wgs84_intersect(t)
+
+	// The equator and a meridian heading south from 10N, 20E meet at 0N, 20E
+	got := in.Closest(0, 0, 90, 10, 20, 180)
+	wantX := WGS84_A * 20 * DEG2RAD
+	if !almost_equal(got.DistanceXM, wantX, 1e-6) {
+		t.Errorf("x = %v; want %v", got.DistanceXM, wantX)
+	}
+	g := Wgs84()
+	wantY := g.InverseCalcDistance(10, 20, 0, 20)
+	if !almost_equal(got.DistanceYM, wantY, 1e-6) {
+		t.Errorf("y = %v; want %v", got.DistanceYM, wantY)
+	}
+	if got.Coincidence != 0 {
+		t.Errorf("c = %v; want 0", got.Coincidence)
+	}
+
+	// A general case
+	lineX := g.LineWithCapabilities(30, 10, 40, INTERSECT_CAPS)
+	lineY := g.LineWithCapabilities(35, -5, 110, INTERSECT_CAPS)
+	got = in.ClosestLines(lineX, lineY, 0, 0)
+	check_intersection(t, "general", lineX, lineY, got.DistanceXM, got.DistanceYM)
+	if !(math.Abs(got.DistanceXM)+math.Abs(got.DistanceYM) < 5e6) {
+		t.Errorf("general: x = %v, y = %v; want a nearby intersection",
+			got.DistanceXM, got.DistanceYM)
+	}
+
+	// Starting from near the antipodal intersection finds that one instead
+	far := in.ClosestLines(lineX, lineY, got.DistanceXM+2e7, got.DistanceYM+2e7)
+	check_intersection(t, "antipodal", lineX, lineY, far.DistanceXM, far.DistanceYM)
+	if !(far.DistanceXM > 1.5e7 && far.DistanceYM > 1.5e7) {
+		t.Errorf("antipodal: x = %v, y = %v; want both about 2e7",
+			far.DistanceXM, far.DistanceYM)
+	}
+}
+
+func TestIntersectClosestCoincident(t *testing.T) {
+	in := wgs84_intersect(t)
+
+	// Two lines along the equator are parallel and coincident. The intersection is
+	// placed midway between the starting points.
+	got := in.Closest(0, 0, 90, 0, 10, 90)
+	want := WGS84_A * 5 * DEG2RAD
+	if got.Coincidence != 1 {
+		t.Errorf("c = %v; want 1", got.Coincidence)
+	}
+	if !almost_equal(got.DistanceXM, want, 1e-6) || !almost_equal(got.DistanceYM, -want, 1e-6) {
+		t.Errorf("x, y = %v, %v; want %v, %v", got.DistanceXM, got.DistanceYM, want, -want)
+	}
+
+	// Heading in opposite directions they are antiparallel
+	got = in.Closest(0, 0, 90, 0, 10, -90)
+	if got.Coincidence != -1 {
+		t.Errorf("c = %v; want -1", got.Coincidence)
+	}
+	if !almost_equal(got.DistanceXM, want, 1e-6) || !almost_equal(got.DistanceYM, want, 1e-6) {
+		t.Errorf("x, y = %v, %v; want %v, %v", got.DistanceXM, got.DistanceYM, want, want)
+	}
+}
+
+func TestIntersectNext(t *testing.T) {
+	// On a sphere, great circles starting at the same point next meet at the antipode,
+	// or at the start after a full circuit of one of them; both are 2*pi*R away in the
+	// L1 distance
+	R := 6.4e6
+	in, err := NewIntersect(NewGeodesic(R, 0))
+	if err != nil {
+		t.Fatalf("NewIntersect error = %v; want nil", err)
+	}
+	got := in.Next(20, 30, 0, 45)
+	d := math.Abs(got.DistanceXM) + math.Abs(got.DistanceYM)
+	if !almost_equal(d, 2*math.Pi*R, 1e-6) {
+		t.Errorf("|x| + |y| = %v; want %v", d, 2*math.Pi*R)
+	}
+
+	// On the ellipsoid the next intersection is not at the start
+	w := wgs84_intersect(t)
+	g := Wgs84()
+	lineX := g.LineWithCapabilities(20, 30, 10, INTERSECT_CAPS)
+	lineY := g.LineWithCapabilities(20, 30, 70, INTERSECT_CAPS)
+	next := w.NextLines(lineX, lineY)
+	check_intersection(t, "next", lineX, lineY, next.DistanceXM, next.DistanceYM)
+	if !(math.Abs(next.DistanceXM)+math.Abs(next.DistanceYM) > 1e7) {
+		t.Errorf("x, y = %v, %v; want an intersection away from the start",
+			next.DistanceXM, next.DistanceYM)
+	}
+
+	// Coincident lines next meet at the conjugate point
+	next = w.Next(0, 0, 90, 90)
+	x := math.Abs(next.DistanceXM)
+	if next.Coincidence != 1 || !(x > 1.9e7 && x < 2.1e7) || next.DistanceYM != next.DistanceXM {
+		t.Errorf("coincident = %+v; want x = y near 2e7 and c = 1", next)
+	}
+}
+
+func TestIntersectSegment(t *testing.T) {
+	in := wgs84_intersect(t)
+	g := Wgs84()
+
+	// Two diagonals of a square centered on 0N, 0E cross at the center
+	got := in.Segment(-1, -1, 1, 1, 1, -1, -1, 1)
+	if got.SegMode != 0 {
+		t.Errorf("segmode = %v; want 0", got.SegMode)
+	}
+	half := g.InverseCalcDistance(-1, -1, 0, 0)
+	if !almost_equal(got.DistanceXM, half, 1e-6) || !almost_equal(got.DistanceYM, half, 1e-6) {
+		t.Errorf("x, y = %v, %v; want %v, %v", got.DistanceXM, got.DistanceYM, half, half)
+	}
+
+	// The segments do not reach one another; the intersection lies beyond the end of X
+	got = in.Segment(0, 0, 0, 1, 5, 5, -5, 5)
+	if got.SegMode != 3 {
+		t.Errorf("segmode = %v; want 3", got.SegMode)
+	}
+
+	// Overlapping segments along the equator; the intersection is placed within both
+	got = in.Segment(0, 0, 0, 10, 0, 5, 0, 20)
+	sx := g.InverseCalcDistance(0, 0, 0, 10)
+	if got.SegMode != 0 || got.Coincidence != 1 {
+		t.Errorf("segmode, c = %v, %v; want 0, 1", got.SegMode, got.Coincidence)
+	}
+	if !(got.DistanceXM >= sx/2 && got.DistanceXM <= sx && got.DistanceYM >= 0) {
+		t.Errorf("x, y = %v, %v; want a point within both segments",
+			got.DistanceXM, got.DistanceYM)
+	}
+}
+
+func TestIntersectAll(t *testing.T) {
+	// On a sphere, two great circles through a point meet every pi*R along each. Within
+	// an L1 distance just over 2*pi*R of the start there are 9 intersections.
+	R := 6.4e6
+	in, err := NewIntersect(NewGeodesic(R, 0))
+	if err != nil {
+		t.Fatalf("NewIntersect error = %v; want nil", err)
+	}
+	got := in.All(0, 0, 0, 0, 0, 45, 2*math.Pi*R*1.01)
+	if len(got) != 9 {
+		t.Fatalf("len(All) = %v; want 9", len(got))
+	}
+	for i := 1; i < len(got); i++ {
+		d0 := math.Abs(got[i-1].DistanceXM) + math.Abs(got[i-1].DistanceYM)
+		d1 := math.Abs(got[i].DistanceXM) + math.Abs(got[i].DistanceYM)
+		if d1 < d0 {
+			t.Errorf("All is not sorted by distance: %v then %v", d0, d1)
+		}
+	}
+
+	// On the ellipsoid, every intersection returned is a real one
+	w := wgs84_intersect(t)
+	g := Wgs84()
+	lineX := g.LineWithCapabilities(10, 20, 30, INTERSECT_CAPS)
+	lineY := g.LineWithCapabilities(-10, 40, -50, INTERSECT_CAPS)
+	all := w.AllLines(lineX, lineY, 6e7, 0, 0)
+	if len(all) < 3 {
+		t.Errorf("len(All) = %v; want at least 3", len(all))
+	}
+	for _, p := range all {
+		check_intersection(t, "all", lineX, lineY, p.DistanceXM, p.DistanceYM)
+		if !(math.Abs(p.DistanceXM)+math.Abs(p.DistanceYM) <= 6e7) {
+			t.Errorf("x, y = %v, %v; want within 6e7", p.DistanceXM, p.DistanceYM)
+		}
+	}
+	if c := w.Closest(10, 20, 30, -10, 40, -50); c.DistanceXM != all[0].DistanceXM {
+		t.Errorf("All()[0].x = %v; want Closest().x = %v", all[0].DistanceXM, c.DistanceXM)
+	}
+}
+
+func BenchmarkIntersectClosest(b *testing.B) {
+	in, _ := NewIntersect(Wgs84())
+	for i := 0; i < b.N; i++ {
+		in.Closest(30, 10, 40, 35, -5, 110)
+	}
+}
+
+func BenchmarkIntersectSegment(b *testing.B) {
+	in, _ := NewIntersect(Wgs84())
+	for i := 0; i < b.N; i++ {
+		in.Segment(-1, -1, 1, 1, 1, -1, -1, 1)
+	}
+}
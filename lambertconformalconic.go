@@ -0,0 +1,603 @@
+package geographiclibgo
+
+import (
+	"errors"
+	"math"
+)
+
+// LambertConformalConic is the Lambert conformal conic projection with one or two
+// standard parallels, following J. P. Snyder, Map Projections: A Working Manual, USGS
+// Professional Paper 1395 (1987), pp. 107-109. This implementation evaluates the
+// projection using divided differences to avoid the loss of accuracy which occurs in
+// Snyder's formulas when the standard parallels are close together or the latitude of
+// the point is close to the latitude of origin.
+//
+// The latitude of origin, lat0, is the latitude at which the scale is a minimum; this is
+// between the two standard parallels. The northing y is measured from lat0 and the
+// easting x from the central meridian lon0, which is specified in the calls to Forward
+// and Reverse. A standard parallel of 0 gives the Mercator projection, and a standard
+// parallel of 90 or -90 gives the polar stereographic projection.
+//
+// The meridian convergence is the bearing of grid north (the y axis) measured clockwise
+// from true north.
+type LambertConformalConic struct {
+	a       float64
+	f       float64
+	fm      float64
+	e2      float64
+	es      float64
+	sign    float64
+	n       float64
+	nc      float64
+	t0nm1   float64
+	scale   float64
+	lat0    float64
+	k0      float64
+	scbet0  float64
+	tchi0   float64
+	scchi0  float64
+	psi0    float64
+	nrho0   float64
+	drhomax float64
+}
+
+// NewLambertConformalConic creates a LambertConformalConic projection with a single
+// standard parallel stdlat_deg (which is also the latitude of origin) for the ellipsoid
+// with equatorial radius a [meters] and flattening f, with scale k0 on the standard
+// parallel.
+func NewLambertConformalConic(a, f, stdlat_deg, k0 float64) LambertConformalConic {
+	sphi, cphi := sincosd(stdlat_deg)
+	l := _new_lambert_conformal_conic(a, f)
+	l._init(sphi, cphi, sphi, cphi, k0)
+	return l
+}
+
+// NewLambertConformalConicTwoParallels creates a LambertConformalConic projection with
+// standard parallels stdlat1_deg and stdlat2_deg for the ellipsoid with equatorial radius
+// a [meters] and flattening f, with scale k1 on the standard parallels. The standard
+// parallels cannot be opposite poles.
+func NewLambertConformalConicTwoParallels(
+	a, f, stdlat1_deg, stdlat2_deg, k1 float64,
+) LambertConformalConic {
+	sphi1, cphi1 := sincosd(stdlat1_deg)
+	sphi2, cphi2 := sincosd(stdlat2_deg)
+	l := _new_lambert_conformal_conic(a, f)
+	l._init(sphi1, cphi1, sphi2, cphi2, k1)
+	return l
+}
+
+func _new_lambert_conformal_conic(a, f float64) LambertConformalConic {
+	e2 := f * (2 - f)
+	es := math.Sqrt(math.Abs(e2))
+	if f < 0 {
+		es = -es
+	}
+	return LambertConformalConic{a: a, f: f, fm: 1 - f, e2: e2, es: es}
+}
+
+func (l *LambertConformalConic) _init(sphi1, cphi1, sphi2, cphi2, k1 float64) {
+	epsx := sq(get_epsilon())
+	{
+		r := math.Hypot(sphi1, cphi1)
+		sphi1 /= r
+		cphi1 /= r
+		r = math.Hypot(sphi2, cphi2)
+		sphi2 /= r
+		cphi2 /= r
+	}
+	polar := cphi1 == 0
+	// Avoid singularities at poles
+	cphi1 = math.Max(epsx, cphi1)
+	cphi2 = math.Max(epsx, cphi2)
+	// Determine hemisphere of tangent latitude
+	l.sign = 1
+	if sphi1+sphi2 < 0 {
+		l.sign = -1
+	}
+	// Internally work with tangent latitude positive
+	sphi1 *= l.sign
+	sphi2 *= l.sign
+	if sphi1 > sphi2 {
+		// Make phi1 < phi2
+		sphi1, sphi2 = sphi2, sphi1
+		cphi1, cphi2 = cphi2, cphi1
+	}
+	tphi1, tphi2 := sphi1/cphi1, sphi2/cphi2
+	var tphi0 float64
+	// Snyder: 15-8: n = (log(m1) - log(m2))/(log(t1)-log(t2))
+	//
+	// m = cos(bet) = 1/sec(bet) = 1/sqrt(1+tan(bet)^2)
+	// bet = parametric lat, tan(bet) = (1-f)*tan(phi)
+	//
+	// t = tan(pi/4-chi/2) = 1/(sec(chi) + tan(chi)) = sec(chi) - tan(chi)
+	// log(t) = -asinh(tan(chi)) = -psi
+	// chi = conformal lat
+	// tan(chi) = tan(phi)*cosh(xi) - sinh(xi)*sec(phi)
+	// xi = eatanhe(sin(phi)), eatanhe(x) = e * atanh(e*x)
+	//
+	// n = (log(sec(bet2))-log(sec(bet1)))/(asinh(tan(chi2))-asinh(tan(chi1)))
+	//
+	// Let log(sec(bet)) = b(tphi), asinh(tan(chi)) = c(tphi)
+	// Then n = Db(tphi2, tphi1)/Dc(tphi2, tphi1)
+	// In limit tphi2 -> tphi1, n -> sphi1
+	tbet1 := l.fm * tphi1
+	scbet1 := hyp(tbet1)
+	tbet2 := l.fm * tphi2
+	scbet2 := hyp(tbet2)
+	scphi1 := 1 / cphi1
+	xi1 := eatanhe(sphi1, l.es)
+	shxi1 := math.Sinh(xi1)
+	chxi1 := hyp(shxi1)
+	tchi1 := chxi1*tphi1 - shxi1*scphi1
+	scchi1 := hyp(tchi1)
+	scphi2 := 1 / cphi2
+	xi2 := eatanhe(sphi2, l.es)
+	shxi2 := math.Sinh(xi2)
+	chxi2 := hyp(shxi2)
+	tchi2 := chxi2*tphi2 - shxi2*scphi2
+	scchi2 := hyp(tchi2)
+	psi1 := math.Asinh(tchi1)
+	if tphi2-tphi1 != 0 {
+		// Db(tphi2, tphi1)
+		num := d_log1p(sq(tbet2)/(1+scbet2), sq(tbet1)/(1+scbet1)) *
+			d_hyp(tbet2, tbet1, scbet2, scbet1) * l.fm
+		// Dc(tphi2, tphi1)
+		den := d_asinh(tphi2, tphi1, scphi2, scphi1) -
+			l._d_eatanhe(sphi2, sphi1)*d_sn(tphi2, tphi1, sphi2, sphi1)
+		l.n = num / den
+
+		if l.n < 0.25 {
+			l.nc = math.Sqrt((1 - l.n) * (1 + l.n))
+		} else {
+			// Compute nc = cos(phi0) = sqrt((1 - n) * (1 + n)), evaluating 1 - n
+			// carefully. First write
+			//
+			// Dc(tphi2, tphi1) * (tphi2 - tphi1)
+			//   = log(tchi2 + scchi2) - log(tchi1 + scchi1)
+			//
+			// then den * (1 - n) =
+			// (log((tchi2 + scchi2)/(2*scbet2)) - log((tchi1 + scchi1)/(2*scbet1)))
+			// / (tphi2 - tphi1)
+			// = Dlog1p(a2, a1) * (tchi2+scchi2 + tchi1+scchi1)/(4*scbet1*scbet2)
+			//   * fm * Q
+			//
+			// where
+			// a1 = ( (tchi1 - scbet1) + (scchi1 - scbet1) ) / (2 * scbet1)
+			// Q = ((scbet2 + scbet1)/fm)/((scchi2 + scchi1)/D(tchi2, tchi1))
+			//     - (tbet2 + tbet1)/(scbet2 + scbet1)
+			var t float64
+			{
+				// s1 = (scbet1 - scchi1) * (scbet1 + scchi1)
+				s1 := tphi1*(2*shxi1*chxi1*scphi1-l.e2*tphi1) - sq(shxi1)*(1+2*sq(tphi1))
+				s2 := tphi2*(2*shxi2*chxi2*scphi2-l.e2*tphi2) - sq(shxi2)*(1+2*sq(tphi2))
+				// t1 = scbet1 - tchi1
+				t1 := (s1 + 1) / (scbet1 + tchi1)
+				if tchi1 < 0 {
+					t1 = scbet1 - tchi1
+				}
+				t2 := (s2 + 1) / (scbet2 + tchi2)
+				if tchi2 < 0 {
+					t2 = scbet2 - tchi2
+				}
+				a2 := -(s2/(scbet2+scchi2) + t2) / (2 * scbet2)
+				a1 := -(s1/(scbet1+scchi1) + t1) / (2 * scbet1)
+				t = d_log1p(a2, a1) / den
+			}
+			// multiply by (tchi2 + scchi2 + tchi1 + scchi1)/(4*scbet1*scbet2) * fm
+			t *= ((exp_asinh(tchi2, scchi2) + exp_asinh(tchi1, scchi1)) /
+				(4 * scbet1 * scbet2)) * l.fm
+
+			// Rewrite
+			// Q = (1 - (tbet2 + tbet1)/(scbet2 + scbet1)) -
+			//     (1 - ((scbet2 + scbet1)/fm)/((scchi2 + scchi1)/D(tchi2, tchi1)))
+			//   = tbm - tam
+			// where
+			tbm := (1/exp_asinh(tbet1, scbet1) + 1/exp_asinh(tbet2, scbet2)) /
+				(scbet1 + scbet2)
+
+			// tam = (1 - ((scbet2+scbet1)/fm)/((scchi2+scchi1)/D(tchi2, tchi1)))
+			//
+			// Let
+			//   (scbet2 + scbet1)/fm = scphi2 + scphi1 + dbet
+			//   (scchi2 + scchi1)/D(tchi2, tchi1) = scphi2 + scphi1 + dchi
+			// then
+			//   tam = D(tchi2, tchi1) * (dchi - dbet) / (scchi1 + scchi2)
+			// D(tchi2, tchi1)
+			dtchi := den / d_asinh(tchi2, tchi1, scchi2, scchi1)
+			// (scbet2 + scbet1)/fm - (scphi2 + scphi1)
+			dbet := (l.e2 / l.fm) * (1/(scbet2+l.fm*scphi2) + 1/(scbet1+l.fm*scphi1))
+
+			// dchi = (scchi2 + scchi1)/D(tchi2, tchi1) - (scphi2 + scphi1)
+			// Let
+			//    tzet = chxiZ * tphi - shxiZ * scphi
+			//    tchi = tzet + nu
+			//    scchi = sczet + mu
+			// where
+			//    xiZ = eatanhe(1), shxiZ = sinh(xiZ), chxiZ = cosh(xiZ)
+			//    nu =   scphi * (shxiZ - shxi) - tphi * (chxiZ - chxi)
+			//    mu = - scphi * (chxiZ - chxi) + tphi * (shxiZ - shxi)
+			// then
+			// dchi = ((mu2 + mu1) - D(nu2, nu1) * (scphi2 + scphi1)) /
+			//         D(tchi2, tchi1)
+			xiZ := eatanhe(1, l.es)
+			shxiZ := math.Sinh(xiZ)
+			chxiZ := hyp(shxiZ)
+			// These are differences not divided differences
+			// dxiZ1 = xiZ - xi1; dshxiZ1 = shxiZ - shxi; dchxiZ1 = chxiZ - chxi
+			dxiZ1 := l._d_eatanhe(1, sphi1) / (scphi1 * (tphi1 + scphi1))
+			dxiZ2 := l._d_eatanhe(1, sphi2) / (scphi2 * (tphi2 + scphi2))
+			dshxiZ1 := d_sinh(xiZ, xi1, shxiZ, shxi1, chxiZ, chxi1) * dxiZ1
+			dshxiZ2 := d_sinh(xiZ, xi2, shxiZ, shxi2, chxiZ, chxi2) * dxiZ2
+			dchxiZ1 := d_hyp(shxiZ, shxi1, chxiZ, chxi1) * dshxiZ1
+			dchxiZ2 := d_hyp(shxiZ, shxi2, chxiZ, chxi2) * dshxiZ2
+			// mu1 + mu2
+			amu12 := -scphi1*dchxiZ1 + tphi1*dshxiZ1 - scphi2*dchxiZ2 + tphi2*dshxiZ2
+			// D(xi2, xi1)
+			dxi := l._d_eatanhe(sphi1, sphi2) * d_sn(tphi2, tphi1, sphi2, sphi1)
+			// D(nu2, nu1)
+			var dnu12 float64
+			if l.f*4*scphi2*dshxiZ2 > l.f*scphi1*dshxiZ1 {
+				// Use divided differences
+				dnu12 = (dshxiZ1+dshxiZ2)/2*d_hyp(tphi1, tphi2, scphi1, scphi2) -
+					(scphi1+scphi2)/2*d_sinh(xi1, xi2, shxi1, shxi2, chxi1, chxi2)*dxi
+			} else {
+				// Use ratio of differences
+				dnu12 = (scphi2*dshxiZ2 - scphi1*dshxiZ1) / (tphi2 - tphi1)
+			}
+			dnu12 += (tphi1+tphi2)/2*d_hyp(shxi1, shxi2, chxi1, chxi2)*
+				d_sinh(xi1, xi2, shxi1, shxi2, chxi1, chxi2)*dxi -
+				(dchxiZ1+dchxiZ2)/2
+			// dtchi * dchi
+			dchia := amu12 - dnu12*(scphi2+scphi1)
+			tam := (dchia - dtchi*dbet) / (scchi1 + scchi2)
+			t *= tbm - tam
+			l.nc = math.Sqrt(math.Max(0, t) * (1 + l.n))
+		}
+		{
+			r := math.Hypot(l.n, l.nc)
+			l.n /= r
+			l.nc /= r
+		}
+		tphi0 = l.n / l.nc
+	} else {
+		tphi0 = tphi1
+		l.nc = 1 / hyp(tphi0)
+		l.n = tphi0 * l.nc
+		if polar {
+			l.nc = 0
+		}
+	}
+
+	l.scbet0 = hyp(l.fm * tphi0)
+	shxi0 := math.Sinh(eatanhe(l.n, l.es))
+	l.tchi0 = tphi0*hyp(shxi0) - shxi0*hyp(tphi0)
+	l.scchi0 = hyp(l.tchi0)
+	l.psi0 = math.Asinh(l.tchi0)
+
+	l.lat0 = math.Atan(l.sign*tphi0) * RAD2DEG
+	l.t0nm1 = math.Expm1(-l.n * l.psi0) // Snyder's t0^n - 1
+	// a * k1 * m1/t1^n = a * k1 * m2/t2^n = a * k1 * n * (Snyder's F)
+	// = a * k1 / (scbet1 * exp(-n * psi1))
+	// exp(n * psi1) = exp(- (1 - n) * psi1) * exp(psi1)
+	// with (1-n) = nc^2/(1+n) and exp(-psi1) = scchi1 + tchi1
+	l.scale = l.a * k1 / scbet1 *
+		math.Exp(-(sq(l.nc)/(1+l.n))*psi1) * exp_asinh(tchi1, scchi1)
+	// Scale at phi0 = k0 = k1 * (scbet0*exp(-n*psi0))/(scbet1*exp(-n*psi1))
+	//                    = k1 * scbet0/scbet1 * exp(n * (psi1 - psi0))
+	// psi1 - psi0 = Dasinh(tchi1, tchi0) * (tchi1 - tchi0)
+	l.k0 = k1 * (l.scbet0 / scbet1) *
+		math.Exp(-(sq(l.nc)/(1+l.n))*d_asinh(tchi1, l.tchi0, scchi1, l.scchi0)*(tchi1-l.tchi0)) *
+		exp_asinh(tchi1, scchi1) / (l.scchi0 + l.tchi0)
+	l.nrho0 = 0
+	if !polar {
+		l.nrho0 = l.a * l.k0 / l.scbet0
+	}
+	{
+		// Figure drhomax using code at beginning of Forward with lat = -90
+		sphi, cphi := -1.0, epsx
+		tphi := sphi / cphi
+		scphi := 1 / cphi
+		shxi := math.Sinh(eatanhe(sphi, l.es))
+		tchi := hyp(shxi)*tphi - shxi*scphi
+		scchi := hyp(tchi)
+		psi := math.Asinh(tchi)
+		dpsi := d_asinh(tchi, l.tchi0, scchi, l.scchi0) * (tchi - l.tchi0)
+		l.drhomax = -l.scale * l._drho(tchi, scchi, psi, dpsi)
+	}
+}
+
+// _drho returns (rho - rho0)/scale given tan(chi), sec(chi), psi, and psi - psi0
+func (l *LambertConformalConic) _drho(tchi, scchi, psi, dpsi float64) float64 {
+	if 2*l.nc < 1 && dpsi != 0 {
+		return (math.Exp(sq(l.nc)/(1+l.n)*psi)/exp_asinh(tchi, scchi) - (l.t0nm1 + 1)) / (-l.n)
+	}
+	return d_exp(-l.n*psi, -l.n*l.psi0) * dpsi
+}
+
+// EquatorialRadius returns the equatorial radius of the ellipsoid [meters]
+func (l *LambertConformalConic) EquatorialRadius() float64 {
+	return l.a
+}
+
+// Flattening returns the flattening of the ellipsoid
+func (l *LambertConformalConic) Flattening() float64 {
+	return l.f
+}
+
+// OriginLatitude returns the latitude of origin, at which the scale is a minimum
+// [degrees]. For a projection with a single standard parallel, this is the standard
+// parallel.
+func (l *LambertConformalConic) OriginLatitude() float64 {
+	return l.lat0
+}
+
+// CentralScale returns the scale at the latitude of origin, k0
+func (l *LambertConformalConic) CentralScale() float64 {
+	return l.k0
+}
+
+// SetScale sets the scale of the projection so that the scale at latitude lat_deg is k.
+// This returns an error if k is not positive, or if lat_deg is not in (-90, 90) (except
+// that lat_deg may be the pole at the apex of the cone of a polar projection).
+func (l *LambertConformalConic) SetScale(lat_deg, k float64) error {
+	if !(!math.IsInf(k, 0) && k > 0) {
+		return errors.New("scale is not positive")
+	}
+	if !(math.Abs(lat_deg) <= 90) {
+		return errors.New("latitude for SetScale not in [-90d, 90d]")
+	}
+	if math.Abs(lat_deg) == 90 && !(l.nc == 0 && lat_deg*l.n > 0) {
+		return errors.New("latitude for SetScale not in (-90d, 90d)")
+	}
+	kold := l.Forward(0, lat_deg, 0).Scale
+	k /= kold
+	l.scale *= k
+	l.k0 *= k
+	return nil
+}
+
+// Forward projects the point lat_deg, lon_deg with central meridian lon0_deg. The
+// projection of a pole at the apex of the cone is the origin of the cone; the projection
+// of the other pole is at infinity.
+func (l *LambertConformalConic) Forward(lon0_deg, lat_deg, lon_deg float64) ProjectionForwardResult {
+	epsx := sq(get_epsilon())
+	lon, _ := ang_diff(lon0_deg, lon_deg)
+	// From Snyder, we have
+	//
+	// theta = n * lambda
+	// x = rho * sin(theta)
+	//   = (nrho0 + n * drho) * sin(theta)/n
+	// y = rho0 - rho * cos(theta)
+	//   = nrho0 * (1-cos(theta))/n - drho * cos(theta)
+	//
+	// where nrho0 = n * rho0, drho = rho - rho0
+	// and drho is evaluated with divided differences
+	sphi, cphi := sincosd(lat_fix(lat_deg) * l.sign)
+	cphi = math.Max(epsx, cphi)
+	lam := lon * DEG2RAD
+	tphi := sphi / cphi
+	scbet := hyp(l.fm * tphi)
+	scphi := 1 / cphi
+	shxi := math.Sinh(eatanhe(sphi, l.es))
+	tchi := hyp(shxi)*tphi - shxi*scphi
+	scchi := hyp(tchi)
+	psi := math.Asinh(tchi)
+	theta := l.n * lam
+	stheta, ctheta := math.Sincos(theta)
+	dpsi := d_asinh(tchi, l.tchi0, scchi, l.scchi0) * (tchi - l.tchi0)
+	drho := -l.scale * l._drho(tchi, scchi, psi, dpsi)
+	var x, y float64
+	if l.n != 0 {
+		x = (l.nrho0 + l.n*drho) * stheta / l.n
+		if ctheta < 0 {
+			y = l.nrho0 * (1 - ctheta) / l.n
+		} else {
+			y = l.nrho0 * sq(stheta) / (1 + ctheta) / l.n
+		}
+	} else {
+		x = (l.nrho0 + l.n*drho) * lam
+	}
+	y -= drho * ctheta
+	k := l.k0 * (scbet / l.scbet0) /
+		(math.Exp(-(sq(l.nc)/(1+l.n))*dpsi) * exp_asinh(tchi, scchi) / (l.scchi0 + l.tchi0))
+	return ProjectionForwardResult{
+		XM:             x,
+		YM:             y * l.sign,
+		ConvergenceDeg: l.sign * theta * RAD2DEG,
+		Scale:          k,
+	}
+}
+
+// Reverse finds the point at easting x_m and northing y_m with central meridian lon0_deg
+func (l *LambertConformalConic) Reverse(lon0_deg, x_m, y_m float64) ProjectionReverseResult {
+	epsx := sq(get_epsilon())
+	ahypover := 53*math.Ln2 + 2
+	// From Snyder, we have
+	//
+	//        x = rho * sin(theta)
+	// rho0 - y = rho * cos(theta)
+	//
+	// rho = hypot(x, rho0 - y)
+	// drho = (n*x^2 - 2*y*nrho0 + n*y^2)/(hypot(n*x, nrho0-n*y) + nrho0)
+	// theta = atan2(n*x, nrho0-n*y)
+	//
+	// From drho, obtain t^n-1
+	// t^n-1 = exp(-n*log(t))-1 dt = -t0^n*(Dexp(-n*log(t), -n*log(t0))*dlogt
+	y := y_m * l.sign
+	x := x_m
+	// Guard against 0 * inf in computation of ny
+	nx := l.n * x
+	ny := 0.0
+	if l.n != 0 {
+		ny = l.n * y
+	}
+	y1 := l.nrho0 - ny
+	den := math.Hypot(nx, y1) + l.nrho0 // 0 implies origin with polar aspect
+	// The finiteness test is to avoid inf/inf
+	drho := den
+	if den != 0 && !math.IsInf(den, 0) && !math.IsNaN(den) {
+		drho = (x*nx + y*(ny-2*l.nrho0)) / den
+	}
+	drho = math.Min(drho, l.drhomax)
+	if l.n == 0 {
+		drho = math.Max(drho, -l.drhomax)
+	}
+	tnm1 := l.t0nm1 + l.n*drho/l.scale
+	var dpsi float64
+	if den == 0 {
+		dpsi = 0
+	} else if tnm1+1 != 0 {
+		dpsi = -d_log1p(tnm1, l.t0nm1) * drho / l.scale
+	} else {
+		dpsi = ahypover
+	}
+	var tchi float64
+	if 2*l.n <= 1 {
+		// tchi = sinh(psi)
+		psi := l.psi0 + dpsi
+		tchia := math.Sinh(psi)
+		scchi := hyp(tchia)
+		dtchi := d_sinh(psi, l.psi0, tchia, l.tchi0, scchi, l.scchi0) * dpsi
+		tchi = l.tchi0 + dtchi // Update tchi using divided difference
+	} else {
+		// tchi = sinh(-1/n * log(tn))
+		//      = sinh((1-1/n) * log(tn) - log(tn))
+		//      = + sinh((1-1/n) * log(tn)) * cosh(log(tn))
+		//        - cosh((1-1/n) * log(tn)) * sinh(log(tn))
+		// (1-1/n) = - nc^2/(n*(1+n))
+		// cosh(log(tn)) = (tn + 1/tn)/2; sinh(log(tn)) = (tn - 1/tn)/2
+		tn := tnm1 + 1
+		if tn == 0 {
+			tn = epsx
+		}
+		logtn := math.Log(tn)
+		if 2*tn > 1 {
+			logtn = math.Log1p(tnm1)
+		}
+		sh := math.Sinh(-sq(l.nc) / (l.n * (1 + l.n)) * logtn)
+		tchi = sh*(tn+1/tn)/2 - hyp(sh)*(tnm1*(tn+1)/tn)/2
+	}
+
+	// log(t) = -asinh(tan(chi)) = -psi
+	gamma := math.Atan2(nx, y1)
+	tphi := tauf(tchi, l.es)
+	scbet := hyp(l.fm * tphi)
+	scchi := hyp(tchi)
+	lam := x / y1
+	if l.n != 0 {
+		lam = gamma / l.n
+	}
+	lat := atand(l.sign * tphi)
+	lon := ang_normalize(lam*RAD2DEG + ang_normalize(lon0_deg))
+	ex := 0.0
+	if l.nc != 0 {
+		ex = -(sq(l.nc) / (1 + l.n)) * dpsi
+	}
+	k := l.k0 * (scbet / l.scbet0) /
+		(math.Exp(ex) * exp_asinh(tchi, scchi) / (l.scchi0 + l.tchi0))
+	return ProjectionReverseResult{
+		LatDeg:         lat,
+		LonDeg:         lon,
+		ConvergenceDeg: gamma * RAD2DEG / l.sign,
+		Scale:          k,
+	}
+}
+
+// _d_eatanhe is the divided difference of eatanhe: (eatanhe(x) - eatanhe(y))/(x - y)
+func (l *LambertConformalConic) _d_eatanhe(x, y float64) float64 {
+	t := x - y
+	d := 1 - l.e2*x*y
+	if t != 0 {
+		return eatanhe(t/d, l.es) / t
+	}
+	return l.e2 / d
+}
+
+// hyp returns sqrt(1 + x^2)
+func hyp(x float64) float64 {
+	return math.Hypot(1, x)
+}
+
+// exp_asinh returns exp(asinh(x)) = x + sqrt(1 + x^2), given hx = sqrt(1 + x^2), avoiding
+// cancellation for negative x
+func exp_asinh(x, hx float64) float64 {
+	if x >= 0 {
+		return hx + x
+	}
+	return 1 / (hx - x)
+}
+
+// Divided differences, Df(x,y) = (f(x)-f(y))/(x-y), following W. M. Kahan and R. J.
+// Fateman, Symbolic computation of divided differences, SIGSAM Bull. 33(3), 7-28 (1999).
+//
+// General rules
+// h(x) = f(g(x)): Dh(x,y) = Df(g(x),g(y))*Dg(x,y)
+// h(x) = f(x)*g(x):
+//        Dh(x,y) = Df(x,y)*g(x) + Dg(x,y)*f(y)
+//                = Df(x,y)*g(y) + Dg(x,y)*f(x)
+//                = Df(x,y)*(g(x)+g(y))/2 + Dg(x,y)*(f(x)+f(y))/2
+
+// d_hyp is the divided difference of hyp, given hx = hyp(x) and hy = hyp(y)
+func d_hyp(x, y, hx, hy float64) float64 {
+	return (x + y) / (hx + hy)
+}
+
+// d_sn is the divided difference of sn(x) = x/sqrt(1+x^2), given sx = sn(x) and sy = sn(y)
+func d_sn(x, y, sx, sy float64) float64 {
+	t := x * y
+	if t > 0 {
+		return (x + y) * sq((sx*sy)/t) / (sx + sy)
+	}
+	if x-y != 0 {
+		return (sx - sy) / (x - y)
+	}
+	return 1
+}
+
+// d_log1p is the divided difference of log1p: log1p((x-y)/(1+y))/(x-y)
+func d_log1p(x, y float64) float64 {
+	t := x - y
+	if t < 0 {
+		t = -t
+		y = x
+	}
+	if t != 0 {
+		return math.Log1p(t/(1+y)) / t
+	}
+	return 1 / (1 + x)
+}
+
+// d_exp is the divided difference of exp: exp((x+y)/2) * 2*sinh((x-y)/2)/(x-y)
+func d_exp(x, y float64) float64 {
+	t := (x - y) / 2
+	s := 1.0
+	if t != 0 {
+		s = math.Sinh(t) / t
+	}
+	return s * math.Exp((x+y)/2)
+}
+
+// d_sinh is the divided difference of sinh, given sx = sinh(x) and cx = cosh(x):
+// 2*sinh((x-y)/2)/(x-y) * cosh((x+y)/2), with
+// cosh((x+y)/2) = sqrt( (sinh(x)*sinh(y) + cosh(x)*cosh(y) + 1)/2 )
+func d_sinh(x, y, sx, sy, cx, cy float64) float64 {
+	t := (x - y) / 2
+	s := 1.0
+	if t != 0 {
+		s = math.Sinh(t) / t
+	}
+	return s * math.Sqrt((sx*sy+cx*cy+1)/2)
+}
+
+// d_asinh is the divided difference of asinh, given hx = hyp(x) and hy = hyp(y):
+// asinh((x-y)*(x+y)/(x*sqrt(1+y^2)+y*sqrt(1+x^2)))/(x-y)
+func d_asinh(x, y, hx, hy float64) float64 {
+	t := x - y
+	if t == 0 {
+		return 1 / hx
+	}
+	if x*y > 0 {
+		return math.Asinh(t*(x+y)/(x*hy+y*hx)) / t
+	}
+	return math.Asinh(x*hy-y*hx) / t
+}
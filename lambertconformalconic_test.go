@@ -0,0 +1,173 @@
+package geographiclibgo
+
+import (
+	"math"
+	"testing"
+)
+
+func TestLambertConformalConicConicProj(t *testing.T) {
+	// echo 39.95N 75.17W | ConicProj -c 40d58 39d56 -l 77d45W
+	// => 220445 -52372
+	lcc := NewLambertConformalConicTwoParallels(
+		WGS84_A, WGS84_F, 40+58/60.0, 39+56/60.0, 1,
+	)
+	lon0 := -(77 + 45/60.0)
+	fwd := lcc.Forward(lon0, 39.95, -75.17)
+	if !almost_equal(fwd.XM, 220445, 0.5) || !almost_equal(fwd.YM, -52372, 0.5) {
+		t.Errorf("Forward() = (%v, %v); want (220445, -52372)", fwd.XM, fwd.YM)
+	}
+	rev := lcc.Reverse(lon0, fwd.XM, fwd.YM)
+	if !almost_equal(rev.LatDeg, 39.95, 1e-12) || !almost_equal(rev.LonDeg, -75.17, 1e-12) {
+		t.Errorf("Reverse() = (%v, %v); want (39.95, -75.17)", rev.LatDeg, rev.LonDeg)
+	}
+}
+
+func TestLambertConformalConicSnyder(t *testing.T) {
+	// Snyder (1987), p. 296: Clarke 1866, standard parallels 33 and 45, origin at 23N
+	// 96W, the point 35N 75W is at x = 1894410.9, y = 1564649.5
+	lcc := NewLambertConformalConicTwoParallels(6378206.4, 1/294.9786982, 33, 45, 1)
+	fwd := lcc.Forward(-96, 35, -75)
+	origin := lcc.Forward(-96, 23, -96)
+	if !almost_equal(fwd.XM, 1894410.9, 0.05) {
+		t.Errorf("x = %v; want %v", fwd.XM, 1894410.9)
+	}
+	if !almost_equal(fwd.YM-origin.YM, 1564649.5, 0.05) {
+		t.Errorf("y = %v; want %v", fwd.YM-origin.YM, 1564649.5)
+	}
+	if !almost_equal(fwd.Scale, 0.9970171, 1e-7) {
+		t.Errorf("k = %v; want %v", fwd.Scale, 0.9970171)
+	}
+}
+
+func TestLambertConformalConicStandardParallels(t *testing.T) {
+	testCases := []struct {
+		desc             string
+		f                float64
+		stdlat1, stdlat2 float64
+	}{
+		{desc: "wgs84 northern", f: WGS84_F, stdlat1: 40 + 58/60.0, stdlat2: 39 + 56/60.0},
+		{desc: "wgs84 wide", f: WGS84_F, stdlat1: 10, stdlat2: 80},
+		{desc: "wgs84 southern", f: WGS84_F, stdlat1: -20, stdlat2: -21},
+		{desc: "wgs84 close", f: WGS84_F, stdlat1: 30, stdlat2: 30.000001},
+		{desc: "wgs84 straddling equator", f: WGS84_F, stdlat1: -10, stdlat2: 20},
+		{desc: "wgs84 near pole", f: WGS84_F, stdlat1: 89, stdlat2: 89.5},
+		{desc: "prolate", f: -1 / 150.0, stdlat1: 60, stdlat2: 70},
+		{desc: "sphere", f: 0, stdlat1: 60, stdlat2: 70},
+		{desc: "very oblate", f: 0.2, stdlat1: 10, stdlat2: 80},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			lcc := NewLambertConformalConicTwoParallels(WGS84_A, tC.f, tC.stdlat1, tC.stdlat2, 1)
+			for _, lat := range []float64{tC.stdlat1, tC.stdlat2} {
+				if k := lcc.Forward(0, lat, 5).Scale; !almost_equal(k, 1, 1e-14) {
+					t.Errorf("k at %v = %v; want 1", lat, k)
+				}
+			}
+			lat0 := lcc.OriginLatitude()
+			if !(lat0 >= math.Min(tC.stdlat1, tC.stdlat2) && lat0 <= math.Max(tC.stdlat1, tC.stdlat2)) {
+				t.Errorf("lat0 = %v; want between %v and %v", lat0, tC.stdlat1, tC.stdlat2)
+			}
+			if k := lcc.Forward(0, lat0, 5).Scale; !almost_equal(k, lcc.CentralScale(), 1e-14) {
+				t.Errorf("k at lat0 = %v; want %v", k, lcc.CentralScale())
+			}
+		})
+	}
+}
+
+func TestLambertConformalConicRoundTrip(t *testing.T) {
+	lcc := NewLambertConformalConicTwoParallels(WGS84_A, WGS84_F, 33, 45, 1)
+	for lat := -80.0; lat < 90; lat += 10 {
+		for lon := -170.0; lon < 180; lon += 20 {
+			fwd := lcc.Forward(-96, lat, lon)
+			rev := lcc.Reverse(-96, fwd.XM, fwd.YM)
+			if !almost_equal(rev.LatDeg, lat, 1e-11) || !almost_equal(rev.LonDeg, lon, 1e-11) {
+				t.Errorf("Reverse(Forward(%v, %v)) = (%v, %v)", lat, lon, rev.LatDeg, rev.LonDeg)
+			}
+			if !almost_equal(rev.ConvergenceDeg, fwd.ConvergenceDeg, 1e-12) {
+				t.Errorf("(%v, %v) reverse gamma = %v; want %v", lat, lon, rev.ConvergenceDeg, fwd.ConvergenceDeg)
+			}
+			if !almost_equal(rev.Scale, fwd.Scale, 1e-12*fwd.Scale) {
+				t.Errorf("(%v, %v) reverse k = %v; want %v", lat, lon, rev.Scale, fwd.Scale)
+			}
+		}
+	}
+}
+
+func TestLambertConformalConicLimits(t *testing.T) {
+	// Test vectors from GeographicLib's ConicProj tests
+	testCases := []struct {
+		desc     string
+		stdlat   float64
+		x, y     float64
+		lat, lon float64
+	}{
+		{desc: "mercator south", stdlat: 0, x: 1113195, y: -1e10, lat: -90, lon: 10},
+		{desc: "mercator inf", stdlat: 0, x: 1113195, y: math.Inf(1), lat: 90, lon: 10},
+		{desc: "conic far south", stdlat: 45, x: 0, y: -1e100, lat: -90, lon: 0},
+		{desc: "conic -inf", stdlat: 45, x: 0, y: math.Inf(-1), lat: -90, lon: 0},
+		{desc: "polar far south", stdlat: 90, x: 0, y: -1e150, lat: -90, lon: 0},
+		{desc: "polar -inf", stdlat: 90, x: 0, y: math.Inf(-1), lat: -90, lon: 0},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			lcc := NewLambertConformalConic(WGS84_A, WGS84_F, tC.stdlat, 1)
+			rev := lcc.Reverse(0, tC.x, tC.y)
+			if rev.LatDeg != tC.lat || !almost_equal(rev.LonDeg, tC.lon, 1e-5) {
+				t.Errorf("Reverse(%v, %v) = (%v, %v); want (%v, %v)",
+					tC.x, tC.y, rev.LatDeg, rev.LonDeg, tC.lat, tC.lon)
+			}
+		})
+	}
+
+	// echo -30 0 | ConicProj -c -30 -30
+	lcc := NewLambertConformalConic(WGS84_A, WGS84_F, -30, 1)
+	fwd := lcc.Forward(0, -30, 0)
+	if fwd.XM != 0 || fwd.YM != 0 || fwd.ConvergenceDeg != 0 || fwd.Scale != 1 {
+		t.Errorf("Forward(-30, 0) = %v; want {0 0 0 1}", fwd)
+	}
+
+	// The apex of the cone of a polar projection is the origin
+	lcc = NewLambertConformalConic(WGS84_A, WGS84_F, 90, 1)
+	fwd = lcc.Forward(0, 90, 0)
+	if fwd.XM != 0 || fwd.YM != 0 || fwd.Scale != 1 {
+		t.Errorf("Forward(90, 0) = %v; want {0 0 0 1}", fwd)
+	}
+}
+
+func TestLambertConformalConicSetScale(t *testing.T) {
+	lcc := NewLambertConformalConic(WGS84_A, WGS84_F, 40, 1)
+	if err := lcc.SetScale(50, 1); err != nil {
+		t.Fatalf("SetScale() error = %v", err)
+	}
+	if k := lcc.Forward(0, 50, 0).Scale; !almost_equal(k, 1, 1e-14) {
+		t.Errorf("k at 50 = %v; want 1", k)
+	}
+	if !(lcc.CentralScale() < 1) {
+		t.Errorf("k0 = %v; want < 1", lcc.CentralScale())
+	}
+
+	if err := lcc.SetScale(45, -1); err == nil {
+		t.Errorf("SetScale(45, -1) error = nil; want non-nil")
+	}
+	if err := lcc.SetScale(90, 1); err == nil {
+		t.Errorf("SetScale(90, 1) error = nil; want non-nil")
+	}
+	polar := NewLambertConformalConic(WGS84_A, WGS84_F, 90, 1)
+	if err := polar.SetScale(90, 0.994); err != nil {
+		t.Errorf("SetScale(90, 0.994) error = %v; want nil", err)
+	}
+}
+
+func BenchmarkLambertConformalConicForward(b *testing.B) {
+	lcc := NewLambertConformalConicTwoParallels(WGS84_A, WGS84_F, 33, 45, 1)
+	for i := 0; i < b.N; i++ {
+		lcc.Forward(-96, 35, -75)
+	}
+}
+
+func BenchmarkLambertConformalConicReverse(b *testing.B) {
+	lcc := NewLambertConformalConicTwoParallels(WGS84_A, WGS84_F, 33, 45, 1)
+	for i := 0; i < b.N; i++ {
+		lcc.Reverse(-96, 1894410.9, 1564649.5)
+	}
+}
@@ -0,0 +1,148 @@
+package geographiclibgo
+
+import "math"
+
+// LocalCartesian converts between geodetic coordinates and local Cartesian coordinates
+// (east, north, up) with an origin at lat0, lon0, h0. The x axis points east, the y axis
+// points north, and the z axis is normal to the ellipsoid at the origin, pointing up.
+// The conversions are done via geocentric coordinates with a Geocentric.
+type LocalCartesian struct {
+	earth Geocentric
+	lat0  float64
+	lon0  float64
+	h0    float64
+	x0    float64
+	y0    float64
+	z0    float64
+	r     RotationMatrix
+}
+
+// LookAngles are the range, azimuth, and elevation of a point as seen from the origin of a
+// LocalCartesian
+type LookAngles struct {
+	RangeM       float64 // Straight-line (slant) distance from the origin [meters]
+	AzimuthDeg   float64 // Azimuth measured clockwise from north [degrees]
+	ElevationDeg float64 // Elevation above the local horizontal plane [degrees]
+}
+
+// NewLocalCartesian creates a LocalCartesian with origin lat0_deg, lon0_deg, h0_m on the
+// ellipsoid of earth
+func NewLocalCartesian(earth Geocentric, lat0_deg, lon0_deg, h0_m float64) LocalCartesian {
+	l := LocalCartesian{earth: earth}
+	l.Reset(lat0_deg, lon0_deg, h0_m)
+	return l
+}
+
+// Reset sets the origin to lat0_deg, lon0_deg, h0_m
+func (l *LocalCartesian) Reset(lat0_deg, lon0_deg, h0_m float64) {
+	l.lat0 = lat_fix(lat0_deg)
+	l.lon0 = ang_normalize(lon0_deg)
+	l.h0 = h0_m
+	c := l.earth.Forward(l.lat0, l.lon0, l.h0)
+	l.x0, l.y0, l.z0 = c.XM, c.YM, c.ZM
+	sphi, cphi := sincosd(l.lat0)
+	slam, clam := sincosd(l.lon0)
+	l.r = geocentric_rotation(sphi, cphi, slam, clam)
+}
+
+// LatitudeOrigin returns the latitude of the origin [degrees]
+func (l *LocalCartesian) LatitudeOrigin() float64 {
+	return l.lat0
+}
+
+// LongitudeOrigin returns the longitude of the origin [degrees]
+func (l *LocalCartesian) LongitudeOrigin() float64 {
+	return l.lon0
+}
+
+// HeightOrigin returns the height of the origin [meters]
+func (l *LocalCartesian) HeightOrigin() float64 {
+	return l.h0
+}
+
+// EquatorialRadius returns the equatorial radius of the ellipsoid [meters]
+func (l *LocalCartesian) EquatorialRadius() float64 {
+	return l.earth.a
+}
+
+// Flattening returns the flattening of the ellipsoid
+func (l *LocalCartesian) Flattening() float64 {
+	return l.earth.f
+}
+
+// Forward converts the geodetic coordinates lat_deg, lon_deg, h_m to local Cartesian
+// coordinates
+//   - lat_deg - Latitude [degrees] [-90.,90.]
+//   - lon_deg - Longitude [degrees]
+//   - h_m - Height above the ellipsoid [meters]
+func (l *LocalCartesian) Forward(lat_deg, lon_deg, h_m float64) Cartesian {
+	c, _ := l._int_forward(lat_deg, lon_deg, h_m, false)
+	return c
+}
+
+// ForwardWithRotation is Forward, also returning the rotation matrix from the local east,
+// north, up frame at the point to the local Cartesian frame at the origin
+func (l *LocalCartesian) ForwardWithRotation(lat_deg, lon_deg, h_m float64) (Cartesian, RotationMatrix) {
+	return l._int_forward(lat_deg, lon_deg, h_m, true)
+}
+
+// Reverse converts the local Cartesian coordinates x_m, y_m, z_m to geodetic coordinates
+func (l *LocalCartesian) Reverse(x_m, y_m, z_m float64) GeodeticPosition {
+	p, _ := l._int_reverse(x_m, y_m, z_m, false)
+	return p
+}
+
+// ReverseWithRotation is Reverse, also returning the rotation matrix from the local east,
+// north, up frame at the point to the local Cartesian frame at the origin
+func (l *LocalCartesian) ReverseWithRotation(x_m, y_m, z_m float64) (GeodeticPosition, RotationMatrix) {
+	return l._int_reverse(x_m, y_m, z_m, true)
+}
+
+// LookAngles finds the slant range, azimuth, and elevation of the point lat_deg, lon_deg,
+// h_m as seen from the origin
+func (l *LocalCartesian) LookAngles(lat_deg, lon_deg, h_m float64) LookAngles {
+	c := l.Forward(lat_deg, lon_deg, h_m)
+	horiz := math.Hypot(c.XM, c.YM)
+	return LookAngles{
+		RangeM:       math.Hypot(horiz, c.ZM),
+		AzimuthDeg:   atan2_deg(c.XM, c.YM),
+		ElevationDeg: atan2_deg(c.ZM, horiz),
+	}
+}
+
+func (l *LocalCartesian) _int_forward(lat, lon, h float64, wantm bool) (Cartesian, RotationMatrix) {
+	c, m := l.earth._int_forward(lat, lon, h, wantm)
+	xc, yc, zc := c.XM-l.x0, c.YM-l.y0, c.ZM-l.z0
+	r := &l.r
+	res := Cartesian{
+		XM: r[0]*xc + r[3]*yc + r[6]*zc,
+		YM: r[1]*xc + r[4]*yc + r[7]*zc,
+		ZM: r[2]*xc + r[5]*yc + r[8]*zc,
+	}
+	if wantm {
+		m = l._matrix_multiply(m)
+	}
+	return res, m
+}
+
+func (l *LocalCartesian) _int_reverse(x, y, z float64, wantm bool) (GeodeticPosition, RotationMatrix) {
+	r := &l.r
+	xc := l.x0 + r[0]*x + r[1]*y + r[2]*z
+	yc := l.y0 + r[3]*x + r[4]*y + r[5]*z
+	zc := l.z0 + r[6]*x + r[7]*y + r[8]*z
+	p, m := l.earth._int_reverse(xc, yc, zc, wantm)
+	if wantm {
+		m = l._matrix_multiply(m)
+	}
+	return p, m
+}
+
+// _matrix_multiply returns r' . m, where r is the rotation matrix at the origin
+func (l *LocalCartesian) _matrix_multiply(m RotationMatrix) RotationMatrix {
+	var res RotationMatrix
+	for i := range res {
+		row, col := i/3, i%3
+		res[i] = l.r[row]*m[col] + l.r[row+3]*m[col+3] + l.r[row+6]*m[col+6]
+	}
+	return res
+}
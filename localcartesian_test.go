@@ -0,0 +1,86 @@
+package geographiclibgo
+
+import (
+	"math"
+	"testing"
+)
+
+func TestLocalCartesian(t *testing.T) {
+	// From the CartConvert documentation
+	l := NewLocalCartesian(Wgs84Geocentric(), 33, 44, 20)
+	got := l.Forward(33.3, 44.4, 6000)
+	want := Cartesian{37288.97, 33374.29, 5783.64}
+	if !almost_equal(got.XM, want.XM, 0.01) || !almost_equal(got.YM, want.YM, 0.01) ||
+		!almost_equal(got.ZM, want.ZM, 0.01) {
+		t.Errorf("Forward(33.3, 44.4, 6000) = %v; want %v", got, want)
+	}
+
+	p := l.Reverse(got.XM, got.YM, got.ZM)
+	if !almost_equal(p.LatDeg, 33.3, 1e-12) || !almost_equal(p.LonDeg, 44.4, 1e-12) ||
+		!almost_equal(p.HeightM, 6000, 1e-8) {
+		t.Errorf("Reverse() = %v; want {33.3 44.4 6000}", p)
+	}
+
+	// The origin maps to 0, 0, 0
+	if o := l.Forward(33, 44, 20); !almost_equal(math.Hypot(o.XM, math.Hypot(o.YM, o.ZM)), 0, 1e-8) {
+		t.Errorf("Forward(origin) = %v; want {0 0 0}", o)
+	}
+}
+
+func TestLocalCartesianReset(t *testing.T) {
+	l := NewLocalCartesian(Wgs84Geocentric(), 0, 0, 0)
+	l.Reset(33, 404, 20)
+	if l.LatitudeOrigin() != 33 || l.LongitudeOrigin() != 44 || l.HeightOrigin() != 20 {
+		t.Errorf("origin = (%v, %v, %v); want (33, 44, 20)",
+			l.LatitudeOrigin(), l.LongitudeOrigin(), l.HeightOrigin())
+	}
+	if got := l.Forward(33.3, 44.4, 6000); !almost_equal(got.XM, 37288.97, 0.005) {
+		t.Errorf("x after Reset = %v; want %v", got.XM, 37288.97)
+	}
+}
+
+func TestLocalCartesianRotation(t *testing.T) {
+	// At the origin, the rotation matrix is the identity
+	l := NewLocalCartesian(Wgs84Geocentric(), 33, 44, 20)
+	_, m := l.ForwardWithRotation(33, 44, 100)
+	for i := range m {
+		want := 0.0
+		if i%4 == 0 {
+			want = 1
+		}
+		if !almost_equal(m[i], want, 1e-15) {
+			t.Errorf("M[%v] = %v; want %v", i, m[i], want)
+		}
+	}
+
+	// Moving up 1 m at a point moves along the last column of the rotation matrix
+	c, m := l.ForwardWithRotation(33.3, 44.4, 6000)
+	c1 := l.Forward(33.3, 44.4, 6001)
+	for i, d := range []float64{c1.XM - c.XM, c1.YM - c.YM, c1.ZM - c.ZM} {
+		if !almost_equal(d, m[3*i+2], 1e-8) {
+			t.Errorf("up[%v] = %v; want %v", i, m[3*i+2], d)
+		}
+	}
+	_, mr := l.ReverseWithRotation(c.XM, c.YM, c.ZM)
+	for i := range m {
+		if !almost_equal(mr[i], m[i], 1e-14) {
+			t.Errorf("reverse M[%v] = %v; want %v", i, mr[i], m[i])
+		}
+	}
+}
+
+func TestLocalCartesianLookAngles(t *testing.T) {
+	l := NewLocalCartesian(Wgs84Geocentric(), 33, 44, 20)
+
+	// Directly overhead
+	got := l.LookAngles(33, 44, 10020)
+	if !almost_equal(got.RangeM, 10000, 1e-8) || !almost_equal(got.ElevationDeg, 90, 1e-9) {
+		t.Errorf("LookAngles(overhead) = %v; want range 10000, elevation 90", got)
+	}
+
+	// A distant point on the ellipsoid due east is below the horizon
+	got = l.LookAngles(33, 46, 20)
+	if !(got.ElevationDeg < 0) || !almost_equal(got.AzimuthDeg, 90, 1) {
+		t.Errorf("LookAngles(east) = %v; want azimuth ~90, elevation < 0", got)
+	}
+}
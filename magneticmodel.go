@@ -0,0 +1,380 @@
+package geographiclibgo
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// _MAGNETIC_IDLENGTH is the length of the ID which starts a magnetic coefficient file
+const _MAGNETIC_IDLENGTH int = 8
+
+// MagneticField is the magnetic field of the earth at a point and time together with its
+// rate of change (secular variation). The components are in the local east, north, up
+// frame.
+type MagneticField struct {
+	EastNT              float64 // Easterly component of the field [nT]
+	NorthNT             float64 // Northerly component of the field [nT]
+	UpNT                float64 // Vertical (up) component of the field [nT]
+	EastNTPerYr         float64 // Rate of change of EastNT [nT/yr]
+	NorthNTPerYr        float64 // Rate of change of NorthNT [nT/yr]
+	UpNTPerYr           float64 // Rate of change of UpNT [nT/yr]
+	HorizontalNT        float64 // Horizontal field strength H [nT]
+	TotalNT             float64 // Total field strength F [nT]
+	DeclinationDeg      float64 // Declination D, clockwise from true north [degrees]
+	InclinationDeg      float64 // Inclination I, down from the horizontal [degrees]
+	HorizontalNTPerYr   float64 // Rate of change of H [nT/yr]
+	TotalNTPerYr        float64 // Rate of change of F [nT/yr]
+	DeclinationDegPerYr float64 // Rate of change of D [degrees/yr]
+	InclinationDegPerYr float64 // Rate of change of I [degrees/yr]
+}
+
+// MagneticModel evaluates a model of the earth's magnetic field, such as the World
+// Magnetic Model (WMM) or the International Geomagnetic Reference Field (IGRF). The
+// models are read from the files distributed with GeographicLib, which consist of a
+// metadata file (e.g. wmm2020.wmm) and a binary coefficient file with the same name
+// followed by ".cof". These can be downloaded from
+// https://geographiclib.sourceforge.io/C++/doc/magnetic.html.
+//
+// A model gives the Gauss coefficients (Schmidt semi-normalized spherical harmonic
+// coefficients of the magnetic potential) at one or more epochs. The field is
+// interpolated linearly in time between epochs and extrapolated beyond the last epoch
+// using its secular variation. Positions are geodetic coordinates on the WGS84
+// ellipsoid.
+//
+// This is a port of the MagneticModel class from GeographicLib by Charles Karney.
+type MagneticModel struct {
+	name        string
+	description string
+	date        string
+	id          string
+	a           float64
+	t0          float64
+	dt0         float64
+	tmin        float64
+	tmax        float64
+	hmin        float64
+	hmax        float64
+	nmodels     int
+	nconstants  int
+	nmax        int
+	mmax        int
+	norm        SphericalNormalization
+	harm        []SphericalHarmonic
+	earth       Geocentric
+}
+
+// NewMagneticModel reads a magnetic model from its metadata file, meta, and its
+// coefficient file, coeffs, in the formats used by GeographicLib
+func NewMagneticModel(meta io.Reader, coeffs io.Reader) (MagneticModel, error) {
+	mm := MagneticModel{
+		description: "NONE",
+		date:        "UNKNOWN",
+		a:           math.NaN(),
+		t0:          math.NaN(),
+		dt0:         1,
+		tmin:        math.Inf(-1),
+		tmax:        math.Inf(1),
+		hmin:        math.Inf(-1),
+		hmax:        math.Inf(1),
+		nmodels:     1,
+		norm:        SchmidtNormalization,
+		earth:       Wgs84Geocentric(),
+	}
+	if err := mm._read_metadata(meta); err != nil {
+		return MagneticModel{}, err
+	}
+
+	br := bufio.NewReader(coeffs)
+	if err := read_model_id(br, mm.id); err != nil {
+		return MagneticModel{}, err
+	}
+	mm.nmax, mm.mmax = -1, -1
+	for i := 0; i < mm.nmodels+1+mm.nconstants; i++ {
+		c, err := read_spherical_coeffs(br)
+		if err != nil {
+			return MagneticModel{}, fmt.Errorf("error reading coefficients for model %d: %v", i, err)
+		}
+		if c.m >= 0 && c.c[0] != 0 {
+			return MagneticModel{}, errors.New("a degree 0 term is not permitted")
+		}
+		mm.harm = append(mm.harm, NewSphericalHarmonic(c, mm.a, mm.norm))
+		if c.n > mm.nmax {
+			mm.nmax = c.n
+		}
+		if c.m > mm.mmax {
+			mm.mmax = c.m
+		}
+	}
+	if err := check_model_eof(br); err != nil {
+		return MagneticModel{}, err
+	}
+	return mm, nil
+}
+
+// NewMagneticModelFromFile reads the magnetic model whose metadata file is at path (e.g.
+// /usr/local/share/GeographicLib/magnetic/wmm2020.wmm). The coefficients are read from
+// path + ".cof".
+func NewMagneticModelFromFile(path string) (MagneticModel, error) {
+	meta, err := os.Open(path)
+	if err != nil {
+		return MagneticModel{}, err
+	}
+	defer meta.Close()
+	coeffs, err := os.Open(path + ".cof")
+	if err != nil {
+		return MagneticModel{}, err
+	}
+	defer coeffs.Close()
+	mm, err := NewMagneticModel(meta, coeffs)
+	if err != nil {
+		return MagneticModel{}, fmt.Errorf("%s: %v", path, err)
+	}
+	return mm, nil
+}
+
+func (mm *MagneticModel) _read_metadata(meta io.Reader) error {
+	sc := bufio.NewScanner(meta)
+	if !sc.Scan() || len(sc.Text()) < 6 || !strings.HasPrefix(sc.Text(), "WMMF-") {
+		return errors.New("no WMMF-n header in magnetic model metadata")
+	}
+	version := strings.TrimSpace(sc.Text()[5:])
+	if version != "1" && version != "2" {
+		return fmt.Errorf("unknown magnetic model version %s", version)
+	}
+	for sc.Scan() {
+		key, val, ok := parse_model_line(sc.Text())
+		if !ok {
+			continue
+		}
+		var err error
+		switch key {
+		case "Name":
+			mm.name = val
+		case "Description":
+			mm.description = val
+		case "ReleaseDate":
+			mm.date = val
+		case "Radius":
+			mm.a, err = strconv.ParseFloat(val, 64)
+		case "Type":
+			if val != "Linear" && val != "linear" {
+				return errors.New("only linear magnetic models are supported")
+			}
+		case "Epoch":
+			mm.t0, err = strconv.ParseFloat(val, 64)
+		case "DeltaEpoch":
+			mm.dt0, err = strconv.ParseFloat(val, 64)
+		case "NumModels":
+			mm.nmodels, err = strconv.Atoi(val)
+		case "NumConstants":
+			mm.nconstants, err = strconv.Atoi(val)
+		case "MinTime":
+			mm.tmin, err = strconv.ParseFloat(val, 64)
+		case "MaxTime":
+			mm.tmax, err = strconv.ParseFloat(val, 64)
+		case "MinHeight":
+			mm.hmin, err = strconv.ParseFloat(val, 64)
+		case "MaxHeight":
+			mm.hmax, err = strconv.ParseFloat(val, 64)
+		case "Normalization":
+			mm.norm, err = parse_model_normalization(val)
+		case "ByteOrder":
+			err = check_model_byte_order(val)
+		case "ID":
+			mm.id = val
+		}
+		if err != nil {
+			return fmt.Errorf("bad %s in magnetic model metadata: %v", key, err)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return err
+	}
+	if !(mm.a > 0 && !math.IsInf(mm.a, 1)) {
+		return errors.New("reference radius must be positive")
+	}
+	if !(mm.t0 > 0) {
+		return errors.New("epoch time not defined")
+	}
+	if mm.tmin >= mm.tmax {
+		return errors.New("min time exceeds max time")
+	}
+	if mm.hmin >= mm.hmax {
+		return errors.New("min height exceeds max height")
+	}
+	if len(mm.id) != _MAGNETIC_IDLENGTH {
+		return fmt.Errorf("invalid ID %q", mm.id)
+	}
+	if mm.nmodels < 1 {
+		return errors.New("NumModels must be positive")
+	}
+	if mm.nconstants != 0 && mm.nconstants != 1 {
+		return errors.New("NumConstants must be 0 or 1")
+	}
+	if !(mm.dt0 > 0) {
+		if mm.nmodels > 1 {
+			return errors.New("DeltaEpoch must be positive")
+		}
+		mm.dt0 = 1
+	}
+	return nil
+}
+
+// Field returns the magnetic field at time t_yr (a decimal year, e.g. 2021.5) at
+// latitude lat_deg, longitude lon_deg, and height h_m above the WGS84 ellipsoid. The
+// model is evaluated even if t_yr or h_m lie outside the range given by MinTime,
+// MaxTime, MinHeight, and MaxHeight, but the results are then unreliable.
+func (mm *MagneticModel) Field(t_yr, lat_deg, lon_deg, h_m float64) MagneticField {
+	t := t_yr - mm.t0
+	n := int(math.Floor(t / mm.dt0))
+	if n > mm.nmodels-1 {
+		n = mm.nmodels - 1
+	}
+	if n < 0 {
+		n = 0
+	}
+	interpolate := n+1 < mm.nmodels
+	t -= float64(n) * mm.dt0
+	p, m := mm.earth.ForwardWithRotation(lat_deg, lon_deg, h_m)
+
+	// The gradients of the potentials in geocentric coordinates
+	_, bx, by, bz := mm.harm[n].Gradient(p.XM, p.YM, p.ZM)
+	_, bxt, byt, bzt := mm.harm[n+1].Gradient(p.XM, p.YM, p.ZM)
+	if interpolate {
+		// Convert to a time derivative
+		bxt = (bxt - bx) / mm.dt0
+		byt = (byt - by) / mm.dt0
+		bzt = (bzt - bz) / mm.dt0
+	}
+	bx += t * bxt
+	by += t * byt
+	bz += t * bzt
+	if mm.nconstants > 0 {
+		_, bxc, byc, bzc := mm.harm[mm.nmodels+1].Gradient(p.XM, p.YM, p.ZM)
+		bx += bxc
+		by += byc
+		bz += bzc
+	}
+	// B = -a * grad(V)
+	bx, by, bz = -mm.a*bx, -mm.a*by, -mm.a*bz
+	bxt, byt, bzt = -mm.a*bxt, -mm.a*byt, -mm.a*bzt
+
+	// Rotate into the local east, north, up frame
+	var f MagneticField
+	f.EastNT = m[0]*bx + m[3]*by + m[6]*bz
+	f.NorthNT = m[1]*bx + m[4]*by + m[7]*bz
+	f.UpNT = m[2]*bx + m[5]*by + m[8]*bz
+	f.EastNTPerYr = m[0]*bxt + m[3]*byt + m[6]*bzt
+	f.NorthNTPerYr = m[1]*bxt + m[4]*byt + m[7]*bzt
+	f.UpNTPerYr = m[2]*bxt + m[5]*byt + m[8]*bzt
+	f._set_components()
+	return f
+}
+
+// _set_components computes H, F, D, and I and their rates of change from the east,
+// north, and up components of the field
+func (f *MagneticField) _set_components() {
+	bx, by, bz := f.EastNT, f.NorthNT, f.UpNT
+	bxt, byt, bzt := f.EastNTPerYr, f.NorthNTPerYr, f.UpNTPerYr
+	h := math.Hypot(bx, by)
+	ht := math.Hypot(bxt, byt)
+	if h != 0 {
+		ht = (bx*bxt + by*byt) / h
+	}
+	if h != 0 {
+		f.DeclinationDeg = atan2_deg(bx, by)
+		f.DeclinationDegPerYr = (by*bxt - bx*byt) / sq(h) * RAD2DEG
+	} else {
+		f.DeclinationDeg = atan2_deg(bxt, byt)
+		f.DeclinationDegPerYr = 0
+	}
+	ff := math.Hypot(h, bz)
+	ft := math.Hypot(ht, bzt)
+	if ff != 0 {
+		ft = (h*ht + bz*bzt) / ff
+		f.InclinationDeg = atan2_deg(-bz, h)
+		f.InclinationDegPerYr = (bz*ht - h*bzt) / sq(ff) * RAD2DEG
+	} else {
+		f.InclinationDeg = atan2_deg(-bzt, ht)
+		f.InclinationDegPerYr = 0
+	}
+	f.HorizontalNT, f.HorizontalNTPerYr = h, ht
+	f.TotalNT, f.TotalNTPerYr = ff, ft
+}
+
+// MagneticAzimuth converts the true azimuth azi_deg (clockwise from true north, as
+// returned by InverseCalc*) at time t_yr and at latitude lat_deg, longitude lon_deg, and
+// height h_m to a magnetic bearing (clockwise from magnetic north) by subtracting the
+// declination. The result is in [-180, 180].
+func (mm *MagneticModel) MagneticAzimuth(t_yr, lat_deg, lon_deg, h_m, azi_deg float64) float64 {
+	f := mm.Field(t_yr, lat_deg, lon_deg, h_m)
+	d, e := ang_diff(f.DeclinationDeg, azi_deg)
+	return d + e
+}
+
+// Name returns the name of the model
+func (mm *MagneticModel) Name() string {
+	return mm.name
+}
+
+// Description returns the description of the model, or "NONE"
+func (mm *MagneticModel) Description() string {
+	return mm.description
+}
+
+// DateTime returns the release date of the model, or "UNKNOWN"
+func (mm *MagneticModel) DateTime() string {
+	return mm.date
+}
+
+// Degree returns the maximum degree of the spherical harmonic series
+func (mm *MagneticModel) Degree() int {
+	return mm.nmax
+}
+
+// Order returns the maximum order of the spherical harmonic series
+func (mm *MagneticModel) Order() int {
+	return mm.mmax
+}
+
+// MinTime returns the earliest time for which the model should be used [decimal years]
+func (mm *MagneticModel) MinTime() float64 {
+	return mm.tmin
+}
+
+// MaxTime returns the latest time for which the model should be used [decimal years]
+func (mm *MagneticModel) MaxTime() float64 {
+	return mm.tmax
+}
+
+// MinHeight returns the lowest height for which the model should be used [meters]
+func (mm *MagneticModel) MinHeight() float64 {
+	return mm.hmin
+}
+
+// MaxHeight returns the greatest height for which the model should be used [meters]
+func (mm *MagneticModel) MaxHeight() float64 {
+	return mm.hmax
+}
+
+// ReferenceRadius returns the reference radius of the spherical harmonic series [meters]
+func (mm *MagneticModel) ReferenceRadius() float64 {
+	return mm.a
+}
+
+// EquatorialRadius returns the equatorial radius of the WGS84 ellipsoid, on which
+// positions are given [meters]
+func (mm *MagneticModel) EquatorialRadius() float64 {
+	return mm.earth.EquatorialRadius()
+}
+
+// Flattening returns the flattening of the WGS84 ellipsoid, on which positions are given
+func (mm *MagneticModel) Flattening() float64 {
+	return mm.earth.Flattening()
+}
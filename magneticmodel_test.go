@@ -0,0 +1,312 @@
+package geographiclibgo
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const test_magnetic_radius = 6371200.0
+
+// test_magnetic_meta is the metadata for a synthetic dipole model with epochs at 2000 and
+// 2005. NumModels and NumConstants are filled in by make_magnetic_model.
+const test_magnetic_meta = `WMMF-2
+# A synthetic model for testing
+Name            testdipole
+Description     Synthetic dipole model
+ReleaseDate     2026-10-16
+Radius          6371200
+Type            linear
+Epoch           2000
+DeltaEpoch      5
+NumModels       %d
+NumConstants    %d
+MinTime         2000
+MaxTime         2010
+MinHeight       -1000
+MaxHeight       600000
+Normalization   Schmidt
+ByteOrder       little
+ID              TESTDIP1
+`
+
+// make_magnetic_model returns the metadata and coefficient files of a degree 1 model.
+// Each element of dipoles is g10, g11, h11 for one set of coefficients: the models at
+// each epoch, then the secular variation, then the constant terms.
+func make_magnetic_model(nmodels, nconstants int, dipoles [][3]float64) (string, []byte) {
+	meta := fmt.Sprintf(test_magnetic_meta, nmodels, nconstants)
+	var b bytes.Buffer
+	b.WriteString("TESTDIP1")
+	for _, d := range dipoles {
+		write_spherical_coeffs(&b, []float64{0, d[0], d[1]}, []float64{d[2]}, 1, 1)
+	}
+	return meta, b.Bytes()
+}
+
+func new_test_magnetic_model(t testing.TB, nmodels, nconstants int, dipoles [][3]float64) MagneticModel {
+	meta, coeffs := make_magnetic_model(nmodels, nconstants, dipoles)
+	mm, err := NewMagneticModel(strings.NewReader(meta), bytes.NewReader(coeffs))
+	if err != nil {
+		t.Fatalf("NewMagneticModel: %v", err)
+	}
+	return mm
+}
+
+// dipole_field returns the east, north, and up components of the field of the dipole
+// with Gauss coefficients g10, g11, h11 in closed form
+func dipole_field(g10, g11, h11, lat, lon, h float64) (float64, float64, float64) {
+	earth := Wgs84Geocentric()
+	p, m := earth.ForwardWithRotation(lat, lon, h)
+	r := [3]float64{p.XM, p.YM, p.ZM}
+	g := [3]float64{g11, h11, g10}
+	rr := math.Sqrt(r[0]*r[0] + r[1]*r[1] + r[2]*r[2])
+	gr := g[0]*r[0] + g[1]*r[1] + g[2]*r[2]
+	a3 := math.Pow(test_magnetic_radius, 3)
+	var b [3]float64
+	for i := range b {
+		b[i] = -a3 * (g[i]/math.Pow(rr, 3) - 3*gr*r[i]/math.Pow(rr, 5))
+	}
+	return m[0]*b[0] + m[3]*b[1] + m[6]*b[2],
+		m[1]*b[0] + m[4]*b[1] + m[7]*b[2],
+		m[2]*b[0] + m[5]*b[1] + m[8]*b[2]
+}
+
+var magnetic_test_points = [][3]float64{
+	{0, 0, 0},
+	{51.5, -0.1, 100},
+	{-33.9, 151.2, 20},
+	{78.2, 15.6, 5000},
+	{-89.9, 45, 0},
+	{10, -120, 400000},
+}
+
+func TestMagneticModelDipole(t *testing.T) {
+	g10, g11, h11 := -29400.0, -1450.0, 4650.0
+	dg10, dg11, dh11 := 6.5, 7.5, -25.0
+	mm := new_test_magnetic_model(t, 1, 0, [][3]float64{{g10, g11, h11}, {dg10, dg11, dh11}})
+	for _, pt := range magnetic_test_points {
+		for _, dt := range []float64{0, 2.5, 7} {
+			f := mm.Field(2000+dt, pt[0], pt[1], pt[2])
+			e, n, u := dipole_field(g10+dt*dg10, g11+dt*dg11, h11+dt*dh11, pt[0], pt[1], pt[2])
+			et, nt, ut := dipole_field(dg10, dg11, dh11, pt[0], pt[1], pt[2])
+			got := []float64{f.EastNT, f.NorthNT, f.UpNT, f.EastNTPerYr, f.NorthNTPerYr, f.UpNTPerYr}
+			want := []float64{e, n, u, et, nt, ut}
+			for i := range got {
+				if !almost_equal(got[i], want[i], 1e-8) {
+					t.Errorf("Field(%v, %v)[%d] = %v; want %v", 2000+dt, pt, i, got[i], want[i])
+				}
+			}
+		}
+	}
+}
+
+func TestMagneticModelComponents(t *testing.T) {
+	mm := new_test_magnetic_model(t, 1, 0, [][3]float64{{-29400, -1450, 4650}, {6.5, 7.5, -25}})
+	for _, pt := range magnetic_test_points {
+		f := mm.Field(2003, pt[0], pt[1], pt[2])
+		if h := math.Hypot(f.EastNT, f.NorthNT); !almost_equal(f.HorizontalNT, h, 1e-9) {
+			t.Errorf("H at %v = %v; want %v", pt, f.HorizontalNT, h)
+		}
+		if ff := math.Sqrt(sq(f.EastNT) + sq(f.NorthNT) + sq(f.UpNT)); !almost_equal(f.TotalNT, ff, 1e-9) {
+			t.Errorf("F at %v = %v; want %v", pt, f.TotalNT, ff)
+		}
+		if d := math.Atan2(f.EastNT, f.NorthNT) * RAD2DEG; !almost_equal(f.DeclinationDeg, d, 1e-12) {
+			t.Errorf("D at %v = %v; want %v", pt, f.DeclinationDeg, d)
+		}
+		if i := math.Atan2(-f.UpNT, f.HorizontalNT) * RAD2DEG; !almost_equal(f.InclinationDeg, i, 1e-12) {
+			t.Errorf("I at %v = %v; want %v", pt, f.InclinationDeg, i)
+		}
+		// The rates of change agree with finite differences in time
+		dt := 1e-3
+		f1 := mm.Field(2003-dt, pt[0], pt[1], pt[2])
+		f2 := mm.Field(2003+dt, pt[0], pt[1], pt[2])
+		rates := [][3]float64{
+			{f.HorizontalNTPerYr, f1.HorizontalNT, f2.HorizontalNT},
+			{f.TotalNTPerYr, f1.TotalNT, f2.TotalNT},
+			{f.DeclinationDegPerYr, f1.DeclinationDeg, f2.DeclinationDeg},
+			{f.InclinationDegPerYr, f1.InclinationDeg, f2.InclinationDeg},
+		}
+		for i, r := range rates {
+			want := (r[2] - r[1]) / (2 * dt)
+			if !almost_equal(r[0], want, 1e-6*math.Max(1, math.Abs(want))) {
+				t.Errorf("rate %d at %v = %v; want %v", i, pt, r[0], want)
+			}
+		}
+	}
+}
+
+func TestMagneticModelAxialDipole(t *testing.T) {
+	// For an axial dipole the declination vanishes and tan(I) = 2 tan(psi) in terms of
+	// the geocentric latitude psi, measured from the geocentric horizontal
+	mm := new_test_magnetic_model(t, 1, 0, [][3]float64{{-30000, 0, 0}, {0, 0, 0}})
+	earth := Wgs84Geocentric()
+	for _, lat := range []float64{-80, -45, -10, 0, 20, 60, 85} {
+		f := mm.Field(2000, lat, 37, 0)
+		if math.Abs(f.DeclinationDeg) > 1e-12 {
+			t.Errorf("D at lat %v = %v; want 0", lat, f.DeclinationDeg)
+		}
+		p := earth.Forward(lat, 37, 0)
+		psi := math.Atan2(p.ZM, math.Hypot(p.XM, p.YM)) * RAD2DEG
+		want := math.Atan(2*math.Tan(psi*DEG2RAD))*RAD2DEG - (lat - psi)
+		if !almost_equal(f.InclinationDeg, want, 1e-10) {
+			t.Errorf("I at lat %v = %v; want %v", lat, f.InclinationDeg, want)
+		}
+	}
+	// At the equator on the surface, F = |g10|
+	if f := mm.Field(2000, 0, 0, test_magnetic_radius-WGS84_A); !almost_equal(f.TotalNT, 30000, 1e-8) {
+		t.Errorf("F at the equator = %v; want 30000", f.TotalNT)
+	}
+}
+
+func TestMagneticModelEpochs(t *testing.T) {
+	// Two epochs: g10 changes from -30000 in 2000 to -29900 in 2005, and then varies at
+	// 15 nT/yr. A constant term adds 100 nT to g10.
+	mm := new_test_magnetic_model(t, 2, 1, [][3]float64{
+		{-30000, 0, 0}, {-29900, 0, 0}, {15, 0, 0}, {100, 0, 0},
+	})
+	testCases := []struct {
+		desc      string
+		t         float64
+		g10, dg10 float64
+	}{
+		{"first epoch", 2000, -30000, 20},
+		{"between epochs", 2002.5, -29950, 20},
+		{"before first epoch", 1998, -30040, 20},
+		{"second epoch", 2005, -29900, 15},
+		{"after last epoch", 2007, -29870, 15},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			f := mm.Field(tC.t, 40, -100, 0)
+			_, n, u := dipole_field(tC.g10+100, 0, 0, 40, -100, 0)
+			_, nt, ut := dipole_field(tC.dg10, 0, 0, 40, -100, 0)
+			got := []float64{f.NorthNT, f.UpNT, f.NorthNTPerYr, f.UpNTPerYr}
+			want := []float64{n, u, nt, ut}
+			for i := range got {
+				if !almost_equal(got[i], want[i], 1e-8) {
+					t.Errorf("component %d = %v; want %v", i, got[i], want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestMagneticModelAzimuth(t *testing.T) {
+	mm := new_test_magnetic_model(t, 1, 0, [][3]float64{{-29400, -1450, 4650}, {0, 0, 0}})
+	g := Wgs84()
+	lat1, lon1, lat2, lon2 := 40.757954, -73.985548, 41.882609, -87.621978
+	azi1 := g.InverseCalcDistanceAzimuths(lat1, lon1, lat2, lon2).Azimuth1Deg
+	d := mm.Field(2003, lat1, lon1, 0).DeclinationDeg
+	got := mm.MagneticAzimuth(2003, lat1, lon1, 0, azi1)
+	if !almost_equal(got, azi1-d, 1e-12) {
+		t.Errorf("MagneticAzimuth = %v; want %v", got, azi1-d)
+	}
+	// The result is reduced to [-180, 180]
+	if got := mm.MagneticAzimuth(2003, lat1, lon1, 0, -179.9); got < -180 || got > 180 {
+		t.Errorf("MagneticAzimuth = %v; want a value in [-180, 180]", got)
+	}
+}
+
+func TestMagneticModelMetadata(t *testing.T) {
+	mm := new_test_magnetic_model(t, 2, 0, [][3]float64{{-30000, 1, 2}, {-29900, 1, 2}, {15, 0, 0}})
+	if mm.Name() != "testdipole" || mm.Description() != "Synthetic dipole model" ||
+		mm.DateTime() != "2026-10-16" {
+		t.Errorf("Name, Description, DateTime = %q, %q, %q", mm.Name(), mm.Description(), mm.DateTime())
+	}
+	if mm.Degree() != 1 || mm.Order() != 1 {
+		t.Errorf("Degree, Order = %d, %d; want 1, 1", mm.Degree(), mm.Order())
+	}
+	if mm.MinTime() != 2000 || mm.MaxTime() != 2010 || mm.MinHeight() != -1000 || mm.MaxHeight() != 600000 {
+		t.Errorf("time and height limits = %v, %v, %v, %v",
+			mm.MinTime(), mm.MaxTime(), mm.MinHeight(), mm.MaxHeight())
+	}
+	if mm.ReferenceRadius() != test_magnetic_radius || mm.EquatorialRadius() != WGS84_A ||
+		mm.Flattening() != WGS84_F {
+		t.Errorf("radii = %v, %v, %v", mm.ReferenceRadius(), mm.EquatorialRadius(), mm.Flattening())
+	}
+}
+
+func TestMagneticModelFromFile(t *testing.T) {
+	meta, coeffs := make_magnetic_model(1, 0, [][3]float64{{-30000, 0, 0}, {10, 0, 0}})
+	path := filepath.Join(t.TempDir(), "testdipole.wmm")
+	if err := os.WriteFile(path, []byte(meta), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewMagneticModelFromFile(path); err == nil {
+		t.Errorf("NewMagneticModelFromFile succeeded without a coefficient file")
+	}
+	if err := os.WriteFile(path+".cof", coeffs, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	mm, err := NewMagneticModelFromFile(path)
+	if err != nil {
+		t.Fatalf("NewMagneticModelFromFile: %v", err)
+	}
+	if f := mm.Field(2000, 0, 0, test_magnetic_radius-WGS84_A); !almost_equal(f.TotalNT, 30000, 1e-8) {
+		t.Errorf("F at the equator = %v; want 30000", f.TotalNT)
+	}
+}
+
+func TestMagneticModelErrors(t *testing.T) {
+	meta, coeffs := make_magnetic_model(1, 0, [][3]float64{{-30000, 0, 0}, {10, 0, 0}})
+	replace := func(old, new string) string { return strings.Replace(meta, old, new, 1) }
+	testCases := []struct {
+		desc   string
+		meta   string
+		coeffs []byte
+	}{
+		{"no header", replace("WMMF-2", "XMMF-2"), coeffs},
+		{"bad version", replace("WMMF-2", "WMMF-3"), coeffs},
+		{"no radius", replace("Radius          6371200", ""), coeffs},
+		{"bad radius", replace("6371200", "big"), coeffs},
+		{"no epoch", replace("Epoch           2000", ""), coeffs},
+		{"quadratic", replace("linear", "quadratic"), coeffs},
+		{"times", replace("MaxTime         2010", "MaxTime 1990"), coeffs},
+		{"heights", replace("MaxHeight       600000", "MaxHeight -2000"), coeffs},
+		{"no models", replace("NumModels       1", "NumModels 0"), coeffs},
+		{"constants", replace("NumConstants    0", "NumConstants 2"), coeffs},
+		{"short ID", replace("TESTDIP1", "TEST"), coeffs},
+		{"big endian", replace("little", "big"), coeffs},
+		{"normalization", replace("Schmidt", "Ferrers"), coeffs},
+		{"ID mismatch", meta, append([]byte("TESTDIP2"), coeffs[8:]...)},
+		{"truncated", meta, coeffs[:len(coeffs)-4]},
+		{"extra data", meta, append(append([]byte(nil), coeffs...), 0)},
+		{"degree 0 term", meta, func() []byte {
+			var b bytes.Buffer
+			b.WriteString("TESTDIP1")
+			write_spherical_coeffs(&b, []float64{1, 0, 0}, []float64{0}, 1, 1)
+			write_spherical_coeffs(&b, []float64{0, 0, 0}, []float64{0}, 1, 1)
+			return b.Bytes()
+		}()},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			_, err := NewMagneticModel(strings.NewReader(tC.meta), bytes.NewReader(tC.coeffs))
+			if err == nil {
+				t.Errorf("NewMagneticModel succeeded; want error")
+			}
+		})
+	}
+}
+
+func BenchmarkMagneticModelField(b *testing.B) {
+	// A degree 12 model, the size of the WMM
+	C, S := test_spherical_coeffs(12, 12, 0.4)
+	C[0] = 0
+	var buf bytes.Buffer
+	buf.WriteString("TESTDIP1")
+	write_spherical_coeffs(&buf, C, S, 12, 12)
+	write_spherical_coeffs(&buf, C, S, 12, 12)
+	meta := fmt.Sprintf(test_magnetic_meta, 1, 0)
+	mm, err := NewMagneticModel(strings.NewReader(meta), bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		b.Fatalf("NewMagneticModel: %v", err)
+	}
+	for i := 0; i < b.N; i++ {
+		mm.Field(2003, 51.5, -0.1, 100)
+	}
+}
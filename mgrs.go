@@ -0,0 +1,550 @@
+package geographiclibgo
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Constants for the MGRS. Eastings and northings are in units of the 100 km tile unless
+// otherwise noted.
+const (
+	_MGRS_MAXPREC          int   = 11 // Maximum precision is 1 um
+	_MGRS_MULT             int64 = 1000000
+	_MGRS_UTM_ROW_PERIOD   int   = 20
+	_MGRS_UTM_EVEN_ROWSHFT int   = 5
+	_MGRS_MIN_UTM_COL      int   = 1
+	_MGRS_MIN_UTM_N_ROW    int   = 0
+	_MGRS_MAX_UTM_S_ROW    int   = 100
+	_MGRS_MIN_UPS_S_IND    int   = 8
+	_MGRS_MIN_UPS_N_IND    int   = 13
+	_MGRS_UPS_EASTING      int   = 20
+	_MGRS_UTM_N_SHIFT      int   = _MGRS_MAX_UTM_S_ROW - _MGRS_MIN_UTM_N_ROW
+)
+
+const (
+	_MGRS_HEMISPHERES = "SN"
+	_MGRS_UTM_ROW     = "ABCDEFGHJKLMNPQRSTUV"
+	_MGRS_LAT_BAND    = "CDEFGHJKLMNPQRSTUVWX"
+	_MGRS_UPS_BAND    = "ABYZ"
+	_MGRS_DIGITS      = "0123456789"
+)
+
+var (
+	_MGRS_UTM_COLS = [3]string{"ABCDEFGH", "JKLMNPQR", "STUVWXYZ"}
+	_MGRS_UPS_COLS = [4]string{"JKLPQRSTUXYZ", "ABCFGHJKLPQR", "RSTUXYZ", "ABCFGHJ"}
+	_MGRS_UPS_ROWS = [2]string{"ABCDEFGHJKLMNPQRSTUVWXYZ", "ABCDEFGHJKLMNP"}
+)
+
+// MGRSResult is a position decoded from an MGRS string
+type MGRSResult struct {
+	Zone      int     // UTM zone in [1, 60], or ZONE_UPS for UPS
+	Northp    bool    // Whether the point is in the northern hemisphere
+	EastingM  float64 // Easting of the point, including the false easting [meters]
+	NorthingM float64 // Northing of the point, including the false northing [meters]
+	Prec      int     // Precision of the string, the number of digits in each coordinate
+}
+
+// MGRS converts between UTM/UPS coordinates and Military Grid Reference System strings,
+// following NGA.SIG.0012_2.0.0_UTMUPS. An MGRS string is the grid zone designator (the
+// UTM zone number and latitude band letter, or a UPS band letter), a two letter 100 km
+// square identifier, and an even number of digits giving the easting and northing
+// within the square. The precision prec is the number of digits in each coordinate:
+//   - prec = -1 - grid zone designator only, e.g. 38S
+//   - prec = 0 - 100 km square, e.g. 38SMB
+//   - prec = 5 - 1 m, e.g. 38SMB4414084706
+//   - prec = 11 - 1 um, the maximum
+//
+// Coordinates are truncated, not rounded, so the string refers to the square containing
+// the point.
+type MGRS struct {
+	utmups UTMUPS
+}
+
+// NewMGRS creates an MGRS for the ellipsoid with equatorial radius a [meters] and
+// flattening f. The ellipsoid is only used to convert to and from latitude and
+// longitude and to find the latitude band when this is not given.
+func NewMGRS(a, f float64) MGRS {
+	return MGRS{utmups: NewUTMUPS(a, f)}
+}
+
+// Wgs84MGRS is a convenience function that creates an MGRS for the WGS84 ellipsoid
+func Wgs84MGRS() MGRS {
+	return NewMGRS(WGS84_A, WGS84_F)
+}
+
+// mgrs_lookup returns the index of the (case-insensitive) character c in s, or -1
+func mgrs_lookup(s string, c byte) int {
+	if 'a' <= c && c <= 'z' {
+		c -= 'a' - 'A'
+	}
+	return strings.IndexByte(s, c)
+}
+
+// utm_row returns the true row index in [-90, 95), given the band index iband in
+// [-10, 10), the column index icol in [0, 8) with origin at an easting of 100 km, and the
+// periodic row index irow in [0, 20) with origin at the equator. This returns
+// _MGRS_MAX_UTM_S_ROW if irow and iband are incompatible.
+func utm_row(iband, icol, irow int) int {
+	// Estimate center row number for latitude band; 90 deg = 100 tiles and 1 band = 8 deg
+	c := 100.0 * float64(8*iband+4) / 90.0
+	northp := 0.0
+	if iband >= 0 {
+		northp = 1.0
+	}
+	// These are safe bounds on the rows
+	minrow := -90
+	if iband > -10 {
+		minrow = int(math.Floor(c - 4.3 - 0.1*northp))
+	}
+	maxrow := 94
+	if iband < 9 {
+		maxrow = int(math.Floor(c + 4.4 - 0.1*northp))
+	}
+	baserow := (minrow+maxrow)/2 - _MGRS_UTM_ROW_PERIOD/2
+	// Offset irow by a multiple of the row period so that it is in
+	// [baserow, baserow + period) (with a little bit of slop)
+	irow = (irow-baserow+_MGRS_MAX_UTM_S_ROW)%_MGRS_UTM_ROW_PERIOD + baserow
+	if !(irow >= minrow && irow <= maxrow) {
+		// Outside the safe bounds, so need to check. Northings of 7100 km and 8000 km
+		// intersect band boundaries: y = 7100 km in scol = 2 (x = [300, 400] km and
+		// [600, 700] km) and y = 8000 km in scol = 1 (x = [200, 300] km and [700, 800] km).
+		// This holds for all the ellipsoids given in NGA.SIG.0012_2.0.0_UTMUPS.
+		sband := iband // Fold [-10, -1] -> [9, 0]
+		if iband < 0 {
+			sband = -iband - 1
+		}
+		srow := irow // Fold [-90, -1] -> [89, 0]
+		if irow < 0 {
+			srow = -irow - 1
+		}
+		scol := icol // Fold [4, 7] -> [3, 0]
+		if icol >= 4 {
+			scol = -icol + 7
+		}
+		if !((srow == 70 && sband == 8 && scol >= 2) ||
+			(srow == 71 && sband == 7 && scol <= 2) ||
+			(srow == 79 && sband == 9 && scol >= 1) ||
+			(srow == 80 && sband == 8 && scol <= 1)) {
+			irow = _MGRS_MAX_UTM_S_ROW
+		}
+	}
+	return irow
+}
+
+// mgrs_check_coords returns an error if x, y is outside the MGRS range for UTM (if utmp)
+// or UPS in the hemisphere northp. Coordinates lying exactly on the (excluded) upper
+// limit are shifted down slightly. UTM northings are folded into the correct hemisphere,
+// so the (possibly corrected) hemisphere and coordinates are returned.
+func mgrs_check_coords(utmp, northp bool, x, y float64) (bool, float64, float64, error) {
+	// The smallest length s.t. 1.0e7 - eps < 1.0e7 (approx 1.9 nm). Use half the
+	// circumference here because a northing of 19500 km is legal in the southern
+	// hemisphere.
+	eps := math.Ldexp(1, -(53 - 25))
+	ind := utmups_index(utmp, northp)
+	ix := int(math.Floor(x / _MGRS_TILE))
+	iy := int(math.Floor(y / _MGRS_TILE))
+	minx := int(_UTMUPS_MIN_EASTING[ind] / _MGRS_TILE)
+	maxx := int(_UTMUPS_MAX_EASTING[ind] / _MGRS_TILE)
+	miny := int(_UTMUPS_MIN_NORTHING[ind] / _MGRS_TILE)
+	maxy := int(_UTMUPS_MAX_NORTHING[ind] / _MGRS_TILE)
+	system := "UPS"
+	if utmp {
+		system = "UTM"
+	}
+	hemi := _MGRS_HEMISPHERES[:1]
+	if northp {
+		hemi = _MGRS_HEMISPHERES[1:]
+	}
+	if !(ix >= minx && ix < maxx) {
+		if ix == maxx && x == _UTMUPS_MAX_EASTING[ind] {
+			x -= eps
+		} else {
+			return northp, x, y, fmt.Errorf(
+				"easting %dkm not in MGRS/%s range for %s hemisphere [%dkm, %dkm)",
+				int(math.Floor(x/1000)), system, hemi, minx*100, maxx*100,
+			)
+		}
+	}
+	if !(iy >= miny && iy < maxy) {
+		if iy == maxy && y == _UTMUPS_MAX_NORTHING[ind] {
+			y -= eps
+		} else {
+			return northp, x, y, fmt.Errorf(
+				"northing %dkm not in MGRS/%s range for %s hemisphere [%dkm, %dkm)",
+				int(math.Floor(y/1000)), system, hemi, miny*100, maxy*100,
+			)
+		}
+	}
+
+	// Correct the UTM northing and hemisphere if necessary
+	if utmp {
+		if northp && iy < _MGRS_MIN_UTM_N_ROW {
+			northp = false
+			y += float64(_MGRS_UTM_N_SHIFT) * _MGRS_TILE
+		} else if !northp && iy >= _MGRS_MAX_UTM_S_ROW {
+			if y == float64(_MGRS_MAX_UTM_S_ROW)*_MGRS_TILE {
+				// If on the equator retain the southern hemisphere
+				y -= eps
+			} else {
+				northp = true
+				y -= float64(_MGRS_UTM_N_SHIFT) * _MGRS_TILE
+			}
+		}
+	}
+	return northp, x, y, nil
+}
+
+// Forward converts the UTM/UPS coordinates x_m, y_m in zone and hemisphere northp to an
+// MGRS string with precision prec in [-1, 11]. The latitude band is estimated from the
+// coordinates, which requires converting them to latitude when the estimate straddles
+// a band boundary. If zone is ZONE_INVALID or x_m or y_m is NaN, the result is "INVALID".
+func (m *MGRS) Forward(zone int, northp bool, x_m, y_m float64, prec int) (string, error) {
+	var lat float64
+	if zone > 0 {
+		// Does a rough estimate for latitude determine the latitude band?
+		ys := y_m
+		if !northp {
+			ys -= float64(_MGRS_UTM_N_SHIFT) * _MGRS_TILE
+		}
+		ys /= _MGRS_TILE
+		if math.Abs(ys) < 1 {
+			// Accurate enough estimate near the equator
+			lat = 0.9 * ys
+		} else {
+			// The poleward bound is a fit from above of lat(x, y) for x = 500 km and
+			// y = [0, 950] km; the equatorward bound is a fit from below of lat(x, y) for
+			// x = 900 km and y = [0, 950] km.
+			latp := 0.901*ys + math.Copysign(0.135, ys)
+			late := 0.902 * ys * (1 - 1.85e-6*ys*ys)
+			if latitude_band(latp) == latitude_band(late) {
+				lat = latp
+			} else {
+				// The bounds straddle a band boundary so compute lat accurately
+				r, err := m.utmups.Reverse(zone, northp, x_m, y_m)
+				if err != nil {
+					return "", err
+				}
+				lat = r.LatDeg
+			}
+		}
+	}
+	// The latitude isn't needed for UPS or for ZONE_INVALID
+	return m.ForwardWithLatitude(zone, northp, x_m, y_m, lat, prec)
+}
+
+// ForwardWithLatitude is Forward with the latitude of the point, lat_deg, given. This is
+// used to determine the latitude band, and it returns an error if lat_deg is inconsistent
+// with the UTM coordinates. For UPS, lat_deg is ignored.
+func (m *MGRS) ForwardWithLatitude(
+	zone int,
+	northp bool,
+	x_m, y_m, lat_deg float64,
+	prec int,
+) (string, error) {
+	// The smallest angle s.t. 90 - angeps < 90 (approx 50e-12 arcsec)
+	angeps := math.Ldexp(1, -(53 - 7))
+	if zone == ZONE_INVALID || math.IsNaN(x_m) || math.IsNaN(y_m) || math.IsNaN(lat_deg) {
+		return "INVALID", nil
+	}
+	if !(zone >= ZONE_UPS && zone <= ZONE_MAX_UTM) {
+		return "", fmt.Errorf("zone %d not in [0, 60]", zone)
+	}
+	if !(prec >= -1 && prec <= _MGRS_MAXPREC) {
+		return "", fmt.Errorf("MGRS precision %d not in [-1, %d]", prec, _MGRS_MAXPREC)
+	}
+	utmp := zone != ZONE_UPS
+	northp, x_m, y_m, err := mgrs_check_coords(utmp, northp, x_m, y_m)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	b.Grow(2 + 3 + 2*_MGRS_MAXPREC)
+	if utmp {
+		fmt.Fprintf(&b, "%02d", zone)
+	}
+	ix := int64(math.Floor(x_m * float64(_MGRS_MULT)))
+	iy := int64(math.Floor(y_m * float64(_MGRS_MULT)))
+	tile := _MGRS_MULT * int64(_MGRS_TILE)
+	xh := int(ix / tile)
+	yh := int(iy / tile)
+	if utmp {
+		// Correct fuzziness in latitude near the equator
+		var iband int
+		if math.Abs(lat_deg) < angeps {
+			if northp {
+				iband = 0
+			} else {
+				iband = -1
+			}
+		} else {
+			iband = latitude_band(lat_deg)
+		}
+		icol := xh - _MGRS_MIN_UTM_COL
+		irow := utm_row(iband, icol, yh%_MGRS_UTM_ROW_PERIOD)
+		rowoff := _MGRS_MAX_UTM_S_ROW
+		if northp {
+			rowoff = _MGRS_MIN_UTM_N_ROW
+		}
+		if irow != yh-rowoff {
+			return "", fmt.Errorf("latitude %v is inconsistent with UTM coordinates", lat_deg)
+		}
+		shift := 0
+		if (zone-1)&1 != 0 {
+			shift = _MGRS_UTM_EVEN_ROWSHFT
+		}
+		b.WriteByte(_MGRS_LAT_BAND[10+iband])
+		if prec >= 0 {
+			b.WriteByte(_MGRS_UTM_COLS[(zone-1)%3][icol])
+			b.WriteByte(_MGRS_UTM_ROW[(yh+shift)%_MGRS_UTM_ROW_PERIOD])
+		}
+	} else {
+		eastp := xh >= _MGRS_UPS_EASTING
+		iband := 0
+		if northp {
+			iband += 2
+		}
+		if eastp {
+			iband++
+		}
+		minind := _MGRS_MIN_UPS_S_IND
+		if northp {
+			minind = _MGRS_MIN_UPS_N_IND
+		}
+		coloff := minind
+		if eastp {
+			coloff = _MGRS_UPS_EASTING
+		}
+		hemi := 0
+		if northp {
+			hemi = 1
+		}
+		b.WriteByte(_MGRS_UPS_BAND[iband])
+		if prec >= 0 {
+			b.WriteByte(_MGRS_UPS_COLS[iband][xh-coloff])
+			b.WriteByte(_MGRS_UPS_ROWS[hemi][yh-minind])
+		}
+	}
+	if prec > 0 {
+		ix -= tile * int64(xh)
+		iy -= tile * int64(yh)
+		d := int64(1)
+		for i := 0; i < _MGRS_MAXPREC-prec; i++ {
+			d *= 10
+		}
+		ix /= d
+		iy /= d
+		digits := make([]byte, 2*prec)
+		for c := prec - 1; c >= 0; c-- {
+			digits[c] = _MGRS_DIGITS[ix%10]
+			ix /= 10
+			digits[c+prec] = _MGRS_DIGITS[iy%10]
+			iy /= 10
+		}
+		b.Write(digits)
+	}
+	return b.String(), nil
+}
+
+// Reverse converts the MGRS string mgrs to UTM/UPS coordinates. If centerp is true, the
+// coordinates are those of the center of the square given by the string; otherwise they
+// are those of its south-west corner. A string consisting of just a grid zone designator
+// gives a point near the center of the zone with Prec = -1. A string beginning with "INV"
+// gives ZONE_INVALID, NaN coordinates, and Prec = -2. The letters may be upper or lower
+// case.
+func (m *MGRS) Reverse(mgrs string, centerp bool) (MGRSResult, error) {
+	n := len(mgrs)
+	if n >= 3 && strings.EqualFold(mgrs[:3], "INV") {
+		nan := math.NaN()
+		return MGRSResult{Zone: ZONE_INVALID, EastingM: nan, NorthingM: nan, Prec: -2}, nil
+	}
+	p := 0
+	zone := 0
+	for p < n {
+		i := mgrs_lookup(_MGRS_DIGITS, mgrs[p])
+		if i < 0 {
+			break
+		}
+		zone = 10*zone + i
+		p++
+	}
+	if p > 0 && !(zone >= ZONE_MIN_UTM && zone <= ZONE_MAX_UTM) {
+		return MGRSResult{}, fmt.Errorf("zone %d not in [1, 60]", zone)
+	}
+	if p > 2 {
+		return MGRSResult{}, fmt.Errorf("more than 2 digits at start of MGRS %s", mgrs[:p])
+	}
+	if n-p < 1 {
+		return MGRSResult{}, fmt.Errorf("MGRS string too short %s", mgrs)
+	}
+	utmp := zone != ZONE_UPS
+	bands := _MGRS_UPS_BAND
+	system := "UPS"
+	if utmp {
+		bands = _MGRS_LAT_BAND
+		system = "UTM"
+	}
+	iband := mgrs_lookup(bands, mgrs[p])
+	if iband < 0 {
+		return MGRSResult{}, fmt.Errorf(
+			"band letter %c not in %s set %s", mgrs[p], system, bands,
+		)
+	}
+	p++
+	northp := iband >= 2
+	if utmp {
+		northp = iband >= 10
+	}
+
+	if p == n {
+		// Grid zone only (ignore centerp). deg is the approximate length of a degree of
+		// meridian arc in units of the tile.
+		deg := float64(_MGRS_UTM_N_SHIFT) / 90.0
+		var x, y float64
+		if utmp {
+			// Pick the central meridian except for 31V
+			if zone == 31 && iband == 17 {
+				x = 4 * _MGRS_TILE
+			} else {
+				x = 5 * _MGRS_TILE
+			}
+			// Pick the center of the 8 degree latitude band
+			y = math.Floor(8*(float64(iband)-9.5)*deg+0.5) * _MGRS_TILE
+			if !northp {
+				y += float64(_MGRS_UTM_N_SHIFT) * _MGRS_TILE
+			}
+		} else {
+			// Pick a point at latitude 86N or 86S and longitude 90E or 90W
+			sign := -1.0
+			if iband&1 != 0 {
+				sign = 1.0
+			}
+			x = (sign*math.Floor(4*deg+0.5) + float64(_MGRS_UPS_EASTING)) * _MGRS_TILE
+			y = float64(_MGRS_UPS_EASTING) * _MGRS_TILE
+		}
+		return MGRSResult{Zone: zone, Northp: northp, EastingM: x, NorthingM: y, Prec: -1}, nil
+	} else if n-p < 2 {
+		return MGRSResult{}, fmt.Errorf("missing row letter in %s", mgrs)
+	}
+
+	hemi := 0
+	if northp {
+		hemi = 1
+	}
+	var cols, rows string
+	if utmp {
+		cols = _MGRS_UTM_COLS[(zone-1)%3]
+		rows = _MGRS_UTM_ROW
+	} else {
+		cols = _MGRS_UPS_COLS[iband]
+		rows = _MGRS_UPS_ROWS[hemi]
+	}
+	icol := mgrs_lookup(cols, mgrs[p])
+	if icol < 0 {
+		where := "UPS band " + mgrs[p-1:p]
+		if utmp {
+			where = "zone " + mgrs[:p-1]
+		}
+		return MGRSResult{}, fmt.Errorf(
+			"column letter %c not in %s set %s", mgrs[p], where, cols,
+		)
+	}
+	p++
+	irow := mgrs_lookup(rows, mgrs[p])
+	if irow < 0 {
+		where := "UPS " + _MGRS_HEMISPHERES[hemi:hemi+1]
+		if utmp {
+			where = "UTM"
+		}
+		return MGRSResult{}, fmt.Errorf("row letter %c not in %s set %s", mgrs[p], where, rows)
+	}
+	p++
+	if utmp {
+		if (zone-1)&1 != 0 {
+			irow = (irow + _MGRS_UTM_ROW_PERIOD - _MGRS_UTM_EVEN_ROWSHFT) % _MGRS_UTM_ROW_PERIOD
+		}
+		iband -= 10
+		irow = utm_row(iband, icol, irow)
+		if irow == _MGRS_MAX_UTM_S_ROW {
+			return MGRSResult{}, fmt.Errorf(
+				"block %s not in zone/band %s", mgrs[p-2:p], mgrs[:p-2],
+			)
+		}
+		if !northp {
+			irow += _MGRS_UTM_N_SHIFT
+		}
+		icol += _MGRS_MIN_UTM_COL
+	} else {
+		minind := _MGRS_MIN_UPS_S_IND
+		if northp {
+			minind = _MGRS_MIN_UPS_N_IND
+		}
+		if iband&1 != 0 {
+			icol += _MGRS_UPS_EASTING
+		} else {
+			icol += minind
+		}
+		irow += minind
+	}
+
+	prec := (n - p) / 2
+	unit := 1.0
+	x := float64(icol)
+	y := float64(irow)
+	for i := 0; i < prec; i++ {
+		unit *= 10
+		ix := mgrs_lookup(_MGRS_DIGITS, mgrs[p+i])
+		iy := mgrs_lookup(_MGRS_DIGITS, mgrs[p+i+prec])
+		if ix < 0 || iy < 0 {
+			return MGRSResult{}, fmt.Errorf("encountered a non-digit in %s", mgrs[p:])
+		}
+		x = 10*x + float64(ix)
+		y = 10*y + float64(iy)
+	}
+	if (n-p)%2 != 0 {
+		if mgrs_lookup(_MGRS_DIGITS, mgrs[n-1]) < 0 {
+			return MGRSResult{}, fmt.Errorf("encountered a non-digit in %s", mgrs[p:])
+		}
+		return MGRSResult{}, fmt.Errorf("not an even number of digits in %s", mgrs[p:])
+	}
+	if prec > _MGRS_MAXPREC {
+		return MGRSResult{}, fmt.Errorf("more than %d digits in %s", 2*_MGRS_MAXPREC, mgrs[p:])
+	}
+	if centerp {
+		unit *= 2
+		x = 2*x + 1
+		y = 2*y + 1
+	}
+	return MGRSResult{
+		Zone:      zone,
+		Northp:    northp,
+		EastingM:  _MGRS_TILE * x / unit,
+		NorthingM: _MGRS_TILE * y / unit,
+		Prec:      prec,
+	}, nil
+}
+
+// FromLatLon converts the point p to an MGRS string with precision prec in [-1, 11], using
+// the standard UTM/UPS zone for the point.
+func (m *MGRS) FromLatLon(p LatLon, prec int) (string, error) {
+	r, err := m.utmups.Forward(p.LatDeg, p.LonDeg)
+	if err != nil {
+		return "", err
+	}
+	return m.ForwardWithLatitude(r.Zone, r.Northp, r.EastingM, r.NorthingM, p.LatDeg, prec)
+}
+
+// ToLatLon converts the MGRS string mgrs to the latitude and longitude of the center of
+// the square it refers to.
+func (m *MGRS) ToLatLon(mgrs string) (LatLon, error) {
+	r, err := m.Reverse(mgrs, true)
+	if err != nil {
+		return LatLon{}, err
+	}
+	ll, err := m.utmups.Reverse(r.Zone, r.Northp, r.EastingM, r.NorthingM)
+	if err != nil {
+		return LatLon{}, err
+	}
+	return LatLon{LatDeg: ll.LatDeg, LonDeg: ll.LonDeg}, nil
+}
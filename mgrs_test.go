@@ -0,0 +1,170 @@
+package geographiclibgo
+
+import (
+	"math"
+	"testing"
+)
+
+func TestMGRSFromLatLon(t *testing.T) {
+	testCases := []struct {
+		desc string
+		p    LatLon
+		prec int
+		want string
+	}{
+		// From the GeoConvert documentation
+		{"1 m", LatLon{33.3, 44.4}, 5, "38SMB4414084706"},
+		{"grid zone only", LatLon{33.3, 44.4}, -1, "38S"},
+		{"100 km", LatLon{33.3, 44.4}, 0, "38SMB"},
+		{"10 km", LatLon{33.3, 44.4}, 1, "38SMB48"},
+		{"1 km", LatLon{33.3, 44.4}, 3, "38SMB441847"},
+		{"1 mm", LatLon{33.3, 44.4}, 8, "38SMB4414054484706355"},
+		{"south", LatLon{-33.3, 44.4}, 2, "38HMJ4415"},
+		{"north pole", LatLon{90, 0}, 5, "ZAH0000000000"},
+		{"south pole", LatLon{-90, 0}, 5, "BAN0000000000"},
+		{"Norway exception", LatLon{60, 5}, 0, "32VKM"},
+		{"Svalbard exception", LatLon{78, 10}, -1, "33X"},
+		{"single digit zone", LatLon{10, -150}, 0, "06PSS"},
+	}
+	m := Wgs84MGRS()
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			got, err := m.FromLatLon(tC.p, tC.prec)
+			if err != nil {
+				t.Fatalf("FromLatLon() error = %v", err)
+			}
+			if got != tC.want {
+				t.Errorf("FromLatLon(%v, %v) = %v; want %v", tC.p, tC.prec, got, tC.want)
+			}
+		})
+	}
+}
+
+func TestMGRSForwardReverse(t *testing.T) {
+	m := Wgs84MGRS()
+	u := Wgs84UTMUPS()
+	for lat := -89.5; lat < 90; lat += 7 {
+		for lon := -179.5; lon < 180; lon += 13 {
+			r, err := u.Forward(lat, lon)
+			if err != nil {
+				t.Fatalf("Forward(%v, %v) error = %v", lat, lon, err)
+			}
+			for _, prec := range []int{0, 5, 11} {
+				// Forward without the latitude must find the same band
+				s, err := m.Forward(r.Zone, r.Northp, r.EastingM, r.NorthingM, prec)
+				if err != nil {
+					t.Fatalf("Forward(%v, %v) error = %v", lat, lon, err)
+				}
+				want, _ := m.FromLatLon(LatLon{lat, lon}, prec)
+				if s != want {
+					t.Errorf("Forward(%v, %v) = %v; want %v", lat, lon, s, want)
+				}
+
+				got, err := m.Reverse(s, false)
+				if err != nil {
+					t.Fatalf("Reverse(%v) error = %v", s, err)
+				}
+				res := 100000 * math.Pow(10, -float64(prec))
+				if got.Zone != r.Zone || got.Northp != r.Northp || got.Prec != prec {
+					t.Errorf("Reverse(%v) = %v%v prec %v; want %v%v prec %v",
+						s, got.Zone, got.Northp, got.Prec, r.Zone, r.Northp, prec)
+				}
+				if dx := r.EastingM - got.EastingM; !(dx >= 0 && dx < res) {
+					t.Errorf("Reverse(%v) easting = %v; want in (%v - %v, %v]",
+						s, got.EastingM, r.EastingM, res, r.EastingM)
+				}
+				if dy := r.NorthingM - got.NorthingM; !(dy >= 0 && dy < res) {
+					t.Errorf("Reverse(%v) northing = %v; want in (%v - %v, %v]",
+						s, got.NorthingM, r.NorthingM, res, r.NorthingM)
+				}
+			}
+		}
+	}
+}
+
+func TestMGRSReverse(t *testing.T) {
+	m := Wgs84MGRS()
+	got, err := m.Reverse("38smb4414084706", false)
+	if err != nil {
+		t.Fatalf("Reverse() error = %v", err)
+	}
+	if got.Zone != 38 || !got.Northp || got.EastingM != 444140 ||
+		got.NorthingM != 3684706 || got.Prec != 5 {
+		t.Errorf("Reverse() = %+v; want 38 north 444140 3684706 prec 5", got)
+	}
+	got, _ = m.Reverse("38SMB4414084706", true)
+	if got.EastingM != 444140.5 || got.NorthingM != 3684706.5 {
+		t.Errorf("Reverse() center = %v, %v; want 444140.5 3684706.5", got.EastingM, got.NorthingM)
+	}
+
+	ll, err := m.ToLatLon("38SMB4414084706")
+	if err != nil {
+		t.Fatalf("ToLatLon() error = %v", err)
+	}
+	if !almost_equal(ll.LatDeg, 33.3, 1e-5) || !almost_equal(ll.LonDeg, 44.4, 1e-5) {
+		t.Errorf("ToLatLon() = %v; want {33.3 44.4}", ll)
+	}
+
+	if got, _ = m.Reverse("38S", false); got.Prec != -1 || got.Zone != 38 || !got.Northp {
+		t.Errorf("Reverse(\"38S\") = %+v; want zone 38 north prec -1", got)
+	}
+	if got, _ = m.Reverse("INVALID", false); got.Zone != ZONE_INVALID || !math.IsNaN(got.EastingM) {
+		t.Errorf("Reverse(\"INVALID\") = %+v; want invalid zone", got)
+	}
+}
+
+func TestMGRSErrors(t *testing.T) {
+	m := Wgs84MGRS()
+	testCases := []struct {
+		desc string
+		mgrs string
+	}{
+		{"zone too big", "61SMB"},
+		{"too many zone digits", "038SMB"},
+		{"too short", "38"},
+		{"bad UTM band", "38ZMB"},
+		{"bad UPS band", "CAH"},
+		{"missing row letter", "38SM"},
+		{"bad column letter", "38SAB"},
+		{"bad row letter", "38SMW"},
+		{"block not in band", "38SMR"},
+		{"odd number of digits", "38SMB441"},
+		{"non-digit", "38SMB44X0"},
+		{"too many digits", "38SMB441408470644140847066"},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			if _, err := m.Reverse(tC.mgrs, false); err == nil {
+				t.Errorf("Reverse(%q) error = nil; want non-nil", tC.mgrs)
+			}
+		})
+	}
+
+	if _, err := m.ForwardWithLatitude(38, true, 444140, 3684706, 33.3, 12); err == nil {
+		t.Errorf("Forward() with prec 12 error = nil; want non-nil")
+	}
+	if _, err := m.ForwardWithLatitude(38, true, 444140, 3684706, 50, 5); err == nil {
+		t.Errorf("Forward() with inconsistent latitude error = nil; want non-nil")
+	}
+	if _, err := m.ForwardWithLatitude(38, true, 950000, 3684706, 33.3, 5); err == nil {
+		t.Errorf("Forward() with easting 950km error = nil; want non-nil")
+	}
+	if s, err := m.Forward(ZONE_INVALID, true, 0, 0, 5); err != nil || s != "INVALID" {
+		t.Errorf("Forward(ZONE_INVALID) = %q, %v; want \"INVALID\"", s, err)
+	}
+}
+
+func BenchmarkMGRSFromLatLon(b *testing.B) {
+	m := Wgs84MGRS()
+	p := LatLon{33.3, 44.4}
+	for i := 0; i < b.N; i++ {
+		m.FromLatLon(p, 5)
+	}
+}
+
+func BenchmarkMGRSReverse(b *testing.B) {
+	m := Wgs84MGRS()
+	for i := 0; i < b.N; i++ {
+		m.Reverse("38SMB4414084706", true)
+	}
+}
@@ -0,0 +1,95 @@
+package geographiclibgo
+
+import "math"
+
+// NearestResult is the point on a geodesic closest to a given point, together with the
+// along-track and cross-track distances of the given point relative to the geodesic. The
+// cross-track distance is positive if the given point lies to the right of the geodesic,
+// looking along it, and negative if it lies to the left.
+type NearestResult struct {
+	LatDeg      float64 // Latitude of the closest point on the geodesic [degrees]
+	LonDeg      float64 // Longitude of the closest point on the geodesic [degrees]
+	AziDeg      float64 // Azimuth of the geodesic at the closest point [degrees]
+	AlongTrackM float64 // Distance from the start of the geodesic to the closest point [meters]
+	CrossTrackM float64 // Signed distance from the closest point to the given point [meters]
+}
+
+// NearestOnLine finds the point on the (infinite) geodesic line which is closest to the
+// point lat_deg, lon_deg. Of the many such points on a geodesic which wraps around the
+// ellipsoid, the one found is the local minimum reached by starting at the first point
+// of the line. The line must have at least the capabilities STANDARD | DISTANCE_IN.
+//   - line - the geodesic
+//   - lat_deg - Latitude of the point [degrees] [-90.,90.]
+//   - lon_deg - Longitude of the point [degrees] [-180., 180.]
+func (g *Geodesic) NearestOnLine(line GeodesicLine, lat_deg, lon_deg float64) NearestResult {
+	return g._gen_nearest(line, lat_deg, lon_deg, 0.0, math.Inf(-1), math.Inf(1))
+}
+
+// NearestOnSegment finds the point on the geodesic segment from lat1_deg, lon1_deg to
+// lat2_deg, lon2_deg which is closest to the point lat_deg, lon_deg. If the closest point
+// is an end of the segment, the cross-track distance is the distance to that end.
+//   - lat1_deg, lon1_deg - Start of the segment [degrees]
+//   - lat2_deg, lon2_deg - End of the segment [degrees]
+//   - lat_deg, lon_deg - The point [degrees]
+func (g *Geodesic) NearestOnSegment(
+	lat1_deg, lon1_deg, lat2_deg, lon2_deg, lat_deg, lon_deg float64,
+) NearestResult {
+	line := g.InverseLineWithCapabilities(
+		lat1_deg, lon1_deg, lat2_deg, lon2_deg, STANDARD|DISTANCE_IN,
+	)
+	s13 := line.Distance()
+	return g._gen_nearest(line, lat_deg, lon_deg, s13/2.0, 0.0, s13)
+}
+
+// _gen_nearest finds the point on line closest to lat, lon, starting at the distance s0
+// and restricting the distance along the line to [smin, smax].
+//
+// The foot point is where the geodesic from the line to the point meets the line at a
+// right angle. If the geodesic from the current foot point to the point has length s12,
+// reduced length m12, and geodesic scale M12, and makes an angle A with the line, then
+// moving the foot by ds along the line changes A by -ds * sin(A) * M12/m12. So the Newton
+// step for cos(A) = 0 is approximately ds = m12 * cos(A) / M12. This is computed as
+// R * atan2(m12 * cos(A), R * M12), which has the same derivative at ds = 0, so
+// convergence is still quadratic, but which is exact on a sphere of radius R and limits
+// the size of the step when the point is far from the line.
+func (g *Geodesic) _gen_nearest(
+	line GeodesicLine,
+	lat, lon float64,
+	s0, smin, smax float64,
+) NearestResult {
+	rR := math.Sqrt(g.c2)
+	s := math.Max(smin, math.Min(smax, s0))
+	var lat2, lon2, azi2, s12, azi1p, m12, M12 float64
+	for i := uint64(0); i < g.maxit2_; i++ {
+		_, lat2, lon2, azi2, _, _, _, _, _ = line._gen_position(
+			false, s, LATITUDE|LONGITUDE|AZIMUTH,
+		)
+		_, _, azi1p, _, m12, M12, _, _ = g._gen_inverse_azi(
+			lat2, lon2, lat, lon, DISTANCE|AZIMUTH|REDUCEDLENGTH|GEODESICSCALE,
+		)
+		A, dA := ang_diff(azi2, azi1p)
+		_, cosA := sincosde(A, dA)
+		ds := rR * math.Atan2(m12*cosA, rR*M12)
+		snew := math.Max(smin, math.Min(smax, s+ds))
+		ds = snew - s
+		s = snew
+		// Also break if ds is NaN
+		if !(math.Abs(ds) > g.a*g.tol1_) {
+			break
+		}
+	}
+
+	_, lat2, lon2, azi2, _, _, _, _, _ = line._gen_position(
+		false, s, LATITUDE|LONGITUDE|AZIMUTH,
+	)
+	_, s12, azi1p, _, _, _, _, _ = g._gen_inverse_azi(lat2, lon2, lat, lon, DISTANCE|AZIMUTH)
+	A, dA := ang_diff(azi2, azi1p)
+	sinA, _ := sincosde(A, dA)
+	return NearestResult{
+		LatDeg:      lat2,
+		LonDeg:      lon2,
+		AziDeg:      azi2,
+		AlongTrackM: s,
+		CrossTrackM: math.Copysign(s12, sinA),
+	}
+}
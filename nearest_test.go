@@ -0,0 +1,128 @@
+package geographiclibgo
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNearestOnLineEquator(t *testing.T) {
+	// The meridian through a point meets the equator at right angles, so the foot of a
+	// point on the equator is directly south or north of it
+	g := Wgs84()
+	line := NewGeodesicLine(g, 0, 0, 90)
+	testCases := []struct {
+		desc      string
+		lat, lon  float64
+		wantAlong float64
+		wantCross float64
+	}{
+		{"north", 10, 20, WGS84_A * 20 * DEG2RAD, -1105854.8332343723},
+		{"south", -10, 170, WGS84_A * 170 * DEG2RAD, 1105854.8332343723},
+		{"on the line", 0, 20, WGS84_A * 20 * DEG2RAD, 0},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			got := g.NearestOnLine(line, tC.lat, tC.lon)
+			if !almost_equal(got.AlongTrackM, tC.wantAlong, 1e-6) {
+				t.Errorf("along = %v; want %v", got.AlongTrackM, tC.wantAlong)
+			}
+			if !almost_equal(got.CrossTrackM, tC.wantCross, 1e-6) {
+				t.Errorf("cross = %v; want %v", got.CrossTrackM, tC.wantCross)
+			}
+			if !almost_equal(got.LatDeg, 0, 1e-12) || !almost_equal(got.LonDeg, tC.lon, 1e-12) {
+				t.Errorf("foot = %v, %v; want 0, %v", got.LatDeg, got.LonDeg, tC.lon)
+			}
+			if got.AziDeg != 90 {
+				t.Errorf("azi = %v; want 90", got.AziDeg)
+			}
+		})
+	}
+}
+
+func TestNearestOnLinePerpendicular(t *testing.T) {
+	// At the foot, the geodesic to the point is perpendicular to the line, and its length
+	// is the cross-track distance
+	g := Wgs84()
+	line := NewGeodesicLine(g, 40.64, -73.78, 50)
+	got := g.NearestOnLine(line, 55, 10)
+	inv := g.InverseCalcAll(got.LatDeg, got.LonDeg, 55, 10)
+	d, _ := ang_diff(got.AziDeg, inv.Azimuth1Deg)
+	if !almost_equal(d, -90, 1e-9) {
+		t.Errorf("angle at foot = %v; want -90", d)
+	}
+	if !almost_equal(got.CrossTrackM, -inv.DistanceM, 1e-6) {
+		t.Errorf("cross = %v; want %v", got.CrossTrackM, -inv.DistanceM)
+	}
+	pos := line.PositionStandard(got.AlongTrackM)
+	if !almost_equal(pos.Lat2Deg, got.LatDeg, 1e-12) || !almost_equal(pos.Lon2Deg, got.LonDeg, 1e-12) {
+		t.Errorf("foot = %v, %v; want %v, %v", got.LatDeg, got.LonDeg, pos.Lat2Deg, pos.Lon2Deg)
+	}
+
+	// No nearby point on the line is closer
+	for _, ds := range []float64{-1000, -1, 1, 1000} {
+		p := line.PositionStandard(got.AlongTrackM + ds)
+		if s := g.InverseCalcDistance(p.Lat2Deg, p.Lon2Deg, 55, 10); s < inv.DistanceM {
+			t.Errorf("point %v m further along is %v m away; want more than %v",
+				ds, s, inv.DistanceM)
+		}
+	}
+}
+
+func TestNearestOnLineSphere(t *testing.T) {
+	// On a sphere, sin(cross / R) = sin(d / R) * sin(A), where d is the distance from the
+	// start to the point and A is the angle there between the line and the point
+	R := 6.4e6
+	g := NewGeodesic(R, 0)
+	line := NewGeodesicLine(g, 10, 20, 30)
+	got := g.NearestOnLine(line, 40, 80)
+	inv := g.InverseCalcAll(10, 20, 40, 80)
+	A := (inv.Azimuth1Deg - 30) * DEG2RAD
+	want := R * math.Asin(math.Sin(inv.DistanceM/R)*math.Sin(A))
+	if !almost_equal(got.CrossTrackM, want, 1e-6) {
+		t.Errorf("cross = %v; want %v", got.CrossTrackM, want)
+	}
+	wantAlong := R * math.Atan(math.Tan(inv.DistanceM/R)*math.Cos(A))
+	if !almost_equal(got.AlongTrackM, wantAlong, 1e-6) {
+		t.Errorf("along = %v; want %v", got.AlongTrackM, wantAlong)
+	}
+}
+
+func TestNearestOnSegment(t *testing.T) {
+	g := Wgs84()
+	testCases := []struct {
+		desc      string
+		lat, lon  float64
+		wantLon   float64
+		wantAlong float64
+	}{
+		{"within", -5, 5, 5, WGS84_A * 5 * DEG2RAD},
+		{"before start", 5, -3, 0, 0},
+		{"after end", 5, 20, 10, WGS84_A * 10 * DEG2RAD},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			got := g.NearestOnSegment(0, 0, 0, 10, tC.lat, tC.lon)
+			if !almost_equal(got.LonDeg, tC.wantLon, 1e-12) || got.LatDeg != 0 {
+				t.Errorf("foot = %v, %v; want 0, %v", got.LatDeg, got.LonDeg, tC.wantLon)
+			}
+			if !almost_equal(got.AlongTrackM, tC.wantAlong, 1e-6) {
+				t.Errorf("along = %v; want %v", got.AlongTrackM, tC.wantAlong)
+			}
+			want := g.InverseCalcDistance(got.LatDeg, got.LonDeg, tC.lat, tC.lon)
+			if !almost_equal(math.Abs(got.CrossTrackM), want, 1e-6) {
+				t.Errorf("|cross| = %v; want %v", math.Abs(got.CrossTrackM), want)
+			}
+			if (tC.lat > 0) != (got.CrossTrackM < 0) {
+				t.Errorf("cross = %v; want the sign to give the side of the segment",
+					got.CrossTrackM)
+			}
+		})
+	}
+}
+
+func BenchmarkNearestOnSegment(b *testing.B) {
+	g := Wgs84()
+	for i := 0; i < b.N; i++ {
+		g.NearestOnSegment(40.64, -73.78, 51.47, -0.45, 55, -30)
+	}
+}
@@ -0,0 +1,299 @@
+package geographiclibgo
+
+import "math"
+
+// _NORMAL_MAXIT bounds the iterations when finding the flattening from J2
+const _NORMAL_MAXIT int = 100
+
+// GravityResult is the acceleration due to gravity at a point, in the local east, north,
+// up frame, together with the corresponding potential. For a disturbance (see
+// GravityModel.Disturbance), these are the differences between the true and the normal
+// values.
+type GravityResult struct {
+	EastMPerS2       float64 // Easterly component of the acceleration [m/s^2]
+	NorthMPerS2      float64 // Northerly component of the acceleration [m/s^2]
+	UpMPerS2         float64 // Upward component of the acceleration [m/s^2]
+	PotentialM2PerS2 float64 // Potential [m^2/s^2]
+}
+
+// NormalGravity is the normal gravity field of the earth: the field of a rotating
+// ellipsoid whose surface is an equipotential. It is specified by the equatorial radius
+// a, the mass constant GM, the angular velocity omega, and either the flattening f or
+// the dynamical form factor J2. The potential is evaluated in closed form in ellipsoidal
+// coordinates following W. A. Heiskanen and H. Moritz, Physical Geodesy (Freeman, 1967),
+// Sec. 2-7 to 2-9, rearranged so that it is accurate even when f is small.
+//
+// This is a port of the NormalGravity class from GeographicLib by Charles Karney.
+type NormalGravity struct {
+	a       float64
+	GM      float64
+	omega   float64
+	f       float64
+	J2      float64
+	omega2  float64
+	aomega2 float64
+	e2      float64
+	ep2     float64
+	b       float64
+	E2      float64
+	Q0      float64
+	k       float64
+	gammae  float64
+	gammap  float64
+	U0      float64
+	earth   Geocentric
+}
+
+// NewNormalGravity creates a NormalGravity for the ellipsoid with equatorial radius a
+// [meters] and flattening f, mass constant GM [m^3/s^2], and angular velocity omega
+// [rad/s]
+func NewNormalGravity(a, GM, omega, f float64) NormalGravity {
+	g := NormalGravity{a: a, GM: GM, omega: omega, f: f}
+	g._init()
+	return g
+}
+
+// NewNormalGravityJ2 creates a NormalGravity for the ellipsoid with equatorial radius a
+// [meters] and dynamical form factor J2, mass constant GM [m^3/s^2], and angular velocity
+// omega [rad/s]. The flattening is found from J2; it is NaN if there is no solution.
+func NewNormalGravityJ2(a, GM, omega, J2 float64) NormalGravity {
+	g := NormalGravity{a: a, GM: GM, omega: omega, f: normal_j2_to_flattening(a, GM, omega, J2)}
+	g._init()
+	return g
+}
+
+// Wgs84NormalGravity is a convenience function that creates the normal gravity field of
+// WGS84
+func Wgs84NormalGravity() NormalGravity {
+	return NewNormalGravity(WGS84_A, 3986004.418e8, 7292115e-11, WGS84_F)
+}
+
+// Grs80NormalGravity is a convenience function that creates the normal gravity field of
+// GRS80, which is defined by its dynamical form factor
+func Grs80NormalGravity() NormalGravity {
+	return NewNormalGravityJ2(6378137, 3986005e8, 7292115e-11, 108263e-8)
+}
+
+func (g *NormalGravity) _init() {
+	g.omega2 = sq(g.omega)
+	g.aomega2 = sq(g.omega * g.a)
+	g.e2 = g.f * (2 - g.f)
+	g.ep2 = g.e2 / sq(1-g.f)
+	g.b = g.a * (1 - g.f)
+	g.E2 = sq(g.a) * g.e2
+	g.earth = NewGeocentric(g.a, g.f)
+	// H+M, Eq 2-70; m = omega^2 a^2 b / GM
+	m := g.aomega2 * g.b / g.GM
+	g.Q0 = normal_qf(g.ep2)
+	G0 := normal_gf(g.ep2)
+	g.J2 = normal_j2(g.e2, m, g.Q0)
+	// H+M, Eqs 2-141 and 2-142
+	g.gammae = g.GM / (g.a * g.b) * (1 - m - m*G0/(6*g.Q0))
+	g.gammap = g.GM / sq(g.a) * (1 + m*G0/(3*g.Q0))
+	// Somigliana's formula is (gammae + k sin^2(phi)) / sqrt(1 - e2 sin^2(phi))
+	g.k = (1-g.f)*g.gammap - g.gammae
+	// H+M, Eq 2-61
+	g.U0 = g.GM/g.b*normal_atanzz(g.ep2) + g.aomega2/3
+}
+
+// normal_atanzz returns atan(z)/z where z = sqrt(y), continued to y < 0 as
+// atanh(sqrt(-y))/sqrt(-y)
+func normal_atanzz(y float64) float64 {
+	z := math.Sqrt(math.Abs(y))
+	if y == 0 {
+		return 1
+	} else if y > 0 {
+		return math.Atan(z) / z
+	}
+	return math.Atanh(z) / z
+}
+
+// normal_qf returns Q(y) = q(z)/z^3 where z = sqrt(y) and
+// q(z) = ((1 + 3/z^2) * atan(z) - 3/z)/2 (H+M, Eq 2-57 with z = E/u). Q(0) = 2/15.
+func normal_qf(y float64) float64 {
+	if !(4*math.Abs(y) < 1) {
+		return ((1+3/y)*normal_atanzz(y) - 3/y) / (2 * y)
+	}
+	// sum(j >= 0) (-y)^j * 2(j+1) / ((2j+3)(2j+5))
+	s, yj := 0.0, 1.0
+	for j := 0; j < _NORMAL_MAXIT; j++ {
+		t := yj * float64(2*(j+1)) / float64((2*j+3)*(2*j+5))
+		s += t
+		if math.Abs(t) <= get_epsilon()/2*math.Abs(s) {
+			break
+		}
+		yj *= -y
+	}
+	return s
+}
+
+// normal_gf returns G(y) = q'(z)/z^2 where z = sqrt(y) and
+// q'(z) = 3 * (1 + 1/z^2) * (1 - atan(z)/z) - 1 (H+M, Eq 2-67 with z = E/u). G(0) = 2/5.
+func normal_gf(y float64) float64 {
+	if !(4*math.Abs(y) < 1) {
+		return (3*(1+1/y)*(1-normal_atanzz(y)) - 1) / y
+	}
+	// sum(j >= 0) (-y)^j * 6 / ((2j+3)(2j+5))
+	s, yj := 0.0, 1.0
+	for j := 0; j < _NORMAL_MAXIT; j++ {
+		t := yj * 6 / float64((2*j+3)*(2*j+5))
+		s += t
+		if math.Abs(t) <= get_epsilon()/2*math.Abs(s) {
+			break
+		}
+		yj *= -y
+	}
+	return s
+}
+
+// normal_j2 returns J2 = e2/3 * (1 - 2 m e' / (15 q0)) (H+M, Eq 2-90) written in terms of
+// Q0 = q0/e'^3
+func normal_j2(e2, m, Q0 float64) float64 {
+	return e2/3 - (1-e2)*2*m/(45*Q0)
+}
+
+// normal_j2_to_flattening solves normal_j2 for the flattening by iterating on e2
+func normal_j2_to_flattening(a, GM, omega, J2 float64) float64 {
+	e2 := 3 * J2
+	for i := 0; i < _NORMAL_MAXIT; i++ {
+		if !(e2 < 1) {
+			return math.NaN()
+		}
+		e2m := 1 - e2
+		m := sq(omega*a) * a * math.Sqrt(e2m) / GM
+		e2a := 3*J2 + e2m*2*m/(15*normal_qf(e2/e2m))
+		if math.Abs(e2a-e2) <= get_epsilon()*math.Max(math.Abs(e2), 1e-3) {
+			e2 = e2a
+			return e2 / (1 + math.Sqrt(1-e2))
+		}
+		e2 = e2a
+	}
+	return math.NaN()
+}
+
+// _gravitational returns the gravitational potential (excluding the centrifugal
+// potential) at distance p from the axis and height z above the equatorial plane,
+// together with its derivatives with respect to p and z. It uses the ellipsoidal
+// coordinates u and beta for which p = sqrt(u^2 + E^2) cos(beta), z = u sin(beta).
+func (g *NormalGravity) _gravitational(p, z float64) (V, gp, gz float64) {
+	d := sq(p) + sq(z) - g.E2
+	s := math.Sqrt(sq(d) + 4*g.E2*sq(z))
+	var u2 float64
+	if d >= 0 {
+		u2 = (d + s) / 2
+	} else {
+		u2 = 2 * g.E2 * sq(z) / (s - d)
+	}
+	u := math.Sqrt(u2)
+	w2 := u2 + g.E2
+	w := math.Sqrt(w2)
+	sbet, cbet := z/u, p/w
+	y := g.E2 / u2
+	Q := normal_qf(y)
+	t := sq(sbet) - 1.0/3
+	// H+M, Eq 2-126 with q(u)/q0 = (b/u)^3 Q(y)/Q0
+	c := g.aomega2 / 2 * g.b * sq(g.b) / g.Q0
+	V = g.GM/u*normal_atanzz(y) + c*Q/(u*u2)*t
+	// Derivatives with respect to u and beta, using dq/du = -E q'(u)/(u^2 + E^2)
+	Vu := -g.GM/w2 - c*normal_gf(y)/(u2*w2)*t
+	Vb := 2 * c * Q / (u * u2) * sbet * cbet
+	den := u2 + g.E2*sq(sbet)
+	gp = w * (Vu*u*cbet - Vb*sbet) / den
+	gz = (Vu*w2*sbet + Vb*u*cbet) / den
+	return V, gp, gz
+}
+
+// _potential returns the normal potential U (gravitational plus centrifugal) at the
+// geocentric point x, y, z together with its gradient
+func (g *NormalGravity) _potential(x, y, z float64) (U, gx, gy, gz float64) {
+	p := math.Hypot(x, y)
+	V, gp, gzz := g._gravitational(p, z)
+	if p != 0 {
+		gx, gy = gp*x/p, gp*y/p
+	}
+	return V + g.omega2*sq(p)/2, gx + g.omega2*x, gy + g.omega2*y, gzz
+}
+
+// Gravity returns the normal gravity at latitude lat_deg and height h_m above the
+// ellipsoid. The easterly component is zero; the potential is the normal potential U.
+func (g *NormalGravity) Gravity(lat_deg, h_m float64) GravityResult {
+	c, m := g.earth.ForwardWithRotation(lat_deg, 0, h_m)
+	U, gx, gy, gz := g._potential(c.XM, c.YM, c.ZM)
+	return GravityResult{
+		NorthMPerS2:      m[1]*gx + m[4]*gy + m[7]*gz,
+		UpMPerS2:         m[2]*gx + m[5]*gy + m[8]*gz,
+		PotentialM2PerS2: U,
+	}
+}
+
+// SurfaceGravity returns the magnitude of normal gravity on the surface of the
+// ellipsoid at latitude lat_deg [m/s^2], using Somigliana's formula
+func (g *NormalGravity) SurfaceGravity(lat_deg float64) float64 {
+	sphi, _ := sincosd(lat_fix(lat_deg))
+	return (g.gammae + g.k*sq(sphi)) / math.Sqrt(1-g.e2*sq(sphi))
+}
+
+// Jn returns the zonal coefficient J_n of the normal gravitational potential, where the
+// potential is GM/r * (1 - sum(n >= 2) J_n (a/r)^n P_n(sin(psi))). J_n vanishes for odd
+// n and J_0 = -1.
+func (g *NormalGravity) Jn(n int) float64 {
+	if n&1 != 0 || n < 0 {
+		return 0
+	}
+	if n == 0 {
+		return -1
+	}
+	n /= 2
+	// H+M, Eq 2-92 written as (-e2)^n * (1 - n + 5 n J2 / e2) to allow e2 = 0
+	e2n1 := 1.0
+	for j := 1; j < n; j++ {
+		e2n1 *= -g.e2
+	}
+	return (3*g.e2*e2n1*float64(1-n) + 15*float64(n)*g.J2*e2n1) / float64((2*n+1)*(2*n+3))
+}
+
+// EquatorialRadius returns the equatorial radius of the ellipsoid [meters]
+func (g *NormalGravity) EquatorialRadius() float64 {
+	return g.a
+}
+
+// Flattening returns the flattening of the ellipsoid
+func (g *NormalGravity) Flattening() float64 {
+	return g.f
+}
+
+// MassConstant returns the mass constant GM [m^3/s^2]
+func (g *NormalGravity) MassConstant() float64 {
+	return g.GM
+}
+
+// AngularVelocity returns the angular velocity of the ellipsoid [rad/s]
+func (g *NormalGravity) AngularVelocity() float64 {
+	return g.omega
+}
+
+// DynamicalFormFactor returns the dynamical form factor J2
+func (g *NormalGravity) DynamicalFormFactor() float64 {
+	return g.J2
+}
+
+// EquatorialGravity returns normal gravity at the equator [m/s^2]
+func (g *NormalGravity) EquatorialGravity() float64 {
+	return g.gammae
+}
+
+// PolarGravity returns normal gravity at the poles [m/s^2]
+func (g *NormalGravity) PolarGravity() float64 {
+	return g.gammap
+}
+
+// SurfacePotential returns the normal potential U0 on the surface of the ellipsoid
+// [m^2/s^2]
+func (g *NormalGravity) SurfacePotential() float64 {
+	return g.U0
+}
+
+// Earth returns the Geocentric for the ellipsoid
+func (g *NormalGravity) Earth() Geocentric {
+	return g.earth
+}
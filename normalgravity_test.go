@@ -0,0 +1,165 @@
+package geographiclibgo
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNormalGravityGrs80(t *testing.T) {
+	// Defining and derived constants from H. Moritz, Geodetic Reference System 1980,
+	// J. Geodesy 74, 128-133 (2000)
+	g := Grs80NormalGravity()
+	testCases := []struct {
+		desc string
+		got  float64
+		want float64
+		thr  float64
+	}{
+		{"1/f", 1 / g.Flattening(), 298.257222101, 1e-9},
+		{"gammae", g.EquatorialGravity(), 9.7803267715, 1e-10},
+		{"gammap", g.PolarGravity(), 9.8321863685, 1e-10},
+		{"U0", g.SurfacePotential(), 62636860.850, 1e-3},
+		{"J2", g.DynamicalFormFactor(), 108263e-8, 1e-18},
+		// The published values of J4, J6, and J8 have 14 decimal places
+		{"J4", g.Jn(4), -0.00000237091222, 5e-15},
+		{"J6", g.Jn(6), 0.00000000608347, 5e-15},
+		{"J8", g.Jn(8), -0.00000000001427, 5e-15},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			if !almost_equal(tC.got, tC.want, tC.thr) {
+				t.Errorf("%s = %v; want %v", tC.desc, tC.got, tC.want)
+			}
+		})
+	}
+}
+
+func TestNormalGravityWgs84(t *testing.T) {
+	// Constants from NIMA TR8350.2, Department of Defense World Geodetic System 1984,
+	// 3rd edition (2000)
+	g := Wgs84NormalGravity()
+	testCases := []struct {
+		desc string
+		got  float64
+		want float64
+		thr  float64
+	}{
+		{"gammae", g.EquatorialGravity(), 9.7803253359, 1e-10},
+		{"gammap", g.PolarGravity(), 9.8321849378, 1e-10},
+		{"U0", g.SurfacePotential(), 62636851.7146, 1e-4},
+		{"C20", -g.DynamicalFormFactor() / math.Sqrt(5), -0.484166774985e-3, 1e-15},
+		{"J0", g.Jn(0), -1, 1e-300},
+		{"J3", g.Jn(3), 0, 1e-300},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			if !almost_equal(tC.got, tC.want, tC.thr) {
+				t.Errorf("%s = %v; want %v", tC.desc, tC.got, tC.want)
+			}
+		})
+	}
+	if g.EquatorialRadius() != WGS84_A || g.Flattening() != WGS84_F ||
+		g.MassConstant() != 3986004.418e8 || g.AngularVelocity() != 7292115e-11 {
+		t.Errorf("defining constants = %v, %v, %v, %v",
+			g.EquatorialRadius(), g.Flattening(), g.MassConstant(), g.AngularVelocity())
+	}
+}
+
+func TestNormalGravityJ2RoundTrip(t *testing.T) {
+	for _, f := range []float64{WGS84_F, 0, 1.0 / 150, -1.0 / 300} {
+		g := NewNormalGravity(6378137, 3986004.418e8, 7292115e-11, f)
+		g2 := NewNormalGravityJ2(6378137, 3986004.418e8, 7292115e-11, g.DynamicalFormFactor())
+		if !almost_equal(g2.Flattening(), f, 1e-15) {
+			t.Errorf("flattening from J2 = %v; want %v", g2.Flattening(), f)
+		}
+	}
+}
+
+func TestNormalGravitySurface(t *testing.T) {
+	// On the ellipsoid the potential is U0 and gravity is normal to the surface with the
+	// magnitude given by Somigliana's formula
+	for _, f := range []float64{WGS84_F, 1.0 / 150, -1.0 / 300} {
+		g := NewNormalGravity(6378137, 3986004.418e8, 7292115e-11, f)
+		for lat := -90.0; lat <= 90; lat += 7.5 {
+			r := g.Gravity(lat, 0)
+			if !almost_equal(r.PotentialM2PerS2, g.SurfacePotential(), 1e-7) {
+				t.Errorf("f = %v: U(%v, 0) = %v; want %v", f, lat, r.PotentialM2PerS2, g.SurfacePotential())
+			}
+			if math.Abs(r.NorthMPerS2) > 1e-14 || r.EastMPerS2 != 0 {
+				t.Errorf("f = %v: horizontal gravity at %v = %v, %v; want 0", f, lat, r.EastMPerS2, r.NorthMPerS2)
+			}
+			if !almost_equal(-r.UpMPerS2, g.SurfaceGravity(lat), 1e-13) {
+				t.Errorf("f = %v: gravity at %v = %v; want %v", f, lat, -r.UpMPerS2, g.SurfaceGravity(lat))
+			}
+		}
+		if !almost_equal(g.SurfaceGravity(0), g.EquatorialGravity(), 1e-15) ||
+			!almost_equal(g.SurfaceGravity(-90), g.PolarGravity(), 1e-15) {
+			t.Errorf("f = %v: SurfaceGravity(0, -90) = %v, %v", f, g.SurfaceGravity(0), g.SurfaceGravity(-90))
+		}
+	}
+}
+
+func TestNormalGravityGradient(t *testing.T) {
+	// The acceleration is the gradient of the potential
+	g := Wgs84NormalGravity()
+	earth := g.Earth()
+	for _, pt := range [][2]float64{{0, 0}, {30, 1000}, {-60, 50000}, {89, 10}, {45, 2e7}} {
+		c := earth.Forward(pt[0], 25, pt[1])
+		_, gx, gy, gz := g._potential(c.XM, c.YM, c.ZM)
+		d := 0.5
+		fd := func(dx, dy, dz float64) float64 {
+			u1, _, _, _ := g._potential(c.XM+dx, c.YM+dy, c.ZM+dz)
+			u2, _, _, _ := g._potential(c.XM-dx, c.YM-dy, c.ZM-dz)
+			return (u1 - u2) / (2 * d)
+		}
+		for i, want := range []float64{fd(d, 0, 0), fd(0, d, 0), fd(0, 0, d)} {
+			got := []float64{gx, gy, gz}[i]
+			if !almost_equal(got, want, 1e-7) {
+				t.Errorf("at %v: gradient[%d] = %v; want %v", pt, i, got, want)
+			}
+		}
+	}
+}
+
+func TestNormalGravitySphere(t *testing.T) {
+	// Without flattening or rotation, gravity is GM/r^2
+	GM := 3986004.418e8
+	g := NewNormalGravity(6371000, GM, 0, 0)
+	for _, h := range []float64{0, 1000, 1e6} {
+		r := g.Gravity(35, h)
+		want := -GM / sq(6371000+h)
+		if !almost_equal(r.UpMPerS2, want, 1e-14) {
+			t.Errorf("gravity at h = %v: %v; want %v", h, r.UpMPerS2, want)
+		}
+		if !almost_equal(r.PotentialM2PerS2, GM/(6371000+h), 1e-7) {
+			t.Errorf("potential at h = %v: %v; want %v", h, r.PotentialM2PerS2, GM/(6371000+h))
+		}
+	}
+	if g.DynamicalFormFactor() != 0 || g.Jn(4) != 0 {
+		t.Errorf("J2, J4 = %v, %v; want 0, 0", g.DynamicalFormFactor(), g.Jn(4))
+	}
+}
+
+func TestNormalGravitySeries(t *testing.T) {
+	// The series and closed forms of Q and G agree where they meet
+	for _, y := range []float64{0.2499, -0.2499} {
+		q := ((1+3/y)*normal_atanzz(y) - 3/y) / (2 * y)
+		if !almost_equal(normal_qf(y), q, 1e-14) {
+			t.Errorf("Q(%v) = %v; want %v", y, normal_qf(y), q)
+		}
+		gg := (3*(1+1/y)*(1-normal_atanzz(y)) - 1) / y
+		if !almost_equal(normal_gf(y), gg, 1e-14) {
+			t.Errorf("G(%v) = %v; want %v", y, normal_gf(y), gg)
+		}
+	}
+	if normal_qf(0) != 2.0/15 || normal_gf(0) != 2.0/5 {
+		t.Errorf("Q(0), G(0) = %v, %v; want 2/15, 2/5", normal_qf(0), normal_gf(0))
+	}
+}
+
+func BenchmarkNormalGravityGravity(b *testing.B) {
+	g := Wgs84NormalGravity()
+	for i := 0; i < b.N; i++ {
+		g.Gravity(45, 1000)
+	}
+}
@@ -0,0 +1,265 @@
+package geographiclibgo
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"unicode"
+)
+
+// Constants for OSGB
+const (
+	_OSGB_A         float64 = 6377563.396 // Airy 1830 equatorial radius [meters]
+	_OSGB_B         float64 = 6356256.909 // Airy 1830 polar semi-axis [meters]
+	_OSGB_K0        float64 = 0.9996012717
+	_OSGB_LAT0      float64 = 49
+	_OSGB_LON0      float64 = -2
+	_OSGB_FALSE_E   float64 = 400000
+	_OSGB_FALSE_N   float64 = -100000
+	_OSGB_LETTERS           = "ABCDEFGHJKLMNOPQRSTUVWXYZ"
+	_OSGB_DIGITS            = "0123456789"
+	_OSGB_TILE      float64 = 100000 // Size of the 100 km squares [meters]
+	_OSGB_BASE      int     = 10
+	_OSGB_TILELEVEL int     = 5 // Number of digits for 1 m precision
+	_OSGB_TILEGRID  int     = 5 // 5x5 squares of 100 km within each 500 km square
+	_OSGB_TILEOFFX  int     = 2 * _OSGB_TILEGRID
+	_OSGB_TILEOFFY  int     = _OSGB_TILEGRID
+	_OSGB_MINX      float64 = -float64(_OSGB_TILEOFFX) * _OSGB_TILE
+	_OSGB_MINY      float64 = -float64(_OSGB_TILEOFFY) * _OSGB_TILE
+	_OSGB_MAXX      float64 = float64(_OSGB_TILEGRID*_OSGB_TILEGRID-_OSGB_TILEOFFX) * _OSGB_TILE
+	_OSGB_MAXY      float64 = float64(_OSGB_TILEGRID*_OSGB_TILEGRID-_OSGB_TILEOFFY) * _OSGB_TILE
+	_OSGB_MAXPREC   int     = _OSGB_TILELEVEL + 6 // Maximum precision is 1 um
+)
+
+// OSGBResult is a position on the British National Grid decoded from a grid reference
+type OSGBResult struct {
+	EastingM  float64 // Easting [meters]
+	NorthingM float64 // Northing [meters]
+	Prec      int     // Number of digits in each of the easting and northing
+}
+
+// OSGB converts between geographic coordinates on the OSGB36 datum and the Ordnance
+// Survey's British National Grid, a transverse Mercator projection on the Airy 1830
+// ellipsoid with a central scale of 0.9996012717 and a true origin at 49N, 2W. The false
+// origin is 400 km west and 100 km north of the true origin. See
+// https://www.ordnancesurvey.co.uk/documents/resources/guide-coordinate-systems-great-britain.pdf
+//
+// The latitude and longitude are with respect to the OSGB36 datum, not WGS84; converting
+// between the two is a datum shift, which differs by up to about 120 m.
+type OSGB struct {
+	tm          TransverseMercator
+	northoffset float64
+}
+
+// NewOSGB creates an OSGB
+func NewOSGB() OSGB {
+	tm := NewTransverseMercator(_OSGB_A, (_OSGB_A-_OSGB_B)/_OSGB_A, _OSGB_K0)
+	// The northing of the true origin on the unshifted projection
+	northoffset := tm.Forward(0, _OSGB_LAT0, 0).YM - _OSGB_FALSE_N
+	return OSGB{tm: tm, northoffset: northoffset}
+}
+
+// EquatorialRadius returns the equatorial radius of the Airy 1830 ellipsoid [meters]
+func (o *OSGB) EquatorialRadius() float64 {
+	return o.tm.EquatorialRadius()
+}
+
+// Flattening returns the flattening of the Airy 1830 ellipsoid
+func (o *OSGB) Flattening() float64 {
+	return o.tm.Flattening()
+}
+
+// CentralScale returns the scale on the central meridian
+func (o *OSGB) CentralScale() float64 {
+	return o.tm.CentralScale()
+}
+
+// OriginLatitude returns the latitude of the true origin [degrees]
+func (o *OSGB) OriginLatitude() float64 {
+	return _OSGB_LAT0
+}
+
+// OriginLongitude returns the longitude of the true origin, the central meridian
+// [degrees]
+func (o *OSGB) OriginLongitude() float64 {
+	return _OSGB_LON0
+}
+
+// FalseNorthing returns the northing of the true origin [meters]
+func (o *OSGB) FalseNorthing() float64 {
+	return _OSGB_FALSE_N
+}
+
+// FalseEasting returns the easting of the true origin [meters]
+func (o *OSGB) FalseEasting() float64 {
+	return _OSGB_FALSE_E
+}
+
+// Forward converts the OSGB36 point lat_deg, lon_deg to an easting and northing on the
+// British National Grid. The result is not restricted to the range of the grid.
+func (o *OSGB) Forward(lat_deg, lon_deg float64) ProjectionForwardResult {
+	r := o.tm.Forward(_OSGB_LON0, lat_deg, lon_deg)
+	r.XM += _OSGB_FALSE_E
+	r.YM -= o.northoffset
+	return r
+}
+
+// Reverse converts the easting x_m and northing y_m on the British National Grid to an
+// OSGB36 latitude and longitude
+func (o *OSGB) Reverse(x_m, y_m float64) ProjectionReverseResult {
+	return o.tm.Reverse(_OSGB_LON0, x_m-_OSGB_FALSE_E, y_m+o.northoffset)
+}
+
+// GridReference converts the easting x_m and northing y_m to a grid reference such as
+// "TQ 30080 80916", made up of the letters of the 100 km square followed by prec digits
+// of each of the easting and northing within the square. prec in [0, 11] gives a
+// precision of 100 km for prec = 0, 1 m for prec = 5, and 1 um for prec = 11; the
+// digits are truncated, so the grid reference is that of the square containing the
+// point. This returns an error if the point is outside the 2500 km square covered by the
+// letters, x_m in [-1000 km, 1500 km) and y_m in [-500 km, 2000 km), and "INVALID" if x_m
+// or y_m is NaN.
+func (o *OSGB) GridReference(x_m, y_m float64, prec int) (string, error) {
+	if err := osgb_check_coords(x_m, y_m); err != nil {
+		return "", err
+	}
+	if prec < 0 || prec > _OSGB_MAXPREC {
+		return "", fmt.Errorf("OSGB precision %d not in [0, %d]", prec, _OSGB_MAXPREC)
+	}
+	if math.IsNaN(x_m) || math.IsNaN(y_m) {
+		return "INVALID", nil
+	}
+	xh := int(math.Floor(x_m / _OSGB_TILE))
+	yh := int(math.Floor(y_m / _OSGB_TILE))
+	xf := x_m - _OSGB_TILE*float64(xh)
+	yf := y_m - _OSGB_TILE*float64(yh)
+	xh += _OSGB_TILEOFFX
+	yh += _OSGB_TILEOFFY
+	var sb strings.Builder
+	sb.WriteByte(_OSGB_LETTERS[(_OSGB_TILEGRID-yh/_OSGB_TILEGRID-1)*_OSGB_TILEGRID+xh/_OSGB_TILEGRID])
+	sb.WriteByte(_OSGB_LETTERS[(_OSGB_TILEGRID-yh%_OSGB_TILEGRID-1)*_OSGB_TILEGRID+xh%_OSGB_TILEGRID])
+	if prec == 0 {
+		return sb.String(), nil
+	}
+	// The unit of ix and iy is 10^-(prec - 5) meters
+	mult := math.Pow(float64(_OSGB_BASE), float64(_OSGB_TILELEVEL-prec))
+	ix := int64(math.Floor(xf / mult))
+	iy := int64(math.Floor(yf / mult))
+	// Guard against xf / mult rounding up to the size of the square
+	maxi := int64(math.Pow(float64(_OSGB_BASE), float64(prec))) - 1
+	if ix > maxi {
+		ix = maxi
+	}
+	if iy > maxi {
+		iy = maxi
+	}
+	digits := make([]byte, 2*prec)
+	for c := prec - 1; c >= 0; c-- {
+		digits[c] = _OSGB_DIGITS[ix%int64(_OSGB_BASE)]
+		ix /= int64(_OSGB_BASE)
+		digits[c+prec] = _OSGB_DIGITS[iy%int64(_OSGB_BASE)]
+		iy /= int64(_OSGB_BASE)
+	}
+	sb.WriteByte(' ')
+	sb.Write(digits[:prec])
+	sb.WriteByte(' ')
+	sb.Write(digits[prec:])
+	return sb.String(), nil
+}
+
+// GridReferenceReverse decodes the grid reference gridref, e.g. "TQ 30080 80916" or
+// "tq3008080916", to an easting and northing. Whitespace is ignored and the letters may be
+// upper or lower case. If centerp is true, the coordinates are those of the center of the
+// square given by the grid reference; otherwise they are those of its south-west corner.
+// A string beginning with "IN" gives NaN coordinates and Prec = -2.
+func (o *OSGB) GridReferenceReverse(gridref string, centerp bool) (OSGBResult, error) {
+	if len(gridref) >= 2 && strings.EqualFold(gridref[:2], "IN") {
+		nan := math.NaN()
+		return OSGBResult{EastingM: nan, NorthingM: nan, Prec: -2}, nil
+	}
+	grid := strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return -1
+		}
+		return r
+	}, gridref)
+	n := len(grid)
+	if n > 2+2*_OSGB_MAXPREC {
+		return OSGBResult{}, fmt.Errorf("OSGB string %s too long", gridref)
+	}
+	if n < 2 {
+		return OSGBResult{}, fmt.Errorf("OSGB string %s too short", gridref)
+	}
+	if n%2 != 0 {
+		return OSGBResult{}, fmt.Errorf("OSGB string %s has odd number of characters", gridref)
+	}
+	xh, yh := 0, 0
+	for p := 0; p < 2; p++ {
+		i := mgrs_lookup(_OSGB_LETTERS, grid[p])
+		if i < 0 {
+			return OSGBResult{}, fmt.Errorf("illegal prefix character in OSGB string %s", gridref)
+		}
+		yh = yh*_OSGB_TILEGRID + _OSGB_TILEGRID - i/_OSGB_TILEGRID - 1
+		xh = xh*_OSGB_TILEGRID + i%_OSGB_TILEGRID
+	}
+	xh -= _OSGB_TILEOFFX
+	yh -= _OSGB_TILEOFFY
+	prec := (n - 2) / 2
+	unit := _OSGB_TILE
+	x := unit * float64(xh)
+	y := unit * float64(yh)
+	for i := 0; i < prec; i++ {
+		unit /= float64(_OSGB_BASE)
+		ix := mgrs_lookup(_OSGB_DIGITS, grid[2+i])
+		iy := mgrs_lookup(_OSGB_DIGITS, grid[2+i+prec])
+		if ix < 0 || iy < 0 {
+			return OSGBResult{}, fmt.Errorf("encountered a non-digit in OSGB string %s", gridref)
+		}
+		x += unit * float64(ix)
+		y += unit * float64(iy)
+	}
+	if centerp {
+		x += unit / 2
+		y += unit / 2
+	}
+	return OSGBResult{EastingM: x, NorthingM: y, Prec: prec}, nil
+}
+
+// FromLatLon converts the OSGB36 point p to a grid reference with precision prec, see
+// GridReference
+func (o *OSGB) FromLatLon(p LatLon, prec int) (string, error) {
+	if math.Abs(p.LatDeg) > 90 {
+		return "", fmt.Errorf("latitude %vd not in [-90d, 90d]", p.LatDeg)
+	}
+	r := o.Forward(p.LatDeg, p.LonDeg)
+	return o.GridReference(r.XM, r.YM, prec)
+}
+
+// ToLatLon converts the grid reference gridref to the OSGB36 latitude and longitude of
+// the center of its square
+func (o *OSGB) ToLatLon(gridref string) (LatLon, error) {
+	r, err := o.GridReferenceReverse(gridref, true)
+	if err != nil {
+		return LatLon{}, err
+	}
+	rr := o.Reverse(r.EastingM, r.NorthingM)
+	return LatLon{LatDeg: rr.LatDeg, LonDeg: rr.LonDeg}, nil
+}
+
+// osgb_check_coords returns an error if x_m, y_m is outside the squares covered by the
+// grid letters. The limits are closed on the lower end and open on the upper end. NaNs
+// are let through.
+func osgb_check_coords(x_m, y_m float64) error {
+	if x_m < _OSGB_MINX || x_m >= _OSGB_MAXX {
+		return fmt.Errorf(
+			"easting %vkm not in OSGB range [%vkm, %vkm)",
+			math.Floor(x_m/1000), _OSGB_MINX/1000, _OSGB_MAXX/1000,
+		)
+	}
+	if y_m < _OSGB_MINY || y_m >= _OSGB_MAXY {
+		return fmt.Errorf(
+			"northing %vkm not in OSGB range [%vkm, %vkm)",
+			math.Floor(y_m/1000), _OSGB_MINY/1000, _OSGB_MAXY/1000,
+		)
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+package geographiclibgo
+
+import (
+	"math"
+	"testing"
+)
+
+func TestOSGBForward(t *testing.T) {
+	// The worked example in Annex C of A Guide to Coordinate Systems in Great Britain,
+	// Ordnance Survey (2020)
+	o := NewOSGB()
+	lat := 52 + 39.0/60 + 27.2531/3600
+	lon := 1 + 43.0/60 + 4.5177/3600
+	r := o.Forward(lat, lon)
+	if !almost_equal(r.XM, 651409.903, 1e-3) || !almost_equal(r.YM, 313177.270, 1e-3) {
+		t.Errorf("Forward(%v, %v) = %v, %v; want 651409.903, 313177.270", lat, lon, r.XM, r.YM)
+	}
+	if !almost_equal(r.Scale, 1.0003773, 1e-7) {
+		t.Errorf("Forward(%v, %v) scale = %v; want 1.0003773", lat, lon, r.Scale)
+	}
+	// The true origin
+	r = o.Forward(49, -2)
+	if !almost_equal(r.XM, 400000, 1e-9) || !almost_equal(r.YM, -100000, 1e-9) ||
+		!almost_equal(r.Scale, 0.9996012717, 1e-15) || !almost_equal(r.ConvergenceDeg, 0, 1e-300) {
+		t.Errorf("Forward(49, -2) = %+v", r)
+	}
+}
+
+func TestOSGBReverse(t *testing.T) {
+	o := NewOSGB()
+	r := o.Reverse(651409.903, 313177.270)
+	lat := 52 + 39.0/60 + 27.2531/3600
+	lon := 1 + 43.0/60 + 4.5177/3600
+	// 1e-8 degrees is about 1 mm
+	if !almost_equal(r.LatDeg, lat, 1e-8) || !almost_equal(r.LonDeg, lon, 1e-8) {
+		t.Errorf("Reverse(651409.903, 313177.270) = %v, %v; want %v, %v", r.LatDeg, r.LonDeg, lat, lon)
+	}
+	for lat := 49.9; lat < 61; lat += 1.3 {
+		for lon := -7.9; lon < 2; lon += 0.7 {
+			f := o.Forward(lat, lon)
+			r := o.Reverse(f.XM, f.YM)
+			if !almost_equal(r.LatDeg, lat, 1e-12) || !almost_equal(r.LonDeg, lon, 1e-12) ||
+				!almost_equal(r.ConvergenceDeg, f.ConvergenceDeg, 1e-12) || !almost_equal(r.Scale, f.Scale, 1e-14) {
+				t.Errorf("Reverse(Forward(%v, %v)) = %+v; forward %+v", lat, lon, r, f)
+			}
+		}
+	}
+}
+
+func TestOSGBGridReference(t *testing.T) {
+	o := NewOSGB()
+	testCases := []struct {
+		desc string
+		x    float64
+		y    float64
+		prec int
+		want string
+	}{
+		{"1 m", 530080.5, 180916.25, 5, "TQ 30080 80916"},
+		{"100 km", 530080.5, 180916.25, 0, "TQ"},
+		{"10 km", 530080.5, 180916.25, 1, "TQ 3 8"},
+		{"100 m", 530080.5, 180916.25, 3, "TQ 300 809"},
+		{"1 mm", 530080.5, 180916.25, 8, "TQ 30080500 80916250"},
+		{"annex c", 651409.903, 313177.270, 5, "TG 51409 13177"},
+		{"false origin", 0, 0, 2, "SV 00 00"},
+		{"grid south west", -1000000, -500000, 0, "VV"},
+		{"nan", math.NaN(), 0, 5, "INVALID"},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			got, err := o.GridReference(tC.x, tC.y, tC.prec)
+			if err != nil {
+				t.Fatalf("GridReference() error = %v", err)
+			}
+			if got != tC.want {
+				t.Errorf("GridReference(%v, %v, %v) = %q; want %q", tC.x, tC.y, tC.prec, got, tC.want)
+			}
+		})
+	}
+	for _, c := range []struct {
+		x, y float64
+		prec int
+	}{{1500000, 0, 5}, {0, -500001, 5}, {0, 2000000, 5}, {0, 0, -1}, {0, 0, 12}} {
+		if s, err := o.GridReference(c.x, c.y, c.prec); err == nil {
+			t.Errorf("GridReference(%v, %v, %v) = %q; want error", c.x, c.y, c.prec, s)
+		}
+	}
+}
+
+func TestOSGBGridReferenceReverse(t *testing.T) {
+	o := NewOSGB()
+	testCases := []struct {
+		desc    string
+		gridref string
+		centerp bool
+		want    OSGBResult
+	}{
+		{"spaced", "TQ 30080 80916", false, OSGBResult{530080, 180916, 5}},
+		{"center", "TQ 30080 80916", true, OSGBResult{530080.5, 180916.5, 5}},
+		{"unspaced lower case", "tq3008080916", false, OSGBResult{530080, 180916, 5}},
+		{"letters only", "TQ", true, OSGBResult{550000, 150000, 0}},
+		{"10 km", "NN 1 7", false, OSGBResult{210000, 770000, 1}},
+		{"false origin", "SV", false, OSGBResult{0, 0, 0}},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			got, err := o.GridReferenceReverse(tC.gridref, tC.centerp)
+			if err != nil {
+				t.Fatalf("GridReferenceReverse() error = %v", err)
+			}
+			if got != tC.want {
+				t.Errorf("GridReferenceReverse(%q, %v) = %+v; want %+v", tC.gridref, tC.centerp, got, tC.want)
+			}
+		})
+	}
+	if r, err := o.GridReferenceReverse("INVALID", true); err != nil || !math.IsNaN(r.EastingM) || r.Prec != -2 {
+		t.Errorf("GridReferenceReverse(INVALID) = %+v, %v; want NaN", r, err)
+	}
+	for _, s := range []string{"T", "TQ 300 80", "TI 30 80", "TQ 3a 80", "TQ 300800000000 809160000000"} {
+		if r, err := o.GridReferenceReverse(s, false); err == nil {
+			t.Errorf("GridReferenceReverse(%q) = %+v; want error", s, r)
+		}
+	}
+}
+
+func TestOSGBLatLonRoundTrip(t *testing.T) {
+	o := NewOSGB()
+	for lat := 49.9; lat < 61; lat += 1.3 {
+		for lon := -7.9; lon < 2; lon += 0.7 {
+			for prec := 0; prec <= 11; prec++ {
+				s, err := o.FromLatLon(LatLon{lat, lon}, prec)
+				if err != nil {
+					t.Fatalf("FromLatLon() error = %v", err)
+				}
+				r, err := o.GridReferenceReverse(s, false)
+				if err != nil {
+					t.Fatalf("GridReferenceReverse(%q) error = %v", s, err)
+				}
+				// The point lies in the square given by the grid reference
+				f := o.Forward(lat, lon)
+				size := 100000 / math.Pow(10, float64(prec))
+				if r.Prec != prec || f.XM < r.EastingM || f.XM > r.EastingM+size ||
+					f.YM < r.NorthingM || f.YM > r.NorthingM+size {
+					t.Errorf("%q = %+v does not contain %v, %v", s, r, f.XM, f.YM)
+				}
+			}
+			// The center of a 1 m square is within a meter of the point
+			s, _ := o.FromLatLon(LatLon{lat, lon}, 5)
+			p, err := o.ToLatLon(s)
+			if err != nil {
+				t.Fatalf("ToLatLon(%q) error = %v", s, err)
+			}
+			if math.Abs(p.LatDeg-lat) > 1e-5 || math.Abs(p.LonDeg-lon) > 2e-5 {
+				t.Errorf("ToLatLon(%q) = %v; want near %v, %v", s, p, lat, lon)
+			}
+		}
+	}
+	if _, err := o.FromLatLon(LatLon{91, 0}, 5); err == nil {
+		t.Errorf("FromLatLon(91, 0) succeeded; want error")
+	}
+}
+
+func BenchmarkOSGBForward(b *testing.B) {
+	o := NewOSGB()
+	for i := 0; i < b.N; i++ {
+		o.Forward(52.657570, 1.717922)
+	}
+}
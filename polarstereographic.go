@@ -0,0 +1,139 @@
+package geographiclibgo
+
+import (
+	"errors"
+	"math"
+)
+
+// PolarStereographic is the polar stereographic projection, following J. P. Snyder, Map
+// Projections: A Working Manual, USGS Professional Paper 1395 (1987), pp. 160-163. This
+// is a straightforward implementation of the equations in Snyder except that Newton's
+// method is used to invert the projection. The scale at the pole is k0.
+//
+// The meridian convergence is the bearing of grid north (the y axis) measured clockwise
+// from true north.
+type PolarStereographic struct {
+	a   float64
+	f   float64
+	e2  float64
+	es  float64
+	e2m float64
+	c   float64
+	k0  float64
+}
+
+// NewPolarStereographic creates a PolarStereographic projection for the ellipsoid with
+// equatorial radius a [meters] and flattening f, with scale k0 at the pole. For UPS,
+// k0 = 0.994.
+func NewPolarStereographic(a, f, k0 float64) PolarStereographic {
+	e2 := f * (2.0 - f)
+	es := math.Sqrt(math.Abs(e2))
+	if f < 0 {
+		es = -es
+	}
+	return PolarStereographic{
+		a:   a,
+		f:   f,
+		e2:  e2,
+		es:  es,
+		e2m: 1.0 - e2,
+		c:   (1.0 - f) * math.Exp(eatanhe(1.0, es)),
+		k0:  k0,
+	}
+}
+
+// EquatorialRadius returns the equatorial radius of the ellipsoid [meters]
+func (p *PolarStereographic) EquatorialRadius() float64 {
+	return p.a
+}
+
+// Flattening returns the flattening of the ellipsoid
+func (p *PolarStereographic) Flattening() float64 {
+	return p.f
+}
+
+// CentralScale returns the scale at the pole, k0
+func (p *PolarStereographic) CentralScale() float64 {
+	return p.k0
+}
+
+// SetScale sets the scale at the pole so that the scale at latitude lat_deg is k. This
+// returns an error if k is not positive, or if lat_deg is not in (-90, 90].
+func (p *PolarStereographic) SetScale(lat_deg, k float64) error {
+	if !(!math.IsInf(k, 0) && k > 0) {
+		return errors.New("scale is not positive")
+	}
+	if !(-90.0 < lat_deg && lat_deg <= 90.0) {
+		return errors.New("latitude must be in (-90, 90]")
+	}
+	p.k0 = 1.0
+	kold := p.Forward(true, lat_deg, 0.0).Scale
+	p.k0 *= k / kold
+	return nil
+}
+
+// Forward projects the point lat_deg, lon_deg onto the map centered on the north pole if
+// northp is true, or the south pole otherwise. No false easting or northing is added.
+// lat_deg should be in the range (-90, 90] for northp = true and [-90, 90) for
+// northp = false.
+func (p *PolarStereographic) Forward(northp bool, lat_deg, lon_deg float64) ProjectionForwardResult {
+	lat := lat_fix(lat_deg)
+	if !northp {
+		lat = -lat
+	}
+	tau := tand(lat)
+	secphi := math.Hypot(1.0, tau)
+	taup := taupf(tau, p.es)
+	rho := math.Hypot(1.0, taup) + math.Abs(taup)
+	if taup >= 0 {
+		if lat != 90.0 {
+			rho = 1.0 / rho
+		} else {
+			rho = 0.0
+		}
+	}
+	rho *= 2.0 * p.k0 * p.a / p.c
+	k := p.k0
+	if lat != 90.0 {
+		k = (rho / p.a) * secphi * math.Sqrt(p.e2m+p.e2/sq(secphi))
+	}
+	x, y := sincosd(lon_deg)
+	x *= rho
+	gamma := ang_normalize(lon_deg)
+	if northp {
+		y *= -rho
+	} else {
+		y *= rho
+		gamma = ang_normalize(-lon_deg)
+	}
+	return ProjectionForwardResult{XM: x, YM: y, ConvergenceDeg: gamma, Scale: k}
+}
+
+// Reverse finds the point at easting x_m and northing y_m on the map centered on the
+// north pole if northp is true, or the south pole otherwise. No false easting or northing
+// is removed.
+func (p *PolarStereographic) Reverse(northp bool, x_m, y_m float64) ProjectionReverseResult {
+	rho := math.Hypot(x_m, y_m)
+	t := sq(get_epsilon())
+	if rho != 0 {
+		t = rho / (2.0 * p.k0 * p.a / p.c)
+	}
+	taup := (1.0/t - t) / 2.0
+	tau := tauf(taup, p.es)
+	secphi := math.Hypot(1.0, tau)
+	k := p.k0
+	if rho != 0 {
+		k = (rho / p.a) * secphi * math.Sqrt(p.e2m+p.e2/sq(secphi))
+	}
+	lat := atand(tau)
+	var lon, gamma float64
+	if northp {
+		lon = atan2_deg(x_m, -y_m)
+		gamma = ang_normalize(lon)
+	} else {
+		lat = -lat
+		lon = atan2_deg(x_m, y_m)
+		gamma = ang_normalize(-lon)
+	}
+	return ProjectionReverseResult{LatDeg: lat, LonDeg: lon, ConvergenceDeg: gamma, Scale: k}
+}
@@ -0,0 +1,81 @@
+package geographiclibgo
+
+import "testing"
+
+func TestPolarStereographicPole(t *testing.T) {
+	ps := NewPolarStereographic(WGS84_A, WGS84_F, 0.994)
+	for _, northp := range []bool{true, false} {
+		lat := 90.0
+		if !northp {
+			lat = -90
+		}
+		got := ps.Forward(northp, lat, 30)
+		if got.XM != 0 || got.YM != 0 {
+			t.Errorf("northp = %v: Forward() = (%v, %v); want (0, 0)", northp, got.XM, got.YM)
+		}
+		if got.Scale != 0.994 {
+			t.Errorf("northp = %v: k = %v; want %v", northp, got.Scale, 0.994)
+		}
+		rev := ps.Reverse(northp, 0, 0)
+		if rev.LatDeg != lat {
+			t.Errorf("northp = %v: Reverse(0, 0) lat = %v; want %v", northp, rev.LatDeg, lat)
+		}
+	}
+}
+
+func TestPolarStereographicRoundTrip(t *testing.T) {
+	ps := NewPolarStereographic(WGS84_A, WGS84_F, 0.994)
+	for _, northp := range []bool{true, false} {
+		for lat := 60.0; lat < 90; lat += 7 {
+			for lon := -179.0; lon < 180; lon += 45 {
+				plat := lat
+				if !northp {
+					plat = -lat
+				}
+				fwd := ps.Forward(northp, plat, lon)
+				rev := ps.Reverse(northp, fwd.XM, fwd.YM)
+				if !almost_equal(rev.LatDeg, plat, 1e-12) || !almost_equal(rev.LonDeg, lon, 1e-12) {
+					t.Errorf("Reverse(Forward(%v, %v)) = (%v, %v)", plat, lon, rev.LatDeg, rev.LonDeg)
+				}
+				if !almost_equal(rev.ConvergenceDeg, fwd.ConvergenceDeg, 1e-12) {
+					t.Errorf("(%v, %v) reverse gamma = %v; want %v", plat, lon, rev.ConvergenceDeg, fwd.ConvergenceDeg)
+				}
+				if !almost_equal(rev.Scale, fwd.Scale, 1e-14) {
+					t.Errorf("(%v, %v) reverse k = %v; want %v", plat, lon, rev.Scale, fwd.Scale)
+				}
+			}
+		}
+	}
+
+	// Grid north along the meridian lon points away from x = y = 0 in the northern
+	// hemisphere, so the y axis points along lon = 180
+	got := ps.Forward(true, 85, 90)
+	if !(got.XM > 0) || !almost_equal(got.YM, 0, 1e-9) || got.ConvergenceDeg != 90 {
+		t.Errorf("Forward(85, 90) = (%v, %v, %v); want (>0, 0, 90)", got.XM, got.YM, got.ConvergenceDeg)
+	}
+}
+
+func TestPolarStereographicSetScale(t *testing.T) {
+	// The UPS scale of 0.994 at the pole gives true scale at about 81d06'52.3"
+	ps := NewPolarStereographic(WGS84_A, WGS84_F, 1)
+	if err := ps.SetScale(81+6/60.0+52.3/3600.0, 1); err != nil {
+		t.Fatalf("SetScale() error = %v", err)
+	}
+	if !almost_equal(ps.CentralScale(), 0.994, 1e-6) {
+		t.Errorf("k0 = %v; want %v", ps.CentralScale(), 0.994)
+	}
+
+	if err := ps.SetScale(45, 0); err == nil {
+		t.Errorf("SetScale(45, 0) error = nil; want non-nil")
+	}
+	if err := ps.SetScale(-90, 1); err == nil {
+		t.Errorf("SetScale(-90, 1) error = nil; want non-nil")
+	}
+}
+
+func BenchmarkPolarStereographicForward(b *testing.B) {
+	ps := NewPolarStereographic(WGS84_A, WGS84_F, 0.994)
+	for i := 0; i < b.N; i++ {
+		ps.Forward(true, 85, 30)
+	}
+}
@@ -4,8 +4,39 @@ import (
 	"math"
 )
 
+// polygon_edger is implemented by the types which can supply the edges of a PolygonArea,
+// i.e. Geodesic for geodesic edges and Rhumb for rhumb line edges. It returns the length
+// and the area under an edge given by its end points, and the end point and area under
+// an edge given by an azimuth and length.
+type polygon_edger interface {
+	_edge_inverse(lat1, lon1, lat2, lon2 float64, outmask uint64) (float64, float64)
+	_edge_direct(lat1, lon1, azi1, s12 float64, outmask uint64) (float64, float64, float64)
+}
+
+func (g *Geodesic) _edge_inverse(lat1, lon1, lat2, lon2 float64, outmask uint64) (float64, float64) {
+	_, s12, _, _, _, _, _, _, _, S12 := g._gen_inverse(lat1, lon1, lat2, lon2, outmask)
+	return s12, S12
+}
+
+func (g *Geodesic) _edge_direct(lat1, lon1, azi1, s12 float64, outmask uint64) (float64, float64, float64) {
+	_, lat2, lon2, _, _, _, _, _, S12, _ := g._gen_direct(lat1, lon1, azi1, false, s12, outmask)
+	return lat2, lon2, S12
+}
+
+func (r *Rhumb) _edge_inverse(lat1, lon1, lat2, lon2 float64, outmask uint64) (float64, float64) {
+	s12, _, S12 := r._gen_inverse(lat1, lon1, lat2, lon2, outmask)
+	return s12, S12
+}
+
+func (r *Rhumb) _edge_direct(lat1, lon1, azi12, s12 float64, outmask uint64) (float64, float64, float64) {
+	return r._gen_direct(lat1, lon1, azi12, s12, outmask)
+}
+
 type PolygonArea struct {
+	// The Geodesic on which the edges are calculated. This is not used if the
+	// PolygonArea was created with NewPolygonAreaRhumb
 	Earth        Geodesic
+	edges        polygon_edger
 	Polyline     bool
 	Area0_M2     float64
 	mask         uint64
@@ -70,6 +101,29 @@ func NewPolygonArea(g Geodesic, is_polyline bool) PolygonArea {
 	return p
 }
 
+// NewPolygonAreaRhumb creates a new struct for calculating area and perimeter of a
+// polygon whose edges are rhumb lines, or for calculating the length of a polyline made
+// up of rhumb lines. The Earth field of the result is not used.
+// Takes inputs:
+//   - r Rhumb on which you wish to calculate
+//   - is_polyline if true, then assume all points added will only form a polyline. No area
+//     will be calculated
+func NewPolygonAreaRhumb(r Rhumb, is_polyline bool) PolygonArea {
+	p := NewPolygonArea(Geodesic{}, is_polyline)
+	p.Area0_M2 = r.EllipsoidArea()
+	p.edges = &r
+	return p
+}
+
+// edger returns the source of the edges of the polygon; this is Earth unless the
+// PolygonArea was created with NewPolygonAreaRhumb
+func (p *PolygonArea) edger() polygon_edger {
+	if p.edges != nil {
+		return p.edges
+	}
+	return &p.Earth
+}
+
 // Clear resets to an empty polygon
 func (p *PolygonArea) Clear() {
 	p.Num = 0
@@ -114,7 +168,7 @@ func (p *PolygonArea) AddPoint(lat_deg, lon_deg float64) {
 		p.lon0_deg = lon_deg
 		p.Lon1_Deg = lon_deg
 	} else {
-		_, s12, _, _, _, _, _, _, _, S12 := p.Earth._gen_inverse(
+		s12, S12 := p.edger()._edge_inverse(
 			p.Lat1_Deg, p.Lon1_Deg, lat_deg, lon_deg, p.mask,
 		)
 		p.perimetersum.Add(s12)
@@ -158,8 +212,8 @@ func (p *PolygonArea) transit_direct(lon1_deg, lon2_deg float64) int {
 // - s_m: the length of the edge in meters
 func (p *PolygonArea) AddEdge(azi_deg, s_m float64) {
 	if p.Num != 0 {
-		_, lat, lon, _, _, _, _, _, S12, _ := p.Earth._gen_direct(
-			p.Lat1_Deg, p.Lon1_Deg, azi_deg, false, s_m, p.mask,
+		lat, lon, S12 := p.edger()._edge_direct(
+			p.Lat1_Deg, p.Lon1_Deg, azi_deg, s_m, p.mask,
 		)
 		p.perimetersum.Add(s_m)
 
@@ -301,7 +355,7 @@ func (p *PolygonArea) Compute(reverse, sign bool) PolygonResult {
 		return PolygonResult{Num: p.Num, Perimeter: p.perimetersum.Sum(0.0), Area: math.NaN()}
 	}
 
-	_, s12, _, _, _, _, _, _, _, S12 := p.Earth._gen_inverse(
+	s12, S12 := p.edger()._edge_inverse(
 		p.Lat1_Deg,
 		p.Lon1_Deg,
 		p.lat0_deg,
@@ -389,7 +443,7 @@ func (p *PolygonArea) TestPoint(
 			this_lat0 = p.lat0_deg
 			this_lon0 = p.lon0_deg
 		}
-		_, s12, _, _, _, _, _, _, _, S12 := p.Earth._gen_inverse(
+		s12, S12 := p.edger()._edge_inverse(
 			this_lat1, this_lon1, this_lat0, this_lon0, p.mask,
 		)
 		perimeter += s12
@@ -456,14 +510,14 @@ func (p *PolygonArea) TestEdge(
 	tempsum := p.areasum.Sum(0.0)
 	crossings := p.crossings
 
-	_, lat, lon, _, _, _, _, _, S12, _ := p.Earth._gen_direct(
-		p.Lat1_Deg, p.Lon1_Deg, azi_deg, false, s_m, p.mask,
+	lat, lon, S12 := p.edger()._edge_direct(
+		p.Lat1_Deg, p.Lon1_Deg, azi_deg, s_m, p.mask,
 	)
 
 	tempsum += S12
 	crossings += p.transit_direct(p.Lon1_Deg, lon)
 
-	_, s12, _, _, _, _, _, _, _, S12 := p.Earth._gen_inverse(
+	s12, S12 := p.edger()._edge_inverse(
 		lat, lon, p.lat0_deg, p.lon0_deg, p.mask,
 	)
 	perimeter += s12
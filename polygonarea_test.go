@@ -463,3 +463,69 @@ func TestPlanimeter29(t *testing.T) {
 		t.Errorf("area = %v; want %v", a.Area, want_area)
 	}
 }
+
+func TestPlanimeterRhumb(t *testing.T) {
+	r := Wgs84Rhumb()
+	e := math.Sqrt(WGS84_F * (2 - WGS84_F))
+	// The sine of the authalic latitude
+	sinxi := func(lat float64) float64 {
+		q := func(x float64) float64 {
+			return (1 - e*e) * (x/(1-e*e*x*x) + math.Atanh(e*x)/e)
+		}
+		return q(math.Sin(lat*DEG2RAD)) / q(1)
+	}
+
+	// Rhumb lines along meridians and parallels bound a lat/lon rectangle
+	polygon := NewPolygonAreaRhumb(r, false)
+	for _, p := range [][2]float64{{10, 0}, {10, 30}, {20, 30}, {20, 0}} {
+		polygon.AddPoint(p[0], p[1])
+	}
+	got := polygon.Compute(false, true)
+	want_area := r.EllipsoidArea() / 2 * (sinxi(20) - sinxi(10)) * 30 / 360
+	want_perimeter := 2*r.InverseCalcDistance(10, 0, 20, 0) +
+		r.InverseCalcDistance(10, 0, 10, 30) + r.InverseCalcDistance(20, 0, 20, 30)
+	if !almost_equal(got.Area, want_area, 1) {
+		t.Errorf("area = %v; want %v", got.Area, want_area)
+	}
+	if !almost_equal(got.Perimeter, want_perimeter, 1e-6) {
+		t.Errorf("perimeter = %v; want %v", got.Perimeter, want_perimeter)
+	}
+
+	// A polygon around the pole along a parallel
+	polygon.Clear()
+	for _, p := range [][2]float64{{89, 0}, {89, 90}, {89, 180}, {89, 270}} {
+		polygon.AddPoint(p[0], p[1])
+	}
+	got = polygon.Compute(false, true)
+	want_area = r.EllipsoidArea() / 2 * (1 - sinxi(89))
+	want_perimeter = 2 * math.Pi * r._CircleRadius(89)
+	if !almost_equal(got.Area, want_area, 1) {
+		t.Errorf("area = %v; want %v", got.Area, want_area)
+	}
+	if !almost_equal(got.Perimeter, want_perimeter, 1e-6) {
+		t.Errorf("perimeter = %v; want %v", got.Perimeter, want_perimeter)
+	}
+
+	// Adding the same polygon with edges and test points
+	polygon.Clear()
+	polygon.AddPoint(89, 0)
+	for i := 0; i < 3; i++ {
+		polygon.AddEdge(90, want_perimeter/4)
+	}
+	got = polygon.Compute(false, true)
+	if !almost_equal(got.Area, want_area, 1) {
+		t.Errorf("AddEdge area = %v; want %v", got.Area, want_area)
+	}
+	polygon.Clear()
+	for _, p := range [][2]float64{{89, 0}, {89, 90}, {89, 180}} {
+		polygon.AddPoint(p[0], p[1])
+	}
+	got = polygon.TestPoint(89, 270, false, true)
+	if !almost_equal(got.Area, want_area, 1) {
+		t.Errorf("TestPoint area = %v; want %v", got.Area, want_area)
+	}
+	got = polygon.TestEdge(90, want_perimeter/4, false, true)
+	if !almost_equal(got.Area, want_area, 1) {
+		t.Errorf("TestEdge area = %v; want %v", got.Area, want_area)
+	}
+}
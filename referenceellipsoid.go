@@ -0,0 +1,179 @@
+package geographiclibgo
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"unicode"
+)
+
+// ReferenceEllipsoid is a named ellipsoid of revolution, such as those that geodetic
+// datums are defined on, or the reference surface of a planet or moon
+type ReferenceEllipsoid struct {
+	Name    string   // Name of the ellipsoid, e.g. "GRS 1980"
+	Aliases []string // Other names the ellipsoid is known by, e.g. "GRS80"
+	EPSG    int      // EPSG code of the ellipsoid, or 0 if it has none
+	A       float64  // Equatorial radius [meters]
+	F       float64  // Flattening; negative for a prolate ellipsoid and 0 for a sphere
+}
+
+// Geodesic returns a Geodesic for the ellipsoid
+func (e ReferenceEllipsoid) Geodesic() Geodesic {
+	return NewGeodesic(e.A, e.F)
+}
+
+// Ellipsoid returns an Ellipsoid with the properties of the ellipsoid
+func (e ReferenceEllipsoid) Ellipsoid() Ellipsoid {
+	return NewEllipsoid(e.A, e.F)
+}
+
+// MinorRadius returns the polar semi-axis of the ellipsoid [meters]
+func (e ReferenceEllipsoid) MinorRadius() float64 {
+	return e.A * (1 - e.F)
+}
+
+// InverseFlattening returns 1/f, which is infinite for a sphere
+func (e ReferenceEllipsoid) InverseFlattening() float64 {
+	return 1 / e.F
+}
+
+// flattening_from_ab returns the flattening of the ellipsoid with equatorial radius a
+// and polar semi-axis b
+func flattening_from_ab(a, b float64) float64 {
+	return (a - b) / a
+}
+
+// flattening_from_inverse returns the flattening given its inverse, treating 0 as a
+// sphere as is the convention in EPSG
+func flattening_from_inverse(invf float64) float64 {
+	if invf == 0 {
+		return 0
+	}
+	return 1 / invf
+}
+
+// flattening_from_eccentricity returns the flattening of the oblate ellipsoid with
+// eccentricity e in [0, 1)
+func flattening_from_eccentricity(e float64) float64 {
+	// 1 - sqrt(1 - e^2) without cancellation
+	return sq(e) / (1 + math.Sqrt((1-e)*(1+e)))
+}
+
+// NewGeodesicFromAB creates a Geodesic for the ellipsoid with equatorial radius a
+// [meters] and polar semi-axis b [meters]. b > a gives a prolate ellipsoid.
+func NewGeodesicFromAB(a, b float64) Geodesic {
+	return NewGeodesic(a, flattening_from_ab(a, b))
+}
+
+// NewGeodesicFromInverseFlattening creates a Geodesic for the ellipsoid with equatorial
+// radius a [meters] and inverse flattening invf, the form in which most reference
+// ellipsoids are defined. invf = 0 gives a sphere.
+func NewGeodesicFromInverseFlattening(a, invf float64) Geodesic {
+	return NewGeodesic(a, flattening_from_inverse(invf))
+}
+
+// NewGeodesicFromEccentricity creates a Geodesic for the oblate ellipsoid with
+// equatorial radius a [meters] and eccentricity e in [0, 1)
+func NewGeodesicFromEccentricity(a, e float64) Geodesic {
+	return NewGeodesic(a, flattening_from_eccentricity(e))
+}
+
+// The catalogue of reference ellipsoids. The terrestrial ellipsoids are as defined by
+// EPSG; the planetary bodies are the IAU 2015 reference surfaces, see B. A. Archinal et
+// al., Report of the IAU Working Group on Cartographic Coordinates and Rotational
+// Elements: 2015, Celest. Mech. Dyn. Astr. 130, 22 (2018).
+var _REFERENCE_ELLIPSOIDS = []ReferenceEllipsoid{
+	{Name: "WGS 84", Aliases: []string{"WGS84"}, EPSG: 7030, A: WGS84_A, F: WGS84_F},
+	{Name: "GRS 1980", Aliases: []string{"GRS80"}, EPSG: 7019, A: 6378137, F: flattening_from_inverse(298.257222101)},
+	{Name: "WGS 72", Aliases: []string{"WGS72"}, EPSG: 7043, A: 6378135, F: flattening_from_inverse(298.26)},
+	{Name: "GRS 1967", Aliases: []string{"GRS67"}, EPSG: 7036, A: 6378160, F: flattening_from_inverse(298.247167427)},
+	{
+		Name: "Australian National Spheroid", Aliases: []string{"ANS"}, EPSG: 7003,
+		A: 6378160, F: flattening_from_inverse(298.25),
+	},
+	{Name: "Clarke 1866", EPSG: 7008, A: 6378206.4, F: flattening_from_ab(6378206.4, 6356583.8)},
+	{
+		Name: "Clarke 1880 (RGS)", Aliases: []string{"Clarke 1880"}, EPSG: 7012,
+		A: 6378249.145, F: flattening_from_inverse(293.465),
+	},
+	{Name: "Bessel 1841", EPSG: 7004, A: 6377397.155, F: flattening_from_inverse(299.1528128)},
+	{Name: "Airy 1830", EPSG: 7001, A: 6377563.396, F: flattening_from_inverse(299.3249646)},
+	{
+		Name: "International 1924", Aliases: []string{"Hayford 1909", "Intl 1924"}, EPSG: 7022,
+		A: 6378388, F: flattening_from_inverse(297),
+	},
+	{
+		Name: "Krassowsky 1940", Aliases: []string{"Krassovsky", "Krasovsky 1940"}, EPSG: 7024,
+		A: 6378245, F: flattening_from_inverse(298.3),
+	},
+	{Name: "Mercury", A: 2440530, F: flattening_from_ab(2440530, 2438260)},
+	{Name: "Venus", A: 6051800, F: 0},
+	{Name: "Moon", A: 1737400, F: 0},
+	{Name: "Mars", A: 3396190, F: flattening_from_ab(3396190, 3376200)},
+	{Name: "Jupiter", A: 71492000, F: flattening_from_ab(71492000, 66854000)},
+	// Prolate ellipsoids, which have geodesics with different behavior from the oblate
+	// case. The first is used in the tests of GeographicLib; the second swaps the axes of
+	// WGS84.
+	{Name: "Prolate 1/150", A: 6.4e6, F: -1.0 / 150},
+	{Name: "Prolate WGS84", A: WGS84_A * (1 - WGS84_F), F: flattening_from_ab(WGS84_A*(1-WGS84_F), WGS84_A)},
+}
+
+// normalize_ellipsoid_name returns name in upper case with only its letters and digits,
+// so that "wgs-84" matches "WGS 84"
+func normalize_ellipsoid_name(name string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToUpper(r)
+		}
+		return -1
+	}, name)
+}
+
+// clone returns a copy of e with its own Aliases, so that changing the copy can't change
+// the catalogue
+func (e ReferenceEllipsoid) clone() ReferenceEllipsoid {
+	if e.Aliases != nil {
+		e.Aliases = append([]string(nil), e.Aliases...)
+	}
+	return e
+}
+
+// ReferenceEllipsoids returns all of the ellipsoids in the catalogue
+func ReferenceEllipsoids() []ReferenceEllipsoid {
+	res := make([]ReferenceEllipsoid, len(_REFERENCE_ELLIPSOIDS))
+	for i, e := range _REFERENCE_ELLIPSOIDS {
+		res[i] = e.clone()
+	}
+	return res
+}
+
+// LookupEllipsoidByName returns the ellipsoid in the catalogue called name, e.g. "GRS80"
+// or "Clarke 1866". The match ignores case, spaces and punctuation, and includes the
+// aliases of the ellipsoids.
+func LookupEllipsoidByName(name string) (ReferenceEllipsoid, error) {
+	key := normalize_ellipsoid_name(name)
+	for _, e := range _REFERENCE_ELLIPSOIDS {
+		if normalize_ellipsoid_name(e.Name) == key {
+			return e.clone(), nil
+		}
+		for _, alias := range e.Aliases {
+			if normalize_ellipsoid_name(alias) == key {
+				return e.clone(), nil
+			}
+		}
+	}
+	return ReferenceEllipsoid{}, fmt.Errorf("unknown ellipsoid %q", name)
+}
+
+// LookupEllipsoidByEPSG returns the ellipsoid in the catalogue with the EPSG code code,
+// e.g. 7019 for GRS 1980
+func LookupEllipsoidByEPSG(code int) (ReferenceEllipsoid, error) {
+	if code > 0 {
+		for _, e := range _REFERENCE_ELLIPSOIDS {
+			if e.EPSG == code {
+				return e.clone(), nil
+			}
+		}
+	}
+	return ReferenceEllipsoid{}, fmt.Errorf("unknown EPSG ellipsoid code %d", code)
+}
@@ -0,0 +1,144 @@
+package geographiclibgo
+
+import (
+	"math"
+	"testing"
+)
+
+func TestLookupEllipsoidByName(t *testing.T) {
+	testCases := []struct {
+		name string
+		want string
+		a    float64
+		invf float64
+	}{
+		{"WGS84", "WGS 84", 6378137, 298.257223563},
+		{"grs80", "GRS 1980", 6378137, 298.257222101},
+		{"GRS 1980", "GRS 1980", 6378137, 298.257222101},
+		{"WGS-72", "WGS 72", 6378135, 298.26},
+		{"Clarke 1866", "Clarke 1866", 6378206.4, 294.9786982},
+		{"Clarke 1880", "Clarke 1880 (RGS)", 6378249.145, 293.465},
+		{"bessel 1841", "Bessel 1841", 6377397.155, 299.1528128},
+		{"Airy 1830", "Airy 1830", 6377563.396, 299.3249646},
+		{"Hayford 1909", "International 1924", 6378388, 297},
+		{"Krassovsky", "Krassowsky 1940", 6378245, 298.3},
+		{"MARS", "Mars", 3396190, 169.8944472},
+		{"Moon", "Moon", 1737400, math.Inf(1)},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			e, err := LookupEllipsoidByName(tC.name)
+			if err != nil {
+				t.Fatalf("LookupEllipsoidByName() error = %v", err)
+			}
+			if e.Name != tC.want || e.A != tC.a || !(math.Abs(e.InverseFlattening()-tC.invf) < 1e-7 ||
+				e.InverseFlattening() == tC.invf) {
+				t.Errorf("LookupEllipsoidByName(%q) = %+v, 1/f = %v", tC.name, e, e.InverseFlattening())
+			}
+		})
+	}
+	if e, err := LookupEllipsoidByName("Clarke 1867"); err == nil {
+		t.Errorf("LookupEllipsoidByName(Clarke 1867) = %+v; want error", e)
+	}
+}
+
+func TestLookupEllipsoidByEPSG(t *testing.T) {
+	for _, e := range ReferenceEllipsoids() {
+		if e.EPSG == 0 {
+			continue
+		}
+		got, err := LookupEllipsoidByEPSG(e.EPSG)
+		if err != nil || got.Name != e.Name {
+			t.Errorf("LookupEllipsoidByEPSG(%d) = %+v, %v; want %s", e.EPSG, got, err, e.Name)
+		}
+	}
+	if e, _ := LookupEllipsoidByEPSG(7019); e.Name != "GRS 1980" {
+		t.Errorf("LookupEllipsoidByEPSG(7019) = %+v", e)
+	}
+	for _, code := range []int{0, -1, 4326} {
+		if e, err := LookupEllipsoidByEPSG(code); err == nil {
+			t.Errorf("LookupEllipsoidByEPSG(%d) = %+v; want error", code, e)
+		}
+	}
+}
+
+func TestReferenceEllipsoids(t *testing.T) {
+	all := ReferenceEllipsoids()
+	names := make(map[string]bool)
+	prolate := 0
+	for _, e := range all {
+		if names[e.Name] {
+			t.Errorf("duplicate ellipsoid %s", e.Name)
+		}
+		names[e.Name] = true
+		if got, err := LookupEllipsoidByName(e.Name); err != nil || got.Name != e.Name {
+			t.Errorf("LookupEllipsoidByName(%q) = %+v, %v", e.Name, got, err)
+		}
+		for _, alias := range e.Aliases {
+			if got, _ := LookupEllipsoidByName(alias); got.Name != e.Name {
+				t.Errorf("LookupEllipsoidByName(%q) = %+v; want %s", alias, got, e.Name)
+			}
+		}
+		if e.F < 0 {
+			prolate++
+		}
+		// Geodesics work on every ellipsoid: a quarter meridian has the length given by
+		// the Ellipsoid
+		g := e.Geodesic()
+		ell := e.Ellipsoid()
+		if s := g.InverseCalcDistance(0, 0, 90, 0); !almost_equal(s, ell.QuarterMeridian(), 1e-9*s) {
+			t.Errorf("%s quarter meridian = %v; want %v", e.Name, s, ell.QuarterMeridian())
+		}
+		if !almost_equal(e.MinorRadius(), ell.MinorRadius(), 1e-6) {
+			t.Errorf("%s MinorRadius() = %v; want %v", e.Name, e.MinorRadius(), ell.MinorRadius())
+		}
+	}
+	if prolate != 2 {
+		t.Errorf("%d prolate ellipsoids; want 2", prolate)
+	}
+	// The catalogue can't be modified through the result
+	all[0].A = 0
+	if e, _ := LookupEllipsoidByName("WGS84"); e.A != WGS84_A {
+		t.Errorf("catalogue modified: %+v", e)
+	}
+	// Nor through the aliases of any of the results
+	all = ReferenceEllipsoids()
+	for i := range all[0].Aliases {
+		all[0].Aliases[i] = "x"
+	}
+	byname, _ := LookupEllipsoidByName("WGS 84")
+	byname.Aliases[0] = "x"
+	byepsg, _ := LookupEllipsoidByEPSG(7030)
+	byepsg.Aliases[0] = "x"
+	if e, err := LookupEllipsoidByName("WGS84"); err != nil || e.Name != "WGS 84" {
+		t.Errorf("LookupEllipsoidByName(\"WGS84\") = %+v, %v after changing the aliases", e, err)
+	}
+	if e, _ := LookupEllipsoidByName("x"); e.Name != "" {
+		t.Errorf("LookupEllipsoidByName(\"x\") = %+v; want error", e)
+	}
+}
+
+func TestNewGeodesicFrom(t *testing.T) {
+	testCases := []struct {
+		desc string
+		g    Geodesic
+		f    float64
+	}{
+		{"a and b", NewGeodesicFromAB(6378206.4, 6356583.8), (6378206.4 - 6356583.8) / 6378206.4},
+		{"inverse flattening", NewGeodesicFromInverseFlattening(WGS84_A, 298.257223563), WGS84_F},
+		{"sphere", NewGeodesicFromInverseFlattening(WGS84_A, 0), 0},
+		{"eccentricity", NewGeodesicFromEccentricity(WGS84_A, 0.0818191908426215), WGS84_F},
+		{"prolate", NewGeodesicFromAB(6356752.314245, WGS84_A), (6356752.314245 - WGS84_A) / 6356752.314245},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			if !almost_equal(tC.g.Flattening(), tC.f, 1e-15) {
+				t.Errorf("Flattening() = %v; want %v", tC.g.Flattening(), tC.f)
+			}
+		})
+	}
+	// Small eccentricities don't lose precision
+	if f := flattening_from_eccentricity(1e-9); !almost_equal(f, 5e-19, 1e-30) {
+		t.Errorf("flattening_from_eccentricity(1e-9) = %v", f)
+	}
+}
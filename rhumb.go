@@ -0,0 +1,538 @@
+package geographiclibgo
+
+import "math"
+
+// Rhumb solves the direct and inverse problems for rhumb lines (also called loxodromes)
+// on an ellipsoid. A rhumb line is a path of constant azimuth; it is not the shortest
+// path between two points (that is the geodesic, see Geodesic), but it is the course
+// sailed when the heading is held fixed.
+//
+// Direct:
+//
+// Place a second point, given the first point, the azimuth of the rhumb line, and a
+// distance.
+//
+// Inverse:
+//
+// Find the distance and azimuth of the rhumb line between two points. Of the two rhumb
+// lines between a pair of points, the one which takes the shorter way in longitude is
+// used, i.e. |lon2 - lon1| <= 180 degrees.
+//
+// Both problems can also return S12, the area between the rhumb line from point 1 to
+// point 2 and the equator, measured counter-clockwise [meters^2]. Rhumb lines may be used
+// as the edges of a polygon with NewPolygonAreaRhumb().
+//
+// The results are accurate for any flattening; the meridian distance is computed in
+// terms of elliptic integrals, and the area with a sine series whose order is chosen
+// when the Rhumb is created.
+type Rhumb struct {
+	a   float64
+	f   float64
+	f1  float64
+	e2  float64
+	es  float64
+	ep2 float64
+	b   float64
+
+	// The area of the ellipsoid divided by 720 [meters^2]
+	c2 float64
+
+	// The quarter meridian [meters]
+	qm float64
+
+	// The elliptic integrals for the meridian distance, with k2 = -ep2
+	ell EllipticFunction
+
+	// The value of the authalic q at the pole
+	qp float64
+
+	// Coefficients of the sine series for the area integrand, in terms of the parametric
+	// latitude beta, sum(pP[k] * sin(2*k*beta), k, 1, N-1)
+	pP []float64
+}
+
+func NewRhumb(a, f float64) Rhumb {
+	_f1 := 1.0 - f
+	_e2 := f * (2.0 - f)
+	_ep2 := _e2 / sq(_f1)
+	_b := a * _f1
+	_es := math.Sqrt(math.Abs(_e2))
+	if f < 0.0 {
+		_es = -_es
+	}
+
+	// The authalic radius squared
+	to_mul := eatanhe(1.0, _es) / _e2
+	if _e2 == 0.0 {
+		to_mul = 1.0
+	}
+	area := 4 * math.Pi * (sq(a) + sq(_b)*to_mul) / 2.0
+
+	ell := NewEllipticFunction(-_ep2, 0)
+
+	r := Rhumb{
+		a:   a,
+		f:   f,
+		f1:  _f1,
+		e2:  _e2,
+		es:  _es,
+		ep2: _ep2,
+		b:   _b,
+		c2:  area / 720.0,
+		qm:  _b * ell.E(),
+		ell: ell,
+	}
+	r.qp = r._q(1.0)
+	r.pP = r._area_coeffs(get_epsilon())
+	return r
+}
+
+func Wgs84Rhumb() Rhumb {
+	return NewRhumb(WGS84_A, WGS84_F)
+}
+
+// EquatorialRadius returns the equatorial radius of the ellipsoid [meters]
+func (r *Rhumb) EquatorialRadius() float64 {
+	return r.a
+}
+
+// Flattening returns the flattening of the ellipsoid
+func (r *Rhumb) Flattening() float64 {
+	return r.f
+}
+
+// EllipsoidArea returns the total area of the ellipsoid [meters^2]
+func (r *Rhumb) EllipsoidArea() float64 {
+	return 720.0 * r.c2
+}
+
+// _q is the authalic q as a function of x = sin(phi); the sine of the authalic latitude
+// is q(sin(phi)) / q(1)
+func (r *Rhumb) _q(x float64) float64 {
+	atanhee := x
+	if r.e2 != 0.0 {
+		atanhee = eatanhe(x, r.es) / r.e2
+	}
+	return (1.0 - r.e2) * (x/(1.0-r.e2*sq(x)) + atanhee)
+}
+
+// _area_integrand is d(S(psi))/d(beta) - d(log(sec(chi)))/d(beta), where S is the
+// integral of sin(xi) with respect to the isometric latitude psi; beta is the parametric
+// latitude, chi the conformal latitude, and xi the authalic latitude. This is
+// (1-f) * (sin(xi) - sin(chi)) / cos(phi), an odd function of beta with period pi.
+//
+// beta is restricted to (0, pi/2). Near the pole sin(xi) and sin(chi) both approach 1,
+// so the difference is found as (1 - sin(chi)) - (1 - sin(xi)) with each term evaluated
+// without cancellation.
+func (r *Rhumb) _area_integrand(beta float64) float64 {
+	sbet, cbet := math.Sincos(beta)
+	sphi, cphi := norm(sbet, r.f1*cbet)
+	// 1 - sin(phi)
+	omsphi := sq(cphi) / (1.0 + sphi)
+
+	taup := taupf(sphi/cphi, r.es)
+	h := math.Hypot(1.0, taup)
+	omschi := 1.0 / (h * (h + taup))
+
+	// (q(1) - q(sin(phi))) / q(1)
+	omsxi := omsphi * ((1.0+r.e2*sphi)/(1.0-r.e2*sq(sphi)) +
+		(1.0-r.e2)*r._Datanhee(1.0, sphi)) / r.qp
+
+	return r.f1 * (omschi - omsxi) / cphi
+}
+
+// _area_coeffs finds the coefficients of the sine series for the area integrand. The
+// number of terms (initially a power of two) is doubled until the upper half of the
+// coefficients are negligible, and then trailing negligible terms are dropped. The series
+// is added to the mean of sin(chi), which is of order 1, so "negligible" is measured in
+// absolute terms. This also allows for the round-off in the integrand near the poles.
+func (r *Rhumb) _area_coeffs(tol float64) []float64 {
+	integrand := func(x float64) float64 { return r._area_integrand(x / 2.0) }
+	var c []float64
+	for n := 16; n <= _MAX_NC4; n *= 2 {
+		c = make([]float64, n)
+		sine_transform(integrand, c)
+		tail := 0.0
+		for _, v := range c[n/2:] {
+			tail = math.Max(tail, math.Abs(v))
+		}
+		if !(tail > 16.0*tol) {
+			break
+		}
+	}
+	k := len(c)
+	for k > 1 && !(math.Abs(c[k-1]) > 16.0*tol) {
+		k--
+	}
+	return c[:k]
+}
+
+// _IsometricLatitude returns the isometric latitude psi [radians] of lat_deg. At the
+// poles this is large, but finite.
+func (r *Rhumb) _IsometricLatitude(lat_deg float64) float64 {
+	return math.Asinh(taupf(tand(lat_deg), r.es))
+}
+
+// _MeridianDistance returns the distance along the meridian from the equator to
+// lat_deg [meters]
+func (r *Rhumb) _MeridianDistance(lat_deg float64) float64 {
+	return r.b * r.ell.EDeg(atand(r.f1*tand(lat_deg)))
+}
+
+// _RectifyingLatitude returns the rectifying latitude mu [degrees] of lat_deg
+func (r *Rhumb) _RectifyingLatitude(lat_deg float64) float64 {
+	if math.Abs(lat_deg) == 90.0 {
+		return lat_deg
+	}
+	return 90.0 * r._MeridianDistance(lat_deg) / r.qm
+}
+
+// _InverseRectifyingLatitude returns the latitude [degrees] whose rectifying latitude
+// is mu_deg
+func (r *Rhumb) _InverseRectifyingLatitude(mu_deg float64) float64 {
+	if math.Abs(mu_deg) == 90.0 {
+		return mu_deg
+	}
+	beta := r.ell.Einv(mu_deg * r.ell.E() / 90.0)
+	sbet, cbet := math.Sincos(beta)
+	return atan2_deg(sbet, r.f1*cbet)
+}
+
+// _CircleRadius returns the radius of the circle of latitude lat_deg [meters]
+func (r *Rhumb) _CircleRadius(lat_deg float64) float64 {
+	if math.Abs(lat_deg) == 90.0 {
+		return 0.0
+	}
+	return r.a / math.Hypot(1.0, r.f1*tand(lat_deg))
+}
+
+// The divided differences, (f(x) - f(y)) / (x - y), of various functions. These are
+// evaluated so as to remain accurate when x and y are close, and reduce to the
+// derivative when x == y.
+
+// dtan is the divided difference of tan, with x and y in degrees. The result is per
+// radian.
+func dtan(x, y float64) float64 {
+	d := x - y
+	tx := tand(x)
+	ty := tand(y)
+	txy := tx * ty
+	if d == 0 {
+		return 1 + txy
+	}
+	if 2*txy > -1 {
+		return (1 + txy) * tand(d) / (d * DEG2RAD)
+	}
+	return (tx - ty) / (d * DEG2RAD)
+}
+
+func datan(x, y float64) float64 {
+	d := x - y
+	xy := x * y
+	if d == 0 {
+		return 1 / (1 + xy)
+	}
+	if 2*xy > -1 {
+		return math.Atan(d/(1+xy)) / d
+	}
+	return (math.Atan(x) - math.Atan(y)) / d
+}
+
+func dsin(x, y float64) float64 {
+	d := (x - y) / 2
+	if d == 0 {
+		return math.Cos((x + y) / 2)
+	}
+	return math.Cos((x+y)/2) * math.Sin(d) / d
+}
+
+func dcosh(x, y float64) float64 {
+	d := (x - y) / 2
+	if d == 0 {
+		return math.Sinh((x + y) / 2)
+	}
+	return math.Sinh((x+y)/2) * math.Sinh(d) / d
+}
+
+func dasinh(x, y float64) float64 {
+	d := x - y
+	hx := math.Hypot(1, x)
+	hy := math.Hypot(1, y)
+	if d == 0 {
+		return 1 / hx
+	}
+	if x*y > 0 {
+		return math.Asinh(d*(x+y)/(x*hy+y*hx)) / d
+	}
+	return math.Asinh(x*hy-y*hx) / d
+}
+
+// dlog is the divided difference of log, for positive x and y
+func dlog(x, y float64) float64 {
+	t := x - y
+	if t == 0 {
+		return 1 / x
+	}
+	// Use asinh(t / (2 * sqrt(x*y))) rather than atanh(t / (x + y)) to avoid taking
+	// atanh(1) when x is large and y is 1
+	return 2 * math.Asinh(t/(2*math.Sqrt(x*y))) / t
+}
+
+// _Deatanhe is the divided difference of eatanhe
+func (r *Rhumb) _Deatanhe(x, y float64) float64 {
+	t := x - y
+	d := 1 - r.e2*x*y
+	if t == 0 {
+		return r.e2 / d
+	}
+	if d <= 0 {
+		// Only possible for a very prolate ellipsoid (e2 < -1), and then x and y are
+		// well separated, so the direct difference is accurate
+		return (eatanhe(x, r.es) - eatanhe(y, r.es)) / t
+	}
+	return eatanhe(t/d, r.es) / t
+}
+
+// _Datanhee is the divided difference of eatanhe(x) / e2, which is x for a sphere
+func (r *Rhumb) _Datanhee(x, y float64) float64 {
+	if r.e2 == 0 {
+		return 1
+	}
+	return r._Deatanhe(x, y) / r.e2
+}
+
+// _DE is the divided difference of the incomplete elliptic integral of the second kind,
+// E(phi), with x and y in radians
+func (r *Rhumb) _DE(x, y float64) float64 {
+	ei := &r.ell
+	d := x - y
+	if x*y <= 0 {
+		if d == 0 {
+			return 1
+		}
+		return (ei.EPhi(x) - ei.EPhi(y)) / d
+	}
+	// See DLMF: Eqs (19.11.2) and (19.11.4) letting theta -> x, phi -> -y, psi -> z
+	//
+	// (E(x) - E(y)) / d = E(z)/d - k2 * sin(x) * sin(y) * sin(z)/d
+	//
+	// tan(z/2) = (sin(x)*Delta(y) - sin(y)*Delta(x)) / (cos(x) + cos(y))
+	//          = d * Dsin(x,y) * (sin(x) + sin(y))/(cos(x) + cos(y)) /
+	//             (sin(x)*Delta(y) + sin(y)*Delta(x))
+	//          = t = d * Dt
+	// sin(z) = 2*t/(1+t^2); cos(z) = (1-t^2)/(1+t^2)
+	sx, cx := math.Sincos(x)
+	sy, cy := math.Sincos(y)
+	Dt := dsin(x, y) * (sx + sy) / ((cx + cy) * (sx*ei.Delta(sy, cy) + sy*ei.Delta(sx, cx)))
+	t := d * Dt
+	Dsz := 2 * Dt / (1 + t*t)
+	sz := d * Dsz
+	cz := (1 - t) * (1 + t) / (1 + t*t)
+	Ez := 1.0
+	if sz != 0 {
+		Ez = ei.ESnCnDn(sz, cz, ei.Delta(sz, cz)) / sz
+	}
+	return (Ez - ei.K2()*sx*sy) * Dsz
+}
+
+// _DMeridian is the divided difference of the meridian distance with respect to the
+// latitude, with latx and laty in degrees [meters per radian]
+func (r *Rhumb) _DMeridian(latx, laty float64) float64 {
+	tbetx := r.f1 * tand(latx)
+	tbety := r.f1 * tand(laty)
+	return r.b * r.f1 * r._DE(math.Atan(tbetx), math.Atan(tbety)) *
+		dtan(latx, laty) * datan(tbetx, tbety)
+}
+
+// _DIsometric is the divided difference of the isometric latitude with respect to the
+// latitude, with latx and laty in degrees [dimensionless]
+func (r *Rhumb) _DIsometric(latx, laty float64) float64 {
+	sphix, _ := sincosd(latx)
+	sphiy, _ := sincosd(laty)
+	return dasinh(tand(latx), tand(laty))*dtan(latx, laty) -
+		r._Deatanhe(sphix, sphiy)*dsin(latx*DEG2RAD, laty*DEG2RAD)
+}
+
+// _MeanSinXi is the mean value of sin(xi), where xi is the authalic latitude, with
+// respect to the isometric latitude between latx and laty [degrees]. The area under a
+// rhumb line is c2 * lon12 * _MeanSinXi(lat2, lat1).
+func (r *Rhumb) _MeanSinXi(latx, laty float64) float64 {
+	psix := r._IsometricLatitude(latx)
+	psiy := r._IsometricLatitude(laty)
+
+	// The integral of sin(chi) with respect to psi is log(cosh(psi))
+	mean := dlog(math.Cosh(psix), math.Cosh(psiy)) * dcosh(psix, psiy)
+
+	// The divided difference of the integral of the sine series with respect to beta,
+	// sum(pP[k] * sin(k*(x+y)) * sin(k*(x-y))/(k*(x-y)), k, 1, N-1), evaluated with the
+	// recurrence sin((k+1)*u) = 2*cos(u)*sin(k*u) - sin((k-1)*u)
+	tbetx := r.f1 * tand(latx)
+	tbety := r.f1 * tand(laty)
+	betx := math.Atan(tbetx)
+	bety := math.Atan(tbety)
+	s := betx + bety
+	d := betx - bety
+	ss, cs := math.Sincos(s)
+	sd, cd := math.Sincos(d)
+	sks, skm1s := ss, 0.0
+	skd, skm1d := sd, 0.0
+	dp := 0.0
+	for k := 1; k < len(r.pP); k++ {
+		sinc := 1.0
+		if d != 0 {
+			sinc = skd / (float64(k) * d)
+		}
+		dp += r.pP[k] * sks * sinc
+		sks, skm1s = 2*cs*sks-skm1s, sks
+		skd, skm1d = 2*cd*skd-skm1d, skd
+	}
+	if dp == 0 {
+		return mean
+	}
+
+	// Convert to the divided difference with respect to psi
+	dbetdpsi := r.f1 * datan(tbetx, tbety) * dtan(latx, laty) / r._DIsometric(latx, laty)
+	return mean + dp*dbetdpsi
+}
+
+// _gen_inverse solves the inverse rhumb line problem. outmask is one or more of
+// AZIMUTH, DISTANCE, and AREA; results not asked for are math.NaN().
+func (r *Rhumb) _gen_inverse(lat1, lon1, lat2, lon2 float64, outmask uint64) (
+	float64, // s12
+	float64, // azi12
+	float64, // S12
+) {
+	s12 := math.NaN()
+	azi12 := math.NaN()
+	S12 := math.NaN()
+
+	lat1 = lat_fix(lat1)
+	lat2 = lat_fix(lat2)
+	lon12, _ := ang_diff(lon1, lon2)
+	psi1 := r._IsometricLatitude(lat1)
+	psi2 := r._IsometricLatitude(lat2)
+	psi12 := psi2 - psi1
+	lam12 := lon12 * DEG2RAD
+
+	if outmask&AZIMUTH != 0 {
+		azi12 = atan2_deg(lam12, psi12)
+	}
+	if outmask&DISTANCE != 0 {
+		h := math.Hypot(lam12, psi12)
+		s12 = h * r._DMeridian(lat2, lat1) / r._DIsometric(lat2, lat1)
+	}
+	if outmask&AREA != 0 {
+		S12 = r.c2 * lon12 * r._MeanSinXi(lat2, lat1)
+	}
+	return s12, azi12, S12
+}
+
+// _gen_direct solves the direct rhumb line problem. outmask is one or more of LATITUDE,
+// LONGITUDE, AREA, and LONG_UNROLL; results not asked for are math.NaN().
+func (r *Rhumb) _gen_direct(lat1, lon1, azi12, s12 float64, outmask uint64) (
+	float64, // lat2
+	float64, // lon2
+	float64, // S12
+) {
+	line := NewRhumbLine(*r, lat1, lon1, azi12)
+	return line._gen_position(s12, outmask)
+}
+
+// RhumbDirectResults contains the results of the direct rhumb line problem
+type RhumbDirectResults struct {
+	LatDeg float64 // Latitude of point 2 [degrees]
+	LonDeg float64 // Longitude of point 2 [degrees]
+	S12M2  float64 // Area under the rhumb line [meters^2]
+}
+
+// DirectCalcLatLon gets the lat and lon of the second point, based on input
+//   - lat1_deg - Latitude of 1st point [degrees] [-90.,90.]
+//   - lon1_deg - Longitude of 1st point [degrees] [-180., 180.]
+//   - azi12_deg - Azimuth of the rhumb line [degrees] [-180., 180.]
+//   - s12_m - Distance from 1st to 2nd point [meters] Value may be negative
+func (r *Rhumb) DirectCalcLatLon(lat1_deg, lon1_deg, azi12_deg, s12_m float64) LatLon {
+	capabilities := LATITUDE | LONGITUDE
+	lat2, lon2, _ := r._gen_direct(lat1_deg, lon1_deg, azi12_deg, s12_m, capabilities)
+	return LatLon{LatDeg: lat2, LonDeg: lon2}
+}
+
+// DirectCalcAll calculates the lat and lon of the second point, and the area under the
+// rhumb line. Takes inputs
+//   - lat1_deg - Latitude of 1st point [degrees] [-90.,90.]
+//   - lon1_deg - Longitude of 1st point [degrees] [-180., 180.]
+//   - azi12_deg - Azimuth of the rhumb line [degrees] [-180., 180.]
+//   - s12_m - Distance from 1st to 2nd point [meters] Value may be negative
+func (r *Rhumb) DirectCalcAll(lat1_deg, lon1_deg, azi12_deg, s12_m float64) RhumbDirectResults {
+	capabilities := LATITUDE | LONGITUDE | AREA
+	lat2, lon2, S12 := r._gen_direct(lat1_deg, lon1_deg, azi12_deg, s12_m, capabilities)
+	return RhumbDirectResults{LatDeg: lat2, LonDeg: lon2, S12M2: S12}
+}
+
+// DirectCalcWithCapabilities allows the user to specify which capabilites they wish to use.
+// Takes inputs
+//   - lat1_deg - Latitude of 1st point [degrees] [-90.,90.]
+//   - lon1_deg - Longitude of 1st point [degrees] [-180., 180.]
+//   - azi12_deg - Azimuth of the rhumb line [degrees] [-180., 180.]
+//   - s12_m - Distance from 1st to 2nd point [meters] Value may be negative
+//   - capabilities - One or more of LATITUDE, LONGITUDE, AREA, and LONG_UNROLL, OR'd
+//     together
+func (r *Rhumb) DirectCalcWithCapabilities(
+	lat1_deg, lon1_deg, azi12_deg, s12_m float64,
+	capabilities uint64,
+) RhumbDirectResults {
+	lat2, lon2, S12 := r._gen_direct(lat1_deg, lon1_deg, azi12_deg, s12_m, capabilities)
+	return RhumbDirectResults{LatDeg: lat2, LonDeg: lon2, S12M2: S12}
+}
+
+// RhumbInverseResults contains the results of the inverse rhumb line problem
+type RhumbInverseResults struct {
+	DistanceM  float64 // distance between point 1 and point 2 [meters]
+	AzimuthDeg float64 // azimuth of the rhumb line [degrees]
+	S12M2      float64 // area under the rhumb line [meters^2]
+}
+
+// InverseCalcDistance returns the distance along the rhumb line from one point to the
+// next. Takes inputs
+// - lat1_deg latitude of point 1 [degrees].
+// - lon1_deg longitude of point 1 [degrees].
+// - lat2_deg latitude of point 2 [degrees].
+// - lon2_deg longitude of point 2 [degrees].
+func (r *Rhumb) InverseCalcDistance(lat1_deg, lon1_deg, lat2_deg, lon2_deg float64) float64 {
+	capabilities := DISTANCE
+	s12, _, _ := r._gen_inverse(lat1_deg, lon1_deg, lat2_deg, lon2_deg, capabilities)
+	return s12
+}
+
+// InverseCalcAll returns the distance, azimuth, and area under the rhumb line between
+// two points. Takes inputs
+// - lat1_deg latitude of point 1 [degrees].
+// - lon1_deg longitude of point 1 [degrees].
+// - lat2_deg latitude of point 2 [degrees].
+// - lon2_deg longitude of point 2 [degrees].
+func (r *Rhumb) InverseCalcAll(lat1_deg, lon1_deg, lat2_deg, lon2_deg float64) RhumbInverseResults {
+	capabilities := DISTANCE | AZIMUTH | AREA
+	s12, azi12, S12 := r._gen_inverse(lat1_deg, lon1_deg, lat2_deg, lon2_deg, capabilities)
+	return RhumbInverseResults{DistanceM: s12, AzimuthDeg: azi12, S12M2: S12}
+}
+
+// InverseCalcWithCapabilities allows the user to specify which capabilites they wish to
+// use. Takes inputs
+//   - lat1_deg latitude of point 1 [degrees].
+//   - lon1_deg longitude of point 1 [degrees].
+//   - lat2_deg latitude of point 2 [degrees].
+//   - lon2_deg longitude of point 2 [degrees].
+//   - capabilities - One or more of DISTANCE, AZIMUTH, and AREA, OR'd together
+func (r *Rhumb) InverseCalcWithCapabilities(
+	lat1_deg, lon1_deg, lat2_deg, lon2_deg float64,
+	capabilities uint64,
+) RhumbInverseResults {
+	s12, azi12, S12 := r._gen_inverse(lat1_deg, lon1_deg, lat2_deg, lon2_deg, capabilities)
+	return RhumbInverseResults{DistanceM: s12, AzimuthDeg: azi12, S12M2: S12}
+}
+
+// InverseLine defines a RhumbLine in terms of the inverse rhumb line problem, starting at
+// point 1 and heading towards point 2
+func (r *Rhumb) InverseLine(lat1_deg, lon1_deg, lat2_deg, lon2_deg float64) RhumbLine {
+	_, azi12, _ := r._gen_inverse(lat1_deg, lon1_deg, lat2_deg, lon2_deg, AZIMUTH)
+	return NewRhumbLine(*r, lat1_deg, lon1_deg, azi12)
+}
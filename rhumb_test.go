@@ -0,0 +1,178 @@
+package geographiclibgo
+
+import (
+	"math"
+	"testing"
+)
+
+// JFK airport to Singapore Changi airport, from the RhumbSolve documentation
+var jfk_lat = 40 + 38/60.0 + 23/3600.0
+var jfk_lon = -(73 + 46/60.0 + 44/3600.0)
+var sin_lat = 1 + 21/60.0 + 33/3600.0
+var sin_lon = 103 + 59/60.0 + 22/3600.0
+
+func TestRhumbInverse(t *testing.T) {
+	r := Wgs84Rhumb()
+	got := r.InverseCalcAll(jfk_lat, jfk_lon, sin_lat, sin_lon)
+
+	want_azi := 103 + 34/60.0 + 58.2/3600.0
+	want_s12 := 18523563.042
+
+	if !almost_equal(got.AzimuthDeg, want_azi, 0.05/3600.0) {
+		t.Errorf("azi12 = %v; want %v", got.AzimuthDeg, want_azi)
+	}
+	if !almost_equal(got.DistanceM, want_s12, 1e-3) {
+		t.Errorf("s12 = %v; want %v", got.DistanceM, want_s12)
+	}
+	if s12 := r.InverseCalcDistance(jfk_lat, jfk_lon, sin_lat, sin_lon); s12 != got.DistanceM {
+		t.Errorf("InverseCalcDistance() = %v; want %v", s12, got.DistanceM)
+	}
+
+	// Reversing the direction of travel reverses the azimuth and the sign of the area
+	rev := r.InverseCalcAll(sin_lat, sin_lon, jfk_lat, jfk_lon)
+	if !almost_equal(rev.DistanceM, got.DistanceM, 1e-8) {
+		t.Errorf("reversed s12 = %v; want %v", rev.DistanceM, got.DistanceM)
+	}
+	if !almost_equal(rev.AzimuthDeg, got.AzimuthDeg-180, 1e-12) {
+		t.Errorf("reversed azi12 = %v; want %v", rev.AzimuthDeg, got.AzimuthDeg-180)
+	}
+	if !almost_equal(rev.S12M2, -got.S12M2, 1e-2) {
+		t.Errorf("reversed S12 = %v; want %v", rev.S12M2, -got.S12M2)
+	}
+}
+
+func BenchmarkRhumbInverse(b *testing.B) {
+	r := Wgs84Rhumb()
+	for i := 0; i < b.N; i++ {
+		r.InverseCalcAll(jfk_lat, jfk_lon, sin_lat, sin_lon)
+	}
+}
+
+func TestRhumbDirect(t *testing.T) {
+	r := Wgs84Rhumb()
+	inv := r.InverseCalcAll(jfk_lat, jfk_lon, sin_lat, sin_lon)
+	got := r.DirectCalcAll(jfk_lat, jfk_lon, inv.AzimuthDeg, inv.DistanceM)
+
+	if !almost_equal(got.LatDeg, sin_lat, 1e-12) {
+		t.Errorf("lat2 = %v; want %v", got.LatDeg, sin_lat)
+	}
+	if !almost_equal(got.LonDeg, sin_lon, 1e-12) {
+		t.Errorf("lon2 = %v; want %v", got.LonDeg, sin_lon)
+	}
+	if !almost_equal(got.S12M2, inv.S12M2, 1e-1) {
+		t.Errorf("S12 = %v; want %v", got.S12M2, inv.S12M2)
+	}
+
+	ll := r.DirectCalcLatLon(jfk_lat, jfk_lon, inv.AzimuthDeg, inv.DistanceM)
+	if ll.LatDeg != got.LatDeg || ll.LonDeg != got.LonDeg {
+		t.Errorf("DirectCalcLatLon() = %v; want %v", ll, LatLon{got.LatDeg, got.LonDeg})
+	}
+
+	// With LONG_UNROLL the longitude is not reduced to [-180, 180]
+	unrolled := r.DirectCalcWithCapabilities(0, 170, 90, 2e6, LATITUDE|LONGITUDE|LONG_UNROLL)
+	rolled := r.DirectCalcWithCapabilities(0, 170, 90, 2e6, LATITUDE|LONGITUDE)
+	if !almost_equal(unrolled.LonDeg-360, rolled.LonDeg, 1e-12) || unrolled.LonDeg <= 180 {
+		t.Errorf("unrolled lon2 = %v, lon2 = %v", unrolled.LonDeg, rolled.LonDeg)
+	}
+	if !math.IsNaN(rolled.S12M2) {
+		t.Errorf("S12 = %v; want NaN", rolled.S12M2)
+	}
+}
+
+func BenchmarkRhumbDirect(b *testing.B) {
+	r := Wgs84Rhumb()
+	for i := 0; i < b.N; i++ {
+		r.DirectCalcAll(jfk_lat, jfk_lon, 103.5828, 18523563.042)
+	}
+}
+
+func TestRhumbMeridianAndParallel(t *testing.T) {
+	r := Wgs84Rhumb()
+	g := Wgs84()
+
+	// Along a meridian, the rhumb line is the geodesic
+	rh := r.InverseCalcAll(-30, 20, 50, 20)
+	geo := g.InverseCalcDistanceAzimuths(-30, 20, 50, 20)
+	if !almost_equal(rh.DistanceM, geo.DistanceM, 1e-8) {
+		t.Errorf("meridian s12 = %v; want %v", rh.DistanceM, geo.DistanceM)
+	}
+	if rh.AzimuthDeg != 0 {
+		t.Errorf("meridian azi12 = %v; want 0", rh.AzimuthDeg)
+	}
+
+	// Along a parallel, the rhumb line is the circle of latitude
+	lat := 45.0
+	rh = r.InverseCalcAll(lat, -10, lat, 50)
+	want := 60 * DEG2RAD * WGS84_A / math.Hypot(1, (1-WGS84_F)*math.Tan(lat*DEG2RAD))
+	if !almost_equal(rh.DistanceM, want, 1e-8) {
+		t.Errorf("parallel s12 = %v; want %v", rh.DistanceM, want)
+	}
+	if rh.AzimuthDeg != 90 {
+		t.Errorf("parallel azi12 = %v; want 90", rh.AzimuthDeg)
+	}
+
+	// The quarter meridian
+	s12 := r.InverseCalcDistance(0, 0, 90, 0)
+	if !almost_equal(s12, 10001965.729, 1e-3) {
+		t.Errorf("quarter meridian = %v; want %v", s12, 10001965.729)
+	}
+}
+
+func TestRhumbSphere(t *testing.T) {
+	R := 6371e3
+	r := NewRhumb(R, 0)
+	lat1, lon1, lat2, lon2 := -20.0, 30.0, 55.0, -160.0
+	got := r.InverseCalcAll(lat1, lon1, lat2, lon2)
+
+	psi := func(lat float64) float64 { return math.Asinh(math.Tan(lat * DEG2RAD)) }
+	lam12 := 170 * DEG2RAD
+	psi12 := psi(lat2) - psi(lat1)
+	alp := math.Atan2(lam12, psi12)
+	want_s12 := R * (lat2 - lat1) * DEG2RAD / math.Cos(alp)
+	// The mean of sin(phi) = tanh(psi) with respect to psi
+	mean := (math.Log(math.Cosh(psi(lat2))) - math.Log(math.Cosh(psi(lat1)))) / psi12
+	want_S12 := R * R * lam12 * mean
+
+	if !almost_equal(got.AzimuthDeg, alp*RAD2DEG, 1e-12) {
+		t.Errorf("azi12 = %v; want %v", got.AzimuthDeg, alp*RAD2DEG)
+	}
+	if !almost_equal(got.DistanceM, want_s12, 1e-6) {
+		t.Errorf("s12 = %v; want %v", got.DistanceM, want_s12)
+	}
+	if !almost_equal(got.S12M2, want_S12, 1e-1) {
+		t.Errorf("S12 = %v; want %v", got.S12M2, want_S12)
+	}
+}
+
+func TestRhumbRoundTrip(t *testing.T) {
+	for _, f := range []float64{0.9, 0.5, 0.1, WGS84_F, 0, -0.1, -0.5, -2} {
+		r := NewRhumb(6.4e6, f)
+		for _, pts := range [][4]float64{
+			{-30, 20, 50, 60},
+			{10, -170, 12, 170},
+			{80, 0, 89.9, 135},
+			{-45, 0, -45.0000001, 1e-7},
+		} {
+			inv := r.InverseCalcAll(pts[0], pts[1], pts[2], pts[3])
+			dir := r.DirectCalcAll(pts[0], pts[1], inv.AzimuthDeg, inv.DistanceM)
+			if !almost_equal(dir.LatDeg, pts[2], 1e-11) {
+				t.Errorf("f = %v, %v -- lat2 = %v; want %v", f, pts, dir.LatDeg, pts[2])
+			}
+			if !almost_equal(ang_diff_deg(dir.LonDeg, pts[3]), 0, 1e-10) {
+				t.Errorf("f = %v, %v -- lon2 = %v; want %v", f, pts, dir.LonDeg, pts[3])
+			}
+			if !almost_equal(dir.S12M2, inv.S12M2, math.Max(1, 1e-12*math.Abs(inv.S12M2))) {
+				t.Errorf("f = %v, %v -- S12 = %v; want %v", f, pts, dir.S12M2, inv.S12M2)
+			}
+		}
+	}
+}
+
+func TestRhumbEllipsoidArea(t *testing.T) {
+	r := Wgs84Rhumb()
+	g := Wgs84()
+	p := NewPolygonArea(g, false)
+	if !almost_equal(r.EllipsoidArea(), p.Area0_M2, 1) {
+		t.Errorf("EllipsoidArea() = %v; want %v", r.EllipsoidArea(), p.Area0_M2)
+	}
+}
@@ -0,0 +1,161 @@
+package geographiclibgo
+
+import "math"
+
+// RhumbLine is a rhumb line (a path of constant azimuth) starting at a given point. It
+// allows the positions of a series of points (waypoints) along the line to be computed
+// efficiently.
+type RhumbLine struct {
+	rh    Rhumb
+	lat1  float64
+	lon1  float64
+	azi12 float64
+	salp  float64
+	calp  float64
+	mu1   float64
+	psi1  float64
+	r1    float64
+}
+
+// NewRhumbLine creates a RhumbLine starting at point 1 with the given azimuth.
+//   - rh - the Rhumb on which the line lies
+//   - lat1_deg - Latitude of point 1 [degrees] [-90.,90.]
+//   - lon1_deg - Longitude of point 1 [degrees] [-180., 180.]
+//   - azi12_deg - Azimuth of the rhumb line [degrees] [-180., 180.]
+func NewRhumbLine(rh Rhumb, lat1_deg, lon1_deg, azi12_deg float64) RhumbLine {
+	lat1 := lat_fix(lat1_deg)
+	azi12 := ang_normalize(azi12_deg)
+	salp, calp := sincosd(azi12)
+	return RhumbLine{
+		rh:    rh,
+		lat1:  lat1,
+		lon1:  lon1_deg,
+		azi12: azi12,
+		salp:  salp,
+		calp:  calp,
+		mu1:   rh._RectifyingLatitude(lat1),
+		psi1:  rh._IsometricLatitude(lat1),
+		r1:    rh._CircleRadius(lat1),
+	}
+}
+
+// _gen_position finds the point a distance s12 along the line. outmask is one or more of
+// LATITUDE, LONGITUDE, AREA, and LONG_UNROLL; results not asked for are math.NaN(). If
+// the line passes over a pole, the longitude and area are math.NaN().
+func (l RhumbLine) _gen_position(s12 float64, outmask uint64) (
+	float64, // lat2
+	float64, // lon2
+	float64, // S12
+) {
+	lat2 := math.NaN()
+	lon2 := math.NaN()
+	S12 := math.NaN()
+
+	mu12 := s12 * l.calp * 90.0 / l.rh.qm
+	mu2 := l.mu1 + mu12
+
+	var lat2x, lon2x float64
+	if math.Abs(mu2) <= 90.0 {
+		var psi12 float64
+		if l.calp != 0 {
+			lat2x = l.rh._InverseRectifyingLatitude(mu2)
+			// The divided difference of the isometric latitude with respect to the
+			// rectifying latitude
+			dpsidmu := l.rh._DIsometric(lat2x, l.lat1) * l.rh.qm /
+				(l.rh._DMeridian(lat2x, l.lat1) * math.Pi / 2.0)
+			psi12 = dpsidmu * mu12 * DEG2RAD
+			lon2x = l.salp * psi12 / l.calp * RAD2DEG
+		} else {
+			lat2x = l.lat1
+			lon2x = l.salp * s12 / l.r1 * RAD2DEG
+		}
+		if outmask&AREA != 0 {
+			S12 = l.rh.c2 * lon2x * l.rh._MeanSinXi(l.lat1, lat2x)
+		}
+		if outmask&LONG_UNROLL != 0 {
+			lon2x = l.lon1 + lon2x
+		} else {
+			lon2x = ang_normalize(ang_normalize(l.lon1) + lon2x)
+		}
+	} else {
+		// Reduce to the interval [-180, 180)
+		mu2 = ang_normalize(mu2)
+		// Deal with points on the anti-meridian
+		if math.Abs(mu2) > 90.0 {
+			mu2 = ang_normalize(180.0 - mu2)
+		}
+		lat2x = l.rh._InverseRectifyingLatitude(mu2)
+		lon2x = math.NaN()
+	}
+
+	if outmask&LATITUDE != 0 {
+		lat2 = lat2x
+	}
+	if outmask&LONGITUDE != 0 {
+		lon2 = lon2x
+	}
+	return lat2, lon2, S12
+}
+
+type RhumbPositionResult struct {
+	Lat1Deg   float64 // Latitude of point 1 [degrees]
+	Lon1Deg   float64 // Longitude of point 1 [degrees]
+	AziDeg    float64 // Azimuth of the rhumb line [degrees]
+	Lat2Deg   float64 // Latitude of point 2 [degrees]
+	Lon2Deg   float64 // Longitude of point 2 [degrees]
+	DistanceM float64 // Distance from point 1 to point 2 [meters]
+	S12M2     float64 // Area under the rhumb line [meters^2]
+}
+
+// Position finds the position on the line given s12_m [meters], and the area under the
+// rhumb line from point 1 to that position
+func (l RhumbLine) Position(s12_m float64) RhumbPositionResult {
+	return l.PositionWithCapabilities(s12_m, LATITUDE|LONGITUDE|AREA)
+}
+
+// PositionWithCapabilities finds the position on the line given s12_m [meters]. The
+// capabilities are one or more of LATITUDE, LONGITUDE, AREA, and LONG_UNROLL. Any
+// results not asked for with the capabilities will be math.NaN()
+func (l RhumbLine) PositionWithCapabilities(s12_m float64, capabilities uint64) RhumbPositionResult {
+	lat2, lon2, S12 := l._gen_position(s12_m, capabilities)
+
+	outlon1 := l.lon1
+	if capabilities&LONG_UNROLL == 0 {
+		outlon1 = ang_normalize(l.lon1)
+	}
+
+	return RhumbPositionResult{
+		Lat1Deg:   l.lat1,
+		Lon1Deg:   outlon1,
+		AziDeg:    l.azi12,
+		Lat2Deg:   lat2,
+		Lon2Deg:   lon2,
+		DistanceM: s12_m,
+		S12M2:     S12,
+	}
+}
+
+// Latitude returns the latitude of point 1 [degrees]
+func (l RhumbLine) Latitude() float64 {
+	return l.lat1
+}
+
+// Longitude returns the longitude of point 1 [degrees]
+func (l RhumbLine) Longitude() float64 {
+	return l.lon1
+}
+
+// Azimuth returns the azimuth of the rhumb line [degrees]
+func (l RhumbLine) Azimuth() float64 {
+	return l.azi12
+}
+
+// EquatorialRadius returns the equatorial radius of the ellipsoid [meters]
+func (l RhumbLine) EquatorialRadius() float64 {
+	return l.rh.a
+}
+
+// Flattening returns the flattening of the ellipsoid
+func (l RhumbLine) Flattening() float64 {
+	return l.rh.f
+}
@@ -0,0 +1,92 @@
+package geographiclibgo
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRhumbLineWaypoints(t *testing.T) {
+	// Waypoints at intervals of 2000 km on the rhumb line from JFK to Singapore, from the
+	// RhumbSolve documentation
+	dms := func(d, m, s float64) float64 { return math.Copysign(math.Abs(d)+m/60+s/3600, d) }
+	testCases := []struct {
+		s12  float64
+		lat2 float64
+		lon2 float64
+	}{
+		{0, dms(40, 38, 23.0), dms(-73, 46, 44.0)},
+		{2e6, dms(36, 24, 30.3), dms(-51, 28, 26.4)},
+		{4e6, dms(32, 10, 26.8), dms(-30, 20, 57.3)},
+		{6e6, dms(27, 56, 13.2), dms(-10, 10, 54.2)},
+		{8e6, dms(23, 41, 50.1), dms(9, 12, 45.5)},
+		{10e6, dms(19, 27, 18.7), dms(27, 59, 22.1)},
+		{12e6, dms(15, 12, 40.2), dms(46, 17, 1.1)},
+		{14e6, dms(10, 57, 55.9), dms(64, 12, 52.8)},
+		{16e6, dms(6, 43, 7.3), dms(81, 53, 28.8)},
+		{18e6, dms(2, 28, 16.2), dms(99, 24, 54.5)},
+		{20e6, -dms(1, 46, 36.0), dms(116, 52, 59.7)},
+	}
+	line := NewRhumbLine(Wgs84Rhumb(), jfk_lat, jfk_lon, dms(103, 34, 58.2))
+	for _, tC := range testCases {
+		got := line.Position(tC.s12)
+		if !almost_equal(got.Lat2Deg, tC.lat2, 0.06/3600) {
+			t.Errorf("s12 = %v -- lat2 = %v; want %v", tC.s12, got.Lat2Deg, tC.lat2)
+		}
+		if !almost_equal(got.Lon2Deg, tC.lon2, 0.06/3600) {
+			t.Errorf("s12 = %v -- lon2 = %v; want %v", tC.s12, got.Lon2Deg, tC.lon2)
+		}
+	}
+}
+
+func BenchmarkRhumbLinePosition(b *testing.B) {
+	line := NewRhumbLine(Wgs84Rhumb(), jfk_lat, jfk_lon, 103.5828)
+	for i := 0; i < b.N; i++ {
+		line.Position(1e7)
+	}
+}
+
+func TestRhumbLineAreaAdds(t *testing.T) {
+	// S13 = S12 + S23 for points along the line
+	r := Wgs84Rhumb()
+	line := NewRhumbLine(r, -40, 10, 35)
+	p2 := line.Position(3e6)
+	p3 := line.Position(7e6)
+	line2 := NewRhumbLine(r, p2.Lat2Deg, p2.Lon2Deg, 35)
+	p23 := line2.Position(4e6)
+
+	if !almost_equal(p23.Lat2Deg, p3.Lat2Deg, 1e-12) {
+		t.Errorf("lat3 = %v; want %v", p23.Lat2Deg, p3.Lat2Deg)
+	}
+	if !almost_equal(p2.S12M2+p23.S12M2, p3.S12M2, 1e-1) {
+		t.Errorf("S12 + S23 = %v; want %v", p2.S12M2+p23.S12M2, p3.S12M2)
+	}
+}
+
+func TestRhumbLineOverPole(t *testing.T) {
+	// A line heading nearly north passes over the pole; the latitude is found, but the
+	// longitude and area are NaN
+	r := Wgs84Rhumb()
+	line := NewRhumbLine(r, 80, 0, 0)
+	got := line.Position(2e6)
+	want := r.DirectCalcLatLon(90, 0, 180, 2e6-r.InverseCalcDistance(80, 0, 90, 0))
+	if !almost_equal(got.Lat2Deg, want.LatDeg, 1e-12) {
+		t.Errorf("lat2 = %v; want %v", got.Lat2Deg, want.LatDeg)
+	}
+	if !math.IsNaN(got.Lon2Deg) || !math.IsNaN(got.S12M2) {
+		t.Errorf("lon2, S12 = %v, %v; want NaN, NaN", got.Lon2Deg, got.S12M2)
+	}
+
+	// Along a parallel the latitude is unchanged
+	line = NewRhumbLine(r, 60, 0, 90)
+	got = line.PositionWithCapabilities(1e6, LATITUDE|LONGITUDE|LONG_UNROLL)
+	if got.Lat2Deg != 60 {
+		t.Errorf("lat2 = %v; want 60", got.Lat2Deg)
+	}
+	want_lon := 1e6 / r._CircleRadius(60) * RAD2DEG
+	if !almost_equal(got.Lon2Deg, want_lon, 1e-12) {
+		t.Errorf("lon2 = %v; want %v", got.Lon2Deg, want_lon)
+	}
+	if line.Latitude() != 60 || line.Longitude() != 0 || line.Azimuth() != 90 {
+		t.Errorf("line = %v, %v, %v; want 60, 0, 90", line.Latitude(), line.Longitude(), line.Azimuth())
+	}
+}
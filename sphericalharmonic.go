@@ -0,0 +1,432 @@
+package geographiclibgo
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+)
+
+// SphericalNormalization selects the normalization of the associated Legendre functions
+// in a spherical harmonic series
+type SphericalNormalization int
+
+const (
+	// FullNormalization uses fully normalized associated Legendre functions, whose mean
+	// square over the sphere is 1. This is the convention for gravity models.
+	FullNormalization SphericalNormalization = iota
+	// SchmidtNormalization uses Schmidt semi-normalized associated Legendre functions,
+	// whose mean square over the sphere is 1/(2n+1). This is the convention for
+	// magnetic models.
+	SchmidtNormalization
+)
+
+// _SPHERICAL_SCALE scales the coefficients while summing the series so that
+// intermediate results do not overflow for high degrees, as in GeographicLib
+const _SPHERICAL_SCALE float64 = 0x1p-614
+
+// SphericalCoefficients holds the coefficients C[n,m] and S[n,m] of a spherical harmonic
+// series of maximum degree N and maximum order M. The coefficients are packed by
+// column: C[n,m] for 0 <= m <= M and m <= n <= N is at index k = m*N - m*(m-1)/2 + n of
+// C, and S[n,m] for 1 <= m <= M is at index k - (N+1) of S. This is the layout of the
+// coefficient files distributed with GeographicLib.
+type SphericalCoefficients struct {
+	c []float64
+	s []float64
+	n int
+	m int
+}
+
+// NewSphericalCoefficients creates a SphericalCoefficients of degree N and order M from
+// the packed coefficients C and S. An empty series has N = M = -1.
+func NewSphericalCoefficients(C, S []float64, N, M int) (SphericalCoefficients, error) {
+	if !((N >= M && M >= 0) || (N == -1 && M == -1)) {
+		return SphericalCoefficients{}, fmt.Errorf("degree %d and order %d are not valid", N, M)
+	}
+	size := spherical_coeff_size(N, M)
+	if len(C) < size {
+		return SphericalCoefficients{}, fmt.Errorf("C has length %d; want at least %d", len(C), size)
+	}
+	if len(S) < size-(N+1) {
+		return SphericalCoefficients{}, fmt.Errorf(
+			"S has length %d; want at least %d", len(S), size-(N+1),
+		)
+	}
+	return SphericalCoefficients{c: C[:size], s: S[:size-(N+1)], n: N, m: M}, nil
+}
+
+// spherical_coeff_size returns the number of C coefficients for degree N and order M
+func spherical_coeff_size(N, M int) int {
+	return (M + 1) * (2*N - M + 2) / 2
+}
+
+// Degree returns the maximum degree N of the series
+func (c *SphericalCoefficients) Degree() int {
+	return c.n
+}
+
+// Order returns the maximum order M of the series
+func (c *SphericalCoefficients) Order() int {
+	return c.m
+}
+
+func (c *SphericalCoefficients) _index(n, m int) int {
+	return m*c.n - m*(m-1)/2 + n
+}
+
+// read_spherical_coeffs reads a set of coefficients in the binary format used by
+// GeographicLib: N and M as little-endian 32-bit integers followed by the packed C and
+// S coefficients as little-endian doubles.
+func read_spherical_coeffs(r io.Reader) (SphericalCoefficients, error) {
+	var nm [2]int32
+	if err := binary.Read(r, binary.LittleEndian, nm[:]); err != nil {
+		return SphericalCoefficients{}, err
+	}
+	N, M := int(nm[0]), int(nm[1])
+	if !((N >= M && M >= 0) || (N == -1 && M == -1)) {
+		return SphericalCoefficients{}, fmt.Errorf("bad degree %d and order %d", N, M)
+	}
+	size := spherical_coeff_size(N, M)
+	C := make([]float64, size)
+	S := make([]float64, size-(N+1))
+	if err := binary.Read(r, binary.LittleEndian, C); err != nil {
+		return SphericalCoefficients{}, err
+	}
+	if err := binary.Read(r, binary.LittleEndian, S); err != nil {
+		return SphericalCoefficients{}, err
+	}
+	return NewSphericalCoefficients(C, S, N, M)
+}
+
+// spherical_sqrt_table returns sqrt(k) for k in [0, 2N+5], which is what the summation
+// for degree N needs
+func spherical_sqrt_table(N int) []float64 {
+	size := 2*N + 6
+	if size < 16 {
+		size = 16
+	}
+	root := make([]float64, size)
+	for k := range root {
+		root[k] = math.Sqrt(float64(k))
+	}
+	return root
+}
+
+// spherical_engine sums the series
+//
+//	V(x, y, z) = sum(n = 0..N, m = 0..n) q^(n+1) * (C'[n,m] * cos(m*lambda) +
+//	  S'[n,m] * sin(m*lambda)) * P[n,m](cos(theta))
+//
+// where r = sqrt(x^2 + y^2 + z^2), q = a/r, theta is the colatitude, lambda the
+// longitude, C' = sum(l) f[l] * c[l].C and S' likewise. The degree and order of c[0]
+// bound those of the other coefficient sets. If gradp, the gradient of V is also
+// returned. Clenshaw summation is used for both the sum over n and the sum over m,
+// following C. W. Clenshaw, A note on the summation of Chebyshev series, Math. Tables
+// Aids Comput. 9(51), 118-120 (1955) and the implementation in GeographicLib.
+func spherical_engine(
+	c []SphericalCoefficients,
+	f []float64,
+	x, y, z, a float64,
+	norm SphericalNormalization,
+	root []float64,
+	gradp bool,
+) (v, gradx, grady, gradz float64) {
+	N, M := c[0].n, c[0].m
+	p := math.Hypot(x, y)
+	// cos(lambda) and sin(lambda); at the pole, pick lambda = 0
+	cl, sl := 1.0, 0.0
+	if p != 0 {
+		cl, sl = x/p, y/p
+	}
+	r := math.Hypot(z, p)
+	// cos(theta) and sin(theta); at the origin, pick theta = pi/2. Avoid the pole.
+	t, u := 0.0, 1.0
+	if r != 0 {
+		t, u = z/r, math.Max(p/r, get_epsilon())
+	}
+	q := a / r
+	q2 := sq(q)
+	uq := u * q
+	uq2 := sq(uq)
+	tu := t / u
+
+	// Outer sum: v[m+1] and v[m+2]. vr, vt, and vl accumulate the sums for the
+	// derivatives with respect to r, theta, and lambda.
+	var vc, vc2, vs, vs2 float64
+	var vrc, vrc2, vrs, vrs2 float64
+	var vtc, vtc2, vts, vts2 float64
+	var vlc, vlc2, vls, vls2 float64
+	var k [2]int
+	for m := M; m >= 0; m-- {
+		// Inner sum: w[n-m+1] and w[n-m+2]
+		var wc, wc2, ws, ws2 float64
+		var wrc, wrc2, wrs, wrs2 float64
+		var wtc, wtc2, wts, wts2 float64
+		for l := range c {
+			k[l] = c[l]._index(N, m) + 1
+		}
+		for n := N; n >= m; n-- {
+			// alpha[l] and beta[l+1]
+			var w, A, Ax, B float64
+			switch norm {
+			case FullNormalization:
+				w = root[2*n+1] / (root[n-m+1] * root[n+m+1])
+				Ax = q * w * root[2*n+3]
+				A = t * Ax
+				B = -q2 * root[2*n+5] / (w * root[n-m+2] * root[n+m+2])
+			case SchmidtNormalization:
+				w = root[n-m+1] * root[n+m+1]
+				Ax = q * float64(2*n+1) / w
+				A = t * Ax
+				B = -q2 * w / (root[n-m+2] * root[n+m+2])
+			}
+			k[0]--
+			R := c[0].c[k[0]]
+			for l := 1; l < len(c); l++ {
+				k[l]--
+				if n <= c[l].n && m <= c[l].m {
+					R += c[l].c[k[l]] * f[l]
+				}
+			}
+			R *= _SPHERICAL_SCALE
+			w = A*wc + B*wc2 + R
+			wc2, wc = wc, w
+			if gradp {
+				w = A*wrc + B*wrc2 + float64(n+1)*R
+				wrc2, wrc = wrc, w
+				w = A*wtc + B*wtc2 - u*Ax*wc2
+				wtc2, wtc = wtc, w
+			}
+			if m > 0 {
+				R = c[0].s[k[0]-(c[0].n+1)]
+				for l := 1; l < len(c); l++ {
+					if n <= c[l].n && m <= c[l].m {
+						R += c[l].s[k[l]-(c[l].n+1)] * f[l]
+					}
+				}
+				R *= _SPHERICAL_SCALE
+				w = A*ws + B*ws2 + R
+				ws2, ws = ws, w
+				if gradp {
+					w = A*wrs + B*wrs2 + float64(n+1)*R
+					wrs2, wrs = wrs, w
+					w = A*wts + B*wts2 - u*Ax*ws2
+					wts2, wts = wts, w
+				}
+			}
+		}
+		// Now Sc[m] = wc, Ss[m] = ws, Sc'[m] = wtc, Ss'[m] = wts
+		if m > 0 {
+			// alpha[m] and beta[m+1]
+			var vv, A, B float64
+			switch norm {
+			case FullNormalization:
+				vv = root[2] * root[2*m+3] / root[m+1]
+				A = cl * vv * uq
+				B = -vv * root[2*m+5] / (root[8] * root[m+2]) * uq2
+			case SchmidtNormalization:
+				vv = root[2] * root[2*m+1] / root[m+1]
+				A = cl * vv * uq
+				B = -vv * root[2*m+3] / (root[8] * root[m+2]) * uq2
+			}
+			vv = A*vc + B*vc2 + wc
+			vc2, vc = vc, vv
+			vv = A*vs + B*vs2 + ws
+			vs2, vs = vs, vv
+			if gradp {
+				// Include the terms Sc[m] * P'[m,m](t) and Ss[m] * P'[m,m](t)
+				wtc += float64(m) * tu * wc
+				wts += float64(m) * tu * ws
+				vv = A*vrc + B*vrc2 + wrc
+				vrc2, vrc = vrc, vv
+				vv = A*vrs + B*vrs2 + wrs
+				vrs2, vrs = vrs, vv
+				vv = A*vtc + B*vtc2 + wtc
+				vtc2, vtc = vtc, vv
+				vv = A*vts + B*vts2 + wts
+				vts2, vts = vts, vv
+				vv = A*vlc + B*vlc2 + float64(m)*ws
+				vlc2, vlc = vlc, vv
+				vv = A*vls + B*vls2 - float64(m)*wc
+				vls2, vls = vls, vv
+			}
+		} else {
+			var A, B float64
+			switch norm {
+			case FullNormalization:
+				A = root[3] * uq
+				B = -root[15] / 2 * uq2
+			case SchmidtNormalization:
+				A = uq
+				B = -root[3] / 2 * uq2
+			}
+			qs := q / _SPHERICAL_SCALE
+			vc = qs * (wc + A*(cl*vc+sl*vs) + B*vc2)
+			if gradp {
+				qs /= r
+				// The components of the gradient in spherical coordinates are
+				// r: dV/dr, theta: 1/r * dV/dtheta, lambda: 1/(r*u) * dV/dlambda
+				vrc = -qs * (wrc + A*(cl*vrc+sl*vrs) + B*vrc2)
+				vtc = qs * (wtc + A*(cl*vtc+sl*vts) + B*vtc2)
+				vlc = qs / u * (A*(cl*vlc+sl*vls) + B*vlc2)
+				// Rotate into cartesian (geocentric) coordinates
+				gradx = cl*(u*vrc+t*vtc) - sl*vlc
+				grady = sl*(u*vrc+t*vtc) + cl*vlc
+				gradz = t*vrc - u*vtc
+			}
+		}
+	}
+	return vc, gradx, grady, gradz
+}
+
+// SphericalHarmonic sums a spherical harmonic series
+//
+//	V(x, y, z) = sum(n = 0..N, m = 0..n) (a/r)^(n+1) * (C[n,m] * cos(m*lambda) +
+//	  S[n,m] * sin(m*lambda)) * P[n,m](cos(theta))
+//
+// where r, theta, and lambda are the spherical coordinates of the point (x, y, z), a is
+// the reference radius, and P[n,m] are the associated Legendre functions with the chosen
+// normalization (without the Condon-Shortley phase). The series is summed with Clenshaw
+// summation, which is accurate and fast even for the degree 2190 of EGM2008.
+//
+// This is a port of the SphericalHarmonic class from GeographicLib by Charles Karney.
+type SphericalHarmonic struct {
+	c    [1]SphericalCoefficients
+	f    [1]float64
+	a    float64
+	norm SphericalNormalization
+	root []float64
+}
+
+// NewSphericalHarmonic creates a SphericalHarmonic for the coefficients c with reference
+// radius a [meters] and the given normalization
+func NewSphericalHarmonic(c SphericalCoefficients, a float64, norm SphericalNormalization) SphericalHarmonic {
+	return SphericalHarmonic{
+		c:    [1]SphericalCoefficients{c},
+		f:    [1]float64{1},
+		a:    a,
+		norm: norm,
+		root: spherical_sqrt_table(c.n),
+	}
+}
+
+// Value returns the sum of the series at the point x_m, y_m, z_m
+func (sh *SphericalHarmonic) Value(x_m, y_m, z_m float64) float64 {
+	v, _, _, _ := spherical_engine(sh.c[:], sh.f[:], x_m, y_m, z_m, sh.a, sh.norm, sh.root, false)
+	return v
+}
+
+// Gradient returns the sum of the series at the point x_m, y_m, z_m together with its
+// gradient in the x, y, and z directions
+func (sh *SphericalHarmonic) Gradient(x_m, y_m, z_m float64) (v, gradx, grady, gradz float64) {
+	return spherical_engine(sh.c[:], sh.f[:], x_m, y_m, z_m, sh.a, sh.norm, sh.root, true)
+}
+
+// SphericalHarmonic1 sums a spherical harmonic series whose coefficients are C + tau * C1
+// (and likewise for S). With tau a time, this represents a field and its secular
+// variation.
+type SphericalHarmonic1 struct {
+	c    [2]SphericalCoefficients
+	a    float64
+	norm SphericalNormalization
+	root []float64
+}
+
+// NewSphericalHarmonic1 creates a SphericalHarmonic1 for the coefficients c and c1 with
+// reference radius a [meters] and the given normalization. The degree and order of c1
+// must not exceed those of c.
+func NewSphericalHarmonic1(
+	c, c1 SphericalCoefficients,
+	a float64,
+	norm SphericalNormalization,
+) (SphericalHarmonic1, error) {
+	if c1.n > c.n || c1.m > c.m {
+		return SphericalHarmonic1{}, fmt.Errorf(
+			"degree and order of c1 (%d, %d) exceed those of c (%d, %d)", c1.n, c1.m, c.n, c.m,
+		)
+	}
+	return SphericalHarmonic1{
+		c:    [2]SphericalCoefficients{c, c1},
+		a:    a,
+		norm: norm,
+		root: spherical_sqrt_table(c.n),
+	}, nil
+}
+
+// Value returns the sum of the series with coefficients C + tau * C1 at the point x_m,
+// y_m, z_m
+func (sh *SphericalHarmonic1) Value(tau, x_m, y_m, z_m float64) float64 {
+	f := [2]float64{1, tau}
+	v, _, _, _ := spherical_engine(sh.c[:], f[:], x_m, y_m, z_m, sh.a, sh.norm, sh.root, false)
+	return v
+}
+
+// Gradient returns the sum of the series with coefficients C + tau * C1 at the point
+// x_m, y_m, z_m together with its gradient in the x, y, and z directions
+func (sh *SphericalHarmonic1) Gradient(tau, x_m, y_m, z_m float64) (v, gradx, grady, gradz float64) {
+	f := [2]float64{1, tau}
+	return spherical_engine(sh.c[:], f[:], x_m, y_m, z_m, sh.a, sh.norm, sh.root, true)
+}
+
+// parse_model_line splits a line of a GeographicLib model metadata file into a key and
+// a value, ignoring comments which start with '#'. ok is false for a blank line.
+func parse_model_line(line string) (key, val string, ok bool) {
+	if i := strings.IndexByte(line, '#'); i >= 0 {
+		line = line[:i]
+	}
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return "", "", false
+	}
+	i := strings.IndexAny(line, " \t")
+	if i < 0 {
+		return line, "", true
+	}
+	return line[:i], strings.TrimSpace(line[i:]), true
+}
+
+// parse_model_normalization parses the Normalization value of a model metadata file
+func parse_model_normalization(val string) (SphericalNormalization, error) {
+	switch val {
+	case "FULL", "Full", "full":
+		return FullNormalization, nil
+	case "SCHMIDT", "Schmidt", "schmidt":
+		return SchmidtNormalization, nil
+	}
+	return 0, fmt.Errorf("unknown normalization %s", val)
+}
+
+// check_model_byte_order checks the ByteOrder value of a model metadata file
+func check_model_byte_order(val string) error {
+	switch val {
+	case "Little", "little":
+		return nil
+	case "Big", "big":
+		return errors.New("only little-endian ordering is supported")
+	}
+	return fmt.Errorf("unknown byte ordering %s", val)
+}
+
+// read_model_id reads the identifier at the start of a coefficient file and checks that
+// it matches the ID from the metadata file
+func read_model_id(r io.Reader, id string) error {
+	buf := make([]byte, len(id))
+	if _, err := io.ReadFull(r, buf); err != nil {
+		return fmt.Errorf("error reading ID: %v", err)
+	}
+	if string(buf) != id {
+		return fmt.Errorf("ID mismatch: %s vs %s", id, buf)
+	}
+	return nil
+}
+
+// check_model_eof checks that there is no data after the coefficients
+func check_model_eof(r io.Reader) error {
+	var buf [1]byte
+	if n, _ := r.Read(buf[:]); n > 0 {
+		return errors.New("extra data after the coefficients")
+	}
+	return nil
+}
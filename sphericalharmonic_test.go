@@ -0,0 +1,265 @@
+package geographiclibgo
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"testing"
+)
+
+// test_spherical_coeffs returns arbitrary but repeatable coefficients of degree N and
+// order M which decay like 1/(n+1)
+func test_spherical_coeffs(N, M int, seed float64) ([]float64, []float64) {
+	C := make([]float64, 0, spherical_coeff_size(N, M))
+	S := make([]float64, 0, spherical_coeff_size(N, M))
+	for m := 0; m <= M; m++ {
+		for n := m; n <= N; n++ {
+			C = append(C, math.Sin(seed+1.3*float64(n)+0.7*float64(m))/float64(n+1))
+			if m > 0 {
+				S = append(S, math.Cos(seed+0.9*float64(n)-1.1*float64(m))/float64(n+1))
+			}
+		}
+	}
+	return C, S
+}
+
+// direct_spherical_sum sums the series term by term using associated Legendre functions
+// computed by the standard recurrence in n
+func direct_spherical_sum(C, S []float64, N, M int, a float64, norm SphericalNormalization, x, y, z float64) float64 {
+	r := math.Sqrt(x*x + y*y + z*z)
+	t, u := z/r, math.Hypot(x, y)/r
+	lam := math.Atan2(y, x)
+	sum := 0.0
+	k := 0
+	for m := 0; m <= M; m++ {
+		// Unnormalized P[m,m] = (2m-1)!! u^m, without the Condon-Shortley phase
+		pmm := 1.0
+		for i := 1; i <= m; i++ {
+			pmm *= float64(2*i-1) * u
+		}
+		p2, p1 := 0.0, pmm
+		for n := m; n <= N; n++ {
+			var p float64
+			if n == m {
+				p = pmm
+			} else {
+				p = (float64(2*n-1)*t*p1 - float64(n+m-1)*p2) / float64(n-m)
+				p2, p1 = p1, p
+			}
+			// (n-m)!/(n+m)!
+			ratio := 1.0
+			for i := n - m + 1; i <= n+m; i++ {
+				ratio /= float64(i)
+			}
+			fac := 1.0
+			if m > 0 {
+				fac = 2
+			}
+			fac = math.Sqrt(fac * ratio)
+			if norm == FullNormalization {
+				fac *= math.Sqrt(float64(2*n + 1))
+			}
+			term := C[k] * math.Cos(float64(m)*lam)
+			if m > 0 {
+				term += S[k-(N+1)] * math.Sin(float64(m)*lam)
+			}
+			sum += math.Pow(a/r, float64(n+1)) * term * fac * p
+			k++
+		}
+	}
+	return sum
+}
+
+var spherical_test_points = [][3]float64{
+	{6378137, 0, 0},
+	{1234567, -4567890, 4012345},
+	{-3000000, 2000000, -5500000},
+	{10, -20, 6356752},
+	{0, 0, -6400000},
+	{2.5e7, 1.5e7, 1e7},
+}
+
+func TestSphericalHarmonicDirect(t *testing.T) {
+	testCases := []struct {
+		desc string
+		N, M int
+		norm SphericalNormalization
+	}{
+		{"full 8 8", 8, 8, FullNormalization},
+		{"full 9 4", 9, 4, FullNormalization},
+		{"schmidt 8 8", 8, 8, SchmidtNormalization},
+		{"schmidt 12 0", 12, 0, SchmidtNormalization},
+	}
+	a := 6371200.0
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			C, S := test_spherical_coeffs(tC.N, tC.M, 0.3)
+			c, err := NewSphericalCoefficients(C, S, tC.N, tC.M)
+			if err != nil {
+				t.Fatalf("NewSphericalCoefficients: %v", err)
+			}
+			sh := NewSphericalHarmonic(c, a, tC.norm)
+			for _, pt := range spherical_test_points {
+				got := sh.Value(pt[0], pt[1], pt[2])
+				want := direct_spherical_sum(C, S, tC.N, tC.M, a, tC.norm, pt[0], pt[1], pt[2])
+				if !almost_equal(got, want, 1e-13*math.Max(1, math.Abs(want))) {
+					t.Errorf("Value(%v) = %v; want %v", pt, got, want)
+				}
+				v, _, _, _ := sh.Gradient(pt[0], pt[1], pt[2])
+				if v != got {
+					t.Errorf("Gradient(%v) value = %v; want %v", pt, v, got)
+				}
+			}
+		})
+	}
+}
+
+func TestSphericalHarmonicGradient(t *testing.T) {
+	for _, norm := range []SphericalNormalization{FullNormalization, SchmidtNormalization} {
+		C, S := test_spherical_coeffs(10, 7, 1.7)
+		c, _ := NewSphericalCoefficients(C, S, 10, 7)
+		sh := NewSphericalHarmonic(c, 6378137, norm)
+		for _, pt := range spherical_test_points {
+			if pt[0] == 0 && pt[1] == 0 {
+				// The longitude is undefined on the axis
+				continue
+			}
+			_, gx, gy, gz := sh.Gradient(pt[0], pt[1], pt[2])
+			d := 1.0
+			fd := func(dx, dy, dz float64) float64 {
+				return (sh.Value(pt[0]+dx, pt[1]+dy, pt[2]+dz) -
+					sh.Value(pt[0]-dx, pt[1]-dy, pt[2]-dz)) / (2 * d)
+			}
+			g := math.Sqrt(gx*gx + gy*gy + gz*gz)
+			for i, want := range []float64{fd(d, 0, 0), fd(0, d, 0), fd(0, 0, d)} {
+				got := []float64{gx, gy, gz}[i]
+				if !almost_equal(got, want, 1e-6*g) {
+					t.Errorf("norm %v at %v: grad[%d] = %v; want %v", norm, pt, i, got, want)
+				}
+			}
+		}
+	}
+}
+
+func TestSphericalHarmonic1(t *testing.T) {
+	N, M, N1, M1 := 6, 6, 4, 3
+	C, S := test_spherical_coeffs(N, M, 0.1)
+	C1, S1 := test_spherical_coeffs(N1, M1, 2.2)
+	c, _ := NewSphericalCoefficients(C, S, N, M)
+	c1, _ := NewSphericalCoefficients(C1, S1, N1, M1)
+	sh1, err := NewSphericalHarmonic1(c, c1, 6371200, SchmidtNormalization)
+	if err != nil {
+		t.Fatalf("NewSphericalHarmonic1: %v", err)
+	}
+	tau := 3.25
+	// Fold c1 into a copy of c by hand
+	Ct := append([]float64(nil), C...)
+	St := append([]float64(nil), S...)
+	for m := 0; m <= M1; m++ {
+		for n := m; n <= N1; n++ {
+			k, k1 := c._index(n, m), c1._index(n, m)
+			Ct[k] += tau * C1[k1]
+			if m > 0 {
+				St[k-(N+1)] += tau * S1[k1-(N1+1)]
+			}
+		}
+	}
+	ct, _ := NewSphericalCoefficients(Ct, St, N, M)
+	sh := NewSphericalHarmonic(ct, 6371200, SchmidtNormalization)
+	for _, pt := range spherical_test_points {
+		v1, gx1, gy1, gz1 := sh1.Gradient(tau, pt[0], pt[1], pt[2])
+		v, gx, gy, gz := sh.Gradient(pt[0], pt[1], pt[2])
+		for i, pair := range [][2]float64{{v1, v}, {gx1, gx}, {gy1, gy}, {gz1, gz}} {
+			if !almost_equal(pair[0], pair[1], 1e-14*math.Max(1, math.Abs(pair[1]))) {
+				t.Errorf("at %v: component %d = %v; want %v", pt, i, pair[0], pair[1])
+			}
+		}
+		if got := sh1.Value(tau, pt[0], pt[1], pt[2]); got != v1 {
+			t.Errorf("Value(%v) = %v; want %v", pt, got, v1)
+		}
+	}
+	if _, err := NewSphericalHarmonic1(c1, c, 6371200, SchmidtNormalization); err == nil {
+		t.Errorf("NewSphericalHarmonic1 with c1 larger than c succeeded")
+	}
+}
+
+func TestNewSphericalCoefficientsErrors(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		C, S     []float64
+		N, M     int
+		want_err bool
+	}{
+		{"empty", nil, nil, -1, -1, false},
+		{"degree 0", []float64{1}, nil, 0, 0, false},
+		{"degree 2", make([]float64, 6), make([]float64, 3), 2, 2, false},
+		{"order too big", make([]float64, 6), make([]float64, 3), 2, 3, true},
+		{"negative order", make([]float64, 6), make([]float64, 3), 2, -1, true},
+		{"short C", make([]float64, 5), make([]float64, 3), 2, 2, true},
+		{"short S", make([]float64, 6), make([]float64, 2), 2, 2, true},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			_, err := NewSphericalCoefficients(tC.C, tC.S, tC.N, tC.M)
+			if (err != nil) != tC.want_err {
+				t.Errorf("NewSphericalCoefficients error = %v; want error %v", err, tC.want_err)
+			}
+		})
+	}
+}
+
+// write_spherical_coeffs writes coefficients in the GeographicLib binary format
+func write_spherical_coeffs(b *bytes.Buffer, C, S []float64, N, M int) {
+	binary.Write(b, binary.LittleEndian, [2]int32{int32(N), int32(M)})
+	binary.Write(b, binary.LittleEndian, C)
+	binary.Write(b, binary.LittleEndian, S)
+}
+
+func TestReadSphericalCoeffs(t *testing.T) {
+	C, S := test_spherical_coeffs(5, 3, 0.5)
+	var b bytes.Buffer
+	write_spherical_coeffs(&b, C, S, 5, 3)
+	write_spherical_coeffs(&b, nil, nil, -1, -1)
+	c, err := read_spherical_coeffs(&b)
+	if err != nil {
+		t.Fatalf("read_spherical_coeffs: %v", err)
+	}
+	if c.Degree() != 5 || c.Order() != 3 {
+		t.Errorf("degree, order = %d, %d; want 5, 3", c.Degree(), c.Order())
+	}
+	for i := range C {
+		if c.c[i] != C[i] {
+			t.Errorf("C[%d] = %v; want %v", i, c.c[i], C[i])
+		}
+	}
+	for i := range S {
+		if c.s[i] != S[i] {
+			t.Errorf("S[%d] = %v; want %v", i, c.s[i], S[i])
+		}
+	}
+	c, err = read_spherical_coeffs(&b)
+	if err != nil || c.Degree() != -1 {
+		t.Errorf("read_spherical_coeffs = degree %d, %v; want -1, nil", c.Degree(), err)
+	}
+	if _, err = read_spherical_coeffs(&b); err == nil {
+		t.Errorf("read_spherical_coeffs at EOF succeeded")
+	}
+}
+
+func BenchmarkSphericalHarmonicValue360(b *testing.B) {
+	C, S := test_spherical_coeffs(360, 360, 0.3)
+	c, _ := NewSphericalCoefficients(C, S, 360, 360)
+	sh := NewSphericalHarmonic(c, 6378137, FullNormalization)
+	for i := 0; i < b.N; i++ {
+		sh.Value(1234567, -4567890, 4012345)
+	}
+}
+
+func BenchmarkSphericalHarmonicGradient360(b *testing.B) {
+	C, S := test_spherical_coeffs(360, 360, 0.3)
+	c, _ := NewSphericalCoefficients(C, S, 360, 360)
+	sh := NewSphericalHarmonic(c, 6378137, FullNormalization)
+	for i := 0; i < b.N; i++ {
+		sh.Gradient(1234567, -4567890, 4012345)
+	}
+}
@@ -0,0 +1,326 @@
+package geographiclibgo
+
+import (
+	"math"
+	"math/cmplx"
+)
+
+// The order of the Krüger series used by TransverseMercator
+const _TM_MAXPOW int = 6
+
+// ProjectionForwardResult is the result of projecting a point onto a map
+type ProjectionForwardResult struct {
+	XM             float64 // Easting of the point [meters]
+	YM             float64 // Northing of the point [meters]
+	ConvergenceDeg float64 // Meridian convergence at the point [degrees]
+	Scale          float64 // Scale of the projection at the point
+}
+
+// ProjectionReverseResult is the result of finding the point corresponding to a position
+// on a map
+type ProjectionReverseResult struct {
+	LatDeg         float64 // Latitude of the point [degrees]
+	LonDeg         float64 // Longitude of the point [degrees]
+	ConvergenceDeg float64 // Meridian convergence at the point [degrees]
+	Scale          float64 // Scale of the projection at the point
+}
+
+// TransverseMercator is the transverse Mercator projection, computed with Krüger's series
+// to 6th order in the third flattening n, following C. F. F. Karney, Transverse Mercator
+// with an accuracy of a few nanometers, J. Geodesy 85(8), 475-485 (Aug. 2011). The error
+// is less than 5 nm within 3900 km of the central meridian. The scale on the central
+// meridian is k0.
+//
+// The meridian convergence is the bearing of grid north (the y axis) measured clockwise
+// from true north.
+type TransverseMercator struct {
+	a   float64
+	f   float64
+	k0  float64
+	e2  float64
+	es  float64
+	e2m float64
+	c   float64
+	n   float64
+	a1  float64
+	b1  float64
+	alp [_TM_MAXPOW + 1]float64
+	bet [_TM_MAXPOW + 1]float64
+}
+
+// NewTransverseMercator creates a TransverseMercator projection for the ellipsoid with
+// equatorial radius a [meters] and flattening f, with scale k0 on the central meridian.
+// For UTM, k0 = 0.9996.
+func NewTransverseMercator(a, f, k0 float64) TransverseMercator {
+	B1COEFF := [5]float64{
+		// b1*(n+1), polynomial in n2 of order 3
+		1, 4, 64, 256, 256,
+	}
+	ALPCOEFF := [27]float64{
+		// alp[1]/n^1, polynomial in n of order 5
+		31564, -66675, 34440, 47250, -100800, 75600, 151200,
+		// alp[2]/n^2, polynomial in n of order 4
+		-1983433, 863232, 748608, -1161216, 524160, 1935360,
+		// alp[3]/n^3, polynomial in n of order 3
+		670412, 406647, -533952, 184464, 725760,
+		// alp[4]/n^4, polynomial in n of order 2
+		6601661, -7732800, 2230245, 7257600,
+		// alp[5]/n^5, polynomial in n of order 1
+		-13675556, 3438171, 7983360,
+		// alp[6]/n^6, polynomial in n of order 0
+		212378941, 319334400,
+	}
+	BETCOEFF := [27]float64{
+		// bet[1]/n^1, polynomial in n of order 5
+		384796, -382725, -6720, 932400, -1612800, 1209600, 2419200,
+		// bet[2]/n^2, polynomial in n of order 4
+		-1118711, 1695744, -1174656, 258048, 80640, 3870720,
+		// bet[3]/n^3, polynomial in n of order 3
+		22276, -16929, -15984, 12852, 362880,
+		// bet[4]/n^4, polynomial in n of order 2
+		-830251, -158400, 197865, 7257600,
+		// bet[5]/n^5, polynomial in n of order 1
+		-435388, 453717, 15966720,
+		// bet[6]/n^6, polynomial in n of order 0
+		20648693, 638668800,
+	}
+
+	e2 := f * (2.0 - f)
+	es := math.Sqrt(math.Abs(e2))
+	if f < 0 {
+		es = -es
+	}
+	e2m := 1.0 - e2
+	n := f / (2.0 - f)
+	t := TransverseMercator{
+		a:   a,
+		f:   f,
+		k0:  k0,
+		e2:  e2,
+		es:  es,
+		e2m: e2m,
+		// The scale at the pole is c * k0
+		c: math.Sqrt(e2m) * math.Exp(eatanhe(1.0, es)),
+		n: n,
+	}
+
+	m := int64(_TM_MAXPOW / 2)
+	t.b1 = polyval(m, B1COEFF[:], sq(n)) / (B1COEFF[m+1] * (1.0 + n))
+	// a1 is the radius of the rectifying sphere, which is 2*pi*a1 in circumference
+	t.a1 = t.b1 * a
+	var o int64 = 0
+	d := n
+	for l := 1; l <= _TM_MAXPOW; l++ {
+		m = int64(_TM_MAXPOW - l)
+		t.alp[l] = d * polyval(m, ALPCOEFF[o:], n) / ALPCOEFF[o+m+1]
+		t.bet[l] = d * polyval(m, BETCOEFF[o:], n) / BETCOEFF[o+m+1]
+		o += m + 2
+		d *= n
+	}
+	return t
+}
+
+// EquatorialRadius returns the equatorial radius of the ellipsoid [meters]
+func (t *TransverseMercator) EquatorialRadius() float64 {
+	return t.a
+}
+
+// Flattening returns the flattening of the ellipsoid
+func (t *TransverseMercator) Flattening() float64 {
+	return t.f
+}
+
+// CentralScale returns the scale on the central meridian, k0
+func (t *TransverseMercator) CentralScale() float64 {
+	return t.k0
+}
+
+// Forward projects the point lat_deg, lon_deg onto the map whose central meridian is
+// lon0_deg. No false easting or northing is added. The result is good for all latitudes
+// and longitudes, although the accuracy degrades far from the central meridian.
+//   - lon0_deg - Central meridian of the projection [degrees]
+//   - lat_deg - Latitude of the point [degrees] [-90.,90.]
+//   - lon_deg - Longitude of the point [degrees]
+func (t *TransverseMercator) Forward(lon0_deg, lat_deg, lon_deg float64) ProjectionForwardResult {
+	lat := lat_fix(lat_deg)
+	lon, _ := ang_diff(lon0_deg, lon_deg)
+	// Explicitly enforce the parity
+	latsign := 1.0
+	if math.Signbit(lat) {
+		latsign = -1.0
+	}
+	lonsign := 1.0
+	if math.Signbit(lon) {
+		lonsign = -1.0
+	}
+	lon *= lonsign
+	lat *= latsign
+	backside := lon > 90.0
+	if backside {
+		if lat == 0 {
+			latsign = -1.0
+		}
+		lon = 180.0 - lon
+	}
+	sphi, cphi := sincosd(lat)
+	slam, clam := sincosd(lon)
+
+	// [xi', eta'] are the Gauss-Schreiber TM coordinates, and [xi, eta] are the
+	// Gauss-Krüger TM coordinates. tau = tan(phi) and tau' = tan(phi') = sinh(psi),
+	// where phi' is the conformal latitude and psi the isometric latitude.
+	var etap, xip, gamma, k float64
+	if lat != 90.0 {
+		tau := sphi / cphi
+		taup := taupf(tau, t.es)
+		xip = math.Atan2(taup, clam)
+		etap = math.Asinh(slam / math.Hypot(taup, clam))
+		// Convergence and scale for Gauss-Schreiber TM (xip, etap)
+		gamma = atan2_deg(slam*taup, clam*math.Hypot(1.0, taup))
+		// This form has cancelling errors
+		k = math.Sqrt(t.e2m+t.e2*sq(cphi)) * math.Hypot(1.0, tau) / math.Hypot(taup, clam)
+	} else {
+		xip = math.Pi / 2.0
+		etap = 0.0
+		gamma = lon
+		k = t.c
+	}
+
+	// zeta = xi + i*eta is found from zeta' = xi' + i*eta' with the series
+	//   zeta = zeta' + sum(alp[j] * sin(2 * j * zeta'), j = 1..maxpow)
+	// which, along with its derivative, is evaluated with Clenshaw summation
+	c0 := math.Cos(2.0 * xip)
+	ch0 := math.Cosh(2.0 * etap)
+	s0 := math.Sin(2.0 * xip)
+	sh0 := math.Sinh(2.0 * etap)
+	a := complex(2.0*c0*ch0, -2.0*s0*sh0) // 2 * cos(2*zeta')
+	n := _TM_MAXPOW
+	var y0, y1, z0, z1 complex128
+	if n&1 != 0 {
+		y0 = complex(t.alp[n], 0)
+		z0 = complex(float64(2*n)*t.alp[n], 0)
+		n--
+	}
+	for n > 0 {
+		y1 = a*y0 - y1 + complex(t.alp[n], 0)
+		z1 = a*z0 - z1 + complex(float64(2*n)*t.alp[n], 0)
+		n--
+		y0 = a*y1 - y0 + complex(t.alp[n], 0)
+		z0 = a*z1 - z0 + complex(float64(2*n)*t.alp[n], 0)
+		n--
+	}
+	a /= 2                         // cos(2*zeta')
+	z1 = 1 - z1 + a*z0             // dzeta/dzeta'
+	a = complex(s0*ch0, c0*sh0)    // sin(2*zeta')
+	y1 = complex(xip, etap) + a*y0 // zeta
+	// Fold in the change in convergence and scale for Gauss-Schreiber TM to
+	// Gauss-Krüger TM
+	gamma -= atan2_deg(imag(z1), real(z1))
+	k *= t.b1 * cmplx.Abs(z1)
+	xi := real(y1)
+	eta := imag(y1)
+	if backside {
+		xi = math.Pi - xi
+	}
+	y := t.a1 * t.k0 * xi * latsign
+	x := t.a1 * t.k0 * eta * lonsign
+	if backside {
+		gamma = 180.0 - gamma
+	}
+	gamma *= latsign * lonsign
+	return ProjectionForwardResult{
+		XM:             x,
+		YM:             y,
+		ConvergenceDeg: ang_normalize(gamma),
+		Scale:          k * t.k0,
+	}
+}
+
+// Reverse finds the point at easting x_m and northing y_m on the map whose central
+// meridian is lon0_deg. No false easting or northing is removed.
+//   - lon0_deg - Central meridian of the projection [degrees]
+//   - x_m - Easting of the point [meters]
+//   - y_m - Northing of the point [meters]
+func (t *TransverseMercator) Reverse(lon0_deg, x_m, y_m float64) ProjectionReverseResult {
+	// This undoes the steps in Forward, using the reverted series to find zeta' from
+	// zeta, and Newton's method to find tan(phi) from tan(phi')
+	xi := y_m / (t.a1 * t.k0)
+	eta := x_m / (t.a1 * t.k0)
+	// Explicitly enforce the parity
+	xisign := 1.0
+	if math.Signbit(xi) {
+		xisign = -1.0
+	}
+	etasign := 1.0
+	if math.Signbit(eta) {
+		etasign = -1.0
+	}
+	xi *= xisign
+	eta *= etasign
+	backside := xi > math.Pi/2.0
+	if backside {
+		xi = math.Pi - xi
+	}
+	c0 := math.Cos(2.0 * xi)
+	ch0 := math.Cosh(2.0 * eta)
+	s0 := math.Sin(2.0 * xi)
+	sh0 := math.Sinh(2.0 * eta)
+	a := complex(2.0*c0*ch0, -2.0*s0*sh0) // 2 * cos(2*zeta)
+	n := _TM_MAXPOW
+	var y0, y1, z0, z1 complex128
+	if n&1 != 0 {
+		y0 = complex(-t.bet[n], 0)
+		z0 = complex(-float64(2*n)*t.bet[n], 0)
+		n--
+	}
+	for n > 0 {
+		y1 = a*y0 - y1 - complex(t.bet[n], 0)
+		z1 = a*z0 - z1 - complex(float64(2*n)*t.bet[n], 0)
+		n--
+		y0 = a*y1 - y0 - complex(t.bet[n], 0)
+		z0 = a*z1 - z0 - complex(float64(2*n)*t.bet[n], 0)
+		n--
+	}
+	a /= 2                       // cos(2*zeta)
+	z1 = 1 - z1 + a*z0           // dzeta'/dzeta
+	a = complex(s0*ch0, c0*sh0)  // sin(2*zeta)
+	y1 = complex(xi, eta) + a*y0 // zeta'
+	// Convergence and scale for Gauss-Schreiber TM to Gauss-Krüger TM
+	gamma := atan2_deg(imag(z1), real(z1))
+	k := t.b1 / cmplx.Abs(z1)
+	xip := real(y1)
+	etap := imag(y1)
+	s := math.Sinh(etap)
+	// cos(pi/2) might be negative
+	c := math.Max(0.0, math.Cos(xip))
+	r := math.Hypot(s, c)
+	var lat, lon float64
+	if r != 0 {
+		lon = atan2_deg(s, c)
+		sxip := math.Sin(xip)
+		tau := tauf(sxip/r, t.es)
+		gamma += atan2_deg(sxip*math.Tanh(etap), c)
+		lat = atand(tau)
+		// Note cos(phi') * cosh(eta') = r
+		k *= math.Sqrt(t.e2m+t.e2/(1.0+sq(tau))) * math.Hypot(1.0, tau) * r
+	} else {
+		lat = 90.0
+		lon = 0.0
+		k *= t.c
+	}
+	lat *= xisign
+	if backside {
+		lon = 180.0 - lon
+	}
+	lon *= etasign
+	lon = ang_normalize(lon + lon0_deg)
+	if backside {
+		gamma = 180.0 - gamma
+	}
+	gamma *= xisign * etasign
+	return ProjectionReverseResult{
+		LatDeg:         lat,
+		LonDeg:         lon,
+		ConvergenceDeg: ang_normalize(gamma),
+		Scale:          k * t.k0,
+	}
+}
@@ -0,0 +1,112 @@
+package geographiclibgo
+
+import (
+	"math"
+	"testing"
+)
+
+func TestTransverseMercatorUTM(t *testing.T) {
+	// 33.3N 44.4E is 38n 444140.54 3684706.36, from the GeoConvert documentation
+	tm := NewTransverseMercator(WGS84_A, WGS84_F, 0.9996)
+	got := tm.Forward(CentralMeridian(38), 33.3, 44.4)
+	if !almost_equal(got.XM+500000, 444140.54, 0.005) {
+		t.Errorf("x = %v; want %v", got.XM+500000, 444140.54)
+	}
+	if !almost_equal(got.YM, 3684706.36, 0.005) {
+		t.Errorf("y = %v; want %v", got.YM, 3684706.36)
+	}
+
+	rev := tm.Reverse(CentralMeridian(38), got.XM, got.YM)
+	if !almost_equal(rev.LatDeg, 33.3, 1e-12) {
+		t.Errorf("lat = %v; want %v", rev.LatDeg, 33.3)
+	}
+	if !almost_equal(rev.LonDeg, 44.4, 1e-12) {
+		t.Errorf("lon = %v; want %v", rev.LonDeg, 44.4)
+	}
+	if !almost_equal(rev.ConvergenceDeg, got.ConvergenceDeg, 1e-12) {
+		t.Errorf("reverse gamma = %v; want %v", rev.ConvergenceDeg, got.ConvergenceDeg)
+	}
+	if !almost_equal(rev.Scale, got.Scale, 1e-14) {
+		t.Errorf("reverse k = %v; want %v", rev.Scale, got.Scale)
+	}
+}
+
+func TestTransverseMercatorCentralMeridian(t *testing.T) {
+	// On the central meridian, x = 0, the convergence is 0, the scale is k0, and y is the
+	// meridian distance scaled by k0
+	g := Wgs84()
+	tm := NewTransverseMercator(WGS84_A, WGS84_F, 0.9996)
+	for _, lat := range []float64{0, 10, 45, 89, 90} {
+		got := tm.Forward(3, lat, 3)
+		s := g.InverseCalcAll(0, 3, lat, 3).DistanceM
+		if got.XM != 0 {
+			t.Errorf("lat = %v: x = %v; want 0", lat, got.XM)
+		}
+		if !almost_equal(got.YM, 0.9996*s, 1e-6) {
+			t.Errorf("lat = %v: y = %v; want %v", lat, got.YM, 0.9996*s)
+		}
+		if got.ConvergenceDeg != 0 {
+			t.Errorf("lat = %v: gamma = %v; want 0", lat, got.ConvergenceDeg)
+		}
+		if !almost_equal(got.Scale, 0.9996, 1e-15) {
+			t.Errorf("lat = %v: k = %v; want %v", lat, got.Scale, 0.9996)
+		}
+	}
+}
+
+func TestTransverseMercatorMatchesExact(t *testing.T) {
+	tm := NewTransverseMercator(WGS84_A, WGS84_F, 1)
+	tme := NewTransverseMercatorExact(WGS84_A, WGS84_F, 1, false)
+	for lat := -80.0; lat <= 80.0; lat += 10 {
+		for lon := -30.0; lon <= 30.0; lon += 7.5 {
+			got := tm.Forward(0, lat, lon)
+			want := tme.Forward(0, lat, lon)
+			if !almost_equal(got.XM, want.XM, 1e-8) || !almost_equal(got.YM, want.YM, 1e-8) {
+				t.Errorf(
+					"Forward(%v, %v) = (%v, %v); want (%v, %v)",
+					lat, lon, got.XM, got.YM, want.XM, want.YM,
+				)
+			}
+			if !almost_equal(got.ConvergenceDeg, want.ConvergenceDeg, 1e-12) {
+				t.Errorf("Forward(%v, %v) gamma = %v; want %v", lat, lon, got.ConvergenceDeg, want.ConvergenceDeg)
+			}
+			if !almost_equal(got.Scale, want.Scale, 1e-14) {
+				t.Errorf("Forward(%v, %v) k = %v; want %v", lat, lon, got.Scale, want.Scale)
+			}
+
+			rev := tm.Reverse(0, got.XM, got.YM)
+			if !almost_equal(rev.LatDeg, lat, 1e-12) || !almost_equal(rev.LonDeg, lon, 1e-12) {
+				t.Errorf("Reverse(Forward(%v, %v)) = (%v, %v)", lat, lon, rev.LatDeg, rev.LonDeg)
+			}
+		}
+	}
+}
+
+func TestTransverseMercatorSymmetry(t *testing.T) {
+	tm := NewTransverseMercator(WGS84_A, WGS84_F, 0.9996)
+	a := tm.Forward(-75, 40, -72)
+	b := tm.Forward(-75, -40, -78)
+	if a.XM != -b.XM || a.YM != -b.YM {
+		t.Errorf("Forward() = (%v, %v); want (%v, %v)", b.XM, b.YM, -a.XM, -a.YM)
+	}
+	if a.ConvergenceDeg != b.ConvergenceDeg || a.Scale != b.Scale {
+		t.Errorf("gamma, k = %v, %v; want %v, %v", b.ConvergenceDeg, b.Scale, a.ConvergenceDeg, a.Scale)
+	}
+	if math.IsNaN(a.XM) {
+		t.Errorf("x is NaN")
+	}
+}
+
+func BenchmarkTransverseMercatorForward(b *testing.B) {
+	tm := NewTransverseMercator(WGS84_A, WGS84_F, 0.9996)
+	for i := 0; i < b.N; i++ {
+		tm.Forward(45, 33.3, 44.4)
+	}
+}
+
+func BenchmarkTransverseMercatorReverse(b *testing.B) {
+	tm := NewTransverseMercator(WGS84_A, WGS84_F, 0.9996)
+	for i := 0; i < b.N; i++ {
+		tm.Reverse(45, -55859.455, 3684706.356)
+	}
+}
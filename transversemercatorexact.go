@@ -0,0 +1,461 @@
+package geographiclibgo
+
+import "math"
+
+// Maximum number of Newton iterations used by TransverseMercatorExact
+const _TMEXACT_NUMIT int = 10
+
+// TransverseMercatorExact is the exact transverse Mercator projection, following
+// L. P. Lee, Conformal Projections Based on Jacobian Elliptic Functions, Part V of
+// Conformal Projections Based on Elliptic Functions (B. V. Gutsell, Toronto, 1976).
+// Compared with TransverseMercator it is accurate to round-off everywhere, and is valid
+// far from the central meridian, but it is several times slower. The flattening must be
+// positive.
+//
+// With extendp set, the projection is extended to cover the whole ellipsoid in a single
+// sheet, rather than folding the back hemisphere over the front one.
+type TransverseMercatorExact struct {
+	a       float64
+	f       float64
+	k0      float64
+	mu      float64 // e^2
+	mv      float64 // 1 - e^2
+	e       float64
+	extendp bool
+	eEu     EllipticFunction
+	eEv     EllipticFunction
+	tol     float64
+	tol2    float64
+	taytol  float64
+}
+
+// NewTransverseMercatorExact creates a TransverseMercatorExact projection for the
+// ellipsoid with equatorial radius a [meters] and flattening f, which must be positive,
+// with scale k0 on the central meridian.
+func NewTransverseMercatorExact(a, f, k0 float64, extendp bool) TransverseMercatorExact {
+	tol := get_epsilon()
+	mu := f * (2.0 - f)
+	mv := 1.0 - mu
+	return TransverseMercatorExact{
+		a:       a,
+		f:       f,
+		k0:      k0,
+		mu:      mu,
+		mv:      mv,
+		e:       math.Sqrt(mu),
+		extendp: extendp,
+		eEu:     NewEllipticFunction(mu, 0),
+		eEv:     NewEllipticFunction(mv, 0),
+		tol:     tol,
+		tol2:    0.1 * tol,
+		taytol:  math.Pow(tol, 0.6),
+	}
+}
+
+// EquatorialRadius returns the equatorial radius of the ellipsoid [meters]
+func (t *TransverseMercatorExact) EquatorialRadius() float64 {
+	return t.a
+}
+
+// Flattening returns the flattening of the ellipsoid
+func (t *TransverseMercatorExact) Flattening() float64 {
+	return t.f
+}
+
+// CentralScale returns the scale on the central meridian, k0
+func (t *TransverseMercatorExact) CentralScale() float64 {
+	return t.k0
+}
+
+// _zeta finds taup = sinh(psi) and lam for the Thompson TM coordinates w = u + i*v,
+// using Lee 54.17
+func (t *TransverseMercatorExact) _zeta(
+	snu, cnu, dnu, snv, cnv, dnv float64,
+) (float64, float64) {
+	// Write atanh(snu * dnv) = asinh(snu * dnv / sqrt(cnu^2 + mv * snu^2 * snv^2)) and
+	// atanh(e * snu / dnv) = asinh(e * snu / sqrt(mu * cnu^2 + mv * cnv^2)).
+	// overflow is such that atan(overflow) = pi/2.
+	overflow := 1.0 / sq(get_epsilon())
+	d1 := math.Sqrt(sq(cnu) + t.mv*sq(snu*snv))
+	d2 := math.Sqrt(t.mu*sq(cnu) + t.mv*sq(cnv))
+	t1 := math.Copysign(overflow, snu)
+	if d1 != 0 {
+		t1 = snu * dnv / d1
+	}
+	t2 := math.Copysign(overflow, snu)
+	if d2 != 0 {
+		t2 = math.Sinh(t.e * math.Asinh(t.e*snu/d2))
+	}
+	// psi = asinh(t1) - asinh(t2) and taup = sinh(psi)
+	taup := t1*math.Hypot(1.0, t2) - t2*math.Hypot(1.0, t1)
+	lam := 0.0
+	if d1 != 0 && d2 != 0 {
+		lam = math.Atan2(dnu*snv, cnu*cnv) - t.e*math.Atan2(t.e*cnu*snv, dnu*cnv)
+	}
+	return taup, lam
+}
+
+// _dwdzeta finds the derivative of w with respect to zeta, using Lee 54.21
+func (t *TransverseMercatorExact) _dwdzeta(
+	snu, cnu, dnu, snv, cnv, dnv float64,
+) (float64, float64) {
+	// Write (1 - dnu^2 * snv^2) = (cnv^2 + mu * snu^2 * snv^2)
+	d := t.mv * sq(sq(cnv)+t.mu*sq(snu*snv))
+	du := cnu * dnu * dnv * (sq(cnv) - t.mu*sq(snu*snv)) / d
+	dv := -snu * snv * cnv * (sq(dnu*dnv) + t.mu*sq(cnu)) / d
+	return du, dv
+}
+
+// _zetainv0 finds a starting point for _zetainv. If the returned bool is true, the
+// starting point is accurate enough to be used without further iteration.
+func (t *TransverseMercatorExact) _zetainv0(psi, lam float64) (float64, float64, bool) {
+	var u, v float64
+	retval := false
+	if psi < -t.e*math.Pi/4.0 &&
+		lam > (1.0-2.0*t.e)*math.Pi/2.0 &&
+		psi < lam-(1.0-t.e)*math.Pi/2.0 {
+		// N.B. this branch is normally not taken because psi < 0 is converted to
+		// psi > 0 by Forward.
+		//
+		// There's a log singularity at w = w0 = Eu.K() + i * Ev.K(), corresponding to
+		// the south pole, where we have, approximately
+		//   psi = e + i * pi/2 - e * atanh(cos(i * (w - w0)/(1 + mu/2)))
+		// Inverting this gives:
+		psix := 1.0 - psi/t.e
+		lamx := (math.Pi/2.0 - lam) / t.e
+		u = math.Asinh(math.Sin(lamx)/math.Hypot(math.Cos(lamx), math.Sinh(psix))) *
+			(1.0 + t.mu/2.0)
+		v = math.Atan2(math.Cos(lamx), math.Sinh(psix)) * (1.0 + t.mu/2.0)
+		u = t.eEu.K() - u
+		v = t.eEv.K() - v
+	} else if psi < t.e*math.Pi/2.0 && lam > (1.0-2.0*t.e)*math.Pi/2.0 {
+		// At w = w0 = i * Ev.K(), we have
+		//   zeta = zeta0 = i * (1 - e) * pi/2
+		//   zeta' = zeta'' = 0
+		// Including the next term in the Taylor series gives:
+		//   zeta = zeta0 - (mv * e) / 3 * (w - w0)^3
+		// When inverting this, we map arg(w - w0) = [-90, 0] to
+		// arg(zeta - zeta0) = [-90, 180]
+		dlam := lam - (1.0-t.e)*math.Pi/2.0
+		rad := math.Hypot(psi, dlam)
+		// atan2(dlam-psi, psi+dlam) + 45d gives arg(zeta - zeta0) in range
+		// [-135, 225). Subtracting 180 (since the multiplier is negative) makes the
+		// range [-315, 45). Multiplying by 1/3 (for the cube root) gives the range
+		// [-105, 15). In particular the range [-90, 180] in zeta space maps to [-90, 0]
+		// in w space as required.
+		ang := math.Atan2(dlam-psi, psi+dlam) - 0.75*math.Pi
+		// Error using this guess is about 0.21 * (rad/e)^(5/3)
+		retval = rad < t.e*t.taytol
+		rad = math.Cbrt(3.0 / (t.mv * t.e) * rad)
+		ang /= 3.0
+		u = rad * math.Cos(ang)
+		v = rad*math.Sin(ang) + t.eEv.K()
+	} else {
+		// Use spherical TM, Lee 12.6, writing atanh(sin(lam) / cosh(psi)) =
+		// asinh(sin(lam) / hypot(cos(lam), sinh(psi))). This takes care of the log
+		// singularity at zeta = Eu.K() (corresponding to the north pole)
+		v = math.Asinh(math.Sin(lam) / math.Hypot(math.Cos(lam), math.Sinh(psi)))
+		u = math.Atan2(math.Sinh(psi), math.Cos(lam))
+		// But scale to put 90,0 on the right place
+		u *= t.eEu.K() / (math.Pi / 2.0)
+		v *= t.eEu.K() / (math.Pi / 2.0)
+	}
+	return u, v, retval
+}
+
+// _zetainv inverts _zeta using Newton's method
+func (t *TransverseMercatorExact) _zetainv(taup, lam float64) (float64, float64) {
+	psi := math.Asinh(taup)
+	scal := 1.0 / math.Hypot(1.0, taup)
+	u, v, done := t._zetainv0(psi, lam)
+	if done {
+		return u, v
+	}
+	stol2 := t.tol2 / sq(math.Max(psi, 1.0))
+	// min iterations = 2, max iterations = 6; mean = 4.0
+	trip := false
+	for i := 0; i < _TMEXACT_NUMIT; i++ {
+		snu, cnu, dnu := t.eEu.Sncndn(u)
+		snv, cnv, dnv := t.eEv.Sncndn(v)
+		tau1, lam1 := t._zeta(snu, cnu, dnu, snv, cnv, dnv)
+		du1, dv1 := t._dwdzeta(snu, cnu, dnu, snv, cnv, dnv)
+		tau1 -= taup
+		lam1 -= lam
+		tau1 *= scal
+		delu := tau1*du1 - lam1*dv1
+		delv := tau1*dv1 + lam1*du1
+		u -= delu
+		v -= delv
+		if trip {
+			break
+		}
+		delw2 := sq(delu) + sq(delv)
+		if !(delw2 >= stol2) {
+			trip = true
+		}
+	}
+	return u, v
+}
+
+// _sigma finds the TM coordinates xi and eta for the Thompson TM coordinates w = u + i*v,
+// using Lee 55.4
+func (t *TransverseMercatorExact) _sigma(
+	snu, cnu, dnu, v, snv, cnv, dnv float64,
+) (float64, float64) {
+	// Write dnu^2 + dnv^2 - 1 = mu * cnu^2 + mv * cnv^2
+	d := t.mu*sq(cnu) + t.mv*sq(cnv)
+	xi := t.eEu.ESnCnDn(snu, cnu, dnu) - t.mu*snu*cnu*dnu/d
+	eta := v - t.eEv.ESnCnDn(snv, cnv, dnv) + t.mv*snv*cnv*dnv/d
+	return xi, eta
+}
+
+// _dwdsigma finds the derivative of w with respect to sigma, the reciprocal of Lee 55.9
+func (t *TransverseMercatorExact) _dwdsigma(
+	snu, cnu, dnu, snv, cnv, dnv float64,
+) (float64, float64) {
+	// dw/ds = dn(w)^2/mv, expanding complex dn(w) using A+S 16.21.4
+	d := t.mv * sq(sq(cnv)+t.mu*sq(snu*snv))
+	dnr := dnu * cnv * dnv
+	dni := -t.mu * snu * cnu * snv
+	du := (sq(dnr) - sq(dni)) / d
+	dv := 2.0 * dnr * dni / d
+	return du, dv
+}
+
+// _sigmainv0 finds a starting point for _sigmainv. If the returned bool is true, the
+// starting point is accurate enough to be used without further iteration.
+func (t *TransverseMercatorExact) _sigmainv0(xi, eta float64) (float64, float64, bool) {
+	var u, v float64
+	retval := false
+	if eta > 1.25*t.eEv.KE() ||
+		(xi < -0.25*t.eEu.E() && xi < eta-t.eEv.KE()) {
+		// sigma has a simple pole at w = w0 = Eu.K() + i * Ev.K(), and sigma is
+		// approximated by
+		//   sigma = (Eu.E() + i * Ev.KE()) + 1/(w - w0)
+		x := xi - t.eEu.E()
+		y := eta - t.eEv.KE()
+		r2 := sq(x) + sq(y)
+		u = t.eEu.K() + x/r2
+		v = t.eEv.K() - y/r2
+	} else if (eta > 0.75*t.eEv.KE() && xi < 0.25*t.eEu.E()) || eta > t.eEv.KE() {
+		// At w = w0 = i * Ev.K(), we have
+		//   sigma = sigma0 = i * Ev.KE()
+		//   sigma' = sigma'' = 0
+		// Including the next term in the Taylor series gives:
+		//   sigma = sigma0 - mv / 3 * (w - w0)^3
+		// When inverting this, we map arg(w - w0) = [-pi/2, -pi/6] to
+		// arg(sigma - sigma0) = [-pi/2, pi/2]
+		deta := eta - t.eEv.KE()
+		rad := math.Hypot(xi, deta)
+		// Map the range [-90, 180] in sigma space to [-90, 0] in w space. See the
+		// discussion in _zetainv0 on the cut for ang.
+		ang := math.Atan2(deta-xi, xi+deta) - 0.75*math.Pi
+		// Error using this guess is about 0.068 * rad^(5/3)
+		retval = rad < 2.0*t.taytol
+		rad = math.Cbrt(3.0 / t.mv * rad)
+		ang /= 3.0
+		u = rad * math.Cos(ang)
+		v = rad*math.Sin(ang) + t.eEv.K()
+	} else {
+		// Else use w = sigma * Eu.K/Eu.E (which is correct in the limit e -> 0)
+		u = xi * t.eEu.K() / t.eEu.E()
+		v = eta * t.eEu.K() / t.eEu.E()
+	}
+	return u, v, retval
+}
+
+// _sigmainv inverts _sigma using Newton's method
+func (t *TransverseMercatorExact) _sigmainv(xi, eta float64) (float64, float64) {
+	u, v, done := t._sigmainv0(xi, eta)
+	if done {
+		return u, v
+	}
+	// min iterations = 2, max iterations = 7; mean = 3.9
+	trip := false
+	for i := 0; i < _TMEXACT_NUMIT; i++ {
+		snu, cnu, dnu := t.eEu.Sncndn(u)
+		snv, cnv, dnv := t.eEv.Sncndn(v)
+		xi1, eta1 := t._sigma(snu, cnu, dnu, v, snv, cnv, dnv)
+		du1, dv1 := t._dwdsigma(snu, cnu, dnu, snv, cnv, dnv)
+		xi1 -= xi
+		eta1 -= eta
+		delu := xi1*du1 - eta1*dv1
+		delv := xi1*dv1 + eta1*du1
+		u -= delu
+		v -= delv
+		if trip {
+			break
+		}
+		delw2 := sq(delu) + sq(delv)
+		if !(delw2 >= t.tol2) {
+			trip = true
+		}
+	}
+	return u, v
+}
+
+// _scale finds the convergence [radians] and scale, given tau = tan(phi) and the Thompson
+// TM coordinates
+func (t *TransverseMercatorExact) _scale(
+	tau, snu, cnu, dnu, snv, cnv, dnv float64,
+) (float64, float64) {
+	// sec(phi)^2
+	sec2 := 1.0 + sq(tau)
+	// Lee 55.12 -- negated for our sign convention. gamma gives the bearing (clockwise
+	// from true north) of grid north
+	gamma := math.Atan2(t.mv*snu*snv*cnv, cnu*dnu*dnv)
+	// Lee 55.13 with nu given by Lee 9.1 -- in sqrt change the numerator from
+	// (1 - snu^2 * dnv^2) to (mv * snv^2 + cnu^2 * dnv^2) to maintain accuracy near
+	// phi = 90 and change the denominator from (dnu^2 + dnv^2 - 1) to
+	// (mu * cnu^2 + mv * cnv^2) to maintain accuracy near phi = 0, lam = 90 * (1 - e).
+	// Similarly rewrite the sqrt term in 9.1 as mv + mu * c^2 instead of
+	// 1 - mu * sin(phi)^2
+	k := math.Sqrt(t.mv+t.mu/sec2) * math.Sqrt(sec2) *
+		math.Sqrt((t.mv*sq(snv)+sq(cnu*dnv))/(t.mu*sq(cnu)+t.mv*sq(cnv)))
+	return gamma, k
+}
+
+// Forward projects the point lat_deg, lon_deg onto the map whose central meridian is
+// lon0_deg. No false easting or northing is added.
+//   - lon0_deg - Central meridian of the projection [degrees]
+//   - lat_deg - Latitude of the point [degrees] [-90.,90.]
+//   - lon_deg - Longitude of the point [degrees]
+func (t *TransverseMercatorExact) Forward(
+	lon0_deg, lat_deg, lon_deg float64,
+) ProjectionForwardResult {
+	lat := lat_fix(lat_deg)
+	lon, _ := ang_diff(lon0_deg, lon_deg)
+	// Explicitly enforce the parity
+	latsign := 1.0
+	if !t.extendp && math.Signbit(lat) {
+		latsign = -1.0
+	}
+	lonsign := 1.0
+	if !t.extendp && math.Signbit(lon) {
+		lonsign = -1.0
+	}
+	lon *= lonsign
+	lat *= latsign
+	backside := !t.extendp && lon > 90.0
+	if backside {
+		if lat == 0 {
+			latsign = -1.0
+		}
+		lon = 180.0 - lon
+	}
+	lam := lon * DEG2RAD
+	tau := tand(lat)
+
+	// u, v are the coordinates for the Thompson TM, Lee 54
+	var u, v float64
+	if lat == 90.0 {
+		u = t.eEu.K()
+		v = 0.0
+	} else if lat == 0 && lon == 90.0*(1.0-t.e) {
+		u = 0.0
+		v = t.eEv.K()
+	} else {
+		// tau = tan(phi), taup = sinh(psi)
+		u, v = t._zetainv(taupf(tau, t.e), lam)
+	}
+
+	snu, cnu, dnu := t.eEu.Sncndn(u)
+	snv, cnv, dnv := t.eEv.Sncndn(v)
+	xi, eta := t._sigma(snu, cnu, dnu, v, snv, cnv, dnv)
+	if backside {
+		xi = 2.0*t.eEu.E() - xi
+	}
+	y := xi * t.a * t.k0 * latsign
+	x := eta * t.a * t.k0 * lonsign
+
+	var gamma, k float64
+	if lat == 90.0 {
+		gamma = lon
+		k = 1.0
+	} else {
+		// Recompute (tau, lam) from (u, v) to improve the accuracy of the scale
+		tau, _ = t._zeta(snu, cnu, dnu, snv, cnv, dnv)
+		tau = tauf(tau, t.e)
+		gamma, k = t._scale(tau, snu, cnu, dnu, snv, cnv, dnv)
+		gamma *= RAD2DEG
+	}
+	if backside {
+		gamma = 180.0 - gamma
+	}
+	gamma *= latsign * lonsign
+	return ProjectionForwardResult{
+		XM:             x,
+		YM:             y,
+		ConvergenceDeg: gamma,
+		Scale:          k * t.k0,
+	}
+}
+
+// Reverse finds the point at easting x_m and northing y_m on the map whose central
+// meridian is lon0_deg. No false easting or northing is removed.
+//   - lon0_deg - Central meridian of the projection [degrees]
+//   - x_m - Easting of the point [meters]
+//   - y_m - Northing of the point [meters]
+func (t *TransverseMercatorExact) Reverse(lon0_deg, x_m, y_m float64) ProjectionReverseResult {
+	// This undoes the steps in Forward
+	xi := y_m / (t.a * t.k0)
+	eta := x_m / (t.a * t.k0)
+	// Explicitly enforce the parity
+	xisign := 1.0
+	if !t.extendp && math.Signbit(xi) {
+		xisign = -1.0
+	}
+	etasign := 1.0
+	if !t.extendp && math.Signbit(eta) {
+		etasign = -1.0
+	}
+	xi *= xisign
+	eta *= etasign
+	backside := !t.extendp && xi > t.eEu.E()
+	if backside {
+		xi = 2.0*t.eEu.E() - xi
+	}
+
+	// u, v are the coordinates for the Thompson TM, Lee 54
+	var u, v float64
+	if xi == 0 && eta == t.eEv.KE() {
+		u = 0.0
+		v = t.eEv.K()
+	} else {
+		u, v = t._sigmainv(xi, eta)
+	}
+
+	snu, cnu, dnu := t.eEu.Sncndn(u)
+	snv, cnv, dnv := t.eEv.Sncndn(v)
+	var lat, lon, gamma, k float64
+	if v != 0 || u != t.eEu.K() {
+		tau, lam := t._zeta(snu, cnu, dnu, snv, cnv, dnv)
+		tau = tauf(tau, t.e)
+		lat = atand(tau)
+		lon = lam * RAD2DEG
+		gamma, k = t._scale(tau, snu, cnu, dnu, snv, cnv, dnv)
+		gamma *= RAD2DEG
+	} else {
+		lat = 90.0
+		lon = 0.0
+		gamma = 0.0
+		k = 1.0
+	}
+
+	if backside {
+		lon = 180.0 - lon
+	}
+	lon *= etasign
+	lon = ang_normalize(lon + ang_normalize(lon0_deg))
+	lat *= xisign
+	if backside {
+		gamma = 180.0 - gamma
+	}
+	gamma *= xisign * etasign
+	return ProjectionReverseResult{
+		LatDeg:         lat,
+		LonDeg:         lon,
+		ConvergenceDeg: gamma,
+		Scale:          k * t.k0,
+	}
+}
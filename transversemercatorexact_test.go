@@ -0,0 +1,61 @@
+package geographiclibgo
+
+import "testing"
+
+func TestTransverseMercatorExactRoundTrip(t *testing.T) {
+	tme := NewTransverseMercatorExact(WGS84_A, WGS84_F, 0.9996, false)
+	for lat := -89.0; lat <= 89.0; lat += 11 {
+		for lon := -89.0; lon <= 89.0; lon += 11 {
+			fwd := tme.Forward(0, lat, lon)
+			rev := tme.Reverse(0, fwd.XM, fwd.YM)
+			if !almost_equal(rev.LatDeg, lat, 1e-11) || !almost_equal(rev.LonDeg, lon, 1e-11) {
+				t.Errorf("Reverse(Forward(%v, %v)) = (%v, %v)", lat, lon, rev.LatDeg, rev.LonDeg)
+			}
+			if !almost_equal(rev.ConvergenceDeg, fwd.ConvergenceDeg, 1e-9) {
+				t.Errorf("(%v, %v) reverse gamma = %v; want %v", lat, lon, rev.ConvergenceDeg, fwd.ConvergenceDeg)
+			}
+			if !almost_equal(rev.Scale, fwd.Scale, 1e-9*fwd.Scale) {
+				t.Errorf("(%v, %v) reverse k = %v; want %v", lat, lon, rev.Scale, fwd.Scale)
+			}
+		}
+	}
+}
+
+func TestTransverseMercatorExactSpecialPoints(t *testing.T) {
+	tme := NewTransverseMercatorExact(WGS84_A, WGS84_F, 1, false)
+
+	// The pole maps to the point on the central meridian a quadrant from the equator
+	pole := tme.Forward(0, 90, 45)
+	g := Wgs84()
+	quarter := g.InverseCalcAll(0, 0, 90, 0).DistanceM
+	if pole.XM != 0 || !almost_equal(pole.YM, quarter, 1e-6) {
+		t.Errorf("Forward(90, 45) = (%v, %v); want (0, %v)", pole.XM, pole.YM, quarter)
+	}
+
+	// The equator beyond the singular point at 90(1-e) degrees from the central meridian
+	// maps to a line parallel to the y axis, and the point 90 degrees from the central
+	// meridian has the same northing as the pole
+	if eq := tme.Forward(0, 0, 90); !(eq.XM > 0) || !almost_equal(eq.YM, quarter, 1e-6) {
+		t.Errorf("Forward(0, 90) = (%v, %v); want (>0, %v)", eq.XM, eq.YM, quarter)
+	}
+}
+
+func TestTransverseMercatorExactExtended(t *testing.T) {
+	// With extendp, the projection covers the whole ellipsoid, with the region beyond 90
+	// degrees of the central meridian mapped to y < 0 in the northern hemisphere
+	tme := NewTransverseMercatorExact(WGS84_A, WGS84_F, 1, true)
+	for _, lon := range []float64{100, 135, 179} {
+		fwd := tme.Forward(0, 10, lon)
+		rev := tme.Reverse(0, fwd.XM, fwd.YM)
+		if !almost_equal(rev.LatDeg, 10, 1e-10) || !almost_equal(rev.LonDeg, lon, 1e-10) {
+			t.Errorf("Reverse(Forward(10, %v)) = (%v, %v)", lon, rev.LatDeg, rev.LonDeg)
+		}
+	}
+}
+
+func BenchmarkTransverseMercatorExactForward(b *testing.B) {
+	tme := NewTransverseMercatorExact(WGS84_A, WGS84_F, 0.9996, false)
+	for i := 0; i < b.N; i++ {
+		tme.Forward(45, 33.3, 44.4)
+	}
+}
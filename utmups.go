@@ -0,0 +1,364 @@
+package geographiclibgo
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Zones and pseudo-zones used by UTMUPS. Zones 1 through 60 are UTM zones.
+const (
+	ZONE_INVALID  int = -4 // The zone of an invalid point, e.g. one with a NaN latitude
+	ZONE_MATCH    int = -3 // Use the zone of the input, when converting between zones
+	ZONE_UTM      int = -2 // Use the standard UTM zone, even in the polar regions
+	ZONE_STANDARD int = -1 // Use the standard UTM or UPS zone
+	ZONE_UPS      int = 0  // The zone of UPS
+	ZONE_MIN_UTM  int = 1  // The smallest UTM zone
+	ZONE_MAX_UTM  int = 60 // The largest UTM zone
+)
+
+// The size of an MGRS 100 km tile [meters]
+const _MGRS_TILE float64 = 100000.0
+
+// The ranges of eastings and northings for UPS south, UPS north, UTM south, and UTM north,
+// indexed by (utmp ? 2 : 0) + (northp ? 1 : 0). The MGRS limits are these; the UTMUPS
+// limits allow an additional 100 km slop.
+var (
+	_UTMUPS_FALSE_EASTING  = [4]float64{2000000.0, 2000000.0, 500000.0, 500000.0}
+	_UTMUPS_FALSE_NORTHING = [4]float64{2000000.0, 2000000.0, 10000000.0, 0.0}
+	_UTMUPS_MIN_EASTING    = [4]float64{800000.0, 1300000.0, 100000.0, 100000.0}
+	_UTMUPS_MAX_EASTING    = [4]float64{3200000.0, 2700000.0, 900000.0, 900000.0}
+	_UTMUPS_MIN_NORTHING   = [4]float64{800000.0, 1300000.0, 1000000.0, -9000000.0}
+	_UTMUPS_MAX_NORTHING   = [4]float64{3200000.0, 2700000.0, 19500000.0, 9500000.0}
+)
+
+// UTMUPSResult is a position expressed in UTM or UPS coordinates
+type UTMUPSResult struct {
+	Zone           int     // UTM zone in [1, 60], or ZONE_UPS for UPS
+	Northp         bool    // Whether the point is in the northern hemisphere
+	EastingM       float64 // Easting of the point, including the false easting [meters]
+	NorthingM      float64 // Northing of the point, including the false northing [meters]
+	ConvergenceDeg float64 // Meridian convergence at the point [degrees]
+	Scale          float64 // Scale of the projection at the point
+}
+
+// UTMUPS converts between geographic coordinates and the Universal Transverse Mercator
+// (UTM) and Universal Polar Stereographic (UPS) systems. UTM uses a transverse Mercator
+// projection with k0 = 0.9996 in 60 zones of 6 degrees of longitude; UPS uses a polar
+// stereographic projection with k0 = 0.994 poleward of latitudes 84N and 80S. The
+// standard zone includes the exceptions for Norway and Svalbard.
+//
+// The legal ranges of the coordinates are those of the MGRS with an additional 100 km of
+// slop: within 900 km of the central meridian for UTM (500 km false easting), and within
+// 1200 km of the pole for UPS (2000 km false easting and northing).
+type UTMUPS struct {
+	tm TransverseMercator
+	ps PolarStereographic
+}
+
+// NewUTMUPS creates a UTMUPS for the ellipsoid with equatorial radius a [meters] and
+// flattening f
+func NewUTMUPS(a, f float64) UTMUPS {
+	return UTMUPS{
+		tm: NewTransverseMercator(a, f, 0.9996),
+		ps: NewPolarStereographic(a, f, 0.994),
+	}
+}
+
+// Wgs84UTMUPS is a convenience function that creates a UTMUPS for the WGS84 ellipsoid
+func Wgs84UTMUPS() UTMUPS {
+	return NewUTMUPS(WGS84_A, WGS84_F)
+}
+
+// EquatorialRadius returns the equatorial radius of the ellipsoid [meters]
+func (u *UTMUPS) EquatorialRadius() float64 {
+	return u.tm.EquatorialRadius()
+}
+
+// Flattening returns the flattening of the ellipsoid
+func (u *UTMUPS) Flattening() float64 {
+	return u.tm.Flattening()
+}
+
+// latitude_band returns the MGRS latitude band for lat_deg, in [-10, 9], where -10
+// corresponds to band C and 9 to band X. Latitudes outside [-80, 84) are clamped.
+func latitude_band(lat_deg float64) int {
+	ilat := int(math.Floor(lat_deg))
+	band := (ilat+80)/8 - 10
+	if band < -10 {
+		return -10
+	}
+	if band > 9 {
+		return 9
+	}
+	return band
+}
+
+// CentralMeridian returns the central meridian of UTM zone [degrees]
+func CentralMeridian(zone int) float64 {
+	return float64(6*zone - 183)
+}
+
+// StandardZone returns the zone of the point lat_deg, lon_deg. If setzone is a zone in
+// [0, 60] or ZONE_INVALID, it is returned. If setzone is ZONE_UTM, the standard UTM zone
+// is returned, even in the polar regions. Otherwise, setzone must be ZONE_STANDARD, and
+// the standard UTM zone is returned for latitudes in [-80, 84) and ZONE_UPS otherwise.
+// If either lat_deg or lon_deg is NaN, ZONE_INVALID is returned.
+func StandardZone(lat_deg, lon_deg float64, setzone int) (int, error) {
+	if !(setzone >= ZONE_INVALID && setzone <= ZONE_MAX_UTM) {
+		return ZONE_INVALID, fmt.Errorf("illegal zone requested %d", setzone)
+	}
+	if setzone >= ZONE_UPS || setzone == ZONE_INVALID {
+		return setzone, nil
+	}
+	if math.IsNaN(lat_deg) || math.IsNaN(lon_deg) {
+		return ZONE_INVALID, nil
+	}
+	if setzone == ZONE_UTM || (lat_deg >= -80.0 && lat_deg < 84.0) {
+		ilon := int(math.Floor(ang_normalize(lon_deg)))
+		if ilon == 180 {
+			ilon = -180
+		}
+		zone := (ilon + 186) / 6
+		band := latitude_band(lat_deg)
+		if band == 7 && zone == 31 && ilon >= 3 {
+			// The Norway exception
+			zone = 32
+		} else if band == 9 && ilon >= 0 && ilon < 42 {
+			// The Svalbard exception
+			zone = 2*((ilon+183)/12) + 1
+		}
+		return zone, nil
+	}
+	return ZONE_UPS, nil
+}
+
+// Forward finds the UTM or UPS coordinates of the point lat_deg, lon_deg in its standard
+// zone. This returns an error if lat_deg is not in [-90, 90] or the point is outside the
+// legal range of the zone.
+func (u *UTMUPS) Forward(lat_deg, lon_deg float64) (UTMUPSResult, error) {
+	return u.ForwardWithZone(lat_deg, lon_deg, ZONE_STANDARD, false)
+}
+
+// ForwardWithZone finds the UTM or UPS coordinates of the point lat_deg, lon_deg, in the
+// zone chosen by setzone (see StandardZone). If mgrslimits is true, the coordinates must
+// lie within the stricter limits of the MGRS. If the zone is ZONE_INVALID, the result has
+// zone ZONE_INVALID and NaN coordinates.
+func (u *UTMUPS) ForwardWithZone(
+	lat_deg, lon_deg float64,
+	setzone int,
+	mgrslimits bool,
+) (UTMUPSResult, error) {
+	if math.Abs(lat_deg) > 90.0 {
+		return UTMUPSResult{}, fmt.Errorf("latitude %vd not in [-90d, 90d]", lat_deg)
+	}
+	northp := lat_deg >= 0
+	zone, err := StandardZone(lat_deg, lon_deg, setzone)
+	if err != nil {
+		return UTMUPSResult{}, err
+	}
+	if zone == ZONE_INVALID {
+		nan := math.NaN()
+		return UTMUPSResult{
+			Zone:           zone,
+			Northp:         northp,
+			EastingM:       nan,
+			NorthingM:      nan,
+			ConvergenceDeg: nan,
+			Scale:          nan,
+		}, nil
+	}
+
+	utmp := zone != ZONE_UPS
+	var r ProjectionForwardResult
+	if utmp {
+		lon0 := CentralMeridian(zone)
+		dlon, _ := ang_diff(lon0, lon_deg)
+		if !(math.Abs(dlon) <= 60.0) {
+			return UTMUPSResult{}, fmt.Errorf(
+				"longitude %vd more than 60d from center of UTM zone %d", lon_deg, zone,
+			)
+		}
+		r = u.tm.Forward(lon0, lat_deg, lon_deg)
+	} else {
+		if math.Abs(lat_deg) < 70.0 {
+			pole := "S"
+			if northp {
+				pole = "N"
+			}
+			return UTMUPSResult{}, fmt.Errorf("latitude %vd more than 20d from %s pole", lat_deg, pole)
+		}
+		r = u.ps.Forward(northp, lat_deg, lon_deg)
+	}
+
+	ind := utmups_index(utmp, northp)
+	x := r.XM + _UTMUPS_FALSE_EASTING[ind]
+	y := r.YM + _UTMUPS_FALSE_NORTHING[ind]
+	if check_coords(utmp, northp, x, y, mgrslimits) != nil {
+		system := "UPS"
+		if utmp {
+			system = fmt.Sprintf("UTM zone %d", zone)
+		}
+		return UTMUPSResult{}, fmt.Errorf(
+			"latitude %vd, longitude %vd out of legal range for %s", lat_deg, lon_deg, system,
+		)
+	}
+	return UTMUPSResult{
+		Zone:           zone,
+		Northp:         northp,
+		EastingM:       x,
+		NorthingM:      y,
+		ConvergenceDeg: r.ConvergenceDeg,
+		Scale:          r.Scale,
+	}, nil
+}
+
+// Reverse finds the geographic coordinates of the point with easting x_m and northing
+// y_m in zone (in [0, 60]) and hemisphere northp. This returns an error if the zone is
+// illegal or the point is outside the legal range of the zone. If zone is ZONE_INVALID or
+// either coordinate is NaN, the result is NaN.
+func (u *UTMUPS) Reverse(zone int, northp bool, x_m, y_m float64) (ProjectionReverseResult, error) {
+	return u.ReverseWithLimits(zone, northp, x_m, y_m, false)
+}
+
+// ReverseWithLimits is Reverse, except that if mgrslimits is true, the coordinates must
+// lie within the stricter limits of the MGRS.
+func (u *UTMUPS) ReverseWithLimits(
+	zone int,
+	northp bool,
+	x_m, y_m float64,
+	mgrslimits bool,
+) (ProjectionReverseResult, error) {
+	if zone == ZONE_INVALID || math.IsNaN(x_m) || math.IsNaN(y_m) {
+		nan := math.NaN()
+		return ProjectionReverseResult{LatDeg: nan, LonDeg: nan, ConvergenceDeg: nan, Scale: nan}, nil
+	}
+	if !(zone >= ZONE_UPS && zone <= ZONE_MAX_UTM) {
+		return ProjectionReverseResult{}, fmt.Errorf("zone %d not in [0, 60]", zone)
+	}
+	utmp := zone != ZONE_UPS
+	if err := check_coords(utmp, northp, x_m, y_m, mgrslimits); err != nil {
+		return ProjectionReverseResult{}, err
+	}
+	ind := utmups_index(utmp, northp)
+	x := x_m - _UTMUPS_FALSE_EASTING[ind]
+	y := y_m - _UTMUPS_FALSE_NORTHING[ind]
+	if utmp {
+		return u.tm.Reverse(CentralMeridian(zone), x, y), nil
+	}
+	return u.ps.Reverse(northp, x, y), nil
+}
+
+// utmups_index returns the index into the tables of false eastings, false northings,
+// and limits
+func utmups_index(utmp, northp bool) int {
+	ind := 0
+	if utmp {
+		ind += 2
+	}
+	if northp {
+		ind++
+	}
+	return ind
+}
+
+// check_coords returns an error if x, y (including the false easting and northing) is
+// outside the legal range for UTM (if utmp) or UPS in the hemisphere northp
+func check_coords(utmp, northp bool, x, y float64, mgrslimits bool) error {
+	slop := _MGRS_TILE
+	if mgrslimits {
+		slop = 0.0
+	}
+	ind := utmups_index(utmp, northp)
+	system := "UPS"
+	if utmp {
+		system = "UTM"
+	}
+	hemi := "S"
+	if northp {
+		hemi = "N"
+	}
+	if x < _UTMUPS_MIN_EASTING[ind]-slop || x > _UTMUPS_MAX_EASTING[ind]+slop {
+		return fmt.Errorf(
+			"easting %vkm not in %s range for %s hemisphere [%vkm, %vkm]",
+			x/1000, system, hemi,
+			(_UTMUPS_MIN_EASTING[ind]-slop)/1000, (_UTMUPS_MAX_EASTING[ind]+slop)/1000,
+		)
+	}
+	if y < _UTMUPS_MIN_NORTHING[ind]-slop || y > _UTMUPS_MAX_NORTHING[ind]+slop {
+		return fmt.Errorf(
+			"northing %vkm not in %s range for %s hemisphere [%vkm, %vkm]",
+			y/1000, system, hemi,
+			(_UTMUPS_MIN_NORTHING[ind]-slop)/1000, (_UTMUPS_MAX_NORTHING[ind]+slop)/1000,
+		)
+	}
+	return nil
+}
+
+// EncodeZone returns the string representation of zone and hemisphere northp, e.g. "38n"
+// for UTM zone 38 north and "s" for UPS south. If abbrev is false, the hemisphere is
+// spelled out, e.g. "38north". ZONE_INVALID is encoded as "INV".
+func EncodeZone(zone int, northp, abbrev bool) (string, error) {
+	if zone == ZONE_INVALID {
+		return "INV", nil
+	}
+	if !(zone >= ZONE_UPS && zone <= ZONE_MAX_UTM) {
+		return "", fmt.Errorf("zone %d not in [0, 60]", zone)
+	}
+	var s string
+	if zone != ZONE_UPS {
+		s = fmt.Sprintf("%02d", zone)
+	}
+	switch {
+	case abbrev && northp:
+		s += "n"
+	case abbrev:
+		s += "s"
+	case northp:
+		s += "north"
+	default:
+		s += "south"
+	}
+	return s, nil
+}
+
+// DecodeZone parses a zone string, such as "38n", "38N", "38north", "n" (UPS north), or
+// "south" (UPS south), returning the zone and hemisphere. "INV" and "invalid" decode to
+// ZONE_INVALID.
+func DecodeZone(zonestr string) (int, bool, error) {
+	if zonestr == "" {
+		return ZONE_INVALID, false, fmt.Errorf("empty zone specification")
+	}
+	lower := strings.ToLower(zonestr)
+	if lower == "inv" || lower == "invalid" {
+		return ZONE_INVALID, false, nil
+	}
+	i := 0
+	for i < len(lower) && lower[i] >= '0' && lower[i] <= '9' {
+		i++
+	}
+	zone := ZONE_UPS
+	if i > 0 {
+		if i > 2 || lower[0] == '0' && i == 1 {
+			return ZONE_INVALID, false, fmt.Errorf("bad zone specification %s", zonestr)
+		}
+		z, err := strconv.Atoi(lower[:i])
+		if err != nil || z < ZONE_MIN_UTM || z > ZONE_MAX_UTM {
+			return ZONE_INVALID, false, fmt.Errorf("zone %s not in [1, 60]", lower[:i])
+		}
+		zone = z
+	}
+	var northp bool
+	switch lower[i:] {
+	case "n", "north":
+		northp = true
+	case "s", "south":
+		northp = false
+	case "":
+		return ZONE_INVALID, false, fmt.Errorf("missing hemisphere in %s", zonestr)
+	default:
+		return ZONE_INVALID, false, fmt.Errorf("illegal hemisphere %s in %s", zonestr[i:], zonestr)
+	}
+	return zone, northp, nil
+}
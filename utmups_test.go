@@ -0,0 +1,167 @@
+package geographiclibgo
+
+import (
+	"math"
+	"testing"
+)
+
+func TestStandardZone(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		lat     float64
+		lon     float64
+		setzone int
+		want    int
+	}{
+		{"equator", 0, 0, ZONE_STANDARD, 31},
+		{"zone 38", 33.3, 44.4, ZONE_STANDARD, 38},
+		{"antimeridian", 10, 180, ZONE_STANDARD, 1},
+		{"last zone", 10, 179.9, ZONE_STANDARD, 60},
+		{"Norway exception", 60, 5, ZONE_STANDARD, 32},
+		{"west of Norway exception", 60, 2.5, ZONE_STANDARD, 31},
+		{"Norway exception south of band V", 55.9, 5, ZONE_STANDARD, 31},
+		{"Svalbard zone 31", 78, 8, ZONE_STANDARD, 31},
+		{"Svalbard zone 33", 78, 10, ZONE_STANDARD, 33},
+		{"Svalbard zone 35", 78, 25, ZONE_STANDARD, 35},
+		{"Svalbard zone 37", 78, 41, ZONE_STANDARD, 37},
+		{"east of Svalbard", 78, 42, ZONE_STANDARD, 38},
+		{"south edge of UTM", -80, 0, ZONE_STANDARD, 31},
+		{"south UPS", -80.5, 0, ZONE_STANDARD, ZONE_UPS},
+		{"north UPS", 84, 0, ZONE_STANDARD, ZONE_UPS},
+		{"force UTM", 85, 0, ZONE_UTM, 31},
+		{"set zone", 0, 0, 30, 30},
+		{"NaN", math.NaN(), 0, ZONE_STANDARD, ZONE_INVALID},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			got, err := StandardZone(tC.lat, tC.lon, tC.setzone)
+			if err != nil {
+				t.Fatalf("StandardZone() error = %v", err)
+			}
+			if got != tC.want {
+				t.Errorf("StandardZone(%v, %v) = %v; want %v", tC.lat, tC.lon, got, tC.want)
+			}
+		})
+	}
+
+	if _, err := StandardZone(0, 0, 61); err == nil {
+		t.Errorf("StandardZone(0, 0, 61) error = nil; want non-nil")
+	}
+}
+
+func TestUTMUPSForwardReverse(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		lat      float64
+		lon      float64
+		zone     int
+		northp   bool
+		easting  float64
+		northing float64
+	}{
+		// From the GeoConvert documentation
+		{"UTM north", 33.3, 44.4, 38, true, 444140.54, 3684706.36},
+		{"UTM south", -33.3, 44.4, 38, false, 444140.54, 10000000 - 3684706.36},
+		{"north pole", 90, 0, ZONE_UPS, true, 2000000, 2000000},
+		{"south pole", -90, 0, ZONE_UPS, false, 2000000, 2000000},
+	}
+	u := Wgs84UTMUPS()
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			got, err := u.Forward(tC.lat, tC.lon)
+			if err != nil {
+				t.Fatalf("Forward() error = %v", err)
+			}
+			if got.Zone != tC.zone || got.Northp != tC.northp {
+				t.Errorf("zone = %v%v; want %v%v", got.Zone, got.Northp, tC.zone, tC.northp)
+			}
+			if !almost_equal(got.EastingM, tC.easting, 0.005) {
+				t.Errorf("easting = %v; want %v", got.EastingM, tC.easting)
+			}
+			if !almost_equal(got.NorthingM, tC.northing, 0.005) {
+				t.Errorf("northing = %v; want %v", got.NorthingM, tC.northing)
+			}
+
+			rev, err := u.Reverse(got.Zone, got.Northp, got.EastingM, got.NorthingM)
+			if err != nil {
+				t.Fatalf("Reverse() error = %v", err)
+			}
+			if !almost_equal(rev.LatDeg, tC.lat, 1e-12) {
+				t.Errorf("lat = %v; want %v", rev.LatDeg, tC.lat)
+			}
+			if math.Abs(tC.lat) != 90 && !almost_equal(rev.LonDeg, tC.lon, 1e-12) {
+				t.Errorf("lon = %v; want %v", rev.LonDeg, tC.lon)
+			}
+			if !almost_equal(rev.Scale, got.Scale, 1e-14) {
+				t.Errorf("k = %v; want %v", rev.Scale, got.Scale)
+			}
+		})
+	}
+}
+
+func TestUTMUPSErrors(t *testing.T) {
+	u := Wgs84UTMUPS()
+	if _, err := u.Forward(91, 0); err == nil {
+		t.Errorf("Forward(91, 0) error = nil; want non-nil")
+	}
+	if _, err := u.ForwardWithZone(0, 0, 40, false); err == nil {
+		t.Errorf("Forward(0, 0) in zone 40 error = nil; want non-nil")
+	}
+	if _, err := u.ForwardWithZone(60, 0, ZONE_UPS, false); err == nil {
+		t.Errorf("Forward(60, 0) in UPS error = nil; want non-nil")
+	}
+	// 20 degrees from the central meridian is beyond the 900 km limit at the equator
+	if _, err := u.ForwardWithZone(0, 23, 31, false); err == nil {
+		t.Errorf("Forward(0, 23) in zone 31 error = nil; want non-nil")
+	}
+	if _, err := u.Reverse(61, true, 500000, 0); err == nil {
+		t.Errorf("Reverse() in zone 61 error = nil; want non-nil")
+	}
+	if _, err := u.Reverse(31, true, 1500000, 0); err == nil {
+		t.Errorf("Reverse() with easting 1500km error = nil; want non-nil")
+	}
+
+	got, err := u.Forward(math.NaN(), 0)
+	if err != nil || got.Zone != ZONE_INVALID || !math.IsNaN(got.EastingM) {
+		t.Errorf("Forward(NaN, 0) = %v, %v; want invalid zone", got, err)
+	}
+}
+
+func TestUTMUPSZoneStrings(t *testing.T) {
+	testCases := []struct {
+		str    string
+		zone   int
+		northp bool
+	}{
+		{"38n", 38, true},
+		{"05s", 5, false},
+		{"n", ZONE_UPS, true},
+		{"s", ZONE_UPS, false},
+	}
+	for _, tC := range testCases {
+		got, err := EncodeZone(tC.zone, tC.northp, true)
+		if err != nil || got != tC.str {
+			t.Errorf("EncodeZone(%v, %v) = %q, %v; want %q", tC.zone, tC.northp, got, err, tC.str)
+		}
+		zone, northp, err := DecodeZone(tC.str)
+		if err != nil || zone != tC.zone || northp != tC.northp {
+			t.Errorf("DecodeZone(%q) = %v, %v, %v; want %v, %v", tC.str, zone, northp, err, tC.zone, tC.northp)
+		}
+	}
+
+	if zone, northp, err := DecodeZone("38North"); err != nil || zone != 38 || !northp {
+		t.Errorf("DecodeZone(\"38North\") = %v, %v, %v; want 38, true", zone, northp, err)
+	}
+	for _, bad := range []string{"", "38", "61n", "0n", "38x", "138n"} {
+		if _, _, err := DecodeZone(bad); err == nil {
+			t.Errorf("DecodeZone(%q) error = nil; want non-nil", bad)
+		}
+	}
+}
+
+func BenchmarkUTMUPSForward(b *testing.B) {
+	u := Wgs84UTMUPS()
+	for i := 0; i < b.N; i++ {
+		u.Forward(33.3, 44.4)
+	}
+}